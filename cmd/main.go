@@ -2,34 +2,422 @@ package main
 
 import (
 	"api-golang/internal/config"
+	"api-golang/internal/discovery"
 	"api-golang/internal/domain"
+	"api-golang/internal/graphapi"
+	"api-golang/internal/grpcapi"
+	"api-golang/internal/grpcapi/centralv1"
 	"api-golang/internal/handler"
+	"api-golang/internal/monitor"
+	"api-golang/internal/openapi"
+	"api-golang/internal/oui"
+	"api-golang/internal/outbox"
 	"api-golang/internal/repository"
+	"api-golang/internal/secrets"
+	"api-golang/internal/snmp"
+	"api-golang/internal/stream"
 	"api-golang/internal/usecase"
+	"api-golang/internal/utils"
+	"api-golang/internal/webhook"
+	"context"
 	"log"
+	"net"
+	"net/netip"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/nats-io/nats.go"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+	"gorm.io/gorm"
 )
 
 func main() {
+	cfg := config.Load()
 
 	db, err := config.InitDB()
 	if err != nil {
 		log.Fatalf("Failed to connect to DB: %v", err)
 	}
-	db.AutoMigrate(&domain.Central{})
+	if err := repository.Migrate(db); err != nil {
+		log.Fatalf("Failed to migrate DB: %v", err)
+	}
+	report, err := repository.NormalizeCentralAddresses(db)
+	if err != nil {
+		log.Fatalf("Failed to normalize central addresses: %v", err)
+	}
+	logAddressReport(report)
+
+	vendors, err := oui.Load(cfg.OUIFile)
+	if err != nil {
+		log.Fatalf("Failed to load OUI file: %v", err)
+	}
+	log.Printf("OUI database: %d vendor prefixes", vendors.Len())
+	refreshed, err := repository.RefreshVendors(db, vendors.VendorOf)
+	if err != nil {
+		log.Fatalf("Failed to refresh vendors: %v", err)
+	}
+	if refreshed > 0 {
+		log.Printf("Updated the vendor of %d central(s) and interface(s)", refreshed)
+	}
 
 	app := fiber.New()
 
+	// Validação opcional pelo contrato OpenAPI
+	if cfg.OpenAPIValidation != config.OpenAPIValidationOff {
+		doc, err := openapi.Load()
+		if err != nil {
+			log.Fatalf("Failed to load OpenAPI document: %v", err)
+		}
+		validator, err := openapi.Middleware(doc, openapi.Options{
+			ValidateResponses: cfg.OpenAPIValidation == config.OpenAPIValidationResponse,
+		})
+		if err != nil {
+			log.Fatalf("Failed to build OpenAPI validator: %v", err)
+		}
+		app.Use(validator)
+	}
+
+	webhookRepo := repository.NewWebhookRepository(db)
+	dispatcher := webhook.New(webhookRepo, webhook.Options{
+		MaxAttempts: cfg.Webhook.MaxAttempts,
+		BaseBackoff: cfg.Webhook.BaseBackoff,
+		MaxBackoff:  cfg.Webhook.MaxBackoff,
+		Timeout:     cfg.Webhook.Timeout,
+	})
+	handler.RegisterWebhookRoutes(app, handler.NewWebhookHandler(usecase.NewWebhookUseCase(webhookRepo)))
+
 	repo := repository.NewCentralRepository(db)
 	uc := usecase.NewCentralUseCase(repo)
-	handler := handler.NewCentralHandler(uc)
+	uc.Vendors = vendors
+	handler.RegisterCentralRoutes(app, handler.NewCentralHandler(uc))
+
+	outboxRepo := repository.NewOutboxRepository(db)
+	hub := startEventStream(app, cfg.Stream, outboxRepo)
+	switch {
+	case hub == nil:
+		log.Printf("WebSocket API disabled: it follows the event stream, which is off (STREAM_POLL_INTERVAL=0)")
+	case len(cfg.APITokens) == 0:
+		log.Printf("WebSocket API disabled: set API_TOKENS to authenticate connections")
+	default:
+		wsHandler := handler.NewWebSocketHandler(uc, hub, cfg.APITokens)
+		if cfg.WebSocketPingInterval > 0 {
+			wsHandler.PingInterval = cfg.WebSocketPingInterval
+		}
+		handler.RegisterWebSocketRoutes(app, wsHandler)
+	}
+	// Sem o stream, o Watch do gRPC responde UNAVAILABLE
+	var events handler.EventStream
+	if hub != nil {
+		events = hub
+	}
+	startGRPCServer(cfg.GRPCAddr, uc, events)
+
+	interfaceRepo := repository.NewNetworkInterfaceRepository(db)
+	interfaceUC := usecase.NewNetworkInterfaceUseCase(interfaceRepo)
+	interfaceUC.Vendors = vendors
+	handler.RegisterInterfaceRoutes(app, handler.NewNetworkInterfaceHandler(interfaceUC))
+
+	handler.RegisterOUIRoutes(app, handler.NewOUIHandler(usecase.NewOUIUseCase(vendors)))
+
+	searchRepo := repository.NewSearchRepository(db)
+	if searchRepo.Engine() == "fts5" {
+		log.Printf("Central search engine: fts5")
+	} else {
+		log.Printf("Central search engine: %s (build with -tags sqlite_fts5 for ranked full-text search)", searchRepo.Engine())
+	}
+	searchUC := usecase.NewSearchUseCase(searchRepo)
+	handler.RegisterSearchRoutes(app, handler.NewSearchHandler(searchUC))
+
+	labelRepo := repository.NewLabelRepository(db)
+	labelUC := usecase.NewLabelUseCase(labelRepo)
+	handler.RegisterLabelRoutes(app, handler.NewLabelHandler(labelUC))
+
+	ipamRepo := repository.NewIPAMRepository(db)
+	ipamUC := usecase.NewIPAMUseCase(ipamRepo)
+	handler.RegisterIPAMRoutes(app, handler.NewIPAMHandler(ipamUC))
+
+	siteRepo := repository.NewSiteRepository(db)
+	siteUC := usecase.NewSiteUseCase(siteRepo, repo)
+	handler.RegisterSiteRoutes(app, handler.NewSiteHandler(siteUC))
+
+	graphapi.RegisterRoutes(app, graphapi.New(uc, siteUC, interfaceUC, graphapi.Options{
+		MaxDepth:      cfg.GraphQL.MaxDepth,
+		MaxComplexity: cfg.GraphQL.MaxComplexity,
+	}))
+
+	telemetryRepo := repository.NewTelemetryRepository(db)
+	telemetryUC := usecase.NewTelemetryUseCase(telemetryRepo, usecase.TelemetryRetention{
+		Raw:        cfg.Telemetry.RawRetention,
+		FiveMinute: cfg.Telemetry.FiveMinuteRetention,
+		Hour:       cfg.Telemetry.HourRetention,
+	})
+	handler.RegisterTelemetryRoutes(app, handler.NewTelemetryHandler(telemetryUC))
+
+	heartbeatUC := usecase.NewHeartbeatUseCase(repository.NewHeartbeatRepository(db), cfg.Heartbeat.Window)
+	heartbeatUC.Telemetry = telemetryRepo
+	handler.RegisterHeartbeatRoutes(app, handler.NewHeartbeatHandler(heartbeatUC))
+
+	discoveryRepo := repository.NewDiscoveryRepository(db)
+	handler.RegisterDiscoveryRoutes(app, handler.NewDiscoveryHandler(usecase.NewDiscoveryUseCase(discoveryRepo, uc)))
+
+	alertUC := usecase.NewAlertUseCase(repository.NewAlertRepository(db), repo, telemetryRepo)
+	handler.RegisterAlertRoutes(app, handler.NewAlertHandler(alertUC))
+
+	startSNMPCollector(app, cfg.SNMP, db)
+	startDiscovery(cfg.Discovery, discoveryRepo, vendors)
+	startMonitor(cfg.Monitor, repository.NewStatusRepository(db))
+	startHeartbeatExpiry(cfg.Heartbeat, heartbeatUC)
+	startTelemetryMaintenance(cfg.Telemetry, telemetryUC)
+	startAlertEvaluation(cfg.AlertEvaluationInterval, alertUC)
+	startWebhookDelivery(cfg.Webhook, dispatcher)
+	startOutboxRelay(cfg.Outbox, outboxRepo, outboxSinks(cfg.Outbox, dispatcher))
+
+	log.Fatal(app.Listen(cfg.Addr))
+}
+
+// Inicia o monitor de alcance em segundo plano. Sem permissão para ICMP,
+// as centrais são sondadas por TCP.
+func startMonitor(cfg config.MonitorConfig, store monitor.Store) {
+	if cfg.Interval == 0 {
+		log.Printf("Reachability monitor disabled")
+		return
+	}
+	var prober monitor.Prober = &monitor.TCPProber{Ports: cfg.Ports, Timeout: cfg.Timeout, DegradedRTT: cfg.DegradedRTT}
+	method := config.MonitorMethodTCP
+	if cfg.Method == config.MonitorMethodICMP {
+		icmpProber, err := monitor.NewICMPProber(3, cfg.Timeout, cfg.DegradedRTT)
+		if err != nil {
+			log.Printf("WARNING: ICMP not permitted (%v); probing TCP ports %v instead", err, cfg.Ports)
+		} else {
+			prober, method = icmpProber, config.MonitorMethodICMP
+		}
+	}
+	log.Printf("Reachability monitor: %s every %s", method, cfg.Interval)
+	m := monitor.New(store, prober, monitor.Options{Interval: cfg.Interval, Concurrency: cfg.Concurrency})
+	m.OnChange = func(changes []domain.StatusChange) {
+		for _, change := range changes {
+			log.Printf("Central %d: %s -> %s", change.CentralID, change.From, change.To)
+		}
+	}
+	go m.Run(context.Background())
+}
+
+// Registra as rotas SNMP e inicia o coletor. Sem a chave que cifra as
+// credenciais, ambos ficam desligados.
+func startSNMPCollector(app *fiber.App, cfg config.SNMPConfig, db *gorm.DB) {
+	if cfg.SecretKey == "" {
+		log.Printf("WARNING: SNMP_SECRET_KEY not set; SNMP collector and routes disabled")
+		return
+	}
+	key, err := secrets.ParseKey(cfg.SecretKey)
+	if err != nil {
+		log.Fatalf("Invalid SNMP_SECRET_KEY: %v", err)
+	}
+	box, err := secrets.NewBox(key)
+	if err != nil {
+		log.Fatalf("Invalid SNMP_SECRET_KEY: %v", err)
+	}
+	snmpRepo := repository.NewSNMPRepository(db, box)
+	handler.RegisterSNMPRoutes(app, handler.NewSNMPHandler(usecase.NewSNMPUseCase(snmpRepo)))
+
+	if cfg.Interval == 0 {
+		log.Printf("SNMP collector disabled")
+		return
+	}
+	log.Printf("SNMP collector: every %s", cfg.Interval)
+	client := &snmp.Client{Timeout: cfg.Timeout, Retries: cfg.Retries, MaxRepetitions: uint32(cfg.MaxRepetitions)}
+	c := snmp.New(snmpRepo, client, snmp.Options{Interval: cfg.Interval, Concurrency: cfg.Concurrency, DegradedRTT: cfg.DegradedRTT})
+	c.OnChange = func(changes []domain.StatusChange) {
+		for _, change := range changes {
+			log.Printf("Central %d (SNMP): %s -> %s", change.CentralID, change.From, change.To)
+		}
+	}
+	go c.Run(context.Background())
+}
+
+// Varre periodicamente as sub-redes configuradas e propõe os equipamentos
+// não cadastrados como candidatos
+func startDiscovery(cfg config.DiscoveryConfig, store discovery.Store, vendors discovery.VendorRegistry) {
+	if len(cfg.Subnets) == 0 || cfg.Interval == 0 {
+		log.Printf("Network discovery disabled")
+		return
+	}
+	prefixes := make([]netip.Prefix, 0, len(cfg.Subnets))
+	for _, subnet := range cfg.Subnets {
+		prefix, err := utils.NormalizeSubnet(subnet)
+		if err != nil {
+			log.Fatalf("Invalid DISCOVERY_SUBNETS: %v", err)
+		}
+		prefixes = append(prefixes, prefix)
+	}
+	log.Printf("Network discovery: %v every %s", prefixes, cfg.Interval)
+	scanner := &discovery.TCPScanner{Ports: cfg.Ports, Timeout: cfg.Timeout, Concurrency: cfg.Concurrency}
+	job := discovery.New(store, scanner, vendors, discovery.Options{Interval: cfg.Interval, Prefixes: prefixes, ARPFile: cfg.ARPFile})
+	job.OnDiscover = func(created int) {
+		if created > 0 {
+			log.Printf("Network discovery found %d new candidate(s)", created)
+		}
+	}
+	go job.Run(context.Background())
+}
+
+// Marca periodicamente como offline as centrais sem heartbeat no prazo
+func startHeartbeatExpiry(cfg config.HeartbeatConfig, uc *usecase.HeartbeatUseCase) {
+	if cfg.CheckInterval == 0 {
+		return
+	}
+	go monitor.RunEvery(context.Background(), cfg.CheckInterval, "Heartbeat expiry", func() error {
+		expired, err := uc.ExpireHeartbeats()
+		for _, change := range expired {
+			log.Printf("Central %d without heartbeat marked offline", change.CentralID)
+		}
+		return err
+	})
+}
+
+// Agrega periodicamente os pontos brutos em rollups e remove os dados fora
+// da retenção
+func startTelemetryMaintenance(cfg config.TelemetryConfig, uc *usecase.TelemetryUseCase) {
+	if cfg.MaintenanceInterval == 0 {
+		return
+	}
+	go monitor.RunEvery(context.Background(), cfg.MaintenanceInterval, "Telemetry maintenance", func() error {
+		if _, err := uc.Rollup(); err != nil {
+			return err
+		}
+		deleted, err := uc.ApplyRetention()
+		if deleted > 0 {
+			log.Printf("Telemetry retention removed %d record(s)", deleted)
+		}
+		return err
+	})
+}
+
+// Avalia periodicamente as regras de alerta
+func startAlertEvaluation(interval time.Duration, uc *usecase.AlertUseCase) {
+	if interval == 0 {
+		log.Printf("Alert evaluation disabled")
+		return
+	}
+	go monitor.RunEvery(context.Background(), interval, "Alert evaluation", func() error {
+		transitions, err := uc.Evaluate()
+		for _, alert := range transitions {
+			log.Printf("Alert %s: rule %d, central %d: %s", alert.State, alert.RuleID, alert.CentralID, alert.Summary)
+		}
+		return err
+	})
+}
+
+// Envia periodicamente as entregas de webhook com tentativa vencida
+func startWebhookDelivery(cfg config.WebhookConfig, d *webhook.Dispatcher) {
+	if cfg.Interval == 0 {
+		log.Printf("Webhook delivery disabled")
+		return
+	}
+	ctx := context.Background()
+	go monitor.RunEvery(ctx, cfg.Interval, "Webhook delivery", func() error {
+		_, err := d.DeliverDue(ctx)
+		return err
+	})
+}
+
+// Destinos do relay do outbox conforme a configuração
+func outboxSinks(cfg config.OutboxConfig, dispatcher *webhook.Dispatcher) []outbox.Sink {
+	var sinks []outbox.Sink
+	for _, name := range cfg.Sinks {
+		switch name {
+		case config.OutboxSinkWebhook:
+			sinks = append(sinks, &outbox.WebhookSink{Dispatcher: dispatcher})
+		case config.OutboxSinkLog:
+			sinks = append(sinks, &outbox.LogSink{})
+		case config.OutboxSinkNATS:
+			conn, err := nats.Connect(cfg.NATSURL, nats.Name("central-api"), nats.MaxReconnects(-1))
+			if err != nil {
+				log.Fatalf("Failed to connect to NATS: %v", err)
+			}
+			sinks = append(sinks, &outbox.NATSSink{Conn: conn, Prefix: cfg.NATSSubject})
+		default:
+			log.Fatalf("Unknown outbox sink %q", name)
+		}
+	}
+	return sinks
+}
+
+// Publica periodicamente os eventos do outbox e remove os já publicados
+// fora da retenção
+func startOutboxRelay(cfg config.OutboxConfig, repo *repository.OutboxRepository, sinks []outbox.Sink) {
+	if cfg.RelayInterval == 0 {
+		log.Printf("Outbox relay disabled")
+		return
+	}
+	log.Printf("Outbox relay: %v every %s", cfg.Sinks, cfg.RelayInterval)
+	ctx := context.Background()
+	relay := outbox.New(repo, sinks, outbox.Options{RetryDelay: cfg.RetryDelay})
+	go monitor.RunEvery(ctx, cfg.RelayInterval, "Outbox relay", func() error {
+		_, err := relay.RelayPending(ctx)
+		return err
+	})
+	if cfg.Retention == 0 {
+		return
+	}
+	go monitor.RunEvery(ctx, time.Hour, "Outbox cleanup", func() error {
+		_, err := repo.PrunePublished(time.Now().Add(-cfg.Retention))
+		return err
+	})
+}
+
+// Registra o stream SSE de eventos e passa a acompanhar o outbox. Retorna
+// nil com o stream desligado.
+func startEventStream(app *fiber.App, cfg config.StreamConfig, repo *repository.OutboxRepository) *stream.Hub {
+	if cfg.PollInterval == 0 {
+		log.Printf("Event stream disabled")
+		return nil
+	}
+	hub := stream.New(repo, stream.Options{BufferSize: cfg.BufferSize})
+	h := handler.NewEventHandler(hub)
+	if cfg.KeepAlive > 0 {
+		h.KeepAlive = cfg.KeepAlive
+	}
+	handler.RegisterEventRoutes(app, h)
+	go monitor.RunEvery(context.Background(), cfg.PollInterval, "Event stream", func() error {
+		_, err := hub.Poll()
+		return err
+	})
+	return hub
+}
 
-	app.Post("/central", handler.CreateCentral)
-	app.Get("/centrals", handler.GetAllCentrals)
-	app.Get("/central/:id", handler.GetCentralByID)
-	app.Put("/central/:id", handler.UpdateCentral)
-	app.Delete("/central/:id", handler.DeleteCentral)
+// Atende o CentralService por gRPC em uma porta separada da API REST
+func startGRPCServer(addr string, uc handler.CentralUseCase, events handler.EventStream) {
+	if addr == "off" {
+		log.Printf("gRPC server disabled")
+		return
+	}
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		log.Fatalf("Failed to listen on %s for gRPC: %v", addr, err)
+	}
+	srv := grpc.NewServer()
+	centralv1.RegisterCentralServiceServer(srv, grpcapi.NewServer(uc, events))
+	reflection.Register(srv)
+	go func() {
+		if err := srv.Serve(ln); err != nil {
+			log.Printf("gRPC server stopped: %v", err)
+		}
+	}()
+	log.Printf("gRPC server listening on %s", ln.Addr())
+}
 
-	log.Fatal(app.Listen(":8080"))
+// Reporta o resultado da normalização de endereços feita na inicialização
+func logAddressReport(report repository.AddressMigrationReport) {
+	if len(report.Normalized) > 0 {
+		log.Printf("Normalized addresses of %d central(s): %v", len(report.Normalized), report.Normalized)
+	}
+	for _, d := range report.Duplicates {
+		log.Printf("WARNING: centrals %v share %s %s in different notations %q; resolve manually",
+			d.CentralIDs, d.Field, d.Canonical, d.Values)
+	}
+	if len(report.Invalid) > 0 {
+		log.Printf("WARNING: centrals with unparseable MAC or IP: %v", report.Invalid)
+	}
 }
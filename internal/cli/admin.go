@@ -0,0 +1,174 @@
+package cli
+
+import (
+	"api-golang/internal/config"
+	"api-golang/internal/domain"
+	"api-golang/internal/handler"
+	"api-golang/internal/oui"
+	"api-golang/internal/repository"
+	"api-golang/internal/usecase"
+	"api-golang/internal/utils"
+	"context"
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+)
+
+func (a *app) adminCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "admin",
+		Short: "Work on the database directly, for recovery",
+		Long: "The admin commands open the database directly instead of calling the API,\n" +
+			"with the same validation and events. Use them when the API is down.",
+	}
+	cmd.PersistentFlags().StringVar(&a.dbPath, "db", "database.db", "SQLite database file")
+	cmd.AddCommand(a.centralCommands(a.dbBackend)...)
+	cmd.AddCommand(a.migrateCommand())
+	return cmd
+}
+
+func (a *app) openDB() (*gorm.DB, error) {
+	open := a.opts.OpenDB
+	if open == nil {
+		open = config.OpenDB
+	}
+	db, err := open(a.dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database %s: %w", a.dbPath, err)
+	}
+	return db, nil
+}
+
+func (a *app) migrateCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "migrate",
+		Short: "Create or update the tables and normalize stored addresses",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, err := a.openDB()
+			if err != nil {
+				return err
+			}
+			if err := repository.Migrate(db); err != nil {
+				return err
+			}
+			report, err := repository.NormalizeCentralAddresses(db)
+			if err != nil {
+				return err
+			}
+			out := cmd.OutOrStdout()
+			fmt.Fprintln(out, "database migrated")
+			if len(report.Normalized) > 0 {
+				fmt.Fprintf(out, "normalized addresses of %d central(s): %v\n", len(report.Normalized), report.Normalized)
+			}
+			for _, d := range report.Duplicates {
+				fmt.Fprintf(out, "centrals %v share %s %s in different notations %q; resolve manually\n",
+					d.CentralIDs, d.Field, d.Canonical, d.Values)
+			}
+			if len(report.Invalid) > 0 {
+				fmt.Fprintf(out, "centrals with unparseable MAC or IP: %v\n", report.Invalid)
+			}
+			return nil
+		},
+	}
+}
+
+// Centrais direto no banco, pelo mesmo caso de uso da API
+type dbBackend struct {
+	uc        *usecase.CentralUseCase
+	validator *validator.Validate
+}
+
+func (a *app) dbBackend() (backend, error) {
+	db, err := a.openDB()
+	if err != nil {
+		return nil, err
+	}
+	// A mesma base de fabricantes do servidor, inclusive com OUI_FILE
+	vendors, err := oui.Load(config.Load().OUIFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load OUI file: %w", err)
+	}
+	uc := usecase.NewCentralUseCase(repository.NewCentralRepository(db))
+	uc.Vendors = vendors
+	return &dbBackend{uc: uc, validator: validator.New()}, nil
+}
+
+func (b *dbBackend) ListCentrals(ctx context.Context, opts listOptions) ([]handler.CentralResponse, error) {
+	filter, err := opts.filter()
+	if err != nil {
+		return nil, err
+	}
+	centrals, err := b.uc.GetAllCentrals(filter)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewCentralResponses(centrals, ""), nil
+}
+
+func (b *dbBackend) GetCentral(ctx context.Context, id uint) (*handler.CentralResponse, error) {
+	central, err := b.uc.GetCentralByID(id)
+	if err != nil {
+		return nil, err
+	}
+	response := handler.NewCentralResponse(central, "")
+	return &response, nil
+}
+
+func (b *dbBackend) CreateCentral(ctx context.Context, req handler.CreateCentralRequest) (*handler.CentralResponse, error) {
+	if err := b.validator.Struct(req); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalid, utils.FormatValidationErrors(err))
+	}
+	central := req.ToDomain()
+	if err := b.uc.CreateCentral(central); err != nil {
+		return nil, err
+	}
+	response := handler.NewCentralResponse(central, "")
+	return &response, nil
+}
+
+func (b *dbBackend) UpdateCentral(ctx context.Context, id uint, req handler.UpdateCentralRequest) (*handler.CentralResponse, error) {
+	if err := b.validator.Struct(req); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalid, utils.FormatValidationErrors(err))
+	}
+	central := req.ToDomain(id)
+	if err := b.uc.UpdateCentral(central); err != nil {
+		return nil, err
+	}
+	response := handler.NewCentralResponse(central, "")
+	return &response, nil
+}
+
+func (b *dbBackend) DeleteCentral(ctx context.Context, id uint) error {
+	return b.uc.DeleteCentral(id)
+}
+
+// Filtro de domínio equivalente à query string de GET /centrals
+func (o listOptions) filter() (domain.CentralFilter, error) {
+	filter := domain.CentralFilter{
+		IP:         o.IP,
+		SiteID:     optionalFlagID(o.SiteID),
+		LocationID: optionalFlagID(o.LocationID),
+		Recursive:  o.Recursive,
+		Unplaced:   o.Unplaced,
+		Vendor:     o.Vendor,
+		Status:     domain.ReachabilityStatus(o.Status),
+	}
+	if filter.Status != "" && !filter.Status.Valid() {
+		return filter, fmt.Errorf("%w: unknown status %q", domain.ErrInvalid, filter.Status)
+	}
+	var err error
+	if filter.Selector, err = utils.ParseLabelSelector(o.Selector); err != nil {
+		return filter, fmt.Errorf("%w: %v", domain.ErrInvalid, err)
+	}
+	if o.Subnet != "" {
+		r, err := utils.SubnetRange(o.Subnet)
+		if err != nil {
+			return filter, fmt.Errorf("%w: %v", domain.ErrInvalid, err)
+		}
+		filter.IPRange = &r
+	}
+	return filter, nil
+}
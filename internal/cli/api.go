@@ -0,0 +1,222 @@
+package cli
+
+import (
+	"api-golang/internal/handler"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Operações de centrais usadas pelos comandos, pela API ou direto no banco
+type backend interface {
+	ListCentrals(ctx context.Context, opts listOptions) ([]handler.CentralResponse, error)
+	GetCentral(ctx context.Context, id uint) (*handler.CentralResponse, error)
+	CreateCentral(ctx context.Context, req handler.CreateCentralRequest) (*handler.CentralResponse, error)
+	UpdateCentral(ctx context.Context, id uint, req handler.UpdateCentralRequest) (*handler.CentralResponse, error)
+	DeleteCentral(ctx context.Context, id uint) error
+}
+
+type backendFunc func() (backend, error)
+
+// Filtros da listagem, os mesmos de GET /centrals; zero não filtra
+type listOptions struct {
+	SiteID     uint
+	LocationID uint
+	Recursive  bool
+	Unplaced   bool
+	Selector   string
+	Status     string
+	Vendor     string
+	IP         string
+	Subnet     string
+}
+
+func (o listOptions) query() url.Values {
+	query := url.Values{}
+	if o.SiteID != 0 {
+		query.Set("site_id", strconv.FormatUint(uint64(o.SiteID), 10))
+	}
+	if o.LocationID != 0 {
+		query.Set("location_id", strconv.FormatUint(uint64(o.LocationID), 10))
+	}
+	if o.Recursive {
+		query.Set("recursive", "true")
+	}
+	if o.Unplaced {
+		query.Set("unplaced", "true")
+	}
+	for key, value := range map[string]string{
+		"selector": o.Selector,
+		"status":   o.Status,
+		"vendor":   o.Vendor,
+		"ip":       o.IP,
+		"subnet":   o.Subnet,
+	} {
+		if value != "" {
+			query.Set(key, value)
+		}
+	}
+	return query
+}
+
+// Erro devolvido pela API, com o status HTTP e a mensagem do corpo
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s (HTTP %d)", e.Message, e.StatusCode)
+}
+
+// Centrais pela API HTTP
+type apiBackend struct {
+	baseURL string
+	token   string
+	client  *http.Client
+}
+
+// Backend da API com servidor e token de --server/--token, das variáveis
+// de ambiente ou do perfil
+func (a *app) apiBackend() (backend, error) {
+	s, err := loadSettings(a.settingsPath())
+	if err != nil {
+		return nil, err
+	}
+	var p profile
+	if name := a.profileName(s); name != "" {
+		var ok bool
+		if p, ok = s.Profiles[name]; !ok {
+			return nil, fmt.Errorf("profile %q not found", name)
+		}
+	}
+
+	server := firstNonEmpty(a.server, os.Getenv(envServer), p.Server, defaultServer)
+	u, err := url.Parse(server)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid server URL %q", server)
+	}
+	client := a.opts.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: a.timeout}
+	}
+	return &apiBackend{
+		baseURL: strings.TrimRight(server, "/"),
+		token:   firstNonEmpty(a.token, os.Getenv(envToken), p.Token),
+		client:  client,
+	}, nil
+}
+
+func (b *apiBackend) ListCentrals(ctx context.Context, opts listOptions) ([]handler.CentralResponse, error) {
+	var centrals []handler.CentralResponse
+	err := b.do(ctx, http.MethodGet, "/centrals", opts.query(), nil, &centrals)
+	return centrals, err
+}
+
+func (b *apiBackend) GetCentral(ctx context.Context, id uint) (*handler.CentralResponse, error) {
+	var central handler.CentralResponse
+	if err := b.do(ctx, http.MethodGet, centralPath(id), nil, nil, &central); err != nil {
+		return nil, err
+	}
+	return &central, nil
+}
+
+func (b *apiBackend) CreateCentral(ctx context.Context, req handler.CreateCentralRequest) (*handler.CentralResponse, error) {
+	var central handler.CentralResponse
+	if err := b.do(ctx, http.MethodPost, "/central", nil, req, &central); err != nil {
+		return nil, err
+	}
+	return &central, nil
+}
+
+// Corpo do PUT. Um mapa de labels vazio remove todos, mas o omitempty do
+// DTO o tiraria do corpo.
+type updateBody struct {
+	handler.UpdateCentralRequest
+	Labels *map[string]string `json:"labels,omitempty"`
+}
+
+func (b *apiBackend) UpdateCentral(ctx context.Context, id uint, req handler.UpdateCentralRequest) (*handler.CentralResponse, error) {
+	body := updateBody{UpdateCentralRequest: req}
+	if req.Labels != nil {
+		body.Labels = &req.Labels
+	}
+	var central handler.CentralResponse
+	if err := b.do(ctx, http.MethodPut, centralPath(id), nil, body, &central); err != nil {
+		return nil, err
+	}
+	return &central, nil
+}
+
+func (b *apiBackend) DeleteCentral(ctx context.Context, id uint) error {
+	return b.do(ctx, http.MethodDelete, centralPath(id), nil, nil, nil)
+}
+
+func centralPath(id uint) string {
+	return "/central/" + strconv.FormatUint(uint64(id), 10)
+}
+
+// Faz a requisição com o corpo em JSON e decodifica a resposta em out.
+// Respostas fora de 2xx viram *APIError.
+func (b *apiBackend) do(ctx context.Context, method, path string, query url.Values, body, out any) error {
+	target := b.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "centralctl")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if b.token != "" {
+		req.Header.Set("Authorization", "Bearer "+b.token)
+	}
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return decodeAPIError(resp)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("invalid response from %s %s: %w", method, path, err)
+	}
+	return nil
+}
+
+// Lê a mensagem de {"error": "..."}; sem ela, usa o texto do status
+func decodeAPIError(resp *http.Response) error {
+	var body struct {
+		Error string `json:"error"`
+	}
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if json.Unmarshal(data, &body) != nil || body.Error == "" {
+		body.Error = http.StatusText(resp.StatusCode)
+	}
+	return &APIError{StatusCode: resp.StatusCode, Message: body.Error}
+}
@@ -0,0 +1,321 @@
+package cli
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/handler"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// Comandos de centrais sobre o backend informado; os mesmos comandos servem
+// à API e ao modo admin
+func (a *app) centralCommands(newBackend backendFunc) []*cobra.Command {
+	return []*cobra.Command{
+		a.listCommand(newBackend),
+		a.getCommand(newBackend),
+		a.createCommand(newBackend),
+		a.updateCommand(newBackend),
+		a.deleteCommand(newBackend),
+		a.importCommand(newBackend),
+		a.exportCommand(newBackend),
+	}
+}
+
+func (a *app) listCommand(newBackend backendFunc) *cobra.Command {
+	var opts listOptions
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List centrals",
+		Example: "  centralctl list --site 3 --recursive --status offline -o json",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			b, err := newBackend()
+			if err != nil {
+				return err
+			}
+			centrals, err := b.ListCentrals(cmd.Context(), opts)
+			if err != nil {
+				return err
+			}
+			return a.writeCentrals(cmd.OutOrStdout(), centrals)
+		},
+	}
+	addListFlags(cmd, &opts)
+	return cmd
+}
+
+// Flags dos filtros da listagem, usadas também pelo export
+func addListFlags(cmd *cobra.Command, opts *listOptions) {
+	flags := cmd.Flags()
+	flags.UintVar(&opts.SiteID, "site", 0, "only centrals of this site")
+	flags.UintVar(&opts.LocationID, "location", 0, "only centrals of this location")
+	flags.BoolVar(&opts.Recursive, "recursive", false, "include the descendant locations of --location")
+	flags.BoolVar(&opts.Unplaced, "unplaced", false, "only centrals without a location")
+	flags.StringVarP(&opts.Selector, "selector", "l", "", "label selector, e.g. env=prod,tier in (a,b)")
+	flags.StringVar(&opts.Status, "status", "", "reachability status: online, offline, degraded or unknown")
+	flags.StringVar(&opts.Vendor, "vendor", "", "part of the vendor name")
+	flags.StringVar(&opts.IP, "ip", "", "IPv4 or IPv6 address")
+	flags.StringVar(&opts.Subnet, "subnet", "", "subnet in CIDR notation")
+	_ = cmd.RegisterFlagCompletionFunc("status", cobra.FixedCompletions([]string{
+		string(domain.StatusOnline), string(domain.StatusOffline), string(domain.StatusDegraded), string(domain.StatusUnknown),
+	}, cobra.ShellCompDirectiveNoFileComp))
+}
+
+func (a *app) getCommand(newBackend backendFunc) *cobra.Command {
+	return &cobra.Command{
+		Use:               "get ID",
+		Short:             "Show a central",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeCentralIDs(newBackend),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseID(args[0])
+			if err != nil {
+				return err
+			}
+			b, err := newBackend()
+			if err != nil {
+				return err
+			}
+			central, err := b.GetCentral(cmd.Context(), id)
+			if err != nil {
+				return err
+			}
+			return a.writeCentral(cmd.OutOrStdout(), central)
+		},
+	}
+}
+
+// Campos de create e update
+type centralFlags struct {
+	name, mac, ip, ipv4, ipv6, notes string
+	site, location, pool             uint
+	labels                           map[string]string
+	removeLabels                     []string
+	heartbeatWindow                  time.Duration
+}
+
+func (f *centralFlags) register(flags *pflag.FlagSet) {
+	flags.StringVar(&f.name, "name", "", "name of the central")
+	flags.StringVar(&f.mac, "mac", "", "MAC address, in any common notation")
+	flags.StringVar(&f.ip, "ip", "", "IPv4 or IPv6 address")
+	flags.StringVar(&f.ipv4, "ipv4", "", "IPv4 address")
+	flags.StringVar(&f.ipv6, "ipv6", "", "IPv6 address")
+	flags.StringVar(&f.notes, "notes", "", "free-form notes")
+	flags.UintVar(&f.site, "site", 0, "site ID")
+	flags.UintVar(&f.location, "location", 0, "location ID; the site is derived from it")
+	flags.StringToStringVar(&f.labels, "label", nil, "label as key=value; repeat for more")
+	flags.DurationVar(&f.heartbeatWindow, "heartbeat-window", 0, "time without heartbeat before the central is offline, e.g. 5m")
+}
+
+func (a *app) createCommand(newBackend backendFunc) *cobra.Command {
+	var f centralFlags
+	cmd := &cobra.Command{
+		Use:     "create",
+		Short:   "Create a central",
+		Example: "  centralctl create --name Portaria --mac 00:11:22:33:44:55 --ip 10.0.0.10 --label env=prod",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req := handler.CreateCentralRequest{
+				Name:                   f.name,
+				MAC:                    f.mac,
+				IP:                     f.ip,
+				IPv4:                   f.ipv4,
+				IPv6:                   f.ipv6,
+				Notes:                  f.notes,
+				SiteID:                 optionalFlagID(f.site),
+				LocationID:             optionalFlagID(f.location),
+				PoolID:                 optionalFlagID(f.pool),
+				Labels:                 f.labels,
+				HeartbeatWindowSeconds: int(f.heartbeatWindow / time.Second),
+			}
+			b, err := newBackend()
+			if err != nil {
+				return err
+			}
+			central, err := b.CreateCentral(cmd.Context(), req)
+			if err != nil {
+				return err
+			}
+			return a.writeCentral(cmd.OutOrStdout(), central)
+		},
+	}
+	f.register(cmd.Flags())
+	cmd.Flags().UintVar(&f.pool, "pool", 0, "allocate the next free address of this IPAM pool")
+	_ = cmd.MarkFlagRequired("name")
+	_ = cmd.MarkFlagRequired("mac")
+	return cmd
+}
+
+func (a *app) updateCommand(newBackend backendFunc) *cobra.Command {
+	var f centralFlags
+	cmd := &cobra.Command{
+		Use:   "update ID",
+		Short: "Change the given fields of a central",
+		Long: "Change the given fields of a central and keep the others. --label adds or\n" +
+			"replaces labels and --remove-label removes them. --site 0 and --location 0\n" +
+			"detach the central.",
+		Example:           "  centralctl update 12 --notes \"rack 3\" --label tier=b --remove-label legacy",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeCentralIDs(newBackend),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseID(args[0])
+			if err != nil {
+				return err
+			}
+			b, err := newBackend()
+			if err != nil {
+				return err
+			}
+			current, err := b.GetCentral(cmd.Context(), id)
+			if err != nil {
+				return err
+			}
+			central, err := b.UpdateCentral(cmd.Context(), id, f.applyTo(cmd.Flags(), current))
+			if err != nil {
+				return err
+			}
+			return a.writeCentral(cmd.OutOrStdout(), central)
+		},
+	}
+	f.register(cmd.Flags())
+	cmd.Flags().StringSliceVar(&f.removeLabels, "remove-label", nil, "label key to remove; repeat for more")
+	return cmd
+}
+
+// Corpo do PUT com a central atual e os campos das flags informadas
+func (f *centralFlags) applyTo(flags *pflag.FlagSet, current *handler.CentralResponse) handler.UpdateCentralRequest {
+	req := handler.UpdateCentralRequest{
+		Name:                   current.Name,
+		MAC:                    current.MAC,
+		IPv4:                   current.IPv4,
+		IPv6:                   current.IPv6,
+		Notes:                  current.Notes,
+		SiteID:                 current.SiteID,
+		LocationID:             current.LocationID,
+		HeartbeatWindowSeconds: current.HeartbeatWindowSeconds,
+	}
+	if flags.Changed("name") {
+		req.Name = f.name
+	}
+	if flags.Changed("mac") {
+		req.MAC = f.mac
+	}
+	if flags.Changed("ip") {
+		// A família definitiva é conferida no caso de uso
+		if strings.Contains(f.ip, ":") {
+			req.IPv6 = f.ip
+		} else {
+			req.IPv4 = f.ip
+		}
+	}
+	if flags.Changed("ipv4") {
+		req.IPv4 = f.ipv4
+	}
+	if flags.Changed("ipv6") {
+		req.IPv6 = f.ipv6
+	}
+	if flags.Changed("notes") {
+		req.Notes = f.notes
+	}
+	if flags.Changed("location") {
+		// O site passa a ser o da nova localização
+		req.LocationID = optionalFlagID(f.location)
+		req.SiteID = nil
+	}
+	if flags.Changed("site") {
+		req.SiteID = optionalFlagID(f.site)
+	}
+	if flags.Changed("heartbeat-window") {
+		req.HeartbeatWindowSeconds = int(f.heartbeatWindow / time.Second)
+	}
+	// Sem flags de label, os labels atuais são mantidos
+	if flags.Changed("label") || flags.Changed("remove-label") {
+		req.Labels = map[string]string{}
+		for key, value := range current.Labels {
+			req.Labels[key] = value
+		}
+		for key, value := range f.labels {
+			req.Labels[key] = value
+		}
+		for _, key := range f.removeLabels {
+			delete(req.Labels, key)
+		}
+	}
+	return req
+}
+
+func (a *app) deleteCommand(newBackend backendFunc) *cobra.Command {
+	return &cobra.Command{
+		Use:               "delete ID...",
+		Aliases:           []string{"rm"},
+		Short:             "Delete centrals",
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: completeCentralIDs(newBackend),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ids := make([]uint, 0, len(args))
+			for _, arg := range args {
+				id, err := parseID(arg)
+				if err != nil {
+					return err
+				}
+				ids = append(ids, id)
+			}
+			b, err := newBackend()
+			if err != nil {
+				return err
+			}
+			for _, id := range ids {
+				if err := b.DeleteCentral(cmd.Context(), id); err != nil {
+					return fmt.Errorf("central %d: %w", id, err)
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "central %d deleted\n", id)
+			}
+			return nil
+		},
+	}
+}
+
+// Completa IDs de centrais, com o nome como descrição
+func completeCentralIDs(newBackend backendFunc) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		b, err := newBackend()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		centrals, err := b.ListCentrals(context.Background(), listOptions{})
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		ids := make([]string, 0, len(centrals))
+		for _, c := range centrals {
+			id := strconv.FormatUint(uint64(c.ID), 10)
+			if strings.HasPrefix(id, toComplete) {
+				ids = append(ids, id+"\t"+c.Name)
+			}
+		}
+		return ids, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+func parseID(value string) (uint, error) {
+	id, err := strconv.ParseUint(value, 10, 0)
+	if err != nil || id == 0 {
+		return 0, fmt.Errorf("invalid central ID %q", value)
+	}
+	return uint(id), nil
+}
+
+// Flags de ID usam zero para "nenhum"
+func optionalFlagID(id uint) *uint {
+	if id == 0 {
+		return nil
+	}
+	return &id
+}
@@ -0,0 +1,105 @@
+// Package cli implementa o centralctl, cliente de linha de comando da API de
+// centrais. Os comandos de centrais falam com a API por HTTP; os mesmos
+// comandos sob "admin" acessam o banco direto, para recuperação com a API
+// fora do ar.
+package cli
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+)
+
+// Dependências substituíveis nos testes
+type Options struct {
+	// Cliente HTTP das chamadas à API; nil usa um com o prazo de --timeout
+	HTTPClient *http.Client
+	// Abre o banco do modo admin; nil abre o arquivo SQLite de --db
+	OpenDB func(path string) (*gorm.DB, error)
+}
+
+// Formatos de saída
+const (
+	OutputTable = "table"
+	OutputJSON  = "json"
+	OutputYAML  = "yaml"
+)
+
+// Variáveis de ambiente que substituem o perfil
+const (
+	envConfig  = "CENTRALCTL_CONFIG"
+	envProfile = "CENTRALCTL_PROFILE"
+	envServer  = "CENTRALCTL_SERVER"
+	envToken   = "CENTRALCTL_TOKEN"
+)
+
+const defaultServer = "http://localhost:8080"
+
+// Estado compartilhado pelos comandos: opções e flags globais
+type app struct {
+	opts Options
+
+	configPath string
+	profile    string
+	server     string
+	token      string
+	output     string
+	timeout    time.Duration
+	dbPath     string
+}
+
+// Monta o comando raiz do centralctl
+func NewRootCommand(opts Options) *cobra.Command {
+	a := &app{opts: opts}
+	root := &cobra.Command{
+		Use:   "centralctl",
+		Short: "Manage centrals through the central API",
+		Long: "centralctl manages centrals through the HTTP API. Server and token come from\n" +
+			"--server/--token, $" + envServer + "/$" + envToken + " or the current profile.\n" +
+			"The admin commands work on the database directly, for recovery.",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			switch a.output {
+			case OutputTable, OutputJSON, OutputYAML:
+				return nil
+			}
+			return fmt.Errorf("unknown output format %q (use table, json or yaml)", a.output)
+		},
+	}
+
+	flags := root.PersistentFlags()
+	flags.StringVar(&a.configPath, "config", "", "profiles file (default $"+envConfig+" or ~/.config/centralctl/config.yaml)")
+	flags.StringVarP(&a.profile, "profile", "p", "", "profile to use (default $"+envProfile+" or the current profile)")
+	flags.StringVar(&a.server, "server", "", "API URL (default from the profile, or "+defaultServer+")")
+	flags.StringVar(&a.token, "token", "", "API token sent as a bearer token")
+	flags.StringVarP(&a.output, "output", "o", OutputTable, "output format: table, json or yaml")
+	flags.DurationVar(&a.timeout, "timeout", 30*time.Second, "timeout of each API request")
+
+	_ = root.RegisterFlagCompletionFunc("output", cobra.FixedCompletions(
+		[]string{OutputTable, OutputJSON, OutputYAML}, cobra.ShellCompDirectiveNoFileComp))
+	_ = root.RegisterFlagCompletionFunc("profile", a.completeProfiles)
+
+	root.AddCommand(a.centralCommands(a.apiBackend)...)
+	root.AddCommand(a.configCommand(), a.adminCommand())
+	return root
+}
+
+// Primeiro valor não vazio
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// Perfil em uso: --profile, $CENTRALCTL_PROFILE ou o atual do arquivo
+func (a *app) profileName(s *settings) string {
+	return firstNonEmpty(a.profile, os.Getenv(envProfile), s.CurrentProfile)
+}
@@ -0,0 +1,310 @@
+package cli_test
+
+import (
+	"api-golang/internal/cli"
+	"api-golang/internal/config"
+	"api-golang/internal/handler"
+	"api-golang/internal/repository"
+	"api-golang/internal/usecase"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+	"gorm.io/gorm"
+)
+
+// Atende as requisições do CLI direto no app, sem abrir porta
+type appTransport struct {
+	app *fiber.App
+}
+
+func (t appTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.app.Test(req, -1)
+}
+
+// Servidor em processo com as rotas reais sobre um SQLite temporário
+type testServer struct {
+	db *gorm.DB
+	// Requisições recebidas, na ordem
+	requests []*http.Request
+}
+
+func setupServer(t *testing.T) *testServer {
+	db, err := config.OpenDB(filepath.Join(t.TempDir(), "central.db"))
+	require.NoError(t, err)
+	require.NoError(t, repository.Migrate(db))
+	return &testServer{db: db}
+}
+
+func (s *testServer) client() *http.Client {
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		req, _ := http.NewRequest(c.Method(), c.OriginalURL(), nil)
+		c.Request().Header.VisitAll(func(key, value []byte) {
+			req.Header.Set(string(key), string(value))
+		})
+		req.Host = string(c.Request().Host())
+		s.requests = append(s.requests, req)
+		return c.Next()
+	})
+	uc := usecase.NewCentralUseCase(repository.NewCentralRepository(s.db))
+	handler.RegisterCentralRoutes(app, handler.NewCentralHandler(uc))
+	return &http.Client{Transport: appTransport{app}}
+}
+
+// Executa o centralctl com um arquivo de perfis próprio do teste
+type runner struct {
+	t      *testing.T
+	opts   cli.Options
+	config string
+}
+
+func newRunner(t *testing.T, s *testServer) *runner {
+	return &runner{
+		t: t,
+		opts: cli.Options{
+			HTTPClient: s.client(),
+			OpenDB:     func(string) (*gorm.DB, error) { return s.db, nil },
+		},
+		config: filepath.Join(t.TempDir(), "config.yaml"),
+	}
+}
+
+func (r *runner) run(stdin string, args ...string) (stdout, stderr string, err error) {
+	cmd := cli.NewRootCommand(r.opts)
+	var out, errOut bytes.Buffer
+	cmd.SetIn(strings.NewReader(stdin))
+	cmd.SetOut(&out)
+	cmd.SetErr(&errOut)
+	cmd.SetArgs(append([]string{"--config", r.config}, args...))
+	err = cmd.Execute()
+	return out.String(), errOut.String(), err
+}
+
+// Executa e falha o teste em caso de erro
+func (r *runner) mustRun(args ...string) string {
+	r.t.Helper()
+	out, stderr, err := r.run("", args...)
+	require.NoError(r.t, err, stderr)
+	return out
+}
+
+func (r *runner) getJSON(id string) handler.CentralResponse {
+	r.t.Helper()
+	var central handler.CentralResponse
+	require.NoError(r.t, json.Unmarshal([]byte(r.mustRun("get", id, "-o", "json")), &central))
+	return central
+}
+
+func TestCreateGetAndList(t *testing.T) {
+	r := newRunner(t, setupServer(t))
+
+	out := r.mustRun("create", "--name", "Portaria", "--mac", "0011.2233.4455", "--ip", "10.0.0.10", "--label", "env=prod")
+	assert.Contains(t, out, "Portaria")
+	assert.Contains(t, out, "00:11:22:33:44:55")
+	r.mustRun("create", "--name", "Garagem", "--mac", "00:11:22:33:44:66", "--ipv4", "10.0.0.11", "--ipv6", "fd00::11")
+
+	central := r.getJSON("1")
+	assert.Equal(t, "Portaria", central.Name)
+	assert.Equal(t, map[string]string{"env": "prod"}, central.Labels)
+
+	table := r.mustRun("list")
+	lines := strings.Split(strings.TrimSpace(table), "\n")
+	require.Len(t, lines, 3)
+	assert.Regexp(t, `^ID\s+NAME\s+MAC\s+IP\s+STATUS`, lines[0])
+	assert.Regexp(t, `^2\s+Garagem\s+00:11:22:33:44:66\s+10\.0\.0\.11,fd00::11\s+unknown`, lines[2])
+
+	var filtered []handler.CentralResponse
+	require.NoError(t, json.Unmarshal([]byte(r.mustRun("list", "-l", "env=prod", "-o", "json")), &filtered))
+	require.Len(t, filtered, 1)
+	assert.Equal(t, uint(1), filtered[0].ID)
+}
+
+func TestYAMLOutputUsesAPIKeys(t *testing.T) {
+	r := newRunner(t, setupServer(t))
+	r.mustRun("create", "--name", "Portaria", "--mac", "00:11:22:33:44:55", "--ip", "10.0.0.10", "--notes", "123")
+
+	var centrals []map[string]any
+	require.NoError(t, yaml.Unmarshal([]byte(r.mustRun("list", "-o", "yaml")), &centrals))
+	require.Len(t, centrals, 1)
+	assert.Equal(t, "00:11:22:33:44:55", centrals[0]["mac"])
+	assert.Equal(t, "10.0.0.10", centrals[0]["ipv4"])
+	// Texto que parece número continua texto
+	assert.Equal(t, "123", centrals[0]["notes"])
+}
+
+func TestUpdateKeepsUnchangedFields(t *testing.T) {
+	r := newRunner(t, setupServer(t))
+	r.mustRun("create", "--name", "Portaria", "--mac", "00:11:22:33:44:55", "--ip", "10.0.0.10",
+		"--label", "env=prod", "--label", "legacy=yes")
+
+	r.mustRun("update", "1", "--notes", "rack 3", "--label", "tier=b", "--remove-label", "legacy", "--ip", "fd00::10")
+
+	central := r.getJSON("1")
+	assert.Equal(t, "Portaria", central.Name)
+	assert.Equal(t, "00:11:22:33:44:55", central.MAC)
+	assert.Equal(t, "rack 3", central.Notes)
+	assert.Equal(t, "10.0.0.10", central.IPv4)
+	assert.Equal(t, "fd00::10", central.IPv6)
+	assert.Equal(t, map[string]string{"env": "prod", "tier": "b"}, central.Labels)
+}
+
+func TestDeleteAndAPIErrors(t *testing.T) {
+	r := newRunner(t, setupServer(t))
+	r.mustRun("create", "--name", "Portaria", "--mac", "00:11:22:33:44:55", "--ip", "10.0.0.10")
+
+	assert.Equal(t, "central 1 deleted\n", r.mustRun("delete", "1"))
+
+	_, _, err := r.run("", "get", "1")
+	var apiErr *cli.APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+
+	_, _, err = r.run("", "create", "--name", "Inválida", "--mac", "xx", "--ip", "10.0.0.1")
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+
+	_, _, err = r.run("", "list", "-o", "xml")
+	assert.EqualError(t, err, `unknown output format "xml" (use table, json or yaml)`)
+}
+
+func TestExportImport(t *testing.T) {
+	source := newRunner(t, setupServer(t))
+	source.mustRun("create", "--name", "Portaria", "--mac", "00:11:22:33:44:55", "--ip", "10.0.0.10", "--label", "env=prod")
+	source.mustRun("create", "--name", "Garagem", "--mac", "00:11:22:33:44:66", "--ip", "10.0.0.11")
+
+	file := filepath.Join(t.TempDir(), "centrals.yaml")
+	source.mustRun("export", "-f", file)
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "id:")
+
+	target := newRunner(t, setupServer(t))
+	assert.Equal(t, "created 2, updated 0, failed 0\n", target.mustRun("import", file))
+	assert.Equal(t, map[string]string{"env": "prod"}, target.getJSON("1").Labels)
+
+	// JSON pelo stdin, atualizando a existente e criando a nova
+	payload := `[
+		{"name": "Portaria Norte", "mac": "00-11-22-33-44-55", "ipv4": "10.0.0.10"},
+		{"name": "Doca", "mac": "00:11:22:33:44:77", "ipv4": "10.0.0.12"},
+		{"name": "Duplicada", "mac": "00:11:22:33:44:88", "ipv4": "10.0.0.11"}
+	]`
+	out, stderr, err := target.run(payload, "import", "--upsert", "-")
+	assert.EqualError(t, err, "1 of 3 record(s) failed")
+	assert.Equal(t, "created 1, updated 1, failed 1\n", out)
+	assert.Contains(t, stderr, "record 3 (00:11:22:33:44:88)")
+
+	updated := target.getJSON("1")
+	assert.Equal(t, "Portaria Norte", updated.Name)
+	assert.Empty(t, updated.Labels)
+}
+
+func TestProfiles(t *testing.T) {
+	s := setupServer(t)
+	r := newRunner(t, s)
+
+	r.mustRun("config", "set-profile", "local", "--server", "http://localhost:8080")
+	r.mustRun("config", "set-profile", "prod", "--server", "https://centrals.example.com/", "--token", "s3cr3t", "--use")
+
+	profiles := r.mustRun("config", "get-profiles")
+	assert.NotContains(t, profiles, "s3cr3t")
+	assert.Regexp(t, `\*\s+prod\s+https://centrals.example.com/\s+set`, profiles)
+
+	r.mustRun("list")
+	req := s.requests[len(s.requests)-1]
+	assert.Equal(t, "centrals.example.com", req.Host)
+	assert.Equal(t, "Bearer s3cr3t", req.Header.Get("Authorization"))
+
+	// --profile e --token têm precedência sobre o perfil atual
+	r.mustRun("list", "--profile", "local")
+	req = s.requests[len(s.requests)-1]
+	assert.Equal(t, "localhost:8080", req.Host)
+	assert.Empty(t, req.Header.Get("Authorization"))
+
+	r.mustRun("list", "--token", "outro")
+	assert.Equal(t, "Bearer outro", s.requests[len(s.requests)-1].Header.Get("Authorization"))
+
+	_, _, err := r.run("", "list", "--profile", "staging")
+	assert.EqualError(t, err, `profile "staging" not found`)
+
+	info, err := os.Stat(r.config)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
+
+func TestAdminWorksWithoutAPI(t *testing.T) {
+	s := setupServer(t)
+	r := newRunner(t, s)
+	// Sem cliente HTTP válido, só o modo admin funciona
+	r.opts.HTTPClient = &http.Client{Transport: failingTransport{}}
+
+	assert.Contains(t, r.mustRun("admin", "migrate"), "database migrated")
+	r.mustRun("admin", "create", "--name", "Portaria", "--mac", "00:11:22:33:44:55", "--ip", "10.0.0.10")
+
+	out := r.mustRun("admin", "list", "--subnet", "10.0.0.0/24", "-o", "json")
+	var centrals []handler.CentralResponse
+	require.NoError(t, json.Unmarshal([]byte(out), &centrals))
+	require.Len(t, centrals, 1)
+	assert.Equal(t, "Portaria", centrals[0].Name)
+
+	_, _, err := r.run("", "admin", "create", "--name", "Sem IP", "--mac", "00:11:22:33:44:66")
+	assert.ErrorContains(t, err, "invalid")
+
+	_, _, err = r.run("", "list")
+	assert.ErrorIs(t, err, assert.AnError)
+
+	// O que o admin grava é visto pela API
+	r.opts.HTTPClient = s.client()
+	assert.Contains(t, r.mustRun("get", "1"), "Portaria")
+}
+
+type failingTransport struct{}
+
+func (failingTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, assert.AnError
+}
+
+func TestCompletion(t *testing.T) {
+	r := newRunner(t, setupServer(t))
+	r.mustRun("create", "--name", "Portaria", "--mac", "00:11:22:33:44:55", "--ip", "10.0.0.10")
+	r.mustRun("config", "set-profile", "prod", "--server", "http://localhost:8080")
+
+	out := r.mustRun("__complete", "get", "")
+	assert.Contains(t, out, "1\tPortaria")
+
+	out = r.mustRun("__complete", "list", "--profile", "")
+	assert.Contains(t, out, "prod")
+
+	out = r.mustRun("__complete", "list", "--status", "off")
+	assert.Contains(t, out, "offline")
+
+	assert.Contains(t, r.mustRun("completion", "bash"), "__start_centralctl")
+}
+
+func TestAdminUsesOUIFile(t *testing.T) {
+	s := setupServer(t)
+	r := newRunner(t, s)
+
+	// O admin atribui os fabricantes da mesma base que o servidor
+	path := filepath.Join(t.TempDir(), "oui.txt")
+	require.NoError(t, os.WriteFile(path, []byte("3C-BB-CC   (hex)\t\tExemplo Ltda\n"), 0o644))
+	t.Setenv("OUI_FILE", path)
+	r.mustRun("admin", "create", "--name", "Portaria", "--mac", "3c:bb:cc:00:00:01", "--ip", "10.0.0.10")
+
+	out := r.mustRun("admin", "get", "1", "-o", "json")
+	var central handler.CentralResponse
+	require.NoError(t, json.Unmarshal([]byte(out), &central))
+	assert.Equal(t, "Exemplo Ltda", central.Vendor)
+
+	t.Setenv("OUI_FILE", filepath.Join(t.TempDir(), "missing.txt"))
+	_, _, err := r.run("", "admin", "get", "1")
+	assert.ErrorContains(t, err, "failed to load OUI file")
+}
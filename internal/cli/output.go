@@ -0,0 +1,117 @@
+package cli
+
+import (
+	"api-golang/internal/handler"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Escreve o valor no formato de --output; em tabela, usa as colunas de table
+func (a *app) write(w io.Writer, value any, table func(*tabwriter.Writer)) error {
+	switch a.output {
+	case OutputJSON:
+		return writeJSON(w, value)
+	case OutputYAML:
+		return writeYAML(w, value)
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	table(tw)
+	return tw.Flush()
+}
+
+func writeJSON(w io.Writer, value any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(value)
+}
+
+// YAML com as mesmas chaves e a mesma ordem do JSON da API
+func writeYAML(w io.Writer, value any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return err
+	}
+	blockStyle(&node)
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(&node); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// O JSON é lido em estilo de fluxo e com aspas; o encoder volta a pôr aspas
+// onde o valor seria lido como outro tipo
+func blockStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		blockStyle(child)
+	}
+}
+
+func (a *app) writeCentrals(w io.Writer, centrals []handler.CentralResponse) error {
+	if centrals == nil {
+		centrals = []handler.CentralResponse{}
+	}
+	return a.write(w, centrals, func(tw *tabwriter.Writer) {
+		fmt.Fprintln(tw, "ID\tNAME\tMAC\tIP\tSTATUS\tSITE\tLOCATION\tVENDOR\tLABELS")
+		for i := range centrals {
+			c := &centrals[i]
+			fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				c.ID, c.Name, c.MAC, orDash(addresses(c)), c.Status,
+				optionalID(c.SiteID), optionalID(c.LocationID), orDash(c.Vendor), orDash(formatLabels(c.Labels)))
+		}
+	})
+}
+
+func (a *app) writeCentral(w io.Writer, central *handler.CentralResponse) error {
+	if a.output == OutputTable {
+		return a.writeCentrals(w, []handler.CentralResponse{*central})
+	}
+	return a.write(w, central, nil)
+}
+
+// IPv4 e IPv6 da central, separados por vírgula
+func addresses(c *handler.CentralResponse) string {
+	var ips []string
+	for _, ip := range []string{c.IPv4, c.IPv6} {
+		if ip != "" {
+			ips = append(ips, ip)
+		}
+	}
+	return strings.Join(ips, ",")
+}
+
+// Labels em ordem de chave, ex. "env=prod,tier=a"
+func formatLabels(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for key, value := range labels {
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func optionalID(id *uint) string {
+	if id == nil {
+		return "-"
+	}
+	return fmt.Sprint(*id)
+}
+
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
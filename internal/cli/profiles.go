@@ -0,0 +1,227 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// Arquivo de perfis, ex.:
+//
+//	current_profile: prod
+//	profiles:
+//	  prod:
+//	    server: https://centrals.example.com
+//	    token: s3cr3t
+type settings struct {
+	CurrentProfile string             `yaml:"current_profile,omitempty"`
+	Profiles       map[string]profile `yaml:"profiles,omitempty"`
+}
+
+type profile struct {
+	Server string `yaml:"server,omitempty"`
+	Token  string `yaml:"token,omitempty"`
+}
+
+// Caminho do arquivo de perfis: --config, $CENTRALCTL_CONFIG ou o diretório
+// de configuração do usuário
+func (a *app) settingsPath() string {
+	if path := firstNonEmpty(a.configPath, os.Getenv(envConfig)); path != "" {
+		return path
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		dir = "."
+	}
+	return filepath.Join(dir, "centralctl", "config.yaml")
+}
+
+// Arquivo ausente equivale a nenhum perfil
+func loadSettings(path string) (*settings, error) {
+	s := &settings{}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("invalid profiles file %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Grava só para o usuário, já que o arquivo guarda tokens
+func saveSettings(path string, s *settings) error {
+	data, err := yaml.Marshal(s)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+func (a *app) configCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Manage connection profiles",
+	}
+
+	var use bool
+	setProfile := &cobra.Command{
+		Use:     "set-profile NAME",
+		Short:   "Create or update a profile with the given --server and --token",
+		Example: "  centralctl config set-profile prod --server https://centrals.example.com --token s3cr3t --use",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Só as flags informadas alteram um perfil existente
+			err := a.updateSettings(func(s *settings) error {
+				if s.Profiles == nil {
+					s.Profiles = map[string]profile{}
+				}
+				p := s.Profiles[args[0]]
+				if cmd.Flags().Changed("server") {
+					p.Server = a.server
+				}
+				if cmd.Flags().Changed("token") {
+					p.Token = a.token
+				}
+				s.Profiles[args[0]] = p
+				if use || s.CurrentProfile == "" {
+					s.CurrentProfile = args[0]
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "profile %q saved\n", args[0])
+			return nil
+		},
+	}
+	setProfile.Flags().BoolVar(&use, "use", false, "make it the current profile")
+
+	useProfile := &cobra.Command{
+		Use:               "use-profile NAME",
+		Short:             "Set the current profile",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: a.completeProfiles,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			err := a.updateSettings(func(s *settings) error {
+				if _, ok := s.Profiles[args[0]]; !ok {
+					return fmt.Errorf("profile %q not found", args[0])
+				}
+				s.CurrentProfile = args[0]
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "using profile %q\n", args[0])
+			return nil
+		},
+	}
+
+	deleteProfile := &cobra.Command{
+		Use:               "delete-profile NAME",
+		Short:             "Remove a profile",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: a.completeProfiles,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			err := a.updateSettings(func(s *settings) error {
+				if _, ok := s.Profiles[args[0]]; !ok {
+					return fmt.Errorf("profile %q not found", args[0])
+				}
+				delete(s.Profiles, args[0])
+				if s.CurrentProfile == args[0] {
+					s.CurrentProfile = ""
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "profile %q deleted\n", args[0])
+			return nil
+		},
+	}
+
+	getProfiles := &cobra.Command{
+		Use:   "get-profiles",
+		Short: "List the profiles; tokens are not shown",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s, err := loadSettings(a.settingsPath())
+			if err != nil {
+				return err
+			}
+			type profileView struct {
+				Name     string `json:"name"`
+				Server   string `json:"server"`
+				HasToken bool   `json:"has_token"`
+				Current  bool   `json:"current"`
+			}
+			views := []profileView{}
+			for _, name := range profileNames(s) {
+				p := s.Profiles[name]
+				views = append(views, profileView{Name: name, Server: p.Server, HasToken: p.Token != "", Current: name == s.CurrentProfile})
+			}
+			return a.write(cmd.OutOrStdout(), views, func(w *tabwriter.Writer) {
+				fmt.Fprintln(w, "CURRENT\tNAME\tSERVER\tTOKEN")
+				for _, v := range views {
+					fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", mark(v.Current, "*", ""), v.Name, v.Server, mark(v.HasToken, "set", "-"))
+				}
+			})
+		},
+	}
+
+	cmd.AddCommand(setProfile, useProfile, deleteProfile, getProfiles)
+	return cmd
+}
+
+// Lê, altera e grava o arquivo de perfis
+func (a *app) updateSettings(change func(*settings) error) error {
+	path := a.settingsPath()
+	s, err := loadSettings(path)
+	if err != nil {
+		return err
+	}
+	if err := change(s); err != nil {
+		return err
+	}
+	return saveSettings(path, s)
+}
+
+func profileNames(s *settings) []string {
+	names := make([]string, 0, len(s.Profiles))
+	for name := range s.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (a *app) completeProfiles(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	s, err := loadSettings(a.settingsPath())
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	return profileNames(s), cobra.ShellCompDirectiveNoFileComp
+}
+
+func mark(ok bool, yes, no string) string {
+	if ok {
+		return yes
+	}
+	return no
+}
@@ -0,0 +1,226 @@
+package cli
+
+import (
+	"api-golang/internal/handler"
+	"api-golang/internal/utils"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// Central no formato de import/export: só os campos que a criação aceita,
+// sem ID nem situação
+type centralRecord struct {
+	Name                   string            `json:"name" yaml:"name"`
+	MAC                    string            `json:"mac" yaml:"mac"`
+	IPv4                   string            `json:"ipv4,omitempty" yaml:"ipv4,omitempty"`
+	IPv6                   string            `json:"ipv6,omitempty" yaml:"ipv6,omitempty"`
+	Notes                  string            `json:"notes,omitempty" yaml:"notes,omitempty"`
+	SiteID                 *uint             `json:"site_id,omitempty" yaml:"site_id,omitempty"`
+	LocationID             *uint             `json:"location_id,omitempty" yaml:"location_id,omitempty"`
+	Labels                 map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	HeartbeatWindowSeconds int               `json:"heartbeat_window_seconds,omitempty" yaml:"heartbeat_window_seconds,omitempty"`
+}
+
+func newCentralRecord(c *handler.CentralResponse) centralRecord {
+	return centralRecord{
+		Name:                   c.Name,
+		MAC:                    c.MAC,
+		IPv4:                   c.IPv4,
+		IPv6:                   c.IPv6,
+		Notes:                  c.Notes,
+		SiteID:                 c.SiteID,
+		LocationID:             c.LocationID,
+		Labels:                 c.Labels,
+		HeartbeatWindowSeconds: c.HeartbeatWindowSeconds,
+	}
+}
+
+func (r centralRecord) createRequest() handler.CreateCentralRequest {
+	return handler.CreateCentralRequest{
+		Name:                   r.Name,
+		MAC:                    r.MAC,
+		IPv4:                   r.IPv4,
+		IPv6:                   r.IPv6,
+		Notes:                  r.Notes,
+		SiteID:                 r.SiteID,
+		LocationID:             r.LocationID,
+		Labels:                 r.Labels,
+		HeartbeatWindowSeconds: r.HeartbeatWindowSeconds,
+	}
+}
+
+// Na atualização, labels ausentes no arquivo removem os atuais
+func (r centralRecord) updateRequest() handler.UpdateCentralRequest {
+	labels := r.Labels
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	return handler.UpdateCentralRequest{
+		Name:                   r.Name,
+		MAC:                    r.MAC,
+		IPv4:                   r.IPv4,
+		IPv6:                   r.IPv6,
+		Notes:                  r.Notes,
+		SiteID:                 r.SiteID,
+		LocationID:             r.LocationID,
+		Labels:                 labels,
+		HeartbeatWindowSeconds: r.HeartbeatWindowSeconds,
+	}
+}
+
+func (a *app) exportCommand(newBackend backendFunc) *cobra.Command {
+	var (
+		opts listOptions
+		file string
+	)
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export centrals in the import format",
+		Long: "Export the centrals matching the filters as a YAML list, or JSON with -o json.\n" +
+			"IDs and status are left out, so the file can be imported into another server.",
+		Example: "  centralctl export --site 3 -f site3.yaml",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			b, err := newBackend()
+			if err != nil {
+				return err
+			}
+			centrals, err := b.ListCentrals(cmd.Context(), opts)
+			if err != nil {
+				return err
+			}
+			records := make([]centralRecord, 0, len(centrals))
+			for i := range centrals {
+				records = append(records, newCentralRecord(&centrals[i]))
+			}
+
+			w := cmd.OutOrStdout()
+			if file != "" && file != "-" {
+				f, err := os.Create(file)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				w = f
+			}
+			if a.output == OutputJSON {
+				err = writeJSON(w, records)
+			} else {
+				err = writeYAML(w, records)
+			}
+			if err != nil {
+				return err
+			}
+			if w != cmd.OutOrStdout() {
+				fmt.Fprintf(cmd.ErrOrStderr(), "exported %d central(s) to %s\n", len(records), file)
+			}
+			return nil
+		},
+	}
+	addListFlags(cmd, &opts)
+	cmd.Flags().StringVarP(&file, "file", "f", "-", "output file; - writes to stdout")
+	return cmd
+}
+
+func (a *app) importCommand(newBackend backendFunc) *cobra.Command {
+	var upsert bool
+	cmd := &cobra.Command{
+		Use:   "import FILE",
+		Short: "Create centrals from a YAML or JSON file",
+		Long: "Create the centrals listed in FILE (- reads stdin), in the format written by\n" +
+			"export. With --upsert, centrals whose MAC already exists are updated instead.\n" +
+			"Failed records are reported and the others are still imported.",
+		Example: "  centralctl import --upsert site3.yaml",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			records, err := readRecords(cmd.InOrStdin(), args[0])
+			if err != nil {
+				return err
+			}
+			b, err := newBackend()
+			if err != nil {
+				return err
+			}
+
+			// Centrais existentes pelo MAC canônico
+			existing := map[string]uint{}
+			if upsert {
+				centrals, err := b.ListCentrals(cmd.Context(), listOptions{})
+				if err != nil {
+					return err
+				}
+				for _, c := range centrals {
+					existing[canonicalMAC(c.MAC)] = c.ID
+				}
+			}
+
+			var created, updated, failed int
+			for i, record := range records {
+				if id, ok := existing[canonicalMAC(record.MAC)]; ok {
+					_, err = b.UpdateCentral(cmd.Context(), id, record.updateRequest())
+					if err == nil {
+						updated++
+					}
+				} else {
+					_, err = b.CreateCentral(cmd.Context(), record.createRequest())
+					if err == nil {
+						created++
+					}
+				}
+				if err != nil {
+					failed++
+					fmt.Fprintf(cmd.ErrOrStderr(), "record %d (%s): %v\n", i+1, record.MAC, err)
+				}
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "created %d, updated %d, failed %d\n", created, updated, failed)
+			if failed > 0 {
+				return fmt.Errorf("%d of %d record(s) failed", failed, len(records))
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&upsert, "upsert", false, "update centrals that already exist with the same MAC")
+	return cmd
+}
+
+// Lê a lista de registros em JSON ou YAML
+func readRecords(stdin io.Reader, file string) ([]centralRecord, error) {
+	var (
+		data []byte
+		err  error
+	)
+	if file == "-" {
+		data, err = io.ReadAll(stdin)
+	} else {
+		data, err = os.ReadFile(file)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var records []centralRecord
+	// JSON indentado com tabs não é YAML válido
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		err = json.Unmarshal(trimmed, &records)
+	} else {
+		err = yaml.Unmarshal(data, &records)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid import file %s: %w", file, err)
+	}
+	return records, nil
+}
+
+// MAC na forma canônica; inválidos ficam como estão e a API os recusa
+func canonicalMAC(mac string) string {
+	if normalized, err := utils.NormalizeMAC(mac); err == nil {
+		return normalized
+	}
+	return mac
+}
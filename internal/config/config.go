@@ -0,0 +1,280 @@
+package config
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Modos de validação pelo contrato OpenAPI
+const (
+	OpenAPIValidationOff      = "off"
+	OpenAPIValidationRequest  = "request"
+	OpenAPIValidationResponse = "response"
+)
+
+type Config struct {
+	Addr              string
+	OpenAPIValidation string
+	// Arquivo oui.txt do IEEE que substitui a base embutida
+	OUIFile string
+	// Tokens aceitos pelas conexões autenticadas (WebSocket); vazio dispensa
+	// a autenticação
+	APITokens []string
+	// Endereço do servidor gRPC; "off" o desliga
+	GRPCAddr string
+
+	Monitor   MonitorConfig
+	Heartbeat HeartbeatConfig
+	Telemetry TelemetryConfig
+	// Intervalo entre avaliações das regras de alerta; zero as desliga
+	AlertEvaluationInterval time.Duration
+	Webhook                 WebhookConfig
+	Outbox                  OutboxConfig
+	Stream                  StreamConfig
+	// Intervalo dos pings nas conexões WebSocket
+	WebSocketPingInterval time.Duration
+	GraphQL               GraphQLConfig
+	SNMP                  SNMPConfig
+	Discovery             DiscoveryConfig
+}
+
+// Descoberta de rede: sub-redes varridas (sem nenhuma, a varredura fica
+// desligada), intervalo (zero desliga), portas TCP sondadas, prazo de cada
+// conexão, conexões simultâneas e a tabela ARP lida após a varredura
+type DiscoveryConfig struct {
+	Subnets     []string
+	Interval    time.Duration
+	Ports       []int
+	Timeout     time.Duration
+	Concurrency int
+	ARPFile     string
+}
+
+// Coletor SNMP: chave (base64 ou hex, 32 bytes) que cifra as credenciais,
+// sem a qual a coleta e as rotas SNMP ficam desligadas; intervalo zero
+// desliga só a coleta
+type SNMPConfig struct {
+	SecretKey      string
+	Interval       time.Duration
+	Timeout        time.Duration
+	Retries        int
+	MaxRepetitions int
+	DegradedRTT    time.Duration
+	Concurrency    int
+}
+
+// Limites das operações GraphQL: profundidade das seleções e custo estimado
+type GraphQLConfig struct {
+	MaxDepth      int
+	MaxComplexity int
+}
+
+// Stream SSE de eventos: frequência da leitura do outbox (zero desliga o
+// stream), eventos guardados por cliente e intervalo dos keepalives
+type StreamConfig struct {
+	PollInterval time.Duration
+	BufferSize   int
+	KeepAlive    time.Duration
+}
+
+// Destinos do relay do outbox
+const (
+	OutboxSinkWebhook = "webhook"
+	OutboxSinkNATS    = "nats"
+	OutboxSinkLog     = "log"
+)
+
+// Relay do outbox: frequência (zero o desliga), espera para reenviar uma
+// central após uma falha, destinos e por quanto tempo os eventos publicados
+// são guardados (zero guarda para sempre)
+type OutboxConfig struct {
+	RelayInterval time.Duration
+	RetryDelay    time.Duration
+	Sinks         []string
+	Retention     time.Duration
+	NATSURL       string
+	NATSSubject   string
+}
+
+// Envio dos webhooks: frequência da fila (zero desliga o envio), tentativas
+// até o dead-letter, backoff entre tentativas e prazo de cada requisição
+type WebhookConfig struct {
+	Interval    time.Duration
+	MaxAttempts int
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	Timeout     time.Duration
+}
+
+// Retenção de cada resolução da telemetria (zero mantém para sempre) e
+// frequência dos rollups e da limpeza; intervalo zero desliga a manutenção
+type TelemetryConfig struct {
+	RawRetention        time.Duration
+	FiveMinuteRetention time.Duration
+	HourRetention       time.Duration
+	MaintenanceInterval time.Duration
+}
+
+// Prazo padrão sem heartbeat e frequência da verificação de prazos vencidos
+type HeartbeatConfig struct {
+	Window        time.Duration
+	CheckInterval time.Duration
+}
+
+// Métodos de sondagem do monitor de alcance
+const (
+	MonitorMethodTCP  = "tcp"
+	MonitorMethodICMP = "icmp"
+)
+
+// Configuração do monitor de alcance; intervalo zero o desliga
+type MonitorConfig struct {
+	Interval    time.Duration
+	Method      string
+	Ports       []int
+	Timeout     time.Duration
+	DegradedRTT time.Duration
+	Concurrency int
+}
+
+// Carrega a configuração a partir das variáveis de ambiente
+func Load() Config {
+	return Config{
+		Addr:              getEnv("APP_ADDR", ":8080"),
+		GRPCAddr:          getEnv("GRPC_ADDR", ":9090"),
+		OpenAPIValidation: getEnv("OPENAPI_VALIDATION", OpenAPIValidationOff),
+		OUIFile:           getEnv("OUI_FILE", ""),
+		APITokens:         getList("API_TOKENS", nil),
+		Monitor: MonitorConfig{
+			Interval:    getDuration("MONITOR_INTERVAL", time.Minute),
+			Method:      getEnv("MONITOR_METHOD", MonitorMethodTCP),
+			Ports:       getInts("MONITOR_PORTS", []int{80, 443}),
+			Timeout:     getDuration("MONITOR_TIMEOUT", 2*time.Second),
+			DegradedRTT: getDuration("MONITOR_DEGRADED_RTT", 500*time.Millisecond),
+			Concurrency: getInt("MONITOR_CONCURRENCY", 16),
+		},
+		Heartbeat: HeartbeatConfig{
+			Window:        getDuration("HEARTBEAT_WINDOW", 5*time.Minute),
+			CheckInterval: getDuration("HEARTBEAT_CHECK_INTERVAL", 30*time.Second),
+		},
+		AlertEvaluationInterval: getDuration("ALERT_EVALUATION_INTERVAL", 30*time.Second),
+		Telemetry: TelemetryConfig{
+			RawRetention:        getDuration("TELEMETRY_RAW_RETENTION", 7*24*time.Hour),
+			FiveMinuteRetention: getDuration("TELEMETRY_5M_RETENTION", 30*24*time.Hour),
+			HourRetention:       getDuration("TELEMETRY_1H_RETENTION", 365*24*time.Hour),
+			MaintenanceInterval: getDuration("TELEMETRY_MAINTENANCE_INTERVAL", 5*time.Minute),
+		},
+		Webhook: WebhookConfig{
+			Interval:    getDuration("WEBHOOK_INTERVAL", 5*time.Second),
+			MaxAttempts: getInt("WEBHOOK_MAX_ATTEMPTS", 8),
+			BaseBackoff: getDuration("WEBHOOK_BASE_BACKOFF", 10*time.Second),
+			MaxBackoff:  getDuration("WEBHOOK_MAX_BACKOFF", time.Hour),
+			Timeout:     getDuration("WEBHOOK_TIMEOUT", 10*time.Second),
+		},
+		Outbox: OutboxConfig{
+			RelayInterval: getDuration("OUTBOX_RELAY_INTERVAL", time.Second),
+			RetryDelay:    getDuration("OUTBOX_RETRY_DELAY", 30*time.Second),
+			Sinks:         getList("OUTBOX_SINKS", []string{OutboxSinkWebhook}),
+			Retention:     getDuration("OUTBOX_RETENTION", 24*time.Hour),
+			NATSURL:       getEnv("NATS_URL", "nats://127.0.0.1:4222"),
+			NATSSubject:   getEnv("NATS_SUBJECT_PREFIX", "centrals"),
+		},
+		Stream: StreamConfig{
+			PollInterval: getDuration("STREAM_POLL_INTERVAL", 500*time.Millisecond),
+			BufferSize:   getInt("STREAM_BUFFER", 256),
+			KeepAlive:    getDuration("STREAM_KEEPALIVE", 15*time.Second),
+		},
+		WebSocketPingInterval: getDuration("WS_PING_INTERVAL", 30*time.Second),
+		GraphQL: GraphQLConfig{
+			MaxDepth:      getInt("GRAPHQL_MAX_DEPTH", 8),
+			MaxComplexity: getInt("GRAPHQL_MAX_COMPLEXITY", 5000),
+		},
+		SNMP: SNMPConfig{
+			SecretKey:      getEnv("SNMP_SECRET_KEY", ""),
+			Interval:       getDuration("SNMP_INTERVAL", 5*time.Minute),
+			Timeout:        getDuration("SNMP_TIMEOUT", 2*time.Second),
+			Retries:        getInt("SNMP_RETRIES", 1),
+			MaxRepetitions: getInt("SNMP_MAX_REPETITIONS", 20),
+			DegradedRTT:    getDuration("SNMP_DEGRADED_RTT", time.Second),
+			Concurrency:    getInt("SNMP_CONCURRENCY", 16),
+		},
+		Discovery: DiscoveryConfig{
+			Subnets:     getList("DISCOVERY_SUBNETS", nil),
+			Interval:    getDuration("DISCOVERY_INTERVAL", time.Hour),
+			Ports:       getInts("DISCOVERY_PORTS", []int{22, 23, 80, 443, 445, 554, 3389, 5060, 5061, 8080, 9100}),
+			Timeout:     getDuration("DISCOVERY_TIMEOUT", time.Second),
+			Concurrency: getInt("DISCOVERY_CONCURRENCY", 256),
+			ARPFile:     getEnv("DISCOVERY_ARP_FILE", "/proc/net/arp"),
+		},
+	}
+}
+
+func getEnv(key, fallback string) string {
+	if value, ok := os.LookupEnv(key); ok && value != "" {
+		return value
+	}
+	return fallback
+}
+
+// Valores inválidos são reportados e trocados pelo padrão
+func getDuration(key string, fallback time.Duration) time.Duration {
+	value := getEnv(key, "")
+	if value == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		log.Printf("WARNING: invalid %s %q, using %s", key, value, fallback)
+		return fallback
+	}
+	return d
+}
+
+func getInt(key string, fallback int) int {
+	value := getEnv(key, "")
+	if value == "" {
+		return fallback
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		log.Printf("WARNING: invalid %s %q, using %d", key, value, fallback)
+		return fallback
+	}
+	return n
+}
+
+// Lista separada por vírgulas, ex. "80,443"
+func getInts(key string, fallback []int) []int {
+	value := getEnv(key, "")
+	if value == "" {
+		return fallback
+	}
+	var ints []int
+	for _, part := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || n <= 0 {
+			log.Printf("WARNING: invalid %s %q, using %v", key, value, fallback)
+			return fallback
+		}
+		ints = append(ints, n)
+	}
+	return ints
+}
+
+// Lista separada por vírgulas, ex. "webhook,log"
+func getList(key string, fallback []string) []string {
+	value := getEnv(key, "")
+	if value == "" {
+		return fallback
+	}
+	var list []string
+	for _, part := range strings.Split(value, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			list = append(list, part)
+		}
+	}
+	return list
+}
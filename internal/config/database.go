@@ -1,12 +1,28 @@
 package config
 
 import (
+	"strings"
+
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
 
+// Parâmetros de conexão do SQLite: espera até 5s por um lock em vez de
+// falhar com "database is locked", e as transações reservam a escrita já
+// no BEGIN, o que serializa as alocações concorrentes de IP.
+const sqliteParams = "_busy_timeout=5000&_txlock=immediate"
+
 func InitDB() (*gorm.DB, error) {
-	db, err := gorm.Open(sqlite.Open("database.db"), &gorm.Config{})
+	return OpenDB("database.db")
+}
+
+// Abre o banco SQLite no caminho informado
+func OpenDB(path string) (*gorm.DB, error) {
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+	db, err := gorm.Open(sqlite.Open(path+separator+sqliteParams), &gorm.Config{})
 	if err != nil {
 		return nil, err
 	}
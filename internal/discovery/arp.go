@@ -0,0 +1,63 @@
+package discovery
+
+import (
+	"api-golang/internal/utils"
+	"bufio"
+	"errors"
+	"io"
+	"io/fs"
+	"net/netip"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Tabela ARP do kernel no Linux
+const DefaultARPFile = "/proc/net/arp"
+
+// Flag ATF_COM: a entrada foi resolvida
+const arpComplete = 0x2
+
+// Lê a tabela ARP no formato de /proc/net/arp. Sem o arquivo (fora do
+// Linux, ou num contêiner que não o expõe), retorna uma tabela vazia.
+func ReadARPTable(path string) (map[netip.Addr]string, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ParseARPTable(f)
+}
+
+// Converte as entradas resolvidas da tabela em IP -> MAC canônico. A
+// primeira linha é o cabeçalho; entradas incompletas são ignoradas.
+//
+//	IP address       HW type     Flags       HW address            Mask     Device
+//	192.168.0.10     0x1         0x2         00:11:22:33:44:55     *        eth0
+func ParseARPTable(r io.Reader) (map[netip.Addr]string, error) {
+	table := map[netip.Addr]string{}
+	scanner := bufio.NewScanner(r)
+	for header := true; scanner.Scan(); header = false {
+		fields := strings.Fields(scanner.Text())
+		if header || len(fields) < 4 {
+			continue
+		}
+		addr, err := netip.ParseAddr(fields[0])
+		if err != nil {
+			continue
+		}
+		flags, err := strconv.ParseUint(fields[2], 0, 32)
+		if err != nil || flags&arpComplete == 0 {
+			continue
+		}
+		mac, err := utils.NormalizeMAC(fields[3])
+		if err != nil || mac == "00:00:00:00:00:00" {
+			continue
+		}
+		table[addr] = mac
+	}
+	return table, scanner.Err()
+}
@@ -0,0 +1,175 @@
+package discovery_test
+
+import (
+	"api-golang/internal/discovery"
+	"api-golang/internal/domain"
+	"context"
+	"errors"
+	"net"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Abre um listener local que faz as vezes de um equipamento
+func listen(t *testing.T) int {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+	return ln.Addr().(*net.TCPAddr).Port
+}
+
+// Porta que recusa conexões
+func closedPort(t *testing.T) int {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := ln.Addr().(*net.TCPAddr).Port
+	ln.Close()
+	return port
+}
+
+func TestTCPScanner(t *testing.T) {
+	open, closed := listen(t), closedPort(t)
+	scanner := &discovery.TCPScanner{Ports: []int{closed, open}, Timeout: time.Second}
+
+	hosts, err := scanner.Scan(context.Background(), netip.MustParsePrefix("127.0.0.1/32"))
+	require.NoError(t, err)
+	require.Len(t, hosts, 1)
+	assert.Equal(t, "127.0.0.1", hosts[0].IP.String())
+	assert.Equal(t, []int{open}, hosts[0].OpenPorts)
+
+	// Só recusas ainda indicam um host presente
+	hosts, err = (&discovery.TCPScanner{Ports: []int{closed}, Timeout: time.Second}).
+		Scan(context.Background(), netip.MustParsePrefix("127.0.0.1/32"))
+	require.NoError(t, err)
+	require.Len(t, hosts, 1)
+	assert.Empty(t, hosts[0].OpenPorts)
+
+	_, err = scanner.Scan(context.Background(), netip.MustParsePrefix("10.0.0.0/8"))
+	assert.Error(t, err)
+}
+
+const arpTable = `IP address       HW type     Flags       HW address            Mask     Device
+192.168.0.1      0x1         0x2         00:1A:2B:3C:4D:5E     *        eth0
+192.168.0.20     0x1         0x0         00:00:00:00:00:00     *        eth0
+192.168.0.30     0x1         0x2         00:50:56:aa:bb:cc     *        eth0
+10.9.0.1         0x1         0x2         52:54:00:12:34:56     *        eth1
+`
+
+func TestParseARPTable(t *testing.T) {
+	table, err := discovery.ParseARPTable(strings.NewReader(arpTable))
+	require.NoError(t, err)
+	assert.Equal(t, map[netip.Addr]string{
+		netip.MustParseAddr("192.168.0.1"):  "00:1a:2b:3c:4d:5e",
+		netip.MustParseAddr("192.168.0.30"): "00:50:56:aa:bb:cc",
+		netip.MustParseAddr("10.9.0.1"):     "52:54:00:12:34:56",
+	}, table)
+
+	// Sem tabela ARP disponível
+	table, err = discovery.ReadARPTable(filepath.Join(t.TempDir(), "missing"))
+	assert.NoError(t, err)
+	assert.Empty(t, table)
+}
+
+func TestFingerprint(t *testing.T) {
+	cases := []struct {
+		ports  []int
+		vendor string
+		want   string
+	}{
+		{[]int{80, 5060}, "", domain.FingerprintPBX},
+		{[]int{80}, "Intelbras", domain.FingerprintPBX},
+		{[]int{80, 5060}, "Yealink Network Technology Co.,Ltd.", domain.FingerprintPhone},
+		{[]int{554}, "", domain.FingerprintCamera},
+		{[]int{9100}, "", domain.FingerprintPrinter},
+		{[]int{22, 443}, "Cisco Systems, Inc", domain.FingerprintNetwork},
+		{[]int{22}, "", domain.FingerprintServer},
+		{[]int{80}, "VMware, Inc.", domain.FingerprintUnknown},
+		{nil, "", domain.FingerprintUnknown},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.want, discovery.Fingerprint(c.ports, c.vendor), "%v %q", c.ports, c.vendor)
+	}
+}
+
+// Scanner com hosts fixos por sub-rede
+type fakeScanner map[netip.Prefix][]domain.DiscoveredHost
+
+func (s fakeScanner) Scan(ctx context.Context, prefix netip.Prefix) ([]domain.DiscoveredHost, error) {
+	hosts, ok := s[prefix]
+	if !ok {
+		return nil, errors.New("network unreachable")
+	}
+	return hosts, nil
+}
+
+type fakeStore struct {
+	saved []domain.DiscoveredHost
+}
+
+func (s *fakeStore) SaveDiscoveredHosts(hosts []domain.DiscoveredHost) (int, error) {
+	s.saved = hosts
+	return len(hosts), nil
+}
+
+type fakeVendors map[string]string
+
+func (v fakeVendors) VendorOf(mac string) string {
+	return v[mac]
+}
+
+func TestScanAll(t *testing.T) {
+	arpFile := filepath.Join(t.TempDir(), "arp")
+	require.NoError(t, os.WriteFile(arpFile, []byte(arpTable), 0o644))
+
+	lan := netip.MustParsePrefix("192.168.0.0/24")
+	scanner := fakeScanner{
+		lan: {
+			{IP: netip.MustParseAddr("192.168.0.1"), OpenPorts: []int{80, 5060}},
+			{IP: netip.MustParseAddr("192.168.0.40"), OpenPorts: []int{22}},
+		},
+	}
+	store := &fakeStore{}
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	job := discovery.New(store, scanner, fakeVendors{"00:1a:2b:3c:4d:5e": "Intelbras"}, discovery.Options{
+		Prefixes: []netip.Prefix{lan, netip.MustParsePrefix("172.16.0.0/24")},
+		ARPFile:  arpFile,
+	})
+	job.Now = func() time.Time { return now }
+	var created int
+	job.OnDiscover = func(n int) { created = n }
+
+	// A sub-rede com erro é reportada, mas as demais são gravadas
+	err := job.ScanAll(context.Background())
+	assert.ErrorContains(t, err, "172.16.0.0/24")
+	assert.Equal(t, 3, created)
+
+	byIP := map[string]domain.DiscoveredHost{}
+	for _, host := range store.saved {
+		byIP[host.IP.String()] = host
+	}
+	assert.Len(t, byIP, 3)
+	assert.Equal(t, domain.DiscoveredHost{IP: netip.MustParseAddr("192.168.0.1"), MAC: "00:1a:2b:3c:4d:5e", OpenPorts: []int{80, 5060},
+		Vendor: "Intelbras", Fingerprint: domain.FingerprintPBX, SeenAt: now}, byIP["192.168.0.1"])
+	// Fora do segmento, sem MAC
+	assert.Empty(t, byIP["192.168.0.40"].MAC)
+	assert.Equal(t, domain.FingerprintServer, byIP["192.168.0.40"].Fingerprint)
+	// Só na tabela ARP, dentro da sub-rede; 10.9.0.1 fica de fora
+	assert.Equal(t, "00:50:56:aa:bb:cc", byIP["192.168.0.30"].MAC)
+	assert.NotContains(t, byIP, "10.9.0.1")
+}
@@ -0,0 +1,68 @@
+package discovery
+
+import (
+	"api-golang/internal/domain"
+	"slices"
+	"strings"
+)
+
+// Portas varridas por padrão: acesso remoto, web, SIP, RTSP e impressão
+var DefaultPorts = []int{22, 23, 80, 443, 445, 554, 3389, 5060, 5061, 8080, 9100}
+
+var (
+	sipPorts     = []int{5060, 5061}
+	rtspPorts    = []int{554}
+	printPorts   = []int{515, 631, 9100}
+	managePorts  = []int{23}
+	serverPorts  = []int{22, 445, 3389}
+	pbxVendors   = []string{"intelbras", "avaya", "grandstream", "yeastar", "mitel", "panasonic", "unify", "alcatel", "khomp"}
+	phoneVendors = []string{"yealink", "polycom", "snom", "fanvil"}
+	netVendors   = []string{"cisco", "juniper", "mikrotik", "routerboard", "ubiquiti", "tp-link", "aruba", "huawei"}
+	camVendors   = []string{"hikvision", "dahua", "axis communications"}
+	printVendors = []string{"brother", "epson", "lexmark", "xerox", "kyocera", "ricoh"}
+)
+
+// Classifica o equipamento pelas portas abertas e pelo fabricante do OUI.
+// As regras vão da mais específica para a mais genérica: SIP aberto indica
+// central ou telefone, conforme o fabricante.
+func Fingerprint(ports []int, vendor string) string {
+	vendor = strings.ToLower(vendor)
+	switch {
+	case anyPort(ports, sipPorts) && vendorIn(vendor, phoneVendors):
+		return domain.FingerprintPhone
+	case anyPort(ports, sipPorts), vendorIn(vendor, pbxVendors):
+		return domain.FingerprintPBX
+	case vendorIn(vendor, phoneVendors):
+		return domain.FingerprintPhone
+	case anyPort(ports, rtspPorts), vendorIn(vendor, camVendors):
+		return domain.FingerprintCamera
+	case anyPort(ports, printPorts), vendorIn(vendor, printVendors):
+		return domain.FingerprintPrinter
+	case anyPort(ports, managePorts), vendorIn(vendor, netVendors):
+		return domain.FingerprintNetwork
+	case anyPort(ports, serverPorts):
+		return domain.FingerprintServer
+	}
+	return domain.FingerprintUnknown
+}
+
+func anyPort(open, wanted []int) bool {
+	for _, port := range wanted {
+		if slices.Contains(open, port) {
+			return true
+		}
+	}
+	return false
+}
+
+func vendorIn(vendor string, names []string) bool {
+	if vendor == "" {
+		return false
+	}
+	for _, name := range names {
+		if strings.Contains(vendor, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,137 @@
+// Package discovery varre periodicamente as sub-redes configuradas em busca
+// de equipamentos não cadastrados e os propõe como novas centrais.
+package discovery
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/monitor"
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"time"
+)
+
+// Encontra os hosts que respondem em uma sub-rede
+type Scanner interface {
+	Scan(ctx context.Context, prefix netip.Prefix) ([]domain.DiscoveredHost, error)
+}
+
+// Destino dos hosts encontrados
+type Store interface {
+	// Grava os hosts não cadastrados como candidatos e retorna quantos
+	// candidatos novos surgiram
+	SaveDiscoveredHosts(hosts []domain.DiscoveredHost) (int, error)
+}
+
+// Fabricante de um MAC canônico, vazio quando desconhecido
+type VendorRegistry interface {
+	VendorOf(mac string) string
+}
+
+type Options struct {
+	// Intervalo entre o início de duas varreduras
+	Interval time.Duration
+	// Sub-redes varridas
+	Prefixes []netip.Prefix
+	// Tabela ARP consultada após a varredura; vazio usa DefaultARPFile
+	ARPFile string
+}
+
+type Job struct {
+	Store   Store
+	Scanner Scanner
+	Vendors VendorRegistry
+	Options Options
+	// Chamado após cada varredura com a quantidade de candidatos novos;
+	// opcional
+	OnDiscover func(created int)
+
+	// Relógio, substituível nos testes
+	Now func() time.Time
+}
+
+func New(store Store, scanner Scanner, vendors VendorRegistry, opts Options) *Job {
+	if opts.ARPFile == "" {
+		opts.ARPFile = DefaultARPFile
+	}
+	return &Job{Store: store, Scanner: scanner, Vendors: vendors, Options: opts, Now: time.Now}
+}
+
+// Executa uma varredura imediatamente e depois a cada intervalo, até o
+// contexto ser cancelado
+func (j *Job) Run(ctx context.Context) {
+	monitor.RunEvery(ctx, j.Options.Interval, "Network discovery", func() error {
+		return j.ScanAll(ctx)
+	})
+}
+
+// Varre todas as sub-redes uma vez e grava os hosts encontrados. Uma
+// sub-rede com erro não impede a gravação das demais.
+func (j *Job) ScanAll(ctx context.Context) error {
+	byAddr := map[netip.Addr]*domain.DiscoveredHost{}
+	var hosts []*domain.DiscoveredHost
+	var errs []error
+	for _, prefix := range j.Options.Prefixes {
+		found, err := j.Scanner.Scan(ctx, prefix)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return ctxErr
+			}
+			errs = append(errs, fmt.Errorf("scan %s: %w", prefix, err))
+			continue
+		}
+		for i := range found {
+			if _, ok := byAddr[found[i].IP]; !ok {
+				byAddr[found[i].IP] = &found[i]
+				hosts = append(hosts, &found[i])
+			}
+		}
+	}
+
+	// As conexões da varredura preenchem a tabela ARP dos hosts do mesmo
+	// segmento, inclusive dos que não responderam em nenhuma porta
+	arp, err := ReadARPTable(j.Options.ARPFile)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("read ARP table: %w", err))
+	}
+	for addr, mac := range arp {
+		host, ok := byAddr[addr]
+		if !ok && j.inPrefixes(addr) {
+			host = &domain.DiscoveredHost{IP: addr}
+			byAddr[addr] = host
+			hosts = append(hosts, host)
+		}
+		if host != nil {
+			host.MAC = mac
+		}
+	}
+
+	now := j.Now()
+	result := make([]domain.DiscoveredHost, 0, len(hosts))
+	for _, host := range hosts {
+		if host.MAC != "" && j.Vendors != nil {
+			host.Vendor = j.Vendors.VendorOf(host.MAC)
+		}
+		host.Fingerprint = Fingerprint(host.OpenPorts, host.Vendor)
+		host.SeenAt = now
+		result = append(result, *host)
+	}
+	created, err := j.Store.SaveDiscoveredHosts(result)
+	if err != nil {
+		return err
+	}
+	if j.OnDiscover != nil {
+		j.OnDiscover(created)
+	}
+	return errors.Join(errs...)
+}
+
+func (j *Job) inPrefixes(addr netip.Addr) bool {
+	for _, prefix := range j.Options.Prefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
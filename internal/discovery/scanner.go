@@ -0,0 +1,114 @@
+package discovery
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/utils"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"sort"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Maior quantidade de endereços varrida em uma sub-rede (um /16)
+const MaxScanHosts = 65534
+
+const defaultScanConcurrency = 256
+
+// Varre uma sub-rede tentando conexões TCP às portas configuradas. Um host
+// responde quando aceita ou recusa (RST) alguma conexão; só as aceitas
+// entram em OpenPorts.
+type TCPScanner struct {
+	Ports   []int
+	Timeout time.Duration
+	// Conexões tentadas ao mesmo tempo
+	Concurrency int
+}
+
+type probe struct {
+	addr netip.Addr
+	port int
+}
+
+func (s *TCPScanner) Scan(ctx context.Context, prefix netip.Prefix) ([]domain.DiscoveredHost, error) {
+	r, err := utils.UsableRange(prefix.String())
+	if err != nil {
+		return nil, err
+	}
+	if size := utils.RangeSize(r); size > MaxScanHosts {
+		return nil, fmt.Errorf("subnet %s has %d addresses, more than the %d allowed in a scan", prefix, size, MaxScanHosts)
+	}
+
+	concurrency := s.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultScanConcurrency
+	}
+	probes := make(chan probe)
+	var (
+		mu    sync.Mutex
+		alive = map[netip.Addr][]int{}
+		wg    sync.WaitGroup
+	)
+	dialer := net.Dialer{Timeout: s.Timeout}
+	for range concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for p := range probes {
+				open, answered := dial(ctx, &dialer, p)
+				if !answered {
+					continue
+				}
+				mu.Lock()
+				ports := alive[p.addr]
+				if open {
+					ports = append(ports, p.port)
+				}
+				alive[p.addr] = ports
+				mu.Unlock()
+			}
+		}()
+	}
+
+send:
+	for addr := r.From; ; addr = addr.Next() {
+		for _, port := range s.Ports {
+			select {
+			case probes <- probe{addr, port}:
+			case <-ctx.Done():
+				break send
+			}
+		}
+		if addr == r.To {
+			break
+		}
+	}
+	close(probes)
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	hosts := make([]domain.DiscoveredHost, 0, len(alive))
+	for addr, ports := range alive {
+		sort.Ints(ports)
+		hosts = append(hosts, domain.DiscoveredHost{IP: addr, OpenPorts: ports})
+	}
+	sort.Slice(hosts, func(i, j int) bool { return hosts[i].IP.Less(hosts[j].IP) })
+	return hosts, nil
+}
+
+// Tenta a conexão; answered indica que o host respondeu, aceitando ou não
+func dial(ctx context.Context, dialer *net.Dialer, p probe) (open, answered bool) {
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(p.addr.String(), strconv.Itoa(p.port)))
+	if err != nil {
+		return false, errors.Is(err, syscall.ECONNREFUSED)
+	}
+	conn.Close()
+	return true, true
+}
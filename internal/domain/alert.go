@@ -0,0 +1,130 @@
+package domain
+
+import "time"
+
+// Tipos de condição das regras de alerta
+type AlertRuleKind string
+
+const (
+	// A central está na situação de alcance informada
+	AlertKindStatus AlertRuleKind = "status"
+	// Um agregado de uma métrica de telemetria na janela ultrapassa o limite
+	AlertKindMetric AlertRuleKind = "metric"
+	// O firmware informado nos heartbeats é anterior à versão mínima
+	AlertKindFirmware AlertRuleKind = "firmware"
+)
+
+type AlertSeverity string
+
+const (
+	SeverityInfo     AlertSeverity = "info"
+	SeverityWarning  AlertSeverity = "warning"
+	SeverityCritical AlertSeverity = "critical"
+)
+
+// Ciclo de vida de um alerta: pendente enquanto a condição não se mantém
+// pelo tempo da regra, disparado depois disso e resolvido quando a condição
+// deixa de valer
+type AlertState string
+
+const (
+	AlertPending  AlertState = "pending"
+	AlertFiring   AlertState = "firing"
+	AlertResolved AlertState = "resolved"
+)
+
+// Agregações e comparações das regras de métrica
+const (
+	AggregationAvg = "avg"
+	AggregationMin = "min"
+	AggregationMax = "max"
+	AggregationP95 = "p95"
+
+	OperatorGreater      = "gt"
+	OperatorGreaterEqual = "gte"
+	OperatorLess         = "lt"
+	OperatorLessEqual    = "lte"
+)
+
+type AlertRule struct {
+	ID        uint
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Name      string
+	Kind      AlertRuleKind
+	Severity  AlertSeverity
+	Enabled   bool
+	// Seletor de labels das centrais avaliadas; vazio avalia todas
+	Selector string
+	// Tempo que a condição precisa se manter antes de o alerta disparar
+	For time.Duration
+
+	// Regras de situação
+	Status ReachabilityStatus
+
+	// Regras de métrica
+	Metric      string
+	Aggregation string
+	Operator    string
+	Threshold   float64
+	Window      time.Duration
+
+	// Regras de firmware
+	MinFirmware string
+
+	// Regras cujos alertas ficam inibidos na mesma central enquanto um
+	// alerta desta estiver disparado
+	Inhibits []uint
+}
+
+// Alerta de uma regra para uma central. Há no máximo um alerta ativo
+// (pendente ou disparado) por regra e central.
+type Alert struct {
+	ID        uint
+	RuleID    uint
+	CentralID uint
+	State     AlertState
+	Severity  AlertSeverity
+	Summary   string
+	// Valor observado na última avaliação, quando a regra tem um
+	Value *float64
+
+	ActiveSince     time.Time
+	FiredAt         *time.Time
+	ResolvedAt      *time.Time
+	LastEvaluatedAt time.Time
+
+	// Alertas suprimidos continuam sendo avaliados, mas não notificam
+	Silenced  bool
+	Inhibited bool
+}
+
+// Filtros da listagem de alertas; sem State, lista os ativos
+type AlertFilter struct {
+	State     AlertState
+	RuleID    uint
+	CentralID uint
+}
+
+// Silencia os alertas da regra e/ou da central no período [StartsAt, EndsAt)
+type Silence struct {
+	ID        uint
+	CreatedAt time.Time
+	RuleID    *uint
+	CentralID *uint
+	StartsAt  time.Time
+	EndsAt    time.Time
+	Comment   string
+	CreatedBy string
+}
+
+// Indica se o silêncio vale no instante e se aplica ao alerta
+func (s *Silence) Matches(alert *Alert, now time.Time) bool {
+	if now.Before(s.StartsAt) || !now.Before(s.EndsAt) {
+		return false
+	}
+	if s.RuleID != nil && *s.RuleID != alert.RuleID {
+		return false
+	}
+	return s.CentralID == nil || *s.CentralID == alert.CentralID
+}
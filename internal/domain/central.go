@@ -1,12 +1,87 @@
 package domain
 
-import "time"
+import (
+	"net/netip"
+	"time"
+)
 
+// Uma central pode ter um endereço IPv4, um IPv6 ou ambos (dual-stack)
 type Central struct {
-	ID        uint      `json:"id" gorm:"primaryKey"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
-	Name      string    `json:"name" gorm:"not null" validade:"required"`
-	MAC       string    `json:"mac" gorm:"unique;not null" validate:"required,mac"`
-	IP        string    `json:"ip" gorm:"unique;not null" validate:"required,ipv4"`
+	ID        uint
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Name      string
+	MAC       string
+	IPv4      string
+	IPv6      string
+	// Observações livres dos operadores
+	Notes string
+	// Fabricante derivado do OUI do MAC; vazio quando desconhecido
+	Vendor string
+
+	// Posição na hierarquia de sites; ambos opcionais
+	SiteID     *uint
+	LocationID *uint
+
+	// Pool de onde alocar o próximo IP livre na criação, em vez de
+	// informar o endereço; não é persistido
+	PoolID *uint
+
+	// Prazo sem heartbeat após o qual a central é dada como offline; zero
+	// usa o padrão do servidor
+	HeartbeatWindow time.Duration
+
+	// Labels livres de chave/valor (ex.: env=prod). Na atualização, nil
+	// mantém os labels atuais e um mapa vazio remove todos.
+	Labels map[string]string
+
+	// Preenchidos pelo repositório nas consultas
+	PrimaryInterface *NetworkInterface
+	Status           CentralStatus
+}
+
+// Endereço principal: o IPv4 quando existir, senão o IPv6
+func (c *Central) PrimaryIP() string {
+	if c.IPv4 != "" {
+		return c.IPv4
+	}
+	return c.IPv6
+}
+
+// Filtros aceitos na listagem de centrais
+type CentralFilter struct {
+	// IP casa com o endereço IPv4 ou IPv6 da central
+	IP string
+	// IPRange restringe aos endereços dentro da faixa (sub-rede ou from/to)
+	IPRange *IPRange
+
+	// SiteID traz todas as centrais do site, estejam ou não numa localização
+	SiteID     *uint
+	LocationID *uint
+	// Recursive inclui as centrais das localizações descendentes de
+	// LocationID; sem ele, vêm apenas as da própria localização
+	Recursive bool
+	// Unplaced restringe às centrais sem localização, como as ligadas direto
+	// ao site
+	Unplaced bool
+
+	// Selector restringe às centrais cujos labels satisfazem todas as condições
+	Selector LabelSelector
+
+	// Vendor casa com parte do nome do fabricante, sem diferenciar maiúsculas
+	Vendor string
+
+	// Status restringe às centrais na situação informada
+	Status ReachabilityStatus
+
+	// Paginação por chave, na ordem dos IDs: AfterID traz só as centrais de
+	// ID maior e Limit limita a quantidade (zero não limita)
+	AfterID uint
+	Limit   int
+}
+
+// Faixa fechada de endereços de uma mesma família
+type IPRange struct {
+	From netip.Addr
+	To   netip.Addr
 }
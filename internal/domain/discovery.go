@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"net/netip"
+	"time"
+)
+
+// Situação de um candidato da descoberta de rede
+type CandidateStatus string
+
+const (
+	CandidatePending  CandidateStatus = "pending"
+	CandidateAccepted CandidateStatus = "accepted"
+	CandidateRejected CandidateStatus = "rejected"
+)
+
+// Tipos de equipamento inferidos pelas portas abertas e pelo fabricante
+const (
+	FingerprintPBX     = "pbx"
+	FingerprintPhone   = "ip-phone"
+	FingerprintNetwork = "network-device"
+	FingerprintCamera  = "camera"
+	FingerprintPrinter = "printer"
+	FingerprintServer  = "server"
+	FingerprintUnknown = "unknown"
+)
+
+// Equipamento encontrado em uma varredura. O MAC vem da tabela ARP e fica
+// vazio quando o endereço não está no mesmo segmento do servidor.
+type DiscoveredHost struct {
+	IP          netip.Addr
+	MAC         string
+	OpenPorts   []int
+	Vendor      string
+	Fingerprint string
+	SeenAt      time.Time
+}
+
+// Par MAC/IP ainda não cadastrado, aguardando a decisão de um operador
+type DiscoveryCandidate struct {
+	ID          uint
+	IP          string
+	MAC         string
+	Vendor      string
+	OpenPorts   []int
+	Fingerprint string
+	Status      CandidateStatus
+
+	FirstSeenAt time.Time
+	LastSeenAt  time.Time
+	// Momento da aceitação ou rejeição
+	DecidedAt *time.Time
+	// Central criada ao aceitar
+	CentralID *uint
+}
+
+// Filtro da listagem de candidatos; sem Status, lista os pendentes
+type CandidateFilter struct {
+	Status CandidateStatus
+}
+
+// Dados da central criada ao aceitar um candidato. Os campos vazios são
+// derivados do candidato; o MAC é obrigatório quando a varredura não o
+// encontrou.
+type CandidateAcceptance struct {
+	Name       string
+	MAC        string
+	Notes      string
+	SiteID     *uint
+	LocationID *uint
+	Labels     map[string]string
+}
@@ -0,0 +1,25 @@
+package domain
+
+import "errors"
+
+// Erros de domínio compartilhados entre as camadas
+var (
+	ErrNotFound = errors.New("not found")
+	ErrInvalid  = errors.New("invalid input")
+	ErrConflict = errors.New("conflict")
+
+	// Conflitos mais específicos, para quem distingue o motivo (o gRPC,
+	// por exemplo). Continuam sendo ErrConflict para as demais camadas.
+	ErrExhausted    = &specificError{parent: ErrConflict} // Recurso esgotado, como um pool sem IPs livres
+	ErrPrecondition = &specificError{parent: ErrConflict} // Estado atual impede a operação, como um site ainda em uso
+)
+
+// Especialização de um erro de domínio: errors.Is reconhece tanto ela quanto
+// o erro genérico, e a mensagem é a do genérico
+type specificError struct {
+	parent error
+}
+
+func (e *specificError) Error() string { return e.parent.Error() }
+
+func (e *specificError) Unwrap() error { return e.parent }
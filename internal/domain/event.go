@@ -0,0 +1,100 @@
+package domain
+
+import "time"
+
+// Eventos do ciclo de vida das centrais
+type EventType string
+
+const (
+	EventCentralCreated       EventType = "central.created"
+	EventCentralUpdated       EventType = "central.updated"
+	EventCentralDeleted       EventType = "central.deleted"
+	EventCentralStatusChanged EventType = "central.status_changed"
+)
+
+// Todos os tipos de evento, na ordem da documentação
+var EventTypes = []EventType{EventCentralCreated, EventCentralUpdated, EventCentralDeleted, EventCentralStatusChanged}
+
+func (t EventType) Valid() bool {
+	for _, known := range EventTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Evento gravado no outbox na mesma transação da alteração
+type Event struct {
+	// Posição no outbox, crescente na ordem de gravação
+	ID         uint
+	Type       EventType
+	CentralID  uint
+	OccurredAt time.Time
+	// Estado da central após o evento; na remoção, o último estado conhecido
+	Central *Central
+	// Preenchido nos eventos de mudança de situação
+	StatusChange *StatusChange
+}
+
+// Mudança na situação de alcance de uma central
+type StatusChange struct {
+	CentralID uint
+	From      ReachabilityStatus
+	To        ReachabilityStatus
+	At        time.Time
+}
+
+// Evento correspondente à mudança de situação
+func (c StatusChange) Event() Event {
+	change := c
+	return Event{Type: EventCentralStatusChanged, CentralID: c.CentralID, OccurredAt: c.At, StatusChange: &change}
+}
+
+// Filtros aceitos pelo stream de eventos; campos vazios não restringem
+type EventFilter struct {
+	// CentralIDs restringe aos eventos das centrais informadas
+	CentralIDs []uint
+	SiteID     *uint
+	Selector   LabelSelector
+	// Status casa com a situação da central após o evento e com as duas
+	// pontas de uma mudança de situação, para que a saída também apareça
+	Status ReachabilityStatus
+}
+
+// Indica se o evento passa pelo filtro. Os eventos trazem o estado da
+// central; sem ele, só passam quando não há filtro.
+func (f EventFilter) Matches(event *Event) bool {
+	if len(f.CentralIDs) > 0 && !containsID(f.CentralIDs, event.CentralID) {
+		return false
+	}
+	if f.SiteID == nil && len(f.Selector) == 0 && f.Status == "" {
+		return true
+	}
+	c := event.Central
+	if c == nil {
+		return false
+	}
+	if f.SiteID != nil && (c.SiteID == nil || *c.SiteID != *f.SiteID) {
+		return false
+	}
+	if !f.Selector.Matches(c.Labels) {
+		return false
+	}
+	if f.Status != "" && c.Status.Status != f.Status {
+		change := event.StatusChange
+		if change == nil || (change.From != f.Status && change.To != f.Status) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsID(ids []uint, id uint) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,18 @@
+package domain
+
+import "time"
+
+// Heartbeat enviado por uma central que se reporta ao servidor
+type Heartbeat struct {
+	ID         uint
+	CentralID  uint
+	ReceivedAt time.Time
+
+	Uptime          time.Duration
+	FirmwareVersion string
+	// Percentuais de 0 a 100; nil quando a central não informa
+	CPUPercent    *float64
+	MemoryPercent *float64
+	// Métricas livres reportadas pela central
+	Metrics map[string]float64
+}
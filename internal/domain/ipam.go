@@ -0,0 +1,57 @@
+package domain
+
+import "time"
+
+// Sub-rede gerenciada pelo IPAM
+type Subnet struct {
+	ID          uint
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	CIDR        string
+	Gateway     string
+	Description string
+	// Faixas que nunca são alocadas automaticamente
+	Reserved []AddressRange
+}
+
+// Faixa de endereços com início e fim inclusivos
+type AddressRange struct {
+	Start string
+	End   string
+}
+
+// Pool de alocação dentro de uma sub-rede
+type Pool struct {
+	ID        uint
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	SubnetID  uint
+	Name      string
+	Start     string
+	End       string
+}
+
+// Contagem de endereços de uma sub-rede ou pool. Total exclui rede e
+// broadcast (IPv4); Reserved conta o gateway e as faixas reservadas. Os
+// valores saturam em 2^64-1 nas sub-redes IPv6 grandes.
+type Utilization struct {
+	Total    uint64
+	Reserved uint64
+	Used     uint64
+	Free     uint64
+	// Percentual de endereços alocáveis em uso
+	Percent float64
+}
+
+type PoolUtilization struct {
+	PoolID uint
+	Name   string
+	Utilization
+}
+
+type SubnetUtilization struct {
+	SubnetID uint
+	CIDR     string
+	Utilization
+	Pools []PoolUtilization
+}
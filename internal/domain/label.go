@@ -0,0 +1,67 @@
+package domain
+
+// Operadores aceitos nos seletores de labels, no estilo do Kubernetes
+const (
+	SelectorEquals    = "="
+	SelectorNotEquals = "!="
+	SelectorIn        = "in"
+	SelectorNotIn     = "notin"
+	SelectorExists    = "exists"
+	SelectorNotExists = "!"
+)
+
+// Uma condição do seletor sobre uma chave de label
+type LabelRequirement struct {
+	Key      string
+	Operator string
+	Values   []string
+}
+
+// Seletor de labels: todas as condições precisam ser satisfeitas
+type LabelSelector []LabelRequirement
+
+// Indica se os labels satisfazem todas as condições. Como no Kubernetes,
+// != e notin também casam quando a chave não existe.
+func (s LabelSelector) Matches(labels map[string]string) bool {
+	for _, req := range s {
+		value, ok := labels[req.Key]
+		switch req.Operator {
+		case SelectorEquals:
+			if !ok || value != req.Values[0] {
+				return false
+			}
+		case SelectorNotEquals:
+			if ok && value == req.Values[0] {
+				return false
+			}
+		case SelectorIn:
+			if !ok || !contains(req.Values, value) {
+				return false
+			}
+		case SelectorNotIn:
+			if ok && contains(req.Values, value) {
+				return false
+			}
+		case SelectorExists:
+			if !ok {
+				return false
+			}
+		case SelectorNotExists:
+			if ok {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
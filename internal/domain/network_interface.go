@@ -0,0 +1,23 @@
+package domain
+
+import "time"
+
+// Nome da interface criada junto com a central
+const DefaultInterfaceName = "eth0"
+
+// Interface de rede de uma central. A interface principal espelha os campos
+// MAC/IPv4/IPv6 da própria central.
+type NetworkInterface struct {
+	ID        uint
+	CentralID uint
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Name      string
+	MAC       string
+	// Fabricante derivado do OUI do MAC; vazio quando desconhecido
+	Vendor string
+	IPs    []string
+	// VLAN 0 indica tráfego sem tag
+	VLAN    int
+	Primary bool
+}
@@ -0,0 +1,12 @@
+package domain
+
+// Resultado da consulta de um OUI (os três primeiros bytes do MAC)
+type OUIInfo struct {
+	Prefix string
+	// Vazio em prefixos administrados localmente
+	Vendor string
+
+	// Bits do primeiro byte que costumam indicar erro de cadastro
+	LocallyAdministered bool
+	Multicast           bool
+}
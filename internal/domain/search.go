@@ -0,0 +1,8 @@
+package domain
+
+// Central encontrada na busca textual, com a relevância calculada pelo
+// mecanismo de busca (maior é mais relevante)
+type CentralSearchResult struct {
+	Central Central
+	Score   float64
+}
@@ -0,0 +1,46 @@
+package domain
+
+import "time"
+
+// Site agrupa centrais de um mesmo endereço físico
+type Site struct {
+	ID          uint
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	Name        string
+	Description string
+}
+
+// Tipos de localização dentro de um site, do mais amplo ao mais específico
+const (
+	LocationBuilding = "building"
+	LocationFloor    = "floor"
+	LocationRoom     = "room"
+)
+
+// Tipo exigido para o pai de cada tipo de localização. Prédios ficam
+// diretamente no site.
+var LocationParentKind = map[string]string{
+	LocationBuilding: "",
+	LocationFloor:    LocationBuilding,
+	LocationRoom:     LocationFloor,
+}
+
+// Localização dentro de um site: site > prédio > andar > sala
+type Location struct {
+	ID        uint
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	SiteID    uint
+	ParentID  *uint
+	Kind      string
+	Name      string
+}
+
+// Como tratar as centrais ao remover um site
+type SiteDeleteOptions struct {
+	// Cascade remove as centrais junto com o site
+	Cascade bool
+	// ReassignTo move as centrais para outro site antes da remoção
+	ReassignTo *uint
+}
@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"net/netip"
+	"time"
+)
+
+// Versões de SNMP aceitas na coleta
+type SNMPVersion string
+
+const (
+	SNMPv2c SNMPVersion = "v2c"
+	SNMPv3  SNMPVersion = "v3"
+)
+
+// Porta padrão dos agentes SNMP
+const DefaultSNMPPort = 161
+
+// Protocolos de autenticação do SNMPv3; vazio não autentica
+var SNMPAuthProtocols = []string{"md5", "sha", "sha224", "sha256", "sha384", "sha512"}
+
+// Protocolos de privacidade do SNMPv3; vazio não cifra
+var SNMPPrivProtocols = []string{"des", "aes", "aes192", "aes256", "aes192c", "aes256c"}
+
+// Credenciais SNMP de uma central. Na v2c vale a comunidade; na v3, o
+// usuário e, conforme o nível de segurança, as senhas de autenticação e de
+// privacidade. As senhas e a comunidade nunca saem da API.
+type SNMPCredentials struct {
+	Version SNMPVersion
+	// Zero usa DefaultSNMPPort
+	Port int
+
+	Community string
+
+	Username     string
+	AuthProtocol string
+	AuthPassword string
+	PrivProtocol string
+	PrivPassword string
+}
+
+// Nível de segurança do SNMPv3 derivado dos protocolos configurados
+func (c SNMPCredentials) SecurityLevel() string {
+	switch {
+	case c.Version != SNMPv3:
+		return ""
+	case c.PrivProtocol != "":
+		return "authPriv"
+	case c.AuthProtocol != "":
+		return "authNoPriv"
+	}
+	return "noAuthNoPriv"
+}
+
+// Interface de rede informada pela tabela ifTable/ifXTable do agente
+type SNMPInterface struct {
+	Index int
+	// ifName, ou ifDescr quando o agente não tem a ifXTable
+	Name  string
+	Descr string
+	// ifType (ex.: 6 para ethernetCsmacd)
+	Type int
+	MTU  int
+	// Velocidade em bits por segundo
+	Speed       uint64
+	MAC         string
+	AdminStatus string
+	OperStatus  string
+}
+
+// Atributos descobertos pela coleta SNMP
+type SNMPInventory struct {
+	SysName   string
+	SysDescr  string
+	SysUpTime time.Duration
+
+	Interfaces []SNMPInterface
+}
+
+// Configuração SNMP de uma central com o resultado da última coleta
+type CentralSNMP struct {
+	CentralID   uint
+	Credentials SNMPCredentials
+
+	// Última coleta bem-sucedida; nil até a primeira
+	Inventory   *SNMPInventory
+	CollectedAt *time.Time
+	// Última tentativa de coleta e o erro dela, vazio quando deu certo
+	PolledAt  *time.Time
+	LastError string
+}
+
+// Central a coletar, com as credenciais já decifradas
+type SNMPTarget struct {
+	CentralID   uint
+	Addr        netip.Addr
+	Credentials SNMPCredentials
+}
+
+// Resultado da coleta de uma central. Sem Inventory, Error explica a
+// falha; Status é a situação de alcance observada.
+type SNMPResult struct {
+	CentralID uint
+	Inventory *SNMPInventory
+	Error     string
+	Status    ReachabilityStatus
+	RTT       time.Duration
+	CheckedAt time.Time
+}
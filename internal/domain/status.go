@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"net/netip"
+	"time"
+)
+
+// Situação de alcance de uma central
+type ReachabilityStatus string
+
+const (
+	// Nunca verificada
+	StatusUnknown ReachabilityStatus = "unknown"
+	StatusOnline  ReachabilityStatus = "online"
+	StatusOffline ReachabilityStatus = "offline"
+	// Responde, mas com perda parcial ou latência acima do limite
+	StatusDegraded ReachabilityStatus = "degraded"
+)
+
+// Indica se o valor é uma das situações conhecidas
+func (s ReachabilityStatus) Valid() bool {
+	switch s {
+	case StatusUnknown, StatusOnline, StatusOffline, StatusDegraded:
+		return true
+	}
+	return false
+}
+
+// Última verificação de alcance da central
+type CentralStatus struct {
+	Status ReachabilityStatus
+	// Última vez em que a central respondeu
+	LastSeenAt *time.Time
+	// Tempo de resposta da última verificação bem-sucedida
+	RTT       time.Duration
+	CheckedAt *time.Time
+	// Último heartbeat recebido; nil para centrais que não se reportam
+	LastHeartbeatAt *time.Time
+	// Versão de firmware do último heartbeat que a informou
+	FirmwareVersion string
+}
+
+// Endereço sondado de uma central
+type ProbeTarget struct {
+	CentralID uint
+	Addr      netip.Addr
+}
+
+// Resultado de uma sondagem
+type ProbeResult struct {
+	CentralID uint
+	Status    ReachabilityStatus
+	RTT       time.Duration
+	CheckedAt time.Time
+}
@@ -0,0 +1,64 @@
+package domain
+
+import "time"
+
+// Resoluções das séries de telemetria. Zero corresponde aos pontos brutos.
+const (
+	ResolutionRaw        time.Duration = 0
+	ResolutionFiveMinute               = 5 * time.Minute
+	ResolutionHour                     = time.Hour
+)
+
+// Amostra de uma métrica de uma central
+type MetricPoint struct {
+	CentralID uint
+	Name      string
+	Timestamp time.Time
+	Value     float64
+}
+
+// Agregado de uma métrica num intervalo [Start, Start+Resolution)
+type MetricRollup struct {
+	CentralID  uint
+	Name       string
+	Resolution time.Duration
+	Start      time.Time
+
+	Count int
+	Sum   float64
+	Min   float64
+	Max   float64
+	P95   float64
+}
+
+// Consulta de uma série agregada em intervalos de Step
+type MetricQuery struct {
+	CentralID uint
+	Name      string
+	From      time.Time
+	To        time.Time
+	Step      time.Duration
+}
+
+type MetricBucket struct {
+	Start time.Time
+	Count int
+	Min   float64
+	Max   float64
+	Avg   float64
+	P95   float64
+}
+
+// Série agregada; Resolution indica a origem dos dados (brutos ou rollups)
+type MetricSeries struct {
+	MetricQuery
+	Resolution time.Duration
+	Buckets    []MetricBucket
+}
+
+// Limites de retenção de cada resolução
+type RetentionCutoffs struct {
+	Raw        time.Time
+	FiveMinute time.Time
+	Hour       time.Time
+}
@@ -0,0 +1,75 @@
+package domain
+
+import "time"
+
+// Assinatura de webhook. Sem eventos, recebe todos.
+type Webhook struct {
+	ID        uint
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	URL       string
+	Events    []EventType
+	// Chave do HMAC-SHA256 das entregas
+	Secret string
+	Active bool
+}
+
+// Indica se a assinatura recebe o tipo de evento
+func (w *Webhook) Subscribes(t EventType) bool {
+	if len(w.Events) == 0 {
+		return true
+	}
+	for _, event := range w.Events {
+		if event == t {
+			return true
+		}
+	}
+	return false
+}
+
+// Situação da entrega: pendente enquanto houver tentativas, entregue ao
+// receber 2xx e morta (dead-letter) ao esgotar as tentativas
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliverySucceeded DeliveryStatus = "succeeded"
+	DeliveryDead      DeliveryStatus = "dead"
+)
+
+// Entrega de um evento a uma assinatura
+type WebhookDelivery struct {
+	ID        uint
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	WebhookID uint
+	EventID   string
+	EventType EventType
+	Payload   []byte
+
+	Status         DeliveryStatus
+	Attempts       int
+	NextAttemptAt  *time.Time
+	LastStatusCode int
+	LastError      string
+	DeliveredAt    *time.Time
+
+	// Destino, preenchido nas entregas a enviar
+	URL    string
+	Secret string
+
+	// Histórico, preenchido na consulta de uma entrega
+	AttemptLog []DeliveryAttempt
+}
+
+// Tentativa de envio de uma entrega
+type DeliveryAttempt struct {
+	ID          uint
+	DeliveryID  uint
+	Number      int
+	AttemptedAt time.Time
+	// Zero quando não houve resposta
+	StatusCode int
+	Error      string
+	Duration   time.Duration
+}
@@ -0,0 +1,87 @@
+package graphapi
+
+import (
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// Recusa, antes de tocar no banco, operações cujo custo estimado passa de
+// MaxComplexity. Cada campo custa 1 mais o custo da sua seleção, e as
+// conexões de centrais multiplicam a seleção pelo tamanho da página.
+//
+// O executor não expõe o documento analisado, então o custo é calculado
+// sobre o do gqlparser. O documento é validado antes pelo executor, para
+// que os erros sigam os dele, e qualquer operação que a análise não consiga
+// avaliar é recusada, em vez de executada sem limite.
+func (h *Handler) checkComplexity(req Request) []*gqlerrors.QueryError {
+	if errs := h.schema.ValidateWithVariables(req.Query, req.Variables); len(errs) > 0 {
+		return errs
+	}
+	doc, errs := gqlparser.LoadQuery(h.analysis, req.Query)
+	if len(errs) > 0 {
+		return []*gqlerrors.QueryError{complexityError("query complexity could not be computed: %s", errs[0].Message)}
+	}
+	op := doc.Operations.ForName(req.OperationName)
+	if op == nil {
+		return []*gqlerrors.QueryError{complexityError("query complexity could not be computed: no operation %q in the document", req.OperationName)}
+	}
+	a := costAnalyzer{vars: req.Variables, limit: h.Options.MaxComplexity}
+	if a.selectionSet(op.SelectionSet) > a.limit {
+		return []*gqlerrors.QueryError{complexityError("query complexity exceeds the limit of %d", a.limit)}
+	}
+	return nil
+}
+
+func complexityError(format string, args ...interface{}) *gqlerrors.QueryError {
+	err := gqlerrors.Errorf(format, args...)
+	err.Extensions = map[string]interface{}{"code": codeComplexityLimit}
+	return err
+}
+
+type costAnalyzer struct {
+	vars  map[string]any
+	limit int
+}
+
+// Custo da seleção, saturado logo acima do limite para não estourar em
+// consultas muito aninhadas
+func (a *costAnalyzer) selectionSet(set ast.SelectionSet) int {
+	cost := 0
+	for _, sel := range set {
+		switch sel := sel.(type) {
+		case *ast.Field:
+			child := a.selectionSet(sel.SelectionSet)
+			if sel.Definition != nil && sel.Definition.Type.Name() == "CentralConnection" {
+				child *= a.pageSize(sel)
+			}
+			cost += 1 + child
+		case *ast.FragmentSpread:
+			cost += a.selectionSet(sel.Definition.SelectionSet)
+		case *ast.InlineFragment:
+			cost += a.selectionSet(sel.SelectionSet)
+		}
+		if cost > a.limit {
+			return a.limit + 1
+		}
+	}
+	return cost
+}
+
+// Tamanho da página pedido no argumento first, como o resolver o aplica
+func (a *costAnalyzer) pageSize(field *ast.Field) int {
+	var first *int32
+	switch value := field.ArgumentMap(a.vars)["first"].(type) {
+	case int64:
+		n := int32(value)
+		first = &n
+	case float64:
+		n := int32(value)
+		first = &n
+	}
+	size, err := pageSize(first)
+	if err != nil {
+		return 0
+	}
+	return size
+}
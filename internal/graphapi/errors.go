@@ -0,0 +1,46 @@
+package graphapi
+
+import (
+	"api-golang/internal/domain"
+	"errors"
+)
+
+// Códigos em extensions.code dos erros
+const (
+	codeBadUserInput    = "BAD_USER_INPUT"
+	codeNotFound        = "NOT_FOUND"
+	codeConflict        = "CONFLICT"
+	codeInternal        = "INTERNAL_SERVER_ERROR"
+	codeComplexityLimit = "COMPLEXITY_LIMIT_EXCEEDED"
+)
+
+// Erro de resolver com o código correspondente ao erro de domínio
+type resolverError struct {
+	err  error
+	code string
+}
+
+func (e *resolverError) Error() string {
+	return e.err.Error()
+}
+
+func (e *resolverError) Unwrap() error {
+	return e.err
+}
+
+func (e *resolverError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.code}
+}
+
+func toResolverError(err error) error {
+	code := codeInternal
+	switch {
+	case errors.Is(err, domain.ErrInvalid):
+		code = codeBadUserInput
+	case errors.Is(err, domain.ErrNotFound):
+		code = codeNotFound
+	case errors.Is(err, domain.ErrConflict):
+		code = codeConflict
+	}
+	return &resolverError{err: err, code: code}
+}
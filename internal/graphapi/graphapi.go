@@ -0,0 +1,112 @@
+// Package graphapi expõe centrais, sites e interfaces por GraphQL, sobre os
+// mesmos casos de uso da API REST.
+package graphapi
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/handler"
+	_ "embed"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	graphql "github.com/graph-gophers/graphql-go"
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+//go:embed schema.graphql
+var schemaSDL string
+
+type SiteUseCase interface {
+	GetSiteByID(id uint) (*domain.Site, error)
+	GetSitesByIDs(ids []uint) ([]domain.Site, error)
+	GetLocationsByIDs(ids []uint) ([]domain.Location, error)
+}
+
+type InterfaceUseCase interface {
+	GetInterfacesByCentrals(centralIDs []uint) ([]domain.NetworkInterface, error)
+}
+
+// Tamanho das páginas das conexões de centrais
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+type Options struct {
+	// Profundidade máxima das seleções
+	MaxDepth int
+	// Custo máximo estimado de uma operação (ver complexity)
+	MaxComplexity int
+	// Espera para juntar as buscas aninhadas num mesmo lote
+	BatchWait time.Duration
+}
+
+const (
+	defaultMaxDepth      = 8
+	defaultMaxComplexity = 5000
+	defaultBatchWait     = 2 * time.Millisecond
+)
+
+type Handler struct {
+	Centrals   handler.CentralUseCase
+	Sites      SiteUseCase
+	Interfaces InterfaceUseCase
+	Options    Options
+
+	schema *graphql.Schema
+	// O mesmo schema para o cálculo de complexidade, que o executor não
+	// oferece
+	analysis *ast.Schema
+}
+
+func New(centrals handler.CentralUseCase, sites SiteUseCase, ifaces InterfaceUseCase, opts Options) *Handler {
+	if opts.MaxDepth <= 0 {
+		opts.MaxDepth = defaultMaxDepth
+	}
+	if opts.MaxComplexity <= 0 {
+		opts.MaxComplexity = defaultMaxComplexity
+	}
+	if opts.BatchWait <= 0 {
+		opts.BatchWait = defaultBatchWait
+	}
+	h := &Handler{Centrals: centrals, Sites: sites, Interfaces: ifaces, Options: opts}
+	h.schema = graphql.MustParseSchema(schemaSDL, &resolver{h: h},
+		graphql.UseStringDescriptions(),
+		graphql.MaxDepth(opts.MaxDepth),
+		// Os itens de uma página resolvem em paralelo, para que as buscas
+		// aninhadas caiam no mesmo lote
+		graphql.MaxParallelism(MaxPageSize),
+	)
+	h.analysis = gqlparser.MustLoadSchema(&ast.Source{Name: "schema.graphql", Input: schemaSDL})
+	return h
+}
+
+// Corpo de uma requisição GraphQL
+type Request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// Executa a operação. Erros de validação e de execução seguem no corpo da
+// resposta, com status 200, como manda o GraphQL sobre HTTP.
+func (h *Handler) Serve(c *fiber.Ctx) error {
+	var req Request
+	if err := c.BodyParser(&req); err != nil || req.Query == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(&graphql.Response{Errors: []*gqlerrors.QueryError{
+			gqlerrors.Errorf("request body must be JSON with a query"),
+		}})
+	}
+	if errs := h.checkComplexity(req); len(errs) > 0 {
+		return c.JSON(&graphql.Response{Errors: errs})
+	}
+	ctx := withLoaders(c.UserContext(), h.newLoaders())
+	return c.JSON(h.schema.Exec(ctx, req.Query, req.OperationName, req.Variables))
+}
+
+// Registra o endpoint GraphQL
+func RegisterRoutes(router fiber.Router, h *Handler) {
+	router.Post("/graphql", h.Serve)
+}
@@ -0,0 +1,334 @@
+package graphapi_test
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/graphapi"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Mock do UseCase
+type MockCentralUseCase struct {
+	mock.Mock
+}
+
+func (m *MockCentralUseCase) CreateCentral(central *domain.Central) error {
+	args := m.Called(central)
+	return args.Error(0)
+}
+
+func (m *MockCentralUseCase) GetAllCentrals(filter domain.CentralFilter) ([]domain.Central, error) {
+	args := m.Called(filter)
+	return args.Get(0).([]domain.Central), args.Error(1)
+}
+
+func (m *MockCentralUseCase) GetCentralByID(id uint) (*domain.Central, error) {
+	args := m.Called(id)
+	return args.Get(0).(*domain.Central), args.Error(1)
+}
+
+func (m *MockCentralUseCase) UpdateCentral(central *domain.Central) error {
+	args := m.Called(central)
+	return args.Error(0)
+}
+
+func (m *MockCentralUseCase) DeleteCentral(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+type MockSiteUseCase struct {
+	mock.Mock
+}
+
+func (m *MockSiteUseCase) GetSiteByID(id uint) (*domain.Site, error) {
+	args := m.Called(id)
+	return args.Get(0).(*domain.Site), args.Error(1)
+}
+
+func (m *MockSiteUseCase) GetSitesByIDs(ids []uint) ([]domain.Site, error) {
+	args := m.Called(sorted(ids))
+	return args.Get(0).([]domain.Site), args.Error(1)
+}
+
+func (m *MockSiteUseCase) GetLocationsByIDs(ids []uint) ([]domain.Location, error) {
+	args := m.Called(sorted(ids))
+	return args.Get(0).([]domain.Location), args.Error(1)
+}
+
+type MockInterfaceUseCase struct {
+	mock.Mock
+}
+
+func (m *MockInterfaceUseCase) GetInterfacesByCentrals(centralIDs []uint) ([]domain.NetworkInterface, error) {
+	args := m.Called(sorted(centralIDs))
+	return args.Get(0).([]domain.NetworkInterface), args.Error(1)
+}
+
+// Os lotes chegam na ordem em que os resolvers pediram
+func sorted(ids []uint) []uint {
+	ids = append([]uint(nil), ids...)
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+type gqlResponse struct {
+	Data   map[string]any `json:"data"`
+	Errors []struct {
+		Message    string         `json:"message"`
+		Extensions map[string]any `json:"extensions"`
+	} `json:"errors"`
+}
+
+func setupGraphQL(opts graphapi.Options) (*fiber.App, *MockCentralUseCase, *MockSiteUseCase, *MockInterfaceUseCase) {
+	centrals, sites, ifaces := new(MockCentralUseCase), new(MockSiteUseCase), new(MockInterfaceUseCase)
+	app := fiber.New()
+	graphapi.RegisterRoutes(app, graphapi.New(centrals, sites, ifaces, opts))
+	return app, centrals, sites, ifaces
+}
+
+func execute(t *testing.T, app *fiber.App, query string, vars map[string]any) gqlResponse {
+	t.Helper()
+	body, _ := json.Marshal(graphapi.Request{Query: query, Variables: vars})
+	req := httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var out gqlResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&out))
+	return out
+}
+
+func errorCode(resp gqlResponse) any {
+	if len(resp.Errors) == 0 {
+		return nil
+	}
+	return resp.Errors[0].Extensions["code"]
+}
+
+func TestCentrals_NestedLookupsAreBatched(t *testing.T) {
+	app, centrals, sites, ifaces := setupGraphQL(graphapi.Options{BatchWait: 20 * time.Millisecond})
+	site1, site2, room := uint(1), uint(2), uint(30)
+	var page []domain.Central
+	for id := uint(1); id <= 10; id++ {
+		central := domain.Central{ID: id, Name: fmt.Sprint("central-", id), MAC: "00:11:22:33:44:55",
+			Status: domain.CentralStatus{Status: domain.StatusOnline, RTT: 2 * time.Millisecond}}
+		if id%2 == 0 {
+			central.SiteID = &site2
+		} else {
+			central.SiteID, central.LocationID = &site1, &room
+		}
+		page = append(page, central)
+	}
+	centrals.On("GetAllCentrals", domain.CentralFilter{Limit: graphapi.DefaultPageSize + 1}).Return(page, nil)
+	sites.On("GetSitesByIDs", []uint{1, 2}).Return([]domain.Site{{ID: 1, Name: "Matriz"}, {ID: 2, Name: "Filial"}}, nil).Once()
+	floor := uint(20)
+	sites.On("GetLocationsByIDs", []uint{30}).Return([]domain.Location{{ID: 30, SiteID: 1, ParentID: &floor, Kind: "room", Name: "Portaria"}}, nil).Once()
+	sites.On("GetLocationsByIDs", []uint{20}).Return([]domain.Location{{ID: 20, SiteID: 1, Kind: "floor", Name: "Térreo"}}, nil).Once()
+	ifaces.On("GetInterfacesByCentrals", []uint{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}).Return([]domain.NetworkInterface{
+		{ID: 100, CentralID: 1, Name: "eth0", MAC: "00:11:22:33:44:55", IPs: []string{"10.0.0.1"}, Primary: true},
+		{ID: 101, CentralID: 1, Name: "eth1", MAC: "00:11:22:33:44:66", VLAN: 10},
+	}, nil).Once()
+
+	resp := execute(t, app, `{
+		centrals {
+			nodes {
+				id name
+				status { status rttMs }
+				site { name }
+				location { name parent { name site { name } } }
+				interfaces { name vlan primary ips }
+			}
+		}
+	}`, nil)
+	require.Empty(t, resp.Errors)
+
+	nodes := resp.Data["centrals"].(map[string]any)["nodes"].([]any)
+	require.Len(t, nodes, 10)
+	first := nodes[0].(map[string]any)
+	assert.Equal(t, "1", first["id"])
+	assert.Equal(t, map[string]any{"status": "ONLINE", "rttMs": 2.0}, first["status"])
+	assert.Equal(t, "Matriz", first["site"].(map[string]any)["name"])
+	location := first["location"].(map[string]any)
+	assert.Equal(t, "Portaria", location["name"])
+	assert.Equal(t, "Térreo", location["parent"].(map[string]any)["name"])
+	assert.Len(t, first["interfaces"], 2)
+	second := nodes[1].(map[string]any)
+	assert.Equal(t, "Filial", second["site"].(map[string]any)["name"])
+	assert.Nil(t, second["location"])
+	assert.Empty(t, second["interfaces"])
+
+	// Uma consulta por tipo, e não uma por central
+	sites.AssertExpectations(t)
+	ifaces.AssertExpectations(t)
+}
+
+func TestCentrals_Pagination(t *testing.T) {
+	app, centrals, _, _ := setupGraphQL(graphapi.Options{})
+	site := uint(4)
+	filter := domain.CentralFilter{SiteID: &site, Status: domain.StatusOffline, Limit: 3}
+	centrals.On("GetAllCentrals", filter).Return([]domain.Central{{ID: 5}, {ID: 8}, {ID: 9}}, nil)
+
+	query := `query($after: String) {
+		centrals(first: 2, after: $after, filter: {siteId: "4", status: OFFLINE}) {
+			edges { cursor node { id } }
+			pageInfo { hasNextPage hasPreviousPage endCursor }
+		}
+	}`
+	resp := execute(t, app, query, nil)
+	require.Empty(t, resp.Errors)
+	conn := resp.Data["centrals"].(map[string]any)
+	assert.Len(t, conn["edges"], 2)
+	info := conn["pageInfo"].(map[string]any)
+	assert.Equal(t, true, info["hasNextPage"])
+	assert.Equal(t, false, info["hasPreviousPage"])
+	lastEdge := conn["edges"].([]any)[1].(map[string]any)
+	assert.Equal(t, lastEdge["cursor"], info["endCursor"])
+
+	// O cursor retoma depois da última central da página
+	filter.AfterID = 8
+	centrals.On("GetAllCentrals", filter).Return([]domain.Central{{ID: 9}}, nil)
+	resp = execute(t, app, query, map[string]any{"after": info["endCursor"]})
+	require.Empty(t, resp.Errors)
+	info = resp.Data["centrals"].(map[string]any)["pageInfo"].(map[string]any)
+	assert.Equal(t, false, info["hasNextPage"])
+
+	resp = execute(t, app, query, map[string]any{"after": "bogus"})
+	assert.Equal(t, "BAD_USER_INPUT", errorCode(resp))
+	resp = execute(t, app, `{ centrals(filter: {subnet: "10.0.0.0/99"}) { nodes { id } } }`, nil)
+	assert.Equal(t, "BAD_USER_INPUT", errorCode(resp))
+}
+
+func TestCentralAndSiteQueries(t *testing.T) {
+	app, centrals, sites, _ := setupGraphQL(graphapi.Options{})
+	centrals.On("GetCentralByID", uint(1)).Return(&domain.Central{ID: 1, Name: "Portaria",
+		Labels: map[string]string{"tier": "a", "env": "prod"}}, nil)
+	centrals.On("GetCentralByID", uint(2)).Return((*domain.Central)(nil), fmt.Errorf("%w: central 2", domain.ErrNotFound))
+	sites.On("GetSiteByID", uint(3)).Return(&domain.Site{ID: 3, Name: "Matriz"}, nil)
+	site := uint(3)
+	centrals.On("GetAllCentrals", domain.CentralFilter{SiteID: &site, Unplaced: true, Limit: 6}).Return([]domain.Central{{ID: 1}}, nil)
+
+	resp := execute(t, app, `{
+		a: central(id: "1") { name status { status } labels { key value } }
+		b: central(id: "2") { name }
+		site(id: "3") { name centrals(first: 5, unplaced: true) { nodes { id } } }
+	}`, nil)
+	require.Empty(t, resp.Errors)
+	a := resp.Data["a"].(map[string]any)
+	assert.Equal(t, "UNKNOWN", a["status"].(map[string]any)["status"])
+	assert.Equal(t, []any{
+		map[string]any{"key": "env", "value": "prod"},
+		map[string]any{"key": "tier", "value": "a"},
+	}, a["labels"])
+	assert.Nil(t, resp.Data["b"])
+	assert.Len(t, resp.Data["site"].(map[string]any)["centrals"].(map[string]any)["nodes"], 1)
+
+	resp = execute(t, app, `{ central(id: "x") { name } }`, nil)
+	assert.Equal(t, "BAD_USER_INPUT", errorCode(resp))
+}
+
+func TestMutations(t *testing.T) {
+	app, centrals, _, _ := setupGraphQL(graphapi.Options{})
+	centrals.On("CreateCentral", mock.MatchedBy(func(c *domain.Central) bool {
+		return c.Name == "Portaria" && c.IPv4 == "10.0.0.1" && c.Labels["env"] == "prod" && c.HeartbeatWindow == time.Minute
+	})).Run(func(args mock.Arguments) {
+		args.Get(0).(*domain.Central).ID = 7
+	}).Return(nil)
+	centrals.On("CreateCentral", mock.MatchedBy(func(c *domain.Central) bool { return c.IPv4 == "10.0.0.2" })).
+		Return(fmt.Errorf("%w: IP in use", domain.ErrConflict))
+
+	resp := execute(t, app, `mutation {
+		createCentral(input: {name: "Portaria", mac: "00:11:22:33:44:55", ipv4: "10.0.0.1",
+			labels: [{key: "env", value: "prod"}], heartbeatWindowSeconds: 60}) { id heartbeatWindowSeconds }
+	}`, nil)
+	require.Empty(t, resp.Errors)
+	assert.Equal(t, map[string]any{"id": "7", "heartbeatWindowSeconds": 60.0}, resp.Data["createCentral"])
+
+	resp = execute(t, app, `mutation { createCentral(input: {name: "x", mac: "00:11:22:33:44:55", ipv4: "10.0.0.2"}) { id } }`, nil)
+	assert.Equal(t, "CONFLICT", errorCode(resp))
+	resp = execute(t, app, `mutation { createCentral(input: {name: "x", mac: "00:11:22:33:44:55"}) { id } }`, nil)
+	assert.Equal(t, "BAD_USER_INPUT", errorCode(resp))
+
+	// Sem labels, mantém os atuais; a resposta traz o estado relido
+	centrals.On("UpdateCentral", mock.MatchedBy(func(c *domain.Central) bool {
+		return c.ID == 7 && c.Name == "Recepção" && c.Labels == nil
+	})).Return(nil)
+	centrals.On("GetCentralByID", uint(7)).Return(&domain.Central{ID: 7, Name: "Recepção", Labels: map[string]string{"env": "prod"}}, nil)
+	resp = execute(t, app, `mutation {
+		updateCentral(id: "7", input: {name: "Recepção", mac: "00:11:22:33:44:55", ipv4: "10.0.0.1"}) { name labels { key } }
+	}`, nil)
+	require.Empty(t, resp.Errors)
+	assert.Len(t, resp.Data["updateCentral"].(map[string]any)["labels"], 1)
+
+	centrals.On("DeleteCentral", uint(7)).Return(nil)
+	centrals.On("DeleteCentral", uint(8)).Return(fmt.Errorf("%w: central 8", domain.ErrNotFound))
+	resp = execute(t, app, `mutation { deleteCentral(id: "7") }`, nil)
+	assert.Equal(t, "7", resp.Data["deleteCentral"])
+	resp = execute(t, app, `mutation { deleteCentral(id: "8") }`, nil)
+	assert.Equal(t, "NOT_FOUND", errorCode(resp))
+	centrals.AssertExpectations(t)
+}
+
+func TestLimits(t *testing.T) {
+	app, centrals, _, _ := setupGraphQL(graphapi.Options{MaxDepth: 6, MaxComplexity: 500})
+
+	// Profundidade além do limite
+	resp := execute(t, app, `{ centrals { nodes { location { parent { parent { parent { id } } } } } } }`, nil)
+	require.NotEmpty(t, resp.Errors)
+	assert.Contains(t, resp.Errors[0].Message, "exceeds max depth")
+
+	// Conexões aninhadas multiplicam o custo pelo tamanho das páginas,
+	// inclusive quando o tamanho vem de variável ou de fragmento
+	resp = execute(t, app, `query($n: Int) { centrals(first: $n) { nodes { ...s } } }
+		fragment s on Central { site { centrals(first: 50) { nodes { id name } } } }`, map[string]any{"n": 20})
+	assert.Equal(t, "COMPLEXITY_LIMIT_EXCEEDED", errorCode(resp))
+
+	// Nada chegou ao caso de uso
+	centrals.AssertNotCalled(t, "GetAllCentrals", mock.Anything)
+
+	// A mesma consulta com páginas menores passa
+	centrals.On("GetAllCentrals", mock.Anything).Return([]domain.Central{}, nil)
+	resp = execute(t, app, `query($n: Int) { centrals(first: $n) { nodes { ...s } } }
+		fragment s on Central { site { centrals(first: 5) { nodes { id name } } } }`, map[string]any{"n": 20})
+	assert.Empty(t, resp.Errors)
+
+	// Operação que a análise não encontra: recusada, em vez de executada
+	// sem limite
+	body, err := json.Marshal(graphapi.Request{Query: `query A { centrals { nodes { id } } }`, OperationName: "B"})
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	httpResp, err := app.Test(req)
+	require.NoError(t, err)
+	var rejected gqlResponse
+	require.NoError(t, json.NewDecoder(httpResp.Body).Decode(&rejected))
+	assert.Equal(t, "COMPLEXITY_LIMIT_EXCEEDED", errorCode(rejected))
+	centrals.AssertNumberOfCalls(t, "GetAllCentrals", 1)
+}
+
+func TestInvalidRequest(t *testing.T) {
+	app, _, _, _ := setupGraphQL(graphapi.Options{})
+	for _, body := range []string{"not json", `{"query": ""}`} {
+		req := httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader([]byte(body)))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, body)
+	}
+
+	// Erros de validação seguem no corpo
+	resp := execute(t, app, `{ centrals { nodes { nope } } }`, nil)
+	assert.NotEmpty(t, resp.Errors)
+}
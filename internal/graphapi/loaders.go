@@ -0,0 +1,67 @@
+package graphapi
+
+import (
+	"api-golang/internal/domain"
+	"context"
+
+	"github.com/graph-gophers/dataloader/v7"
+)
+
+// Carregadores de uma requisição. As buscas aninhadas feitas pelos itens de
+// uma página são agrupadas numa única consulta por tipo, em vez de uma por
+// central.
+type loaders struct {
+	sites      *dataloader.Loader[uint, *domain.Site]
+	locations  *dataloader.Loader[uint, *domain.Location]
+	interfaces *dataloader.Loader[uint, []domain.NetworkInterface]
+}
+
+type loadersKey struct{}
+
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+func (h *Handler) newLoaders() *loaders {
+	return &loaders{
+		sites: dataloader.NewBatchedLoader(func(ctx context.Context, ids []uint) []*dataloader.Result[*domain.Site] {
+			sites, err := h.Sites.GetSitesByIDs(ids)
+			return byID(ids, sites, err, func(s *domain.Site) uint { return s.ID })
+		}, dataloader.WithWait[uint, *domain.Site](h.Options.BatchWait)),
+
+		locations: dataloader.NewBatchedLoader(func(ctx context.Context, ids []uint) []*dataloader.Result[*domain.Location] {
+			locations, err := h.Sites.GetLocationsByIDs(ids)
+			return byID(ids, locations, err, func(l *domain.Location) uint { return l.ID })
+		}, dataloader.WithWait[uint, *domain.Location](h.Options.BatchWait)),
+
+		interfaces: dataloader.NewBatchedLoader(func(ctx context.Context, ids []uint) []*dataloader.Result[[]domain.NetworkInterface] {
+			results := make([]*dataloader.Result[[]domain.NetworkInterface], len(ids))
+			ifaces, err := h.Interfaces.GetInterfacesByCentrals(ids)
+			grouped := make(map[uint][]domain.NetworkInterface, len(ids))
+			for _, iface := range ifaces {
+				grouped[iface.CentralID] = append(grouped[iface.CentralID], iface)
+			}
+			for i, id := range ids {
+				results[i] = &dataloader.Result[[]domain.NetworkInterface]{Data: grouped[id], Error: err}
+			}
+			return results
+		}, dataloader.WithWait[uint, []domain.NetworkInterface](h.Options.BatchWait)),
+	}
+}
+
+// Resultados na ordem das chaves pedidas; as que não existem ficam nil
+func byID[V any](ids []uint, items []V, err error, idOf func(*V) uint) []*dataloader.Result[*V] {
+	found := make(map[uint]*V, len(items))
+	for i := range items {
+		found[idOf(&items[i])] = &items[i]
+	}
+	results := make([]*dataloader.Result[*V], len(ids))
+	for i, id := range ids {
+		results[i] = &dataloader.Result[*V]{Data: found[id], Error: err}
+	}
+	return results
+}
@@ -0,0 +1,557 @@
+package graphapi
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/utils"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+// Raiz das consultas e mutações
+type resolver struct {
+	h *Handler
+}
+
+func (r *resolver) Central(args struct{ ID graphql.ID }) (*centralResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, toResolverError(err)
+	}
+	central, err := r.h.Centrals.GetCentralByID(id)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, toResolverError(err)
+	}
+	return &centralResolver{h: r.h, c: central}, nil
+}
+
+type centralFilterInput struct {
+	SiteID     *graphql.ID
+	LocationID *graphql.ID
+	Recursive  *bool
+	Unplaced   *bool
+	Selector   *string
+	Status     *string
+	Vendor     *string
+	IP         *string
+	Subnet     *string
+}
+
+func (r *resolver) Centrals(args struct {
+	First  *int32
+	After  *string
+	Filter *centralFilterInput
+}) (*connectionResolver, error) {
+	filter, err := args.Filter.toDomain()
+	if err != nil {
+		return nil, toResolverError(err)
+	}
+	return r.h.connection(filter, args.First, args.After)
+}
+
+func (r *resolver) Site(args struct{ ID graphql.ID }) (*siteResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, toResolverError(err)
+	}
+	site, err := r.h.Sites.GetSiteByID(id)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, toResolverError(err)
+	}
+	return &siteResolver{h: r.h, s: site}, nil
+}
+
+type labelInput struct {
+	Key   string
+	Value string
+}
+
+type createCentralInput struct {
+	Name                   string
+	MAC                    string
+	IPv4                   *string
+	IPv6                   *string
+	PoolID                 *graphql.ID
+	Notes                  *string
+	SiteID                 *graphql.ID
+	LocationID             *graphql.ID
+	Labels                 *[]labelInput
+	HeartbeatWindowSeconds *int32
+}
+
+func (r *resolver) CreateCentral(args struct{ Input createCentralInput }) (*centralResolver, error) {
+	in := args.Input
+	central := &domain.Central{Name: in.Name, MAC: in.MAC, IPv4: deref(in.IPv4), IPv6: deref(in.IPv6), Notes: deref(in.Notes)}
+	var err error
+	if central.PoolID, err = parseOptionalID(in.PoolID); err != nil {
+		return nil, toResolverError(err)
+	}
+	if central.IPv4 == "" && central.IPv6 == "" && central.PoolID == nil {
+		return nil, toResolverError(fmt.Errorf("%w: ipv4, ipv6 or poolId is required", domain.ErrInvalid))
+	}
+	if err := setPlacement(central, in.SiteID, in.LocationID, in.HeartbeatWindowSeconds); err != nil {
+		return nil, toResolverError(err)
+	}
+	if in.Labels != nil {
+		central.Labels = labelMap(*in.Labels)
+	}
+	if err := r.h.Centrals.CreateCentral(central); err != nil {
+		return nil, toResolverError(err)
+	}
+	return &centralResolver{h: r.h, c: central}, nil
+}
+
+type updateCentralInput struct {
+	Name                   string
+	MAC                    string
+	IPv4                   *string
+	IPv6                   *string
+	Notes                  *string
+	SiteID                 *graphql.ID
+	LocationID             *graphql.ID
+	Labels                 *[]labelInput
+	HeartbeatWindowSeconds *int32
+}
+
+func (r *resolver) UpdateCentral(args struct {
+	ID    graphql.ID
+	Input updateCentralInput
+}) (*centralResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, toResolverError(err)
+	}
+	in := args.Input
+	central := &domain.Central{ID: id, Name: in.Name, MAC: in.MAC, IPv4: deref(in.IPv4), IPv6: deref(in.IPv6), Notes: deref(in.Notes)}
+	if central.IPv4 == "" && central.IPv6 == "" {
+		return nil, toResolverError(fmt.Errorf("%w: ipv4 or ipv6 is required", domain.ErrInvalid))
+	}
+	if err := setPlacement(central, in.SiteID, in.LocationID, in.HeartbeatWindowSeconds); err != nil {
+		return nil, toResolverError(err)
+	}
+	if in.Labels != nil {
+		central.Labels = labelMap(*in.Labels)
+	}
+	if err := r.h.Centrals.UpdateCentral(central); err != nil {
+		return nil, toResolverError(err)
+	}
+	// Relê para devolver o estado completo, como labels mantidos e situação
+	updated, err := r.h.Centrals.GetCentralByID(id)
+	if err != nil {
+		return nil, toResolverError(err)
+	}
+	return &centralResolver{h: r.h, c: updated}, nil
+}
+
+func (r *resolver) DeleteCentral(args struct{ ID graphql.ID }) (graphql.ID, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return "", toResolverError(err)
+	}
+	if err := r.h.Centrals.DeleteCentral(id); err != nil {
+		return "", toResolverError(err)
+	}
+	return args.ID, nil
+}
+
+func (f *centralFilterInput) toDomain() (domain.CentralFilter, error) {
+	var filter domain.CentralFilter
+	if f == nil {
+		return filter, nil
+	}
+	var err error
+	if filter.SiteID, err = parseOptionalID(f.SiteID); err != nil {
+		return filter, err
+	}
+	if filter.LocationID, err = parseOptionalID(f.LocationID); err != nil {
+		return filter, err
+	}
+	filter.Recursive = f.Recursive != nil && *f.Recursive
+	filter.Unplaced = f.Unplaced != nil && *f.Unplaced
+	if filter.Selector, err = utils.ParseLabelSelector(deref(f.Selector)); err != nil {
+		return filter, fmt.Errorf("%w: %v", domain.ErrInvalid, err)
+	}
+	if f.Status != nil {
+		filter.Status = domain.ReachabilityStatus(strings.ToLower(*f.Status))
+	}
+	filter.Vendor = deref(f.Vendor)
+	filter.IP = deref(f.IP)
+	if f.Subnet != nil {
+		r, err := utils.SubnetRange(*f.Subnet)
+		if err != nil {
+			return filter, fmt.Errorf("%w: %v", domain.ErrInvalid, err)
+		}
+		filter.IPRange = &r
+	}
+	return filter, nil
+}
+
+// Página de centrais a partir do cursor. Uma central a mais é lida para
+// saber se há próxima página.
+func (h *Handler) connection(filter domain.CentralFilter, first *int32, after *string) (*connectionResolver, error) {
+	size, err := pageSize(first)
+	if err != nil {
+		return nil, toResolverError(err)
+	}
+	if after != nil {
+		if filter.AfterID, err = decodeCursor(*after); err != nil {
+			return nil, toResolverError(err)
+		}
+	}
+	filter.Limit = size + 1
+	centrals, err := h.Centrals.GetAllCentrals(filter)
+	if err != nil {
+		return nil, toResolverError(err)
+	}
+	conn := &connectionResolver{hasNext: len(centrals) > size}
+	if conn.hasNext {
+		centrals = centrals[:size]
+	}
+	conn.nodes = make([]*centralResolver, len(centrals))
+	for i := range centrals {
+		conn.nodes[i] = &centralResolver{h: h, c: &centrals[i]}
+	}
+	return conn, nil
+}
+
+// Tamanho da página: DefaultPageSize sem first, limitado a MaxPageSize
+func pageSize(first *int32) (int, error) {
+	if first == nil {
+		return DefaultPageSize, nil
+	}
+	if *first < 0 {
+		return 0, fmt.Errorf("%w: first must not be negative", domain.ErrInvalid)
+	}
+	return min(int(*first), MaxPageSize), nil
+}
+
+type connectionResolver struct {
+	nodes   []*centralResolver
+	hasNext bool
+}
+
+func (r *connectionResolver) Edges() []*edgeResolver {
+	edges := make([]*edgeResolver, len(r.nodes))
+	for i, node := range r.nodes {
+		edges[i] = &edgeResolver{node: node}
+	}
+	return edges
+}
+
+func (r *connectionResolver) Nodes() []*centralResolver {
+	return r.nodes
+}
+
+func (r *connectionResolver) PageInfo() *pageInfoResolver {
+	info := &pageInfoResolver{hasNext: r.hasNext}
+	if len(r.nodes) > 0 {
+		start := encodeCursor(r.nodes[0].c.ID)
+		end := encodeCursor(r.nodes[len(r.nodes)-1].c.ID)
+		info.start, info.end = &start, &end
+	}
+	return info
+}
+
+type edgeResolver struct {
+	node *centralResolver
+}
+
+func (r *edgeResolver) Cursor() string {
+	return encodeCursor(r.node.c.ID)
+}
+
+func (r *edgeResolver) Node() *centralResolver {
+	return r.node
+}
+
+type pageInfoResolver struct {
+	hasNext    bool
+	start, end *string
+}
+
+func (r *pageInfoResolver) HasNextPage() bool     { return r.hasNext }
+func (r *pageInfoResolver) HasPreviousPage() bool { return false }
+func (r *pageInfoResolver) StartCursor() *string  { return r.start }
+func (r *pageInfoResolver) EndCursor() *string    { return r.end }
+
+type centralResolver struct {
+	h *Handler
+	c *domain.Central
+}
+
+func (r *centralResolver) ID() graphql.ID  { return formatID(r.c.ID) }
+func (r *centralResolver) Name() string    { return r.c.Name }
+func (r *centralResolver) MAC() string     { return r.c.MAC }
+func (r *centralResolver) IPv4() *string   { return optional(r.c.IPv4) }
+func (r *centralResolver) IPv6() *string   { return optional(r.c.IPv6) }
+func (r *centralResolver) Notes() string   { return r.c.Notes }
+func (r *centralResolver) Vendor() *string { return optional(r.c.Vendor) }
+func (r *centralResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: r.c.CreatedAt}
+}
+func (r *centralResolver) UpdatedAt() graphql.Time {
+	return graphql.Time{Time: r.c.UpdatedAt}
+}
+
+func (r *centralResolver) HeartbeatWindowSeconds() int32 {
+	return int32(r.c.HeartbeatWindow / time.Second)
+}
+
+// Labels em ordem de chave, para respostas estáveis
+func (r *centralResolver) Labels() []*labelResolver {
+	labels := make([]*labelResolver, 0, len(r.c.Labels))
+	for key, value := range r.c.Labels {
+		labels = append(labels, &labelResolver{key: key, value: value})
+	}
+	sort.Slice(labels, func(i, j int) bool { return labels[i].key < labels[j].key })
+	return labels
+}
+
+func (r *centralResolver) Status() *statusResolver {
+	return &statusResolver{s: &r.c.Status}
+}
+
+func (r *centralResolver) Site(ctx context.Context) (*siteResolver, error) {
+	if r.c.SiteID == nil {
+		return nil, nil
+	}
+	site, err := loadersFrom(ctx).sites.Load(ctx, *r.c.SiteID)()
+	if err != nil {
+		return nil, toResolverError(err)
+	}
+	if site == nil {
+		return nil, nil
+	}
+	return &siteResolver{h: r.h, s: site}, nil
+}
+
+func (r *centralResolver) Location(ctx context.Context) (*locationResolver, error) {
+	if r.c.LocationID == nil {
+		return nil, nil
+	}
+	return r.h.location(ctx, *r.c.LocationID)
+}
+
+func (r *centralResolver) Interfaces(ctx context.Context) ([]*interfaceResolver, error) {
+	ifaces, err := loadersFrom(ctx).interfaces.Load(ctx, r.c.ID)()
+	if err != nil {
+		return nil, toResolverError(err)
+	}
+	resolvers := make([]*interfaceResolver, len(ifaces))
+	for i := range ifaces {
+		resolvers[i] = &interfaceResolver{i: &ifaces[i]}
+	}
+	return resolvers, nil
+}
+
+type labelResolver struct {
+	key, value string
+}
+
+func (r *labelResolver) Key() string   { return r.key }
+func (r *labelResolver) Value() string { return r.value }
+
+type statusResolver struct {
+	s *domain.CentralStatus
+}
+
+func (r *statusResolver) Status() string {
+	if r.s.Status == "" {
+		return strings.ToUpper(string(domain.StatusUnknown))
+	}
+	return strings.ToUpper(string(r.s.Status))
+}
+
+func (r *statusResolver) LastSeenAt() *graphql.Time      { return optionalTime(r.s.LastSeenAt) }
+func (r *statusResolver) CheckedAt() *graphql.Time       { return optionalTime(r.s.CheckedAt) }
+func (r *statusResolver) LastHeartbeatAt() *graphql.Time { return optionalTime(r.s.LastHeartbeatAt) }
+func (r *statusResolver) FirmwareVersion() *string       { return optional(r.s.FirmwareVersion) }
+
+func (r *statusResolver) RTTMs() *float64 {
+	if r.s.RTT <= 0 {
+		return nil
+	}
+	rtt := float64(r.s.RTT.Microseconds()) / 1000
+	return &rtt
+}
+
+type interfaceResolver struct {
+	i *domain.NetworkInterface
+}
+
+func (r *interfaceResolver) ID() graphql.ID  { return formatID(r.i.ID) }
+func (r *interfaceResolver) Name() string    { return r.i.Name }
+func (r *interfaceResolver) MAC() string     { return r.i.MAC }
+func (r *interfaceResolver) Vendor() *string { return optional(r.i.Vendor) }
+func (r *interfaceResolver) VLAN() int32     { return int32(r.i.VLAN) }
+func (r *interfaceResolver) Primary() bool   { return r.i.Primary }
+
+func (r *interfaceResolver) IPs() []string {
+	if r.i.IPs == nil {
+		return []string{}
+	}
+	return r.i.IPs
+}
+
+type siteResolver struct {
+	h *Handler
+	s *domain.Site
+}
+
+func (r *siteResolver) ID() graphql.ID      { return formatID(r.s.ID) }
+func (r *siteResolver) Name() string        { return r.s.Name }
+func (r *siteResolver) Description() string { return r.s.Description }
+func (r *siteResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: r.s.CreatedAt}
+}
+func (r *siteResolver) UpdatedAt() graphql.Time {
+	return graphql.Time{Time: r.s.UpdatedAt}
+}
+
+func (r *siteResolver) Centrals(args struct {
+	First    *int32
+	After    *string
+	Unplaced *bool
+}) (*connectionResolver, error) {
+	filter := domain.CentralFilter{SiteID: &r.s.ID, Unplaced: args.Unplaced != nil && *args.Unplaced}
+	return r.h.connection(filter, args.First, args.After)
+}
+
+type locationResolver struct {
+	h *Handler
+	l *domain.Location
+}
+
+func (h *Handler) location(ctx context.Context, id uint) (*locationResolver, error) {
+	location, err := loadersFrom(ctx).locations.Load(ctx, id)()
+	if err != nil {
+		return nil, toResolverError(err)
+	}
+	if location == nil {
+		return nil, nil
+	}
+	return &locationResolver{h: h, l: location}, nil
+}
+
+func (r *locationResolver) ID() graphql.ID { return formatID(r.l.ID) }
+func (r *locationResolver) Kind() string   { return r.l.Kind }
+func (r *locationResolver) Name() string   { return r.l.Name }
+
+func (r *locationResolver) Site(ctx context.Context) (*siteResolver, error) {
+	site, err := loadersFrom(ctx).sites.Load(ctx, r.l.SiteID)()
+	if err != nil {
+		return nil, toResolverError(err)
+	}
+	if site == nil {
+		return nil, toResolverError(fmt.Errorf("%w: site %d", domain.ErrNotFound, r.l.SiteID))
+	}
+	return &siteResolver{h: r.h, s: site}, nil
+}
+
+func (r *locationResolver) Parent(ctx context.Context) (*locationResolver, error) {
+	if r.l.ParentID == nil {
+		return nil, nil
+	}
+	return r.h.location(ctx, *r.l.ParentID)
+}
+
+// Define site, localização e prazo de heartbeat comuns à criação e à
+// atualização
+func setPlacement(central *domain.Central, siteID, locationID *graphql.ID, heartbeatWindow *int32) error {
+	var err error
+	if central.SiteID, err = parseOptionalID(siteID); err != nil {
+		return err
+	}
+	if central.LocationID, err = parseOptionalID(locationID); err != nil {
+		return err
+	}
+	if heartbeatWindow != nil {
+		if *heartbeatWindow < 0 {
+			return fmt.Errorf("%w: heartbeatWindowSeconds must not be negative", domain.ErrInvalid)
+		}
+		central.HeartbeatWindow = time.Duration(*heartbeatWindow) * time.Second
+	}
+	return nil
+}
+
+func labelMap(labels []labelInput) map[string]string {
+	m := make(map[string]string, len(labels))
+	for _, label := range labels {
+		m[label.Key] = label.Value
+	}
+	return m
+}
+
+func parseID(id graphql.ID) (uint, error) {
+	n, err := strconv.ParseUint(string(id), 10, 0)
+	if err != nil || n == 0 {
+		return 0, fmt.Errorf("%w: invalid id %q", domain.ErrInvalid, id)
+	}
+	return uint(n), nil
+}
+
+func parseOptionalID(id *graphql.ID) (*uint, error) {
+	if id == nil {
+		return nil, nil
+	}
+	n, err := parseID(*id)
+	if err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
+
+func formatID(id uint) graphql.ID {
+	return graphql.ID(strconv.FormatUint(uint64(id), 10))
+}
+
+// Cursores opacos com o ID da central
+func encodeCursor(id uint) string {
+	return base64.RawURLEncoding.EncodeToString([]byte("central:" + strconv.FormatUint(uint64(id), 10)))
+}
+
+func decodeCursor(cursor string) (uint, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err == nil {
+		if id, ok := strings.CutPrefix(string(raw), "central:"); ok {
+			if n, err := strconv.ParseUint(id, 10, 0); err == nil {
+				return uint(n), nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("%w: invalid cursor", domain.ErrInvalid)
+}
+
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func optionalTime(t *time.Time) *graphql.Time {
+	if t == nil {
+		return nil
+	}
+	return &graphql.Time{Time: *t}
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
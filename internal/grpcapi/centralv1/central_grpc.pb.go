@@ -0,0 +1,325 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: central/v1/central.proto
+
+package centralv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	CentralService_CreateCentral_FullMethodName = "/central.v1.CentralService/CreateCentral"
+	CentralService_GetCentral_FullMethodName    = "/central.v1.CentralService/GetCentral"
+	CentralService_ListCentrals_FullMethodName  = "/central.v1.CentralService/ListCentrals"
+	CentralService_UpdateCentral_FullMethodName = "/central.v1.CentralService/UpdateCentral"
+	CentralService_DeleteCentral_FullMethodName = "/central.v1.CentralService/DeleteCentral"
+	CentralService_WatchCentrals_FullMethodName = "/central.v1.CentralService/WatchCentrals"
+)
+
+// CentralServiceClient is the client API for CentralService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Cadastro de centrais, com as mesmas regras da API REST
+type CentralServiceClient interface {
+	CreateCentral(ctx context.Context, in *CreateCentralRequest, opts ...grpc.CallOption) (*Central, error)
+	GetCentral(ctx context.Context, in *GetCentralRequest, opts ...grpc.CallOption) (*Central, error)
+	// Lista paginada, na ordem dos IDs
+	ListCentrals(ctx context.Context, in *ListCentralsRequest, opts ...grpc.CallOption) (*ListCentralsResponse, error)
+	UpdateCentral(ctx context.Context, in *UpdateCentralRequest, opts ...grpc.CallOption) (*Central, error)
+	DeleteCentral(ctx context.Context, in *DeleteCentralRequest, opts ...grpc.CallOption) (*DeleteCentralResponse, error)
+	// Eventos de criação, alteração, remoção e mudança de situação, na ordem
+	// de gravação. Com after_event_id, reenvia antes os eventos perdidos.
+	WatchCentrals(ctx context.Context, in *WatchCentralsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CentralEvent], error)
+}
+
+type centralServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCentralServiceClient(cc grpc.ClientConnInterface) CentralServiceClient {
+	return &centralServiceClient{cc}
+}
+
+func (c *centralServiceClient) CreateCentral(ctx context.Context, in *CreateCentralRequest, opts ...grpc.CallOption) (*Central, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Central)
+	err := c.cc.Invoke(ctx, CentralService_CreateCentral_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *centralServiceClient) GetCentral(ctx context.Context, in *GetCentralRequest, opts ...grpc.CallOption) (*Central, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Central)
+	err := c.cc.Invoke(ctx, CentralService_GetCentral_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *centralServiceClient) ListCentrals(ctx context.Context, in *ListCentralsRequest, opts ...grpc.CallOption) (*ListCentralsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCentralsResponse)
+	err := c.cc.Invoke(ctx, CentralService_ListCentrals_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *centralServiceClient) UpdateCentral(ctx context.Context, in *UpdateCentralRequest, opts ...grpc.CallOption) (*Central, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Central)
+	err := c.cc.Invoke(ctx, CentralService_UpdateCentral_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *centralServiceClient) DeleteCentral(ctx context.Context, in *DeleteCentralRequest, opts ...grpc.CallOption) (*DeleteCentralResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteCentralResponse)
+	err := c.cc.Invoke(ctx, CentralService_DeleteCentral_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *centralServiceClient) WatchCentrals(ctx context.Context, in *WatchCentralsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CentralEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CentralService_ServiceDesc.Streams[0], CentralService_WatchCentrals_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchCentralsRequest, CentralEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CentralService_WatchCentralsClient = grpc.ServerStreamingClient[CentralEvent]
+
+// CentralServiceServer is the server API for CentralService service.
+// All implementations must embed UnimplementedCentralServiceServer
+// for forward compatibility.
+//
+// Cadastro de centrais, com as mesmas regras da API REST
+type CentralServiceServer interface {
+	CreateCentral(context.Context, *CreateCentralRequest) (*Central, error)
+	GetCentral(context.Context, *GetCentralRequest) (*Central, error)
+	// Lista paginada, na ordem dos IDs
+	ListCentrals(context.Context, *ListCentralsRequest) (*ListCentralsResponse, error)
+	UpdateCentral(context.Context, *UpdateCentralRequest) (*Central, error)
+	DeleteCentral(context.Context, *DeleteCentralRequest) (*DeleteCentralResponse, error)
+	// Eventos de criação, alteração, remoção e mudança de situação, na ordem
+	// de gravação. Com after_event_id, reenvia antes os eventos perdidos.
+	WatchCentrals(*WatchCentralsRequest, grpc.ServerStreamingServer[CentralEvent]) error
+	mustEmbedUnimplementedCentralServiceServer()
+}
+
+// UnimplementedCentralServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCentralServiceServer struct{}
+
+func (UnimplementedCentralServiceServer) CreateCentral(context.Context, *CreateCentralRequest) (*Central, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCentral not implemented")
+}
+func (UnimplementedCentralServiceServer) GetCentral(context.Context, *GetCentralRequest) (*Central, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCentral not implemented")
+}
+func (UnimplementedCentralServiceServer) ListCentrals(context.Context, *ListCentralsRequest) (*ListCentralsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCentrals not implemented")
+}
+func (UnimplementedCentralServiceServer) UpdateCentral(context.Context, *UpdateCentralRequest) (*Central, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCentral not implemented")
+}
+func (UnimplementedCentralServiceServer) DeleteCentral(context.Context, *DeleteCentralRequest) (*DeleteCentralResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCentral not implemented")
+}
+func (UnimplementedCentralServiceServer) WatchCentrals(*WatchCentralsRequest, grpc.ServerStreamingServer[CentralEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchCentrals not implemented")
+}
+func (UnimplementedCentralServiceServer) mustEmbedUnimplementedCentralServiceServer() {}
+func (UnimplementedCentralServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeCentralServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CentralServiceServer will
+// result in compilation errors.
+type UnsafeCentralServiceServer interface {
+	mustEmbedUnimplementedCentralServiceServer()
+}
+
+func RegisterCentralServiceServer(s grpc.ServiceRegistrar, srv CentralServiceServer) {
+	// If the following call pancis, it indicates UnimplementedCentralServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&CentralService_ServiceDesc, srv)
+}
+
+func _CentralService_CreateCentral_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCentralRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CentralServiceServer).CreateCentral(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CentralService_CreateCentral_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CentralServiceServer).CreateCentral(ctx, req.(*CreateCentralRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CentralService_GetCentral_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCentralRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CentralServiceServer).GetCentral(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CentralService_GetCentral_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CentralServiceServer).GetCentral(ctx, req.(*GetCentralRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CentralService_ListCentrals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCentralsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CentralServiceServer).ListCentrals(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CentralService_ListCentrals_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CentralServiceServer).ListCentrals(ctx, req.(*ListCentralsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CentralService_UpdateCentral_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCentralRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CentralServiceServer).UpdateCentral(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CentralService_UpdateCentral_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CentralServiceServer).UpdateCentral(ctx, req.(*UpdateCentralRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CentralService_DeleteCentral_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCentralRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CentralServiceServer).DeleteCentral(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CentralService_DeleteCentral_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CentralServiceServer).DeleteCentral(ctx, req.(*DeleteCentralRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CentralService_WatchCentrals_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchCentralsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CentralServiceServer).WatchCentrals(m, &grpc.GenericServerStream[WatchCentralsRequest, CentralEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CentralService_WatchCentralsServer = grpc.ServerStreamingServer[CentralEvent]
+
+// CentralService_ServiceDesc is the grpc.ServiceDesc for CentralService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CentralService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "central.v1.CentralService",
+	HandlerType: (*CentralServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateCentral",
+			Handler:    _CentralService_CreateCentral_Handler,
+		},
+		{
+			MethodName: "GetCentral",
+			Handler:    _CentralService_GetCentral_Handler,
+		},
+		{
+			MethodName: "ListCentrals",
+			Handler:    _CentralService_ListCentrals_Handler,
+		},
+		{
+			MethodName: "UpdateCentral",
+			Handler:    _CentralService_UpdateCentral_Handler,
+		},
+		{
+			MethodName: "DeleteCentral",
+			Handler:    _CentralService_DeleteCentral_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchCentrals",
+			Handler:       _CentralService_WatchCentrals_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "central/v1/central.proto",
+}
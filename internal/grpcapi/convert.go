@@ -0,0 +1,115 @@
+package grpcapi
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/grpcapi/centralv1"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var statusToProto = map[domain.ReachabilityStatus]centralv1.ReachabilityStatus{
+	domain.StatusUnknown:  centralv1.ReachabilityStatus_REACHABILITY_STATUS_UNKNOWN,
+	domain.StatusOnline:   centralv1.ReachabilityStatus_REACHABILITY_STATUS_ONLINE,
+	domain.StatusOffline:  centralv1.ReachabilityStatus_REACHABILITY_STATUS_OFFLINE,
+	domain.StatusDegraded: centralv1.ReachabilityStatus_REACHABILITY_STATUS_DEGRADED,
+}
+
+var eventTypeToProto = map[domain.EventType]centralv1.EventType{
+	domain.EventCentralCreated:       centralv1.EventType_EVENT_TYPE_CENTRAL_CREATED,
+	domain.EventCentralUpdated:       centralv1.EventType_EVENT_TYPE_CENTRAL_UPDATED,
+	domain.EventCentralDeleted:       centralv1.EventType_EVENT_TYPE_CENTRAL_DELETED,
+	domain.EventCentralStatusChanged: centralv1.EventType_EVENT_TYPE_CENTRAL_STATUS_CHANGED,
+}
+
+func toProtoStatus(s domain.ReachabilityStatus) centralv1.ReachabilityStatus {
+	if s == "" {
+		s = domain.StatusUnknown
+	}
+	return statusToProto[s]
+}
+
+// Situação correspondente ao enum; UNSPECIFIED vira vazio (sem filtro)
+func fromProtoStatus(s centralv1.ReachabilityStatus) (domain.ReachabilityStatus, bool) {
+	if s == centralv1.ReachabilityStatus_REACHABILITY_STATUS_UNSPECIFIED {
+		return "", true
+	}
+	for status, value := range statusToProto {
+		if value == s {
+			return status, true
+		}
+	}
+	return "", false
+}
+
+func toProtoCentral(c *domain.Central) *centralv1.Central {
+	central := &centralv1.Central{
+		Id:                     uint64(c.ID),
+		Name:                   c.Name,
+		Mac:                    c.MAC,
+		Ipv4:                   c.IPv4,
+		Ipv6:                   c.IPv6,
+		Notes:                  c.Notes,
+		Vendor:                 c.Vendor,
+		SiteId:                 toProtoID(c.SiteID),
+		LocationId:             toProtoID(c.LocationID),
+		Labels:                 c.Labels,
+		HeartbeatWindowSeconds: int64(c.HeartbeatWindow / time.Second),
+		Status: &centralv1.CentralStatus{
+			Status:          toProtoStatus(c.Status.Status),
+			LastSeenAt:      toProtoTime(c.Status.LastSeenAt),
+			CheckedAt:       toProtoTime(c.Status.CheckedAt),
+			LastHeartbeatAt: toProtoTime(c.Status.LastHeartbeatAt),
+			FirmwareVersion: c.Status.FirmwareVersion,
+		},
+		CreatedAt: timestamppb.New(c.CreatedAt),
+		UpdatedAt: timestamppb.New(c.UpdatedAt),
+	}
+	if c.Status.RTT > 0 {
+		central.Status.RttMs = float64(c.Status.RTT.Microseconds()) / 1000
+	}
+	return central
+}
+
+func toProtoEvent(e *domain.Event) *centralv1.CentralEvent {
+	event := &centralv1.CentralEvent{
+		Id:         uint64(e.ID),
+		Type:       eventTypeToProto[e.Type],
+		CentralId:  uint64(e.CentralID),
+		OccurredAt: timestamppb.New(e.OccurredAt),
+	}
+	if e.Central != nil {
+		event.Central = toProtoCentral(e.Central)
+	}
+	if c := e.StatusChange; c != nil {
+		event.StatusChange = &centralv1.StatusChange{
+			From: toProtoStatus(c.From),
+			To:   toProtoStatus(c.To),
+			At:   timestamppb.New(c.At),
+		}
+	}
+	return event
+}
+
+func toProtoID(id *uint) *uint64 {
+	if id == nil {
+		return nil
+	}
+	value := uint64(*id)
+	return &value
+}
+
+func fromProtoID(id *uint64) *uint {
+	if id == nil {
+		return nil
+	}
+	value := uint(*id)
+	return &value
+}
+
+func toProtoTime(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
@@ -0,0 +1,295 @@
+// Package grpcapi expõe o cadastro de centrais por gRPC, sobre os mesmos
+// casos de uso da API REST.
+package grpcapi
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/grpcapi/centralv1"
+	"api-golang/internal/handler"
+	"api-golang/internal/utils"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Tamanho das páginas do ListCentrals
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 500
+)
+
+type Server struct {
+	centralv1.UnimplementedCentralServiceServer
+
+	UseCase handler.CentralUseCase
+	// Fonte dos eventos do WatchCentrals; nil o deixa indisponível
+	Stream handler.EventStream
+}
+
+func NewServer(uc handler.CentralUseCase, stream handler.EventStream) *Server {
+	return &Server{UseCase: uc, Stream: stream}
+}
+
+func (s *Server) CreateCentral(ctx context.Context, req *centralv1.CreateCentralRequest) (*centralv1.Central, error) {
+	if req.Name == "" || req.Mac == "" {
+		return nil, status.Error(codes.InvalidArgument, "name and mac are required")
+	}
+	if req.Ipv4 == "" && req.Ipv6 == "" && req.PoolId == nil {
+		return nil, status.Error(codes.InvalidArgument, "ipv4, ipv6 or pool_id is required")
+	}
+	if req.HeartbeatWindowSeconds < 0 {
+		return nil, status.Error(codes.InvalidArgument, "heartbeat_window_seconds must not be negative")
+	}
+	central := &domain.Central{
+		Name:            req.Name,
+		MAC:             req.Mac,
+		IPv4:            req.Ipv4,
+		IPv6:            req.Ipv6,
+		PoolID:          fromProtoID(req.PoolId),
+		Notes:           req.Notes,
+		SiteID:          fromProtoID(req.SiteId),
+		LocationID:      fromProtoID(req.LocationId),
+		Labels:          req.Labels,
+		HeartbeatWindow: time.Duration(req.HeartbeatWindowSeconds) * time.Second,
+	}
+	if err := s.UseCase.CreateCentral(central); err != nil {
+		return nil, toStatusError(err)
+	}
+	return toProtoCentral(central), nil
+}
+
+func (s *Server) GetCentral(ctx context.Context, req *centralv1.GetCentralRequest) (*centralv1.Central, error) {
+	if req.Id == 0 {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	central, err := s.UseCase.GetCentralByID(uint(req.Id))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return toProtoCentral(central), nil
+}
+
+// Lista por páginas na ordem dos IDs; o token é o último ID da página
+// anterior. Uma central a mais é lida para saber se há próxima página.
+func (s *Server) ListCentrals(ctx context.Context, req *centralv1.ListCentralsRequest) (*centralv1.ListCentralsResponse, error) {
+	size := int(req.PageSize)
+	switch {
+	case size < 0:
+		return nil, status.Error(codes.InvalidArgument, "page_size must not be negative")
+	case size == 0:
+		size = DefaultPageSize
+	case size > MaxPageSize:
+		size = MaxPageSize
+	}
+	filter, err := listFilter(req)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	if filter.AfterID, err = decodePageToken(req.PageToken); err != nil {
+		return nil, toStatusError(err)
+	}
+	filter.Limit = size + 1
+
+	centrals, err := s.UseCase.GetAllCentrals(filter)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	resp := &centralv1.ListCentralsResponse{}
+	if len(centrals) > size {
+		centrals = centrals[:size]
+		resp.NextPageToken = encodePageToken(centrals[size-1].ID)
+	}
+	resp.Centrals = make([]*centralv1.Central, 0, len(centrals))
+	for i := range centrals {
+		resp.Centrals = append(resp.Centrals, toProtoCentral(&centrals[i]))
+	}
+	return resp, nil
+}
+
+func (s *Server) UpdateCentral(ctx context.Context, req *centralv1.UpdateCentralRequest) (*centralv1.Central, error) {
+	if req.Id == 0 {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	if req.Name == "" || req.Mac == "" {
+		return nil, status.Error(codes.InvalidArgument, "name and mac are required")
+	}
+	if req.Ipv4 == "" && req.Ipv6 == "" {
+		return nil, status.Error(codes.InvalidArgument, "ipv4 or ipv6 is required")
+	}
+	if req.HeartbeatWindowSeconds < 0 {
+		return nil, status.Error(codes.InvalidArgument, "heartbeat_window_seconds must not be negative")
+	}
+	central := &domain.Central{
+		ID:              uint(req.Id),
+		Name:            req.Name,
+		MAC:             req.Mac,
+		IPv4:            req.Ipv4,
+		IPv6:            req.Ipv6,
+		Notes:           req.Notes,
+		SiteID:          fromProtoID(req.SiteId),
+		LocationID:      fromProtoID(req.LocationId),
+		HeartbeatWindow: time.Duration(req.HeartbeatWindowSeconds) * time.Second,
+	}
+	if req.Labels != nil {
+		central.Labels = req.Labels.Values
+		if central.Labels == nil {
+			central.Labels = map[string]string{}
+		}
+	}
+	if err := s.UseCase.UpdateCentral(central); err != nil {
+		return nil, toStatusError(err)
+	}
+	// Relê para devolver o estado completo, como labels mantidos e situação
+	updated, err := s.UseCase.GetCentralByID(central.ID)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return toProtoCentral(updated), nil
+}
+
+func (s *Server) DeleteCentral(ctx context.Context, req *centralv1.DeleteCentralRequest) (*centralv1.DeleteCentralResponse, error) {
+	if req.Id == 0 {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	// A remoção em si não falha para IDs inexistentes
+	if _, err := s.UseCase.GetCentralByID(uint(req.Id)); err != nil {
+		return nil, toStatusError(err)
+	}
+	if err := s.UseCase.DeleteCentral(uint(req.Id)); err != nil {
+		return nil, toStatusError(err)
+	}
+	return &centralv1.DeleteCentralResponse{}, nil
+}
+
+// Envia os eventos até o cliente cancelar. Um cliente que não acompanha o
+// ritmo recebe RESOURCE_EXHAUSTED e pode retomar com after_event_id.
+func (s *Server) WatchCentrals(req *centralv1.WatchCentralsRequest, stream centralv1.CentralService_WatchCentralsServer) error {
+	if s.Stream == nil {
+		return status.Error(codes.Unavailable, "event stream disabled")
+	}
+	filter, err := watchFilter(req)
+	if err != nil {
+		return toStatusError(err)
+	}
+	sub, err := s.Stream.Subscribe(filter)
+	if err != nil {
+		return toStatusError(err)
+	}
+	defer sub.Close()
+
+	var last uint
+	send := func(event domain.Event) error {
+		if event.ID <= last {
+			return nil
+		}
+		if err := stream.Send(toProtoEvent(&event)); err != nil {
+			return err
+		}
+		last = event.ID
+		return nil
+	}
+	if req.AfterEventId != nil {
+		last = uint(*req.AfterEventId)
+		if err := s.Stream.Replay(last, sub.Since, filter, send); err != nil {
+			return toStatusError(err)
+		}
+	}
+
+	ctx := stream.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case event, ok := <-sub.Events():
+			if !ok {
+				return status.Errorf(codes.ResourceExhausted, "watcher fell behind; resume with after_event_id=%d", last)
+			}
+			if err := send(event); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func listFilter(req *centralv1.ListCentralsRequest) (domain.CentralFilter, error) {
+	filter := domain.CentralFilter{
+		IP:         req.Ip,
+		SiteID:     fromProtoID(req.SiteId),
+		LocationID: fromProtoID(req.LocationId),
+		Recursive:  req.Recursive,
+		Vendor:     req.Vendor,
+	}
+	var ok bool
+	if filter.Status, ok = fromProtoStatus(req.Status); !ok {
+		return filter, fmt.Errorf("%w: unknown status %d", domain.ErrInvalid, req.Status)
+	}
+	var err error
+	if filter.Selector, err = utils.ParseLabelSelector(req.Selector); err != nil {
+		return filter, fmt.Errorf("%w: %v", domain.ErrInvalid, err)
+	}
+	if req.Subnet != "" {
+		r, err := utils.SubnetRange(req.Subnet)
+		if err != nil {
+			return filter, fmt.Errorf("%w: %v", domain.ErrInvalid, err)
+		}
+		filter.IPRange = &r
+	}
+	return filter, nil
+}
+
+func watchFilter(req *centralv1.WatchCentralsRequest) (domain.EventFilter, error) {
+	filter := domain.EventFilter{SiteID: fromProtoID(req.SiteId)}
+	for _, id := range req.CentralIds {
+		filter.CentralIDs = append(filter.CentralIDs, uint(id))
+	}
+	var ok bool
+	if filter.Status, ok = fromProtoStatus(req.Status); !ok {
+		return filter, fmt.Errorf("%w: unknown status %d", domain.ErrInvalid, req.Status)
+	}
+	var err error
+	if filter.Selector, err = utils.ParseLabelSelector(req.Selector); err != nil {
+		return filter, fmt.Errorf("%w: %v", domain.ErrInvalid, err)
+	}
+	return filter, nil
+}
+
+func encodePageToken(lastID uint) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatUint(uint64(lastID), 10)))
+}
+
+func decodePageToken(token string) (uint, error) {
+	if token == "" {
+		return 0, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err == nil {
+		var id uint64
+		if id, err = strconv.ParseUint(string(raw), 10, 0); err == nil {
+			return uint(id), nil
+		}
+	}
+	return 0, fmt.Errorf("%w: invalid page_token", domain.ErrInvalid)
+}
+
+// Código gRPC correspondente a um erro de domínio
+func toStatusError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrInvalid):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrExhausted):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, domain.ErrPrecondition):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, domain.ErrConflict):
+		return status.Error(codes.AlreadyExists, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
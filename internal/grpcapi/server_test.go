@@ -0,0 +1,392 @@
+package grpcapi_test
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/grpcapi"
+	"api-golang/internal/grpcapi/centralv1"
+	"api-golang/internal/stream"
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+)
+
+// Mock do UseCase
+type MockCentralUseCase struct {
+	mock.Mock
+}
+
+func (m *MockCentralUseCase) CreateCentral(central *domain.Central) error {
+	args := m.Called(central)
+	return args.Error(0)
+}
+
+func (m *MockCentralUseCase) GetAllCentrals(filter domain.CentralFilter) ([]domain.Central, error) {
+	args := m.Called(filter)
+	return args.Get(0).([]domain.Central), args.Error(1)
+}
+
+func (m *MockCentralUseCase) GetCentralByID(id uint) (*domain.Central, error) {
+	args := m.Called(id)
+	return args.Get(0).(*domain.Central), args.Error(1)
+}
+
+func (m *MockCentralUseCase) UpdateCentral(central *domain.Central) error {
+	args := m.Called(central)
+	return args.Error(0)
+}
+
+func (m *MockCentralUseCase) DeleteCentral(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+// Outbox em memória para o stream
+type memoryOutbox struct {
+	mu     sync.Mutex
+	events []domain.Event
+}
+
+func (o *memoryOutbox) add(event domain.Event) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	event.ID = uint(len(o.events) + 1)
+	o.events = append(o.events, event)
+}
+
+func (o *memoryOutbox) EventsAfter(afterID uint, limit int) ([]domain.Event, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	var result []domain.Event
+	for _, e := range o.events {
+		if e.ID > afterID && len(result) < limit {
+			result = append(result, e)
+		}
+	}
+	return result, nil
+}
+
+func (o *memoryOutbox) LastEventID() (uint, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return uint(len(o.events)), nil
+}
+
+// Sobe o serviço em memória e devolve um cliente conectado a ele
+func setupServer(t *testing.T) (centralv1.CentralServiceClient, *MockCentralUseCase, *memoryOutbox, *stream.Hub) {
+	mockUseCase := new(MockCentralUseCase)
+	outbox := &memoryOutbox{}
+	hub := stream.New(outbox, stream.Options{})
+
+	client := dialServer(t, grpcapi.NewServer(mockUseCase, hub))
+	return client, mockUseCase, outbox, hub
+}
+
+func dialServer(t *testing.T, server centralv1.CentralServiceServer) centralv1.CentralServiceClient {
+	ln := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	centralv1.RegisterCentralServiceServer(srv, server)
+	go srv.Serve(ln)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return ln.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return centralv1.NewCentralServiceClient(conn)
+}
+
+func siteEvent(eventType domain.EventType, centralID, siteID uint) domain.Event {
+	return domain.Event{
+		Type: eventType, CentralID: centralID, OccurredAt: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+		Central: &domain.Central{ID: centralID, Name: "Portaria", SiteID: &siteID, Status: domain.CentralStatus{Status: domain.StatusOnline}},
+	}
+}
+
+func TestCreateAndGetCentral(t *testing.T) {
+	client, mockUseCase, _, _ := setupServer(t)
+	ctx := context.Background()
+	mockUseCase.On("CreateCentral", mock.MatchedBy(func(c *domain.Central) bool {
+		return c.Name == "Portaria" && c.IPv4 == "10.0.0.1" && c.Labels["env"] == "prod" && c.HeartbeatWindow == time.Minute
+	})).Run(func(args mock.Arguments) {
+		c := args.Get(0).(*domain.Central)
+		c.ID = 7
+		c.MAC = "00:11:22:33:44:55"
+	}).Return(nil)
+
+	created, err := client.CreateCentral(ctx, &centralv1.CreateCentralRequest{
+		Name: "Portaria", Mac: "0011.2233.4455", Ipv4: "10.0.0.1",
+		Labels: map[string]string{"env": "prod"}, HeartbeatWindowSeconds: 60,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(7), created.Id)
+	assert.Equal(t, "00:11:22:33:44:55", created.Mac)
+	assert.Equal(t, int64(60), created.HeartbeatWindowSeconds)
+	assert.Equal(t, centralv1.ReachabilityStatus_REACHABILITY_STATUS_UNKNOWN, created.Status.Status)
+
+	site := uint(3)
+	mockUseCase.On("GetCentralByID", uint(7)).Return(&domain.Central{ID: 7, Name: "Portaria", SiteID: &site,
+		Status: domain.CentralStatus{Status: domain.StatusOnline, RTT: 1500 * time.Microsecond}}, nil)
+	got, err := client.GetCentral(ctx, &centralv1.GetCentralRequest{Id: 7})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), got.GetSiteId())
+	assert.Nil(t, got.LocationId)
+	assert.Equal(t, centralv1.ReachabilityStatus_REACHABILITY_STATUS_ONLINE, got.Status.Status)
+	assert.Equal(t, 1.5, got.Status.RttMs)
+}
+
+func TestStatusCodes(t *testing.T) {
+	client, mockUseCase, _, _ := setupServer(t)
+	ctx := context.Background()
+	mockUseCase.On("GetCentralByID", uint(2)).Return((*domain.Central)(nil), fmt.Errorf("%w: central 2", domain.ErrNotFound))
+	mockUseCase.On("CreateCentral", mock.MatchedBy(func(c *domain.Central) bool { return c.MAC == "bad" })).
+		Return(fmt.Errorf("%w: invalid MAC", domain.ErrInvalid))
+	mockUseCase.On("CreateCentral", mock.MatchedBy(func(c *domain.Central) bool { return c.IPv4 == "10.0.0.1" })).
+		Return(fmt.Errorf("%w: IP in use", domain.ErrConflict))
+	mockUseCase.On("CreateCentral", mock.MatchedBy(func(c *domain.Central) bool { return c.IPv4 == "10.0.0.2" })).
+		Return(fmt.Errorf("%w: pool 1 is exhausted", domain.ErrExhausted))
+	mockUseCase.On("UpdateCentral", mock.MatchedBy(func(c *domain.Central) bool { return c.Name == "busy" })).
+		Return(fmt.Errorf("%w: location still has 1 central(s)", domain.ErrPrecondition))
+	mockUseCase.On("GetCentralByID", uint(3)).Return(&domain.Central{ID: 3}, nil)
+	mockUseCase.On("DeleteCentral", uint(3)).Return(fmt.Errorf("database is locked"))
+
+	tests := []struct {
+		name string
+		call func() error
+		want codes.Code
+	}{
+		{"get missing", func() error {
+			_, err := client.GetCentral(ctx, &centralv1.GetCentralRequest{Id: 2})
+			return err
+		}, codes.NotFound},
+		{"get without id", func() error {
+			_, err := client.GetCentral(ctx, &centralv1.GetCentralRequest{})
+			return err
+		}, codes.InvalidArgument},
+		{"create without ip", func() error {
+			_, err := client.CreateCentral(ctx, &centralv1.CreateCentralRequest{Name: "x", Mac: "00:11:22:33:44:55"})
+			return err
+		}, codes.InvalidArgument},
+		{"create invalid mac", func() error {
+			_, err := client.CreateCentral(ctx, &centralv1.CreateCentralRequest{Name: "x", Mac: "bad", Ipv4: "10.0.0.9"})
+			return err
+		}, codes.InvalidArgument},
+		{"create duplicate", func() error {
+			_, err := client.CreateCentral(ctx, &centralv1.CreateCentralRequest{Name: "x", Mac: "00:11:22:33:44:55", Ipv4: "10.0.0.1"})
+			return err
+		}, codes.AlreadyExists},
+		{"create from exhausted pool", func() error {
+			_, err := client.CreateCentral(ctx, &centralv1.CreateCentralRequest{Name: "x", Mac: "00:11:22:33:44:55", Ipv4: "10.0.0.2"})
+			return err
+		}, codes.ResourceExhausted},
+		{"update blocked by precondition", func() error {
+			_, err := client.UpdateCentral(ctx, &centralv1.UpdateCentralRequest{Id: 1, Name: "busy", Mac: "00:11:22:33:44:55", Ipv4: "10.0.0.1"})
+			return err
+		}, codes.FailedPrecondition},
+		{"delete missing", func() error {
+			_, err := client.DeleteCentral(ctx, &centralv1.DeleteCentralRequest{Id: 2})
+			return err
+		}, codes.NotFound},
+		{"delete failure", func() error {
+			_, err := client.DeleteCentral(ctx, &centralv1.DeleteCentralRequest{Id: 3})
+			return err
+		}, codes.Internal},
+		{"update without name", func() error {
+			_, err := client.UpdateCentral(ctx, &centralv1.UpdateCentralRequest{Id: 1, Mac: "00:11:22:33:44:55", Ipv4: "10.0.0.1"})
+			return err
+		}, codes.InvalidArgument},
+		{"list negative page", func() error {
+			_, err := client.ListCentrals(ctx, &centralv1.ListCentralsRequest{PageSize: -1})
+			return err
+		}, codes.InvalidArgument},
+		{"list invalid token", func() error {
+			_, err := client.ListCentrals(ctx, &centralv1.ListCentralsRequest{PageToken: "???"})
+			return err
+		}, codes.InvalidArgument},
+		{"list invalid subnet", func() error {
+			_, err := client.ListCentrals(ctx, &centralv1.ListCentralsRequest{Subnet: "10.0.0.0/99"})
+			return err
+		}, codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, status.Code(tt.call()))
+		})
+	}
+}
+
+func TestUpdateAndDeleteCentral(t *testing.T) {
+	client, mockUseCase, _, _ := setupServer(t)
+	ctx := context.Background()
+
+	// Sem labels, mantém os atuais; com labels vazios, remove todos
+	mockUseCase.On("UpdateCentral", mock.MatchedBy(func(c *domain.Central) bool { return c.Name == "keep" })).Return(nil).Once()
+	mockUseCase.On("UpdateCentral", mock.MatchedBy(func(c *domain.Central) bool { return c.Name == "clear" })).Return(nil).Once()
+	mockUseCase.On("GetCentralByID", uint(1)).Return(&domain.Central{ID: 1, Name: "x", Labels: map[string]string{"env": "prod"}}, nil)
+
+	_, err := client.UpdateCentral(ctx, &centralv1.UpdateCentralRequest{Id: 1, Name: "keep", Mac: "00:11:22:33:44:55", Ipv4: "10.0.0.1"})
+	require.NoError(t, err)
+	updated, err := client.UpdateCentral(ctx, &centralv1.UpdateCentralRequest{Id: 1, Name: "clear", Mac: "00:11:22:33:44:55",
+		Ipv4: "10.0.0.1", Labels: &centralv1.Labels{}})
+	require.NoError(t, err)
+	assert.Equal(t, "prod", updated.Labels["env"])
+
+	calls := mockUseCase.Calls
+	var labels []map[string]string
+	for _, call := range calls {
+		if call.Method == "UpdateCentral" {
+			labels = append(labels, call.Arguments.Get(0).(*domain.Central).Labels)
+		}
+	}
+	require.Len(t, labels, 2)
+	assert.Nil(t, labels[0])
+	assert.Equal(t, map[string]string{}, labels[1])
+
+	mockUseCase.On("DeleteCentral", uint(1)).Return(nil)
+	_, err = client.DeleteCentral(ctx, &centralv1.DeleteCentralRequest{Id: 1})
+	require.NoError(t, err)
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestListCentrals_Pagination(t *testing.T) {
+	client, mockUseCase, _, _ := setupServer(t)
+	ctx := context.Background()
+	page := func(ids ...uint) []domain.Central {
+		centrals := make([]domain.Central, len(ids))
+		for i, id := range ids {
+			centrals[i] = domain.Central{ID: id, Name: fmt.Sprint("central-", id)}
+		}
+		return centrals
+	}
+	site := uint(4)
+	base := domain.CentralFilter{SiteID: &site, Status: domain.StatusOnline}
+	first, second := base, base
+	first.Limit, second.Limit = 3, 3
+	second.AfterID = 2
+	// Lê uma central a mais que o tamanho da página para saber se há próxima
+	mockUseCase.On("GetAllCentrals", first).Return(page(1, 2, 3), nil)
+	mockUseCase.On("GetAllCentrals", second).Return(page(3), nil)
+
+	req := &centralv1.ListCentralsRequest{PageSize: 2, SiteId: proto.Uint64(4),
+		Status: centralv1.ReachabilityStatus_REACHABILITY_STATUS_ONLINE}
+	resp, err := client.ListCentrals(ctx, req)
+	require.NoError(t, err)
+	require.Len(t, resp.Centrals, 2)
+	assert.Equal(t, uint64(2), resp.Centrals[1].Id)
+	require.NotEmpty(t, resp.NextPageToken)
+
+	req.PageToken = resp.NextPageToken
+	resp, err = client.ListCentrals(ctx, req)
+	require.NoError(t, err)
+	require.Len(t, resp.Centrals, 1)
+	assert.Equal(t, uint64(3), resp.Centrals[0].Id)
+	assert.Empty(t, resp.NextPageToken)
+
+	// Sem page_size, usa o padrão; acima do máximo, é limitado
+	mockUseCase.On("GetAllCentrals", domain.CentralFilter{Limit: grpcapi.DefaultPageSize + 1}).Return(page(), nil)
+	mockUseCase.On("GetAllCentrals", domain.CentralFilter{Limit: grpcapi.MaxPageSize + 1}).Return(page(), nil)
+	_, err = client.ListCentrals(ctx, &centralv1.ListCentralsRequest{})
+	require.NoError(t, err)
+	_, err = client.ListCentrals(ctx, &centralv1.ListCentralsRequest{PageSize: 10000})
+	require.NoError(t, err)
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestWatchCentrals(t *testing.T) {
+	client, _, outbox, hub := setupServer(t)
+	for id := uint(1); id <= 3; id++ {
+		outbox.add(siteEvent(domain.EventCentralCreated, id, 1))
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Retoma depois do evento 1 e recebe os seguintes, filtrados pelo site
+	watch, err := client.WatchCentrals(ctx, &centralv1.WatchCentralsRequest{SiteId: proto.Uint64(1), AfterEventId: proto.Uint64(1)})
+	require.NoError(t, err)
+	for _, want := range []uint64{2, 3} {
+		event, err := watch.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, want, event.CentralId)
+		assert.Equal(t, centralv1.EventType_EVENT_TYPE_CENTRAL_CREATED, event.Type)
+		assert.Equal(t, "Portaria", event.Central.Name)
+	}
+
+	outbox.add(siteEvent(domain.EventCentralUpdated, 9, 2))
+	site := uint(1)
+	outbox.add(domain.Event{Type: domain.EventCentralStatusChanged, CentralID: 3,
+		StatusChange: &domain.StatusChange{From: domain.StatusOnline, To: domain.StatusOffline},
+		Central:      &domain.Central{ID: 3, SiteID: &site}})
+	_, err = hub.Poll()
+	require.NoError(t, err)
+
+	event, err := watch.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), event.Id)
+	assert.Equal(t, centralv1.ReachabilityStatus_REACHABILITY_STATUS_OFFLINE, event.StatusChange.To)
+
+	cancel()
+	_, err = watch.Recv()
+	assert.Equal(t, codes.Canceled, status.Code(err))
+}
+
+// Segura o replay para que os eventos ao vivo lotem o buffer da inscrição
+type pausedStream struct {
+	*stream.Hub
+	replaying chan struct{}
+	release   chan struct{}
+}
+
+func (s *pausedStream) Replay(after, until uint, filter domain.EventFilter, fn func(domain.Event) error) error {
+	close(s.replaying)
+	<-s.release
+	return s.Hub.Replay(after, until, filter, fn)
+}
+
+func TestWatchCentrals_Lagged(t *testing.T) {
+	outbox := &memoryOutbox{}
+	hub := stream.New(outbox, stream.Options{BufferSize: 1})
+	paused := &pausedStream{Hub: hub, replaying: make(chan struct{}), release: make(chan struct{})}
+	srv := grpcapi.NewServer(new(MockCentralUseCase), paused)
+	client := dialServer(t, srv)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	watch, err := client.WatchCentrals(ctx, &centralv1.WatchCentralsRequest{AfterEventId: proto.Uint64(0)})
+	require.NoError(t, err)
+	<-paused.replaying
+	for i := 0; i < 3; i++ {
+		outbox.add(siteEvent(domain.EventCentralUpdated, 1, 1))
+	}
+	_, err = hub.Poll()
+	require.NoError(t, err)
+	close(paused.release)
+
+	// Recebe o que coube no buffer e depois o erro com a posição para retomar
+	event, err := watch.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), event.Id)
+	_, err = watch.Recv()
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Contains(t, status.Convert(err).Message(), "after_event_id=1")
+}
+
+func TestWatchCentrals_InvalidFilter(t *testing.T) {
+	client, _, _, _ := setupServer(t)
+	watch, err := client.WatchCentrals(context.Background(), &centralv1.WatchCentralsRequest{Selector: "=prod"})
+	require.NoError(t, err)
+	_, err = watch.Recv()
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
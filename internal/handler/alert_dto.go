@@ -0,0 +1,212 @@
+package handler
+
+import (
+	"api-golang/internal/domain"
+	"time"
+)
+
+// Corpo aceito na criação e atualização de uma regra de alerta. Os campos
+// usados dependem do tipo: "status" para status, "metric", "aggregation",
+// "operator", "threshold" e "window_seconds" para metric, e "min_firmware"
+// para firmware.
+type AlertRuleRequest struct {
+	Name       string `json:"name" validate:"required"`
+	Kind       string `json:"kind" validate:"required,oneof=status metric firmware"`
+	Severity   string `json:"severity,omitempty" validate:"omitempty,oneof=info warning critical"`
+	Enabled    *bool  `json:"enabled,omitempty"`
+	Selector   string `json:"selector,omitempty"`
+	ForSeconds int    `json:"for_seconds,omitempty" validate:"gte=0"`
+
+	Status string `json:"status,omitempty" validate:"required_if=Kind status"`
+
+	Metric        string  `json:"metric,omitempty" validate:"required_if=Kind metric"`
+	Aggregation   string  `json:"aggregation,omitempty" validate:"omitempty,oneof=avg min max p95"`
+	Operator      string  `json:"operator,omitempty" validate:"required_if=Kind metric,omitempty,oneof=gt gte lt lte"`
+	Threshold     float64 `json:"threshold,omitempty"`
+	WindowSeconds int     `json:"window_seconds,omitempty" validate:"gte=0"`
+
+	MinFirmware string `json:"min_firmware,omitempty" validate:"required_if=Kind firmware"`
+
+	Inhibits []uint `json:"inhibits,omitempty"`
+}
+
+func (r AlertRuleRequest) ToDomain(id uint) *domain.AlertRule {
+	return &domain.AlertRule{
+		ID:          id,
+		Name:        r.Name,
+		Kind:        domain.AlertRuleKind(r.Kind),
+		Severity:    domain.AlertSeverity(r.Severity),
+		Enabled:     r.Enabled == nil || *r.Enabled,
+		Selector:    r.Selector,
+		For:         time.Duration(r.ForSeconds) * time.Second,
+		Status:      domain.ReachabilityStatus(r.Status),
+		Metric:      r.Metric,
+		Aggregation: r.Aggregation,
+		Operator:    r.Operator,
+		Threshold:   r.Threshold,
+		Window:      time.Duration(r.WindowSeconds) * time.Second,
+		MinFirmware: r.MinFirmware,
+		Inhibits:    r.Inhibits,
+	}
+}
+
+type AlertRuleResponse struct {
+	ID         uint      `json:"id"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+	Name       string    `json:"name"`
+	Kind       string    `json:"kind"`
+	Severity   string    `json:"severity"`
+	Enabled    bool      `json:"enabled"`
+	Selector   string    `json:"selector,omitempty"`
+	ForSeconds int64     `json:"for_seconds"`
+
+	Status string `json:"status,omitempty"`
+
+	Metric        string   `json:"metric,omitempty"`
+	Aggregation   string   `json:"aggregation,omitempty"`
+	Operator      string   `json:"operator,omitempty"`
+	Threshold     *float64 `json:"threshold,omitempty"`
+	WindowSeconds int64    `json:"window_seconds,omitempty"`
+
+	MinFirmware string `json:"min_firmware,omitempty"`
+
+	Inhibits []uint `json:"inhibits"`
+}
+
+func NewAlertRuleResponse(rule *domain.AlertRule) AlertRuleResponse {
+	response := AlertRuleResponse{
+		ID:            rule.ID,
+		CreatedAt:     rule.CreatedAt,
+		UpdatedAt:     rule.UpdatedAt,
+		Name:          rule.Name,
+		Kind:          string(rule.Kind),
+		Severity:      string(rule.Severity),
+		Enabled:       rule.Enabled,
+		Selector:      rule.Selector,
+		ForSeconds:    int64(rule.For / time.Second),
+		Status:        string(rule.Status),
+		Metric:        rule.Metric,
+		Aggregation:   rule.Aggregation,
+		Operator:      rule.Operator,
+		WindowSeconds: int64(rule.Window / time.Second),
+		MinFirmware:   rule.MinFirmware,
+		Inhibits:      rule.Inhibits,
+	}
+	if rule.Kind == domain.AlertKindMetric {
+		threshold := rule.Threshold
+		response.Threshold = &threshold
+	}
+	if response.Inhibits == nil {
+		response.Inhibits = []uint{}
+	}
+	return response
+}
+
+func NewAlertRuleResponses(rules []domain.AlertRule) []AlertRuleResponse {
+	responses := make([]AlertRuleResponse, 0, len(rules))
+	for i := range rules {
+		responses = append(responses, NewAlertRuleResponse(&rules[i]))
+	}
+	return responses
+}
+
+type AlertResponse struct {
+	ID        uint     `json:"id"`
+	RuleID    uint     `json:"rule_id"`
+	CentralID uint     `json:"central_id"`
+	State     string   `json:"state"`
+	Severity  string   `json:"severity"`
+	Summary   string   `json:"summary"`
+	Value     *float64 `json:"value,omitempty"`
+
+	ActiveSince     time.Time  `json:"active_since"`
+	FiredAt         *time.Time `json:"fired_at,omitempty"`
+	ResolvedAt      *time.Time `json:"resolved_at,omitempty"`
+	LastEvaluatedAt time.Time  `json:"last_evaluated_at"`
+
+	Silenced  bool `json:"silenced"`
+	Inhibited bool `json:"inhibited"`
+}
+
+func NewAlertResponse(alert *domain.Alert) AlertResponse {
+	return AlertResponse{
+		ID:              alert.ID,
+		RuleID:          alert.RuleID,
+		CentralID:       alert.CentralID,
+		State:           string(alert.State),
+		Severity:        string(alert.Severity),
+		Summary:         alert.Summary,
+		Value:           alert.Value,
+		ActiveSince:     alert.ActiveSince,
+		FiredAt:         alert.FiredAt,
+		ResolvedAt:      alert.ResolvedAt,
+		LastEvaluatedAt: alert.LastEvaluatedAt,
+		Silenced:        alert.Silenced,
+		Inhibited:       alert.Inhibited,
+	}
+}
+
+func NewAlertResponses(alerts []domain.Alert) []AlertResponse {
+	responses := make([]AlertResponse, 0, len(alerts))
+	for i := range alerts {
+		responses = append(responses, NewAlertResponse(&alerts[i]))
+	}
+	return responses
+}
+
+// Corpo aceito na criação de um silêncio; sem "starts_at", começa agora
+type SilenceRequest struct {
+	RuleID    *uint      `json:"rule_id,omitempty" validate:"required_without=CentralID,omitempty,gt=0"`
+	CentralID *uint      `json:"central_id,omitempty" validate:"omitempty,gt=0"`
+	StartsAt  *time.Time `json:"starts_at,omitempty"`
+	EndsAt    time.Time  `json:"ends_at" validate:"required"`
+	Comment   string     `json:"comment,omitempty"`
+	CreatedBy string     `json:"created_by,omitempty"`
+}
+
+func (r SilenceRequest) ToDomain() *domain.Silence {
+	silence := &domain.Silence{
+		RuleID:    r.RuleID,
+		CentralID: r.CentralID,
+		EndsAt:    r.EndsAt,
+		Comment:   r.Comment,
+		CreatedBy: r.CreatedBy,
+	}
+	if r.StartsAt != nil {
+		silence.StartsAt = *r.StartsAt
+	}
+	return silence
+}
+
+type SilenceResponse struct {
+	ID        uint      `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	RuleID    *uint     `json:"rule_id,omitempty"`
+	CentralID *uint     `json:"central_id,omitempty"`
+	StartsAt  time.Time `json:"starts_at"`
+	EndsAt    time.Time `json:"ends_at"`
+	Comment   string    `json:"comment,omitempty"`
+	CreatedBy string    `json:"created_by,omitempty"`
+}
+
+func NewSilenceResponse(silence *domain.Silence) SilenceResponse {
+	return SilenceResponse{
+		ID:        silence.ID,
+		CreatedAt: silence.CreatedAt,
+		RuleID:    silence.RuleID,
+		CentralID: silence.CentralID,
+		StartsAt:  silence.StartsAt,
+		EndsAt:    silence.EndsAt,
+		Comment:   silence.Comment,
+		CreatedBy: silence.CreatedBy,
+	}
+}
+
+func NewSilenceResponses(silences []domain.Silence) []SilenceResponse {
+	responses := make([]SilenceResponse, 0, len(silences))
+	for i := range silences {
+		responses = append(responses, NewSilenceResponse(&silences[i]))
+	}
+	return responses
+}
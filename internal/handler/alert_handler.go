@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"api-golang/internal/domain"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type AlertUseCase interface {
+	CreateRule(rule *domain.AlertRule) error
+	GetRules() ([]domain.AlertRule, error)
+	GetRuleByID(id uint) (*domain.AlertRule, error)
+	UpdateRule(rule *domain.AlertRule) error
+	DeleteRule(id uint) error
+
+	GetAlerts(filter domain.AlertFilter) ([]domain.Alert, error)
+
+	CreateSilence(silence *domain.Silence) error
+	GetSilences() ([]domain.Silence, error)
+	ExpireSilence(id uint) error
+}
+
+type AlertHandler struct {
+	UseCase   AlertUseCase
+	Validator *validator.Validate
+}
+
+func NewAlertHandler(uc AlertUseCase) *AlertHandler {
+	return &AlertHandler{
+		UseCase:   uc,
+		Validator: validator.New(),
+	}
+}
+
+// Create Alert Rule
+func (h *AlertHandler) CreateRule(c *fiber.Ctx) error {
+	var req AlertRuleRequest
+	if err := parseBody(c, h.Validator, &req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	rule := req.ToDomain(0)
+	if err := h.UseCase.CreateRule(rule); err != nil {
+		return errorResponse(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(NewAlertRuleResponse(rule))
+}
+
+// Get All Alert Rules
+func (h *AlertHandler) GetRules(c *fiber.Ctx) error {
+	rules, err := h.UseCase.GetRules()
+	if err != nil {
+		return errorResponse(c, err)
+	}
+	return c.JSON(NewAlertRuleResponses(rules))
+}
+
+// Get Alert Rule by ID
+func (h *AlertHandler) GetRuleByID(c *fiber.Ctx) error {
+	id, _ := c.ParamsInt("id")
+	rule, err := h.UseCase.GetRuleByID(uint(id))
+	if err != nil {
+		return errorResponse(c, err)
+	}
+	return c.JSON(NewAlertRuleResponse(rule))
+}
+
+// Update Alert Rule
+func (h *AlertHandler) UpdateRule(c *fiber.Ctx) error {
+	id, _ := c.ParamsInt("id")
+	var req AlertRuleRequest
+	if err := parseBody(c, h.Validator, &req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	rule := req.ToDomain(uint(id))
+	if err := h.UseCase.UpdateRule(rule); err != nil {
+		return errorResponse(c, err)
+	}
+	return c.JSON(NewAlertRuleResponse(rule))
+}
+
+// Delete Alert Rule
+func (h *AlertHandler) DeleteRule(c *fiber.Ctx) error {
+	id, _ := c.ParamsInt("id")
+	if err := h.UseCase.DeleteRule(uint(id)); err != nil {
+		return errorResponse(c, err)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// Get Alerts. Sem ?state=, lista os ativos (pendentes e disparados).
+func (h *AlertHandler) GetAlerts(c *fiber.Ctx) error {
+	filter := domain.AlertFilter{State: domain.AlertState(c.Query("state"))}
+	ruleID, err := optionalUintQuery(c, "rule_id")
+	if err != nil {
+		return errorResponse(c, err)
+	}
+	if ruleID != nil {
+		filter.RuleID = *ruleID
+	}
+	centralID, err := optionalUintQuery(c, "central_id")
+	if err != nil {
+		return errorResponse(c, err)
+	}
+	if centralID != nil {
+		filter.CentralID = *centralID
+	}
+
+	alerts, err := h.UseCase.GetAlerts(filter)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+	return c.JSON(NewAlertResponses(alerts))
+}
+
+// Create Silence
+func (h *AlertHandler) CreateSilence(c *fiber.Ctx) error {
+	var req SilenceRequest
+	if err := parseBody(c, h.Validator, &req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	silence := req.ToDomain()
+	if err := h.UseCase.CreateSilence(silence); err != nil {
+		return errorResponse(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(NewSilenceResponse(silence))
+}
+
+// Get active and scheduled Silences
+func (h *AlertHandler) GetSilences(c *fiber.Ctx) error {
+	silences, err := h.UseCase.GetSilences()
+	if err != nil {
+		return errorResponse(c, err)
+	}
+	return c.JSON(NewSilenceResponses(silences))
+}
+
+// Expire Silence
+func (h *AlertHandler) ExpireSilence(c *fiber.Ctx) error {
+	id, _ := c.ParamsInt("id")
+	if err := h.UseCase.ExpireSilence(uint(id)); err != nil {
+		return errorResponse(c, err)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,146 @@
+package handler_test
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/handler"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock do UseCase de alertas
+type MockAlertUseCase struct {
+	mock.Mock
+}
+
+func (m *MockAlertUseCase) CreateRule(rule *domain.AlertRule) error {
+	args := m.Called(rule)
+	return args.Error(0)
+}
+
+func (m *MockAlertUseCase) GetRules() ([]domain.AlertRule, error) {
+	args := m.Called()
+	return args.Get(0).([]domain.AlertRule), args.Error(1)
+}
+
+func (m *MockAlertUseCase) GetRuleByID(id uint) (*domain.AlertRule, error) {
+	args := m.Called(id)
+	rule, _ := args.Get(0).(*domain.AlertRule)
+	return rule, args.Error(1)
+}
+
+func (m *MockAlertUseCase) UpdateRule(rule *domain.AlertRule) error {
+	args := m.Called(rule)
+	return args.Error(0)
+}
+
+func (m *MockAlertUseCase) DeleteRule(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockAlertUseCase) GetAlerts(filter domain.AlertFilter) ([]domain.Alert, error) {
+	args := m.Called(filter)
+	return args.Get(0).([]domain.Alert), args.Error(1)
+}
+
+func (m *MockAlertUseCase) CreateSilence(silence *domain.Silence) error {
+	args := m.Called(silence)
+	return args.Error(0)
+}
+
+func (m *MockAlertUseCase) GetSilences() ([]domain.Silence, error) {
+	args := m.Called()
+	return args.Get(0).([]domain.Silence), args.Error(1)
+}
+
+func (m *MockAlertUseCase) ExpireSilence(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func setupAlertApp() (*fiber.App, *MockAlertUseCase) {
+	mockUseCase := new(MockAlertUseCase)
+	app := fiber.New()
+	handler.RegisterAlertRoutes(app, handler.NewAlertHandler(mockUseCase))
+	return app, mockUseCase
+}
+
+func TestCreateAlertRule(t *testing.T) {
+	app, mockUseCase := setupAlertApp()
+
+	mockUseCase.On("CreateRule", mock.MatchedBy(func(r *domain.AlertRule) bool {
+		return r.Kind == domain.AlertKindMetric && r.Metric == "rtt_ms" && r.Threshold == 200 &&
+			r.Window == 10*time.Minute && r.Enabled
+	})).Return(nil)
+
+	body := `{"name":"RTT alto","kind":"metric","metric":"rtt_ms","aggregation":"p95","operator":"gt","threshold":200,"window_seconds":600}`
+	resp := postJSON(app, "/alerts/rules", body)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var rule handler.AlertRuleResponse
+	json.NewDecoder(resp.Body).Decode(&rule)
+	assert.Equal(t, float64(200), *rule.Threshold)
+	assert.Equal(t, []uint{}, rule.Inhibits)
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestCreateAlertRule_Invalid(t *testing.T) {
+	app, _ := setupAlertApp()
+
+	for _, body := range []string{
+		`{"name":"x"}`,
+		`{"name":"x","kind":"status"}`,
+		`{"name":"x","kind":"metric","metric":"rtt_ms"}`,
+		`{"name":"x","kind":"firmware"}`,
+		`{"name":"x","kind":"status","status":"offline","severity":"page"}`,
+	} {
+		assert.Equal(t, http.StatusBadRequest, postJSON(app, "/alerts/rules", body).StatusCode, body)
+	}
+}
+
+func TestGetAlerts(t *testing.T) {
+	app, mockUseCase := setupAlertApp()
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	mockUseCase.On("GetAlerts", domain.AlertFilter{State: domain.AlertFiring, CentralID: 3}).Return([]domain.Alert{
+		{ID: 1, RuleID: 2, CentralID: 3, State: domain.AlertFiring, Severity: domain.SeverityCritical, ActiveSince: now, FiredAt: &now, Inhibited: true},
+	}, nil)
+	mockUseCase.On("GetAlerts", domain.AlertFilter{State: "open"}).Return([]domain.Alert(nil), domain.ErrInvalid)
+
+	resp, _ := app.Test(httptest.NewRequest(http.MethodGet, "/alerts?state=firing&central_id=3", nil), -1)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	var alerts []handler.AlertResponse
+	json.NewDecoder(resp.Body).Decode(&alerts)
+	assert.Len(t, alerts, 1)
+	assert.True(t, alerts[0].Inhibited)
+
+	resp, _ = app.Test(httptest.NewRequest(http.MethodGet, "/alerts?state=open", nil), -1)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	resp, _ = app.Test(httptest.NewRequest(http.MethodGet, "/alerts?rule_id=abc", nil), -1)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestSilences(t *testing.T) {
+	app, mockUseCase := setupAlertApp()
+
+	mockUseCase.On("CreateSilence", mock.MatchedBy(func(s *domain.Silence) bool {
+		return s.CentralID != nil && *s.CentralID == 3 && s.RuleID == nil && s.StartsAt.IsZero()
+	})).Return(nil)
+	mockUseCase.On("ExpireSilence", uint(5)).Return(domain.ErrNotFound)
+
+	resp := postJSON(app, "/alerts/silences", `{"central_id":3,"ends_at":"2024-01-01T13:00:00Z","comment":"manutenção"}`)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	resp = postJSON(app, "/alerts/silences", `{"ends_at":"2024-01-01T13:00:00Z"}`)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp, _ = app.Test(httptest.NewRequest(http.MethodDelete, "/alerts/silences/5", nil), -1)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	mockUseCase.AssertExpectations(t)
+}
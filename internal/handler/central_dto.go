@@ -0,0 +1,197 @@
+package handler
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/oui"
+	"api-golang/internal/utils"
+	"strings"
+	"time"
+)
+
+// Corpo aceito na criação de uma central. MAC e IP podem vir em qualquer
+// notação comum; a normalização acontece no caso de uso. O campo "ip" é
+// mantido por compatibilidade e aceita qualquer família; para dual-stack use
+// "ipv4" e "ipv6". Com "pool_id", o endereço é alocado pelo IPAM.
+type CreateCentralRequest struct {
+	Name string `json:"name" validate:"required"`
+	MAC  string `json:"mac" validate:"required"`
+	IP   string `json:"ip,omitempty" validate:"required_without_all=IPv4 IPv6 PoolID,excluded_with=IPv4 IPv6"`
+	IPv4 string `json:"ipv4,omitempty"`
+	IPv6 string `json:"ipv6,omitempty"`
+
+	// Aloca o próximo IP livre do pool em vez de informar o endereço
+	PoolID *uint `json:"pool_id,omitempty"`
+
+	Notes string `json:"notes,omitempty"`
+
+	// Basta informar a localização; o site é deduzido dela
+	SiteID     *uint `json:"site_id,omitempty"`
+	LocationID *uint `json:"location_id,omitempty"`
+
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Prazo sem heartbeat até a central ser dada como offline; zero usa o padrão
+	HeartbeatWindowSeconds int `json:"heartbeat_window_seconds,omitempty" validate:"gte=0"`
+}
+
+func (r CreateCentralRequest) ToDomain() *domain.Central {
+	central := newCentral(0, r.Name, r.MAC, r.IP, r.IPv4, r.IPv6)
+	central.SiteID, central.LocationID = r.SiteID, r.LocationID
+	central.PoolID = r.PoolID
+	central.Notes = r.Notes
+	central.Labels = r.Labels
+	central.HeartbeatWindow = time.Duration(r.HeartbeatWindowSeconds) * time.Second
+	return central
+}
+
+// Corpo aceito na atualização de uma central
+type UpdateCentralRequest struct {
+	Name string `json:"name" validate:"required"`
+	MAC  string `json:"mac" validate:"required"`
+	IP   string `json:"ip,omitempty" validate:"required_without_all=IPv4 IPv6,excluded_with=IPv4 IPv6"`
+	IPv4 string `json:"ipv4,omitempty"`
+	IPv6 string `json:"ipv6,omitempty"`
+
+	Notes string `json:"notes,omitempty"`
+
+	SiteID     *uint `json:"site_id,omitempty"`
+	LocationID *uint `json:"location_id,omitempty"`
+
+	// Ausente mantém os labels atuais; um objeto substitui todos
+	Labels map[string]string `json:"labels,omitempty"`
+
+	HeartbeatWindowSeconds int `json:"heartbeat_window_seconds,omitempty" validate:"gte=0"`
+}
+
+func (r UpdateCentralRequest) ToDomain(id uint) *domain.Central {
+	central := newCentral(id, r.Name, r.MAC, r.IP, r.IPv4, r.IPv6)
+	central.SiteID, central.LocationID = r.SiteID, r.LocationID
+	central.Notes = r.Notes
+	central.Labels = r.Labels
+	central.HeartbeatWindow = time.Duration(r.HeartbeatWindowSeconds) * time.Second
+	return central
+}
+
+func newCentral(id uint, name, mac, ip, ipv4, ipv6 string) *domain.Central {
+	central := &domain.Central{ID: id, Name: name, MAC: mac, IPv4: ipv4, IPv6: ipv6}
+	if ip != "" {
+		// A família definitiva é conferida no caso de uso
+		if strings.Contains(ip, ":") {
+			central.IPv6 = ip
+		} else {
+			central.IPv4 = ip
+		}
+	}
+	return central
+}
+
+// Representação da central nas respostas da API. "ip" traz o endereço
+// principal (IPv4 quando houver) para clientes anteriores ao dual-stack.
+type CentralResponse struct {
+	ID        uint      `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Name      string    `json:"name"`
+	MAC       string    `json:"mac"`
+	IP        string    `json:"ip"`
+	IPv4      string    `json:"ipv4,omitempty"`
+	IPv6      string    `json:"ipv6,omitempty"`
+	Notes     string    `json:"notes,omitempty"`
+	Vendor    string    `json:"vendor,omitempty"`
+	// Indicações de MAC provavelmente cadastrado errado (ver MACWarning*)
+	MACWarnings []string `json:"mac_warnings,omitempty"`
+
+	SiteID     *uint `json:"site_id,omitempty"`
+	LocationID *uint `json:"location_id,omitempty"`
+
+	Labels map[string]string `json:"labels"`
+
+	// Resultado do monitor de alcance
+	Status     domain.ReachabilityStatus `json:"status"`
+	LastSeenAt *time.Time                `json:"last_seen_at,omitempty"`
+	RTTMillis  *float64                  `json:"rtt_ms,omitempty"`
+
+	HeartbeatWindowSeconds int        `json:"heartbeat_window_seconds,omitempty"`
+	LastHeartbeatAt        *time.Time `json:"last_heartbeat_at,omitempty"`
+	FirmwareVersion        string     `json:"firmware_version,omitempty"`
+
+	PrimaryInterface *InterfaceResponse `json:"primary_interface,omitempty"`
+}
+
+// Monta a resposta com o MAC no formato pedido (ver utils.MACFormat*)
+func NewCentralResponse(central *domain.Central, macFormat string) CentralResponse {
+	mac, err := utils.FormatMAC(central.MAC, macFormat)
+	if err != nil {
+		mac = central.MAC
+	}
+	response := CentralResponse{
+		ID:        central.ID,
+		CreatedAt: central.CreatedAt,
+		UpdatedAt: central.UpdatedAt,
+		Name:      central.Name,
+		MAC:       mac,
+		IP:        central.PrimaryIP(),
+		IPv4:      central.IPv4,
+		IPv6:      central.IPv6,
+		Notes:     central.Notes,
+		Vendor:    central.Vendor,
+
+		MACWarnings: macWarnings(central.MAC),
+
+		SiteID:     central.SiteID,
+		LocationID: central.LocationID,
+
+		Labels: central.Labels,
+	}
+	if response.Labels == nil {
+		response.Labels = map[string]string{}
+	}
+	response.Status = central.Status.Status
+	if response.Status == "" {
+		response.Status = domain.StatusUnknown
+	}
+	response.LastSeenAt = central.Status.LastSeenAt
+	response.LastHeartbeatAt = central.Status.LastHeartbeatAt
+	response.FirmwareVersion = central.Status.FirmwareVersion
+	response.HeartbeatWindowSeconds = int(central.HeartbeatWindow / time.Second)
+	if central.Status.RTT > 0 {
+		rtt := float64(central.Status.RTT.Microseconds()) / 1000
+		response.RTTMillis = &rtt
+	}
+	if central.PrimaryInterface != nil {
+		primary := NewInterfaceResponse(central.PrimaryInterface, macFormat)
+		response.PrimaryInterface = &primary
+	}
+	return response
+}
+
+// Indicações sobre o MAC nas respostas
+const (
+	MACWarningLocallyAdministered = "locally_administered"
+	MACWarningMulticast           = "multicast"
+)
+
+// MACs administrados localmente ou de grupo raramente são de uma central
+// física e costumam indicar erro de cadastro
+func macWarnings(mac string) []string {
+	prefix, err := oui.ParsePrefix(mac)
+	if err != nil {
+		return nil
+	}
+	var warnings []string
+	if prefix.LocallyAdministered() {
+		warnings = append(warnings, MACWarningLocallyAdministered)
+	}
+	if prefix.Multicast() {
+		warnings = append(warnings, MACWarningMulticast)
+	}
+	return warnings
+}
+
+func NewCentralResponses(centrals []domain.Central, macFormat string) []CentralResponse {
+	responses := make([]CentralResponse, 0, len(centrals))
+	for i := range centrals {
+		responses = append(responses, NewCentralResponse(&centrals[i], macFormat))
+	}
+	return responses
+}
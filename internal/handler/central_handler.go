@@ -3,6 +3,10 @@ package handler
 import (
 	"api-golang/internal/domain"
 	"api-golang/internal/utils"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
@@ -10,7 +14,7 @@ import (
 
 type CentralUseCase interface {
 	CreateCentral(central *domain.Central) error
-	GetAllCentrals() ([]domain.Central, error)
+	GetAllCentrals(filter domain.CentralFilter) ([]domain.Central, error)
 	GetCentralByID(id uint) (*domain.Central, error)
 	UpdateCentral(central *domain.Central) error
 	DeleteCentral(id uint) error
@@ -30,76 +34,222 @@ func NewCentralHandler(uc CentralUseCase) *CentralHandler {
 
 // Create Central
 func (h *CentralHandler) CreateCentral(c *fiber.Ctx) error {
-	var central domain.Central
+	var req CreateCentralRequest
 
 	// Parse JSON do corpo da requisição
-	if err := c.BodyParser(&central); err != nil {
+	if err := c.BodyParser(&req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid payload"})
 	}
 
 	// Validação usando Validator
-	if err := h.Validator.Struct(central); err != nil {
+	if err := h.Validator.Struct(req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": utils.FormatValidationErrors(err),
+			"error": utils.FormatValidationErrors(err).Error(),
 		})
 	}
 
+	macFormat, err := macFormatQuery(c)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
 	// Chama o caso de uso para criar a central
-	if err := h.UseCase.CreateCentral(&central); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	central := req.ToDomain()
+	if err := h.UseCase.CreateCentral(central); err != nil {
+		return errorResponse(c, err)
 	}
-	return c.Status(fiber.StatusCreated).JSON(central)
+	return c.Status(fiber.StatusCreated).JSON(NewCentralResponse(central, macFormat))
 }
 
 // Get All Centrals
 func (h *CentralHandler) GetAllCentrals(c *fiber.Ctx) error {
-	centrals, err := h.UseCase.GetAllCentrals()
+	macFormat, err := macFormatQuery(c)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	filter, err := centralFilterQuery(c)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+	limit, err := pageQuery(c, &filter)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	centrals, err := h.UseCase.GetAllCentrals(filter)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+	// Com limit, busca um item a mais só para saber se há próxima página
+	if limit > 0 && len(centrals) > limit {
+		centrals = centrals[:limit]
+		c.Set(fiber.HeaderLink, nextPageLink(c, centrals[limit-1].ID))
+	}
+	return c.JSON(NewCentralResponses(centrals, macFormat))
+}
+
+// Maior página aceita em ?limit=
+const maxPageLimit = 1000
+
+// Lê a paginação por chave de ?limit= e ?after_id=. Sem limit, a listagem
+// traz todas as centrais, como antes da paginação.
+func pageQuery(c *fiber.Ctx, filter *domain.CentralFilter) (int, error) {
+	limit := c.QueryInt("limit", 0)
+	if c.Query("limit") != "" && (limit <= 0 || limit > maxPageLimit) {
+		return 0, fmt.Errorf("%w: limit must be between 1 and %d", domain.ErrInvalid, maxPageLimit)
+	}
+	afterID, err := optionalUintQuery(c, "after_id")
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return 0, err
+	}
+	if afterID != nil {
+		filter.AfterID = *afterID
+	}
+	if limit > 0 {
+		filter.Limit = limit + 1
 	}
-	return c.JSON(centrals)
+	return limit, nil
+}
+
+// Link para a próxima página, com os mesmos filtros
+func nextPageLink(c *fiber.Ctx, lastID uint) string {
+	query, _ := url.ParseQuery(string(c.Request().URI().QueryString()))
+	query.Set("after_id", strconv.FormatUint(uint64(lastID), 10))
+	return fmt.Sprintf("<%s?%s>; rel=\"next\"", c.Path(), query.Encode())
 }
 
 // Get Central by ID
 func (h *CentralHandler) GetCentralByID(c *fiber.Ctx) error {
 	id, _ := c.ParamsInt("id")
+	macFormat, err := macFormatQuery(c)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
 	central, err := h.UseCase.GetCentralByID(uint(id))
 	if err != nil {
 		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Central not found"})
 	}
-	return c.JSON(central)
+	return c.JSON(NewCentralResponse(central, macFormat))
 }
 
 // Update Central
 func (h *CentralHandler) UpdateCentral(c *fiber.Ctx) error {
 	id, _ := c.ParamsInt("id")
-	var central domain.Central
+	var req UpdateCentralRequest
 
 	// Parse JSON do corpo da requisição
-	if err := c.BodyParser(&central); err != nil {
+	if err := c.BodyParser(&req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid payload"})
 	}
 
 	// Validação usando Validator
-	if err := h.Validator.Struct(central); err != nil {
+	if err := h.Validator.Struct(req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": utils.FormatValidationErrors(err),
+			"error": utils.FormatValidationErrors(err).Error(),
 		})
 	}
 
-	// Define o ID da central antes de atualizar
-	central.ID = uint(id)
-	if err := h.UseCase.UpdateCentral(&central); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	macFormat, err := macFormatQuery(c)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	// O ID vem sempre da rota, nunca do corpo
+	central := req.ToDomain(uint(id))
+	if err := h.UseCase.UpdateCentral(central); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Central not found"})
+		}
+		return errorResponse(c, err)
 	}
-	return c.JSON(central)
+	return c.JSON(NewCentralResponse(central, macFormat))
 }
 
 // Delete Central
 func (h *CentralHandler) DeleteCentral(c *fiber.Ctx) error {
 	id, _ := c.ParamsInt("id")
 	if err := h.UseCase.DeleteCentral(uint(id)); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return errorResponse(c, err)
 	}
 	return c.SendStatus(fiber.StatusNoContent)
 }
+
+// Lê o formato de saída do MAC em ?mac_format=
+func macFormatQuery(c *fiber.Ctx) (string, error) {
+	format := c.Query("mac_format")
+	if !utils.IsMACFormat(format) {
+		return "", fmt.Errorf("%w: unknown mac_format %q", domain.ErrInvalid, format)
+	}
+	return format, nil
+}
+
+// Lê os filtros da listagem de centrais a partir da query string
+func centralFilterQuery(c *fiber.Ctx) (domain.CentralFilter, error) {
+	// Filtro por endereço, em qualquer família
+	filter := domain.CentralFilter{
+		IP:        c.Query("ip"),
+		Recursive: c.QueryBool("recursive", false),
+		Unplaced:  c.QueryBool("unplaced", false),
+		Vendor:    c.Query("vendor"),
+		Status:    domain.ReachabilityStatus(c.Query("status")),
+	}
+	if filter.Status != "" && !filter.Status.Valid() {
+		return filter, fmt.Errorf("%w: unknown status %q", domain.ErrInvalid, filter.Status)
+	}
+
+	var err error
+	if filter.SiteID, err = optionalUintQuery(c, "site_id"); err != nil {
+		return filter, err
+	}
+	if filter.LocationID, err = optionalUintQuery(c, "location_id"); err != nil {
+		return filter, err
+	}
+	if filter.Selector, err = utils.ParseLabelSelector(c.Query("selector")); err != nil {
+		return filter, fmt.Errorf("%w: %v", domain.ErrInvalid, err)
+	}
+	if filter.IPRange, err = ipRangeQuery(c); err != nil {
+		return filter, fmt.Errorf("%w: %v", domain.ErrInvalid, err)
+	}
+	return filter, nil
+}
+
+// Lê a faixa de endereços de ?subnet= ou de ?ip_from= e ?ip_to=
+func ipRangeQuery(c *fiber.Ctx) (*domain.IPRange, error) {
+	subnet, from, to := c.Query("subnet"), c.Query("ip_from"), c.Query("ip_to")
+	if subnet == "" && from == "" && to == "" {
+		return nil, nil
+	}
+	if subnet != "" && (from != "" || to != "") {
+		return nil, errors.New("use either subnet or ip_from/ip_to")
+	}
+
+	var (
+		r   domain.IPRange
+		err error
+	)
+	if subnet != "" {
+		r, err = utils.SubnetRange(subnet)
+	} else {
+		r, err = utils.AddressRange(from, to)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// Lê um ID opcional da query string
+func optionalUintQuery(c *fiber.Ctx, key string) (*uint, error) {
+	value := c.Query(key)
+	if value == "" {
+		return nil, nil
+	}
+	id, err := strconv.ParseUint(value, 10, 0)
+	if err != nil || id == 0 {
+		return nil, fmt.Errorf("%w: %s must be a positive integer", domain.ErrInvalid, key)
+	}
+	result := uint(id)
+	return &result, nil
+}
@@ -6,9 +6,13 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/netip"
+	"net/url"
 	"testing"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/stretchr/testify/assert"
@@ -25,8 +29,8 @@ func (m *MockCentralUseCase) CreateCentral(central *domain.Central) error {
 	return args.Error(0)
 }
 
-func (m *MockCentralUseCase) GetAllCentrals() ([]domain.Central, error) {
-	args := m.Called()
+func (m *MockCentralUseCase) GetAllCentrals(filter domain.CentralFilter) ([]domain.Central, error) {
+	args := m.Called(filter)
 	return args.Get(0).([]domain.Central), args.Error(1)
 }
 
@@ -59,7 +63,7 @@ func TestCreateCentral_ValidData(t *testing.T) {
 	app.Post("/central", centralHandler.CreateCentral)
 
 	// Dados válidos
-	data := domain.Central{Name: "Central 1", MAC: "00:11:22:33:44:55", IP: "192.168.0.1"}
+	data := handler.CreateCentralRequest{Name: "Central 1", MAC: "00:11:22:33:44:55", IP: "192.168.0.1"}
 	payload, _ := json.Marshal(data)
 
 	mockUseCase.On("CreateCentral", mock.AnythingOfType("*domain.Central")).Return(nil)
@@ -79,7 +83,7 @@ func TestCreateCentral_InvalidData(t *testing.T) {
 	app.Post("/central", centralHandler.CreateCentral)
 
 	// Dados inválidos
-	data := domain.Central{Name: "", MAC: "", IP: ""}
+	data := handler.CreateCentralRequest{Name: "", MAC: "", IP: ""}
 	payload, _ := json.Marshal(data)
 
 	req := httptest.NewRequest(http.MethodPost, "/central", bytes.NewReader(payload))
@@ -89,6 +93,128 @@ func TestCreateCentral_InvalidData(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
 }
 
+func TestCreateCentral_MissingName(t *testing.T) {
+	app := fiber.New()
+	centralHandler, mockUseCase := setupHandler()
+
+	app.Post("/central", centralHandler.CreateCentral)
+
+	// Nome ausente deve ser rejeitado
+	data := handler.CreateCentralRequest{MAC: "00:11:22:33:44:55", IP: "192.168.0.1"}
+	payload, _ := json.Marshal(data)
+
+	req := httptest.NewRequest(http.MethodPost, "/central", bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req, -1)
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	mockUseCase.AssertNotCalled(t, "CreateCentral", mock.Anything)
+}
+
+func TestCreateCentral_IgnoresServerManagedFields(t *testing.T) {
+	app := fiber.New()
+	centralHandler, mockUseCase := setupHandler()
+
+	app.Post("/central", centralHandler.CreateCentral)
+
+	// O cliente tenta definir id e datas
+	payload := []byte(`{"id":42,"created_at":"2000-01-01T00:00:00Z","updated_at":"2000-01-01T00:00:00Z",` +
+		`"name":"Central 1","mac":"00:11:22:33:44:55","ip":"192.168.0.1"}`)
+
+	mockUseCase.On("CreateCentral", mock.MatchedBy(func(c *domain.Central) bool {
+		return c.ID == 0 && c.CreatedAt.IsZero() && c.UpdatedAt.IsZero()
+	})).Return(nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/central", bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req, -1)
+
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestCreateCentral_IPv6(t *testing.T) {
+	app := fiber.New()
+	centralHandler, mockUseCase := setupHandler()
+
+	app.Post("/central", centralHandler.CreateCentral)
+
+	// O campo "ip" aceita IPv6
+	data := handler.CreateCentralRequest{Name: "Central 1", MAC: "00:11:22:33:44:55", IP: "2001:db8::1"}
+	payload, _ := json.Marshal(data)
+
+	mockUseCase.On("CreateCentral", mock.MatchedBy(func(c *domain.Central) bool {
+		return c.IPv6 == "2001:db8::1" && c.IPv4 == ""
+	})).Return(nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/central", bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req, -1)
+
+	var body handler.CentralResponse
+	json.NewDecoder(resp.Body).Decode(&body)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, "2001:db8::1", body.IP)
+	assert.Equal(t, "2001:db8::1", body.IPv6)
+}
+
+func TestCreateCentral_IPWithFamilyFields(t *testing.T) {
+	app := fiber.New()
+	centralHandler, _ := setupHandler()
+
+	app.Post("/central", centralHandler.CreateCentral)
+
+	// "ip" não pode ser combinado com "ipv4"/"ipv6"
+	data := handler.CreateCentralRequest{Name: "Central 1", MAC: "00:11:22:33:44:55", IP: "192.168.0.1", IPv6: "2001:db8::1"}
+	payload, _ := json.Marshal(data)
+
+	req := httptest.NewRequest(http.MethodPost, "/central", bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req, -1)
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestCreateCentral_Conflict(t *testing.T) {
+	app := fiber.New()
+	centralHandler, mockUseCase := setupHandler()
+
+	app.Post("/central", centralHandler.CreateCentral)
+
+	data := handler.CreateCentralRequest{Name: "Central 1", MAC: "00-11-22-33-44-55", IP: "192.168.0.1"}
+	payload, _ := json.Marshal(data)
+
+	// MAC já cadastrado em outra notação
+	mockUseCase.On("CreateCentral", mock.AnythingOfType("*domain.Central")).Return(domain.ErrConflict)
+
+	req := httptest.NewRequest(http.MethodPost, "/central", bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req, -1)
+
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+}
+
+func TestCreateCentral_PoolExhausted(t *testing.T) {
+	app := fiber.New()
+	centralHandler, mockUseCase := setupHandler()
+	app.Post("/central", centralHandler.CreateCentral)
+
+	pool := uint(1)
+	mockUseCase.On("CreateCentral", mock.AnythingOfType("*domain.Central")).
+		Return(fmt.Errorf("%w: pool 1 is exhausted", domain.ErrExhausted))
+
+	payload, _ := json.Marshal(handler.CreateCentralRequest{Name: "Central 1", MAC: "00:11:22:33:44:55", PoolID: &pool})
+	req := httptest.NewRequest(http.MethodPost, "/central", bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req, -1)
+
+	// Pool esgotado também é 409 na API REST
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	var body map[string]string
+	json.NewDecoder(resp.Body).Decode(&body)
+	assert.Equal(t, "conflict: pool 1 is exhausted", body["error"])
+}
+
 func TestGetAllCentrals(t *testing.T) {
 	app := fiber.New()
 	centralHandler, mockUseCase := setupHandler()
@@ -97,16 +223,133 @@ func TestGetAllCentrals(t *testing.T) {
 
 	// Dados simulados
 	centrals := []domain.Central{
-		{Name: "Central 1", MAC: "00:11:22:33:44:55", IP: "192.168.0.1"},
-		{Name: "Central 2", MAC: "00:11:22:33:44:56", IP: "192.168.0.2"},
+		{Name: "Central 1", MAC: "00:11:22:33:44:55", IPv4: "192.168.0.1"},
+		{Name: "Central 2", MAC: "00:11:22:33:44:56", IPv4: "192.168.0.2"},
 	}
-	mockUseCase.On("GetAllCentrals").Return(centrals, nil)
+	mockUseCase.On("GetAllCentrals", domain.CentralFilter{}).Return(centrals, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/centrals", nil)
 	resp, _ := app.Test(req, -1)
 
 	assert.Equal(t, http.StatusOK, resp.StatusCode)
-	mockUseCase.AssertCalled(t, "GetAllCentrals")
+	mockUseCase.AssertCalled(t, "GetAllCentrals", domain.CentralFilter{})
+}
+
+func TestGetAllCentrals_FilterByIP(t *testing.T) {
+	app := fiber.New()
+	centralHandler, mockUseCase := setupHandler()
+
+	app.Get("/centrals", centralHandler.GetAllCentrals)
+
+	mockUseCase.On("GetAllCentrals", domain.CentralFilter{IP: "2001:db8::1"}).Return([]domain.Central{}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/centrals?ip=2001:db8::1", nil)
+	resp, _ := app.Test(req, -1)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	mockUseCase.AssertCalled(t, "GetAllCentrals", domain.CentralFilter{IP: "2001:db8::1"})
+}
+
+func TestGetAllCentrals_FilterByStatus(t *testing.T) {
+	app := fiber.New()
+	centralHandler, mockUseCase := setupHandler()
+
+	app.Get("/centrals", centralHandler.GetAllCentrals)
+
+	lastSeen := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	mockUseCase.On("GetAllCentrals", domain.CentralFilter{Status: domain.StatusOffline}).Return([]domain.Central{
+		{ID: 1, Name: "Central 1", MAC: "00:11:22:33:44:55", IPv4: "192.168.0.1",
+			Status: domain.CentralStatus{Status: domain.StatusOffline, LastSeenAt: &lastSeen}},
+	}, nil)
+
+	resp, _ := app.Test(httptest.NewRequest(http.MethodGet, "/centrals?status=offline", nil), -1)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	var body []handler.CentralResponse
+	json.NewDecoder(resp.Body).Decode(&body)
+	assert.Len(t, body, 1)
+	assert.Equal(t, domain.StatusOffline, body[0].Status)
+	assert.True(t, lastSeen.Equal(*body[0].LastSeenAt))
+
+	resp, _ = app.Test(httptest.NewRequest(http.MethodGet, "/centrals?status=sleeping", nil), -1)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestGetAllCentrals_Selector(t *testing.T) {
+	app := fiber.New()
+	centralHandler, mockUseCase := setupHandler()
+
+	app.Get("/centrals", centralHandler.GetAllCentrals)
+
+	filter := domain.CentralFilter{Selector: domain.LabelSelector{
+		{Key: "env", Operator: domain.SelectorEquals, Values: []string{"prod"}},
+		{Key: "tier", Operator: domain.SelectorIn, Values: []string{"a", "b"}},
+	}}
+	mockUseCase.On("GetAllCentrals", filter).Return([]domain.Central{}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/centrals?selector="+url.QueryEscape("env=prod,tier in (a,b)"), nil)
+	resp, _ := app.Test(req, -1)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	req = httptest.NewRequest(http.MethodGet, "/centrals?selector="+url.QueryEscape("env=,,"), nil)
+	resp, _ = app.Test(req, -1)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestGetAllCentrals_Pagination(t *testing.T) {
+	app := fiber.New()
+	centralHandler, mockUseCase := setupHandler()
+
+	app.Get("/centrals", centralHandler.GetAllCentrals)
+
+	// Um item a mais que o limite indica que há próxima página
+	mockUseCase.On("GetAllCentrals", domain.CentralFilter{Status: domain.StatusOnline, AfterID: 10, Limit: 3}).Return([]domain.Central{
+		{ID: 11, Name: "Central 11"}, {ID: 12, Name: "Central 12"}, {ID: 13, Name: "Central 13"},
+	}, nil).Once()
+	resp, _ := app.Test(httptest.NewRequest(http.MethodGet, "/centrals?status=online&limit=2&after_id=10", nil), -1)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	var body []handler.CentralResponse
+	json.NewDecoder(resp.Body).Decode(&body)
+	assert.Len(t, body, 2)
+	assert.Equal(t, `</centrals?after_id=12&limit=2&status=online>; rel="next"`, resp.Header.Get("Link"))
+
+	// Última página
+	mockUseCase.On("GetAllCentrals", domain.CentralFilter{AfterID: 12, Limit: 3}).Return([]domain.Central{
+		{ID: 13, Name: "Central 13"},
+	}, nil).Once()
+	resp, _ = app.Test(httptest.NewRequest(http.MethodGet, "/centrals?limit=2&after_id=12", nil), -1)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Empty(t, resp.Header.Get("Link"))
+
+	for _, query := range []string{"limit=0", "limit=1001", "after_id=x"} {
+		resp, _ = app.Test(httptest.NewRequest(http.MethodGet, "/centrals?"+query, nil), -1)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, query)
+	}
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestGetAllCentrals_Subnet(t *testing.T) {
+	app := fiber.New()
+	centralHandler, mockUseCase := setupHandler()
+
+	app.Get("/centrals", centralHandler.GetAllCentrals)
+
+	filter := domain.CentralFilter{IPRange: &domain.IPRange{
+		From: netip.MustParseAddr("10.20.0.0"),
+		To:   netip.MustParseAddr("10.20.255.255"),
+	}}
+	mockUseCase.On("GetAllCentrals", filter).Return([]domain.Central{}, nil)
+
+	resp, _ := app.Test(httptest.NewRequest(http.MethodGet, "/centrals?subnet=10.20.0.0/16", nil), -1)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// Sub-rede e faixa juntas são ambíguas
+	resp, _ = app.Test(httptest.NewRequest(http.MethodGet, "/centrals?subnet=10.20.0.0/16&ip_from=10.20.0.1", nil), -1)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp, _ = app.Test(httptest.NewRequest(http.MethodGet, "/centrals?ip_from=10.0.0.1&ip_to=2001:db8::1", nil), -1)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	mockUseCase.AssertExpectations(t)
 }
 
 func TestGetCentralByID_ValidID(t *testing.T) {
@@ -120,7 +363,7 @@ func TestGetCentralByID_ValidID(t *testing.T) {
 		ID:   1,
 		Name: "Central Test",
 		MAC:  "00:11:22:33:44:55",
-		IP:   "192.168.0.1",
+		IPv4: "192.168.0.1",
 	}
 	mockUseCase.On("GetCentralByID", uint(1)).Return(mockCentral, nil)
 
@@ -131,6 +374,29 @@ func TestGetCentralByID_ValidID(t *testing.T) {
 	mockUseCase.AssertCalled(t, "GetCentralByID", uint(1))
 }
 
+func TestGetCentralByID_MACFormat(t *testing.T) {
+	app := fiber.New()
+	centralHandler, mockUseCase := setupHandler()
+
+	app.Get("/central/:id", centralHandler.GetCentralByID)
+
+	mockCentral := &domain.Central{ID: 1, Name: "Central Test", MAC: "00:11:22:aa:bb:cc", IPv4: "192.168.0.1"}
+	mockUseCase.On("GetCentralByID", uint(1)).Return(mockCentral, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/central/1?mac_format=dot", nil)
+	resp, _ := app.Test(req, -1)
+
+	var body handler.CentralResponse
+	json.NewDecoder(resp.Body).Decode(&body)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "0011.22aa.bbcc", body.MAC)
+
+	// Formato desconhecido
+	req = httptest.NewRequest(http.MethodGet, "/central/1?mac_format=weird", nil)
+	resp, _ = app.Test(req, -1)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
 func TestGetCentralByID_InvalidID(t *testing.T) {
 	app := fiber.New()
 	centralHandler, mockUseCase := setupHandler()
@@ -154,7 +420,7 @@ func TestUpdateCentral(t *testing.T) {
 	app.Put("/central/:id", centralHandler.UpdateCentral)
 
 	// Dados válidos
-	data := domain.Central{Name: "Updated Central", MAC: "00:11:22:33:44:55", IP: "192.168.0.2"}
+	data := handler.UpdateCentralRequest{Name: "Updated Central", MAC: "00:11:22:33:44:55", IP: "192.168.0.2"}
 	payload, _ := json.Marshal(data)
 
 	mockUseCase.On("UpdateCentral", mock.AnythingOfType("*domain.Central")).Return(nil)
@@ -167,6 +433,24 @@ func TestUpdateCentral(t *testing.T) {
 	mockUseCase.AssertCalled(t, "UpdateCentral", mock.AnythingOfType("*domain.Central"))
 }
 
+func TestUpdateCentral_NotFound(t *testing.T) {
+	app := fiber.New()
+	centralHandler, mockUseCase := setupHandler()
+
+	app.Put("/central/:id", centralHandler.UpdateCentral)
+
+	data := handler.UpdateCentralRequest{Name: "Updated Central", MAC: "00:11:22:33:44:55", IP: "192.168.0.2"}
+	payload, _ := json.Marshal(data)
+
+	mockUseCase.On("UpdateCentral", mock.AnythingOfType("*domain.Central")).Return(domain.ErrNotFound)
+
+	req := httptest.NewRequest(http.MethodPut, "/central/99", bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req, -1)
+
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
 func TestDeleteCentral(t *testing.T) {
 	app := fiber.New()
 	centralHandler, mockUseCase := setupHandler()
@@ -0,0 +1,106 @@
+package handler_test
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/handler"
+	"api-golang/internal/openapi"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Monta o app com as rotas reais e validação completa do contrato
+func setupContractApp(t *testing.T) (*fiber.App, *MockCentralUseCase) {
+	doc, err := openapi.Load()
+	require.NoError(t, err)
+	validator, err := openapi.Middleware(doc, openapi.Options{ValidateResponses: true})
+	require.NoError(t, err)
+
+	centralHandler, mockUseCase := setupHandler()
+	app := fiber.New()
+	app.Use(validator)
+	handler.RegisterCentralRoutes(app, centralHandler)
+	return app, mockUseCase
+}
+
+// Falha o teste se a resposta divergir do contrato
+func assertContract(t *testing.T, resp *http.Response, expectedStatus int) {
+	t.Helper()
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, expectedStatus, resp.StatusCode, string(body))
+}
+
+func TestContract_CreateCentral(t *testing.T) {
+	app, mockUseCase := setupContractApp(t)
+	mockUseCase.On("CreateCentral", mock.AnythingOfType("*domain.Central")).Return(nil)
+
+	payload := `{"name":"Central 1","mac":"00:11:22:33:44:55","ip":"192.168.0.1"}`
+	req := httptest.NewRequest(http.MethodPost, "/central", bytes.NewBufferString(payload))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req, -1)
+
+	assertContract(t, resp, http.StatusCreated)
+}
+
+func TestContract_CreateCentral_ValidationError(t *testing.T) {
+	app, mockUseCase := setupContractApp(t)
+	mockUseCase.On("CreateCentral", mock.AnythingOfType("*domain.Central")).Return(fmt.Errorf("%w: invalid MAC address", domain.ErrInvalid))
+
+	// Passa pelo contrato mas falha na validação do handler
+	payload := `{"name":"Central 1","mac":"invalid","ip":"192.168.0.1"}`
+	req := httptest.NewRequest(http.MethodPost, "/central", bytes.NewBufferString(payload))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req, -1)
+
+	assertContract(t, resp, http.StatusBadRequest)
+}
+
+func TestContract_GetAllCentrals(t *testing.T) {
+	app, mockUseCase := setupContractApp(t)
+	mockUseCase.On("GetAllCentrals", domain.CentralFilter{}).Return([]domain.Central{
+		{ID: 1, Name: "Central 1", MAC: "00:11:22:33:44:55", IPv4: "192.168.0.1"},
+	}, nil)
+
+	resp, _ := app.Test(httptest.NewRequest(http.MethodGet, "/centrals", nil), -1)
+
+	assertContract(t, resp, http.StatusOK)
+}
+
+func TestContract_GetCentralByID(t *testing.T) {
+	app, mockUseCase := setupContractApp(t)
+	mockUseCase.On("GetCentralByID", uint(1)).Return(&domain.Central{
+		ID: 1, Name: "Central 1", MAC: "00:11:22:33:44:55", IPv4: "192.168.0.1",
+		PrimaryInterface: &domain.NetworkInterface{ID: 1, CentralID: 1, Name: "eth0", MAC: "00:11:22:33:44:55", IPs: []string{"192.168.0.1"}, Primary: true},
+	}, nil)
+	mockUseCase.On("GetCentralByID", uint(2)).Return((*domain.Central)(nil), errors.New("not found"))
+
+	resp, _ := app.Test(httptest.NewRequest(http.MethodGet, "/central/1", nil), -1)
+	assertContract(t, resp, http.StatusOK)
+
+	resp, _ = app.Test(httptest.NewRequest(http.MethodGet, "/central/2", nil), -1)
+	assertContract(t, resp, http.StatusNotFound)
+}
+
+func TestContract_UpdateAndDeleteCentral(t *testing.T) {
+	app, mockUseCase := setupContractApp(t)
+	mockUseCase.On("UpdateCentral", mock.AnythingOfType("*domain.Central")).Return(nil)
+	mockUseCase.On("DeleteCentral", uint(1)).Return(nil)
+
+	payload := `{"name":"Central 1","mac":"00:11:22:33:44:55","ip":"192.168.0.2"}`
+	req := httptest.NewRequest(http.MethodPut, "/central/1", bytes.NewBufferString(payload))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req, -1)
+	assertContract(t, resp, http.StatusOK)
+
+	resp, _ = app.Test(httptest.NewRequest(http.MethodDelete, "/central/1", nil), -1)
+	assertContract(t, resp, http.StatusNoContent)
+}
@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"api-golang/internal/domain"
+	"time"
+)
+
+// Corpo opcional da aceitação de um candidato. Os campos vazios vêm do
+// candidato; "mac" é obrigatório quando a varredura não encontrou o MAC.
+type AcceptCandidateRequest struct {
+	Name       string            `json:"name,omitempty"`
+	MAC        string            `json:"mac,omitempty"`
+	Notes      string            `json:"notes,omitempty"`
+	SiteID     *uint             `json:"site_id,omitempty"`
+	LocationID *uint             `json:"location_id,omitempty"`
+	Labels     map[string]string `json:"labels,omitempty"`
+}
+
+func (r AcceptCandidateRequest) ToDomain() domain.CandidateAcceptance {
+	return domain.CandidateAcceptance{
+		Name:       r.Name,
+		MAC:        r.MAC,
+		Notes:      r.Notes,
+		SiteID:     r.SiteID,
+		LocationID: r.LocationID,
+		Labels:     r.Labels,
+	}
+}
+
+type CandidateResponse struct {
+	ID          uint       `json:"id"`
+	IP          string     `json:"ip"`
+	MAC         string     `json:"mac,omitempty"`
+	Vendor      string     `json:"vendor,omitempty"`
+	OpenPorts   []int      `json:"open_ports"`
+	Fingerprint string     `json:"fingerprint"`
+	Status      string     `json:"status"`
+	FirstSeenAt time.Time  `json:"first_seen_at"`
+	LastSeenAt  time.Time  `json:"last_seen_at"`
+	DecidedAt   *time.Time `json:"decided_at,omitempty"`
+	CentralID   *uint      `json:"central_id,omitempty"`
+}
+
+func NewCandidateResponse(c *domain.DiscoveryCandidate) CandidateResponse {
+	ports := c.OpenPorts
+	if ports == nil {
+		ports = []int{}
+	}
+	return CandidateResponse{
+		ID:          c.ID,
+		IP:          c.IP,
+		MAC:         c.MAC,
+		Vendor:      c.Vendor,
+		OpenPorts:   ports,
+		Fingerprint: c.Fingerprint,
+		Status:      string(c.Status),
+		FirstSeenAt: c.FirstSeenAt,
+		LastSeenAt:  c.LastSeenAt,
+		DecidedAt:   c.DecidedAt,
+		CentralID:   c.CentralID,
+	}
+}
+
+func NewCandidateResponses(candidates []domain.DiscoveryCandidate) []CandidateResponse {
+	responses := make([]CandidateResponse, 0, len(candidates))
+	for i := range candidates {
+		responses = append(responses, NewCandidateResponse(&candidates[i]))
+	}
+	return responses
+}
+
+// Resposta da aceitação: o candidato aceito e a central criada
+type AcceptedCandidateResponse struct {
+	Candidate CandidateResponse `json:"candidate"`
+	Central   CentralResponse   `json:"central"`
+}
@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"api-golang/internal/domain"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type DiscoveryUseCase interface {
+	GetCandidates(filter domain.CandidateFilter) ([]domain.DiscoveryCandidate, error)
+	GetCandidate(id uint) (*domain.DiscoveryCandidate, error)
+	AcceptCandidate(id uint, acceptance domain.CandidateAcceptance) (*domain.Central, *domain.DiscoveryCandidate, error)
+	RejectCandidate(id uint) (*domain.DiscoveryCandidate, error)
+}
+
+type DiscoveryHandler struct {
+	UseCase DiscoveryUseCase
+}
+
+func NewDiscoveryHandler(uc DiscoveryUseCase) *DiscoveryHandler {
+	return &DiscoveryHandler{UseCase: uc}
+}
+
+// Get Discovery Candidates, pendentes por padrão ou por ?status=
+func (h *DiscoveryHandler) GetCandidates(c *fiber.Ctx) error {
+	candidates, err := h.UseCase.GetCandidates(domain.CandidateFilter{Status: domain.CandidateStatus(c.Query("status"))})
+	if err != nil {
+		return errorResponse(c, err)
+	}
+	return c.JSON(NewCandidateResponses(candidates))
+}
+
+// Get Discovery Candidate by ID
+func (h *DiscoveryHandler) GetCandidate(c *fiber.Ctx) error {
+	id, _ := c.ParamsInt("id")
+	candidate, err := h.UseCase.GetCandidate(uint(id))
+	if err != nil {
+		return errorResponse(c, err)
+	}
+	return c.JSON(NewCandidateResponse(candidate))
+}
+
+// Accept Discovery Candidate, criando a central
+func (h *DiscoveryHandler) AcceptCandidate(c *fiber.Ctx) error {
+	id, _ := c.ParamsInt("id")
+	var req AcceptCandidateRequest
+	// O corpo é opcional
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid payload"})
+		}
+	}
+
+	central, candidate, err := h.UseCase.AcceptCandidate(uint(id), req.ToDomain())
+	if err != nil {
+		return errorResponse(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(AcceptedCandidateResponse{
+		Candidate: NewCandidateResponse(candidate),
+		Central:   NewCentralResponse(central, ""),
+	})
+}
+
+// Reject Discovery Candidate
+func (h *DiscoveryHandler) RejectCandidate(c *fiber.Ctx) error {
+	id, _ := c.ParamsInt("id")
+	candidate, err := h.UseCase.RejectCandidate(uint(id))
+	if err != nil {
+		return errorResponse(c, err)
+	}
+	return c.JSON(NewCandidateResponse(candidate))
+}
@@ -0,0 +1,103 @@
+package handler_test
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/handler"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock do UseCase de descoberta
+type MockDiscoveryUseCase struct {
+	mock.Mock
+}
+
+func (m *MockDiscoveryUseCase) GetCandidates(filter domain.CandidateFilter) ([]domain.DiscoveryCandidate, error) {
+	args := m.Called(filter)
+	return args.Get(0).([]domain.DiscoveryCandidate), args.Error(1)
+}
+
+func (m *MockDiscoveryUseCase) GetCandidate(id uint) (*domain.DiscoveryCandidate, error) {
+	args := m.Called(id)
+	return args.Get(0).(*domain.DiscoveryCandidate), args.Error(1)
+}
+
+func (m *MockDiscoveryUseCase) AcceptCandidate(id uint, acceptance domain.CandidateAcceptance) (*domain.Central, *domain.DiscoveryCandidate, error) {
+	args := m.Called(id, acceptance)
+	centralID := uint(7)
+	return &domain.Central{ID: centralID, Name: "Intelbras 10.0.0.10", MAC: "00:aa:bb:cc:dd:01", IPv4: "10.0.0.10"},
+		&domain.DiscoveryCandidate{ID: id, Status: domain.CandidateAccepted, CentralID: &centralID}, args.Error(0)
+}
+
+func (m *MockDiscoveryUseCase) RejectCandidate(id uint) (*domain.DiscoveryCandidate, error) {
+	args := m.Called(id)
+	return &domain.DiscoveryCandidate{ID: id, Status: domain.CandidateRejected}, args.Error(0)
+}
+
+func setupDiscoveryApp() (*fiber.App, *MockDiscoveryUseCase) {
+	mockUseCase := new(MockDiscoveryUseCase)
+	app := fiber.New()
+	handler.RegisterDiscoveryRoutes(app, handler.NewDiscoveryHandler(mockUseCase))
+	return app, mockUseCase
+}
+
+func TestGetCandidates(t *testing.T) {
+	app, mockUseCase := setupDiscoveryApp()
+	seen := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	mockUseCase.On("GetCandidates", domain.CandidateFilter{}).Return([]domain.DiscoveryCandidate{
+		{ID: 1, IP: "10.0.0.10", MAC: "00:aa:bb:cc:dd:01", OpenPorts: []int{5060}, Fingerprint: domain.FingerprintPBX,
+			Status: domain.CandidatePending, FirstSeenAt: seen, LastSeenAt: seen},
+	}, nil)
+	mockUseCase.On("GetCandidates", domain.CandidateFilter{Status: "bogus"}).Return([]domain.DiscoveryCandidate(nil), domain.ErrInvalid)
+
+	resp, _ := app.Test(httptest.NewRequest(http.MethodGet, "/discovery/candidates", nil), -1)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	var body []handler.CandidateResponse
+	json.NewDecoder(resp.Body).Decode(&body)
+	assert.Len(t, body, 1)
+	assert.Equal(t, "pbx", body[0].Fingerprint)
+
+	resp, _ = app.Test(httptest.NewRequest(http.MethodGet, "/discovery/candidates?status=bogus", nil), -1)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestAcceptCandidate(t *testing.T) {
+	app, mockUseCase := setupDiscoveryApp()
+	mockUseCase.On("AcceptCandidate", uint(1), domain.CandidateAcceptance{}).Return(nil)
+	mockUseCase.On("AcceptCandidate", uint(2), domain.CandidateAcceptance{Name: "Portaria", MAC: "00:aa:bb:cc:dd:02"}).Return(nil)
+	mockUseCase.On("AcceptCandidate", uint(3), mock.Anything).Return(domain.ErrConflict)
+
+	// Um clique: sem corpo
+	resp, _ := app.Test(httptest.NewRequest(http.MethodPost, "/discovery/candidates/1/accept", nil), -1)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	var body handler.AcceptedCandidateResponse
+	json.NewDecoder(resp.Body).Decode(&body)
+	assert.Equal(t, uint(7), body.Central.ID)
+	assert.Equal(t, "accepted", body.Candidate.Status)
+
+	resp = postJSON(app, "/discovery/candidates/2/accept", `{"name":"Portaria","mac":"00:aa:bb:cc:dd:02"}`)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	resp = postJSON(app, "/discovery/candidates/2/accept", `{"name":`)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	resp, _ = app.Test(httptest.NewRequest(http.MethodPost, "/discovery/candidates/3/accept", nil), -1)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestRejectCandidate(t *testing.T) {
+	app, mockUseCase := setupDiscoveryApp()
+	mockUseCase.On("RejectCandidate", uint(1)).Return(nil)
+	mockUseCase.On("RejectCandidate", uint(2)).Return(domain.ErrNotFound)
+
+	resp, _ := app.Test(httptest.NewRequest(http.MethodPost, "/discovery/candidates/1/reject", nil), -1)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	resp, _ = app.Test(httptest.NewRequest(http.MethodPost, "/discovery/candidates/2/reject", nil), -1)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
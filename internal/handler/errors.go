@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/utils"
+	"errors"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+// Status HTTP correspondente a um erro de domínio
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrInvalid):
+		return fiber.StatusBadRequest
+	case errors.Is(err, domain.ErrNotFound):
+		return fiber.StatusNotFound
+	// Recurso esgotado e pré-condição são conflitos mais específicos; na
+	// API REST continuam 409, como documentado no contrato, e só o gRPC os
+	// distingue
+	case errors.Is(err, domain.ErrExhausted), errors.Is(err, domain.ErrPrecondition):
+		return fiber.StatusConflict
+	case errors.Is(err, domain.ErrConflict):
+		return fiber.StatusConflict
+	}
+	return fiber.StatusInternalServerError
+}
+
+// Responde com o status e a mensagem do erro
+func errorResponse(c *fiber.Ctx, err error) error {
+	return c.Status(errorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+}
+
+// Faz o parse e a validação do corpo da requisição
+func parseBody(c *fiber.Ctx, validate *validator.Validate, req interface{}) error {
+	if err := c.BodyParser(req); err != nil {
+		return errors.New("invalid payload")
+	}
+	if err := validate.Struct(req); err != nil {
+		return utils.FormatValidationErrors(err)
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/stream"
+	"api-golang/internal/utils"
+	"api-golang/internal/webhook"
+	"bufio"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type EventStream interface {
+	Subscribe(filter domain.EventFilter) (*stream.Subscription, error)
+	Replay(after, until uint, filter domain.EventFilter, fn func(domain.Event) error) error
+}
+
+type EventHandler struct {
+	Stream EventStream
+	// Intervalo dos comentários que mantêm a conexão aberta em proxies
+	KeepAlive time.Duration
+	// Espera sugerida ao cliente antes de reconectar
+	Retry time.Duration
+}
+
+func NewEventHandler(s EventStream) *EventHandler {
+	return &EventHandler{Stream: s, KeepAlive: 15 * time.Second, Retry: 3 * time.Second}
+}
+
+// Stream SSE dos eventos de centrais. Com Last-Event-ID (ou ?last_event_id=),
+// os eventos perdidos desde aquele ID são reenviados antes dos novos.
+func (h *EventHandler) StreamEvents(c *fiber.Ctx) error {
+	filter, err := eventFilterQuery(c)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+	lastID, resume, err := lastEventID(c)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+	sub, err := h.Stream.Subscribe(filter)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer sub.Close()
+		send := func(event domain.Event) error {
+			if event.ID <= lastID {
+				return nil
+			}
+			if err := writeEvent(w, event); err != nil {
+				return err
+			}
+			lastID = event.ID
+			return w.Flush()
+		}
+
+		fmt.Fprintf(w, "retry: %d\n\n", h.Retry.Milliseconds())
+		if err := w.Flush(); err != nil {
+			return
+		}
+		if resume {
+			if err := h.Stream.Replay(lastID, sub.Since, filter, send); err != nil {
+				return
+			}
+		}
+
+		ticker := time.NewTicker(h.KeepAlive)
+		defer ticker.Stop()
+		for {
+			select {
+			case event, ok := <-sub.Events():
+				// Fechado por atraso: o cliente reconecta com o último ID
+				if !ok {
+					return
+				}
+				if err := send(event); err != nil {
+					return
+				}
+			case <-ticker.C:
+				w.WriteString(": keepalive\n\n")
+				if err := w.Flush(); err != nil {
+					return
+				}
+			}
+		}
+	})
+	return nil
+}
+
+// Escreve o evento no formato SSE, com o mesmo corpo dos webhooks
+func writeEvent(w *bufio.Writer, event domain.Event) error {
+	id := strconv.FormatUint(uint64(event.ID), 10)
+	data, err := webhook.Encode(id, event)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", id, event.Type, data)
+	return err
+}
+
+// Lê os filtros do stream: site, seletor de labels e situação
+func eventFilterQuery(c *fiber.Ctx) (domain.EventFilter, error) {
+	filter := domain.EventFilter{Status: domain.ReachabilityStatus(c.Query("status"))}
+	if filter.Status != "" && !filter.Status.Valid() {
+		return filter, fmt.Errorf("%w: unknown status %q", domain.ErrInvalid, filter.Status)
+	}
+	var err error
+	if filter.SiteID, err = optionalUintQuery(c, "site_id"); err != nil {
+		return filter, err
+	}
+	if filter.Selector, err = utils.ParseLabelSelector(c.Query("selector")); err != nil {
+		return filter, fmt.Errorf("%w: %v", domain.ErrInvalid, err)
+	}
+	return filter, nil
+}
+
+// ID do último evento recebido pelo cliente, quando informado; zero retoma
+// desde o evento mais antigo ainda no outbox
+func lastEventID(c *fiber.Ctx) (uint, bool, error) {
+	value := c.Get("Last-Event-ID")
+	if value == "" {
+		value = c.Query("last_event_id")
+	}
+	if value == "" {
+		return 0, false, nil
+	}
+	id, err := strconv.ParseUint(value, 10, 0)
+	if err != nil {
+		return 0, false, fmt.Errorf("%w: Last-Event-ID must be a non-negative integer", domain.ErrInvalid)
+	}
+	return uint(id), true, nil
+}
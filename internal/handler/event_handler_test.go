@@ -0,0 +1,171 @@
+package handler_test
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/handler"
+	"api-golang/internal/stream"
+	"bufio"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Outbox em memória lido pelo hub
+type memoryOutbox struct {
+	mu     sync.Mutex
+	events []domain.Event
+}
+
+func (o *memoryOutbox) add(event domain.Event) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	event.ID = uint(len(o.events) + 1)
+	o.events = append(o.events, event)
+}
+
+func (o *memoryOutbox) EventsAfter(afterID uint, limit int) ([]domain.Event, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	var result []domain.Event
+	for _, e := range o.events {
+		if e.ID > afterID && len(result) < limit {
+			result = append(result, e)
+		}
+	}
+	return result, nil
+}
+
+func (o *memoryOutbox) LastEventID() (uint, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return uint(len(o.events)), nil
+}
+
+func siteEvent(eventType domain.EventType, centralID, siteID uint) domain.Event {
+	return domain.Event{
+		Type: eventType, CentralID: centralID, OccurredAt: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+		Central: &domain.Central{ID: centralID, Name: "Portaria", SiteID: &siteID, Status: domain.CentralStatus{Status: domain.StatusOnline}},
+	}
+}
+
+// Sobe o app em uma porta local, já que o stream não termina sozinho
+func setupEventServer(t *testing.T) (string, *memoryOutbox, *stream.Hub) {
+	outbox := &memoryOutbox{}
+	hub := stream.New(outbox, stream.Options{})
+	h := handler.NewEventHandler(hub)
+	h.KeepAlive = 50 * time.Millisecond
+
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	handler.RegisterEventRoutes(app, h)
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go app.Listener(ln)
+	t.Cleanup(func() { app.ShutdownWithTimeout(time.Second) })
+	return "http://" + ln.Addr().String(), outbox, hub
+}
+
+type sseEvent struct {
+	ID    string
+	Event string
+	Data  string
+}
+
+// Lê o próximo evento, ignorando comentários e o retry
+func nextEvent(t *testing.T, r *bufio.Reader) sseEvent {
+	t.Helper()
+	var e sseEvent
+	for {
+		line, err := r.ReadString('\n')
+		require.NoError(t, err)
+		line = strings.TrimRight(line, "\n")
+		switch {
+		case line == "" && e.ID != "":
+			return e
+		case strings.HasPrefix(line, "id: "):
+			e.ID = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "event: "):
+			e.Event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			e.Data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+}
+
+func openStream(t *testing.T, url string, lastEventID string) *bufio.Reader {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	return bufio.NewReader(resp.Body)
+}
+
+func TestStreamEvents_LiveWithFilter(t *testing.T) {
+	base, outbox, hub := setupEventServer(t)
+	outbox.add(siteEvent(domain.EventCentralCreated, 1, 1))
+
+	r := openStream(t, base+"/centrals/events?site_id=2", "")
+	outbox.add(siteEvent(domain.EventCentralCreated, 2, 1))
+	outbox.add(siteEvent(domain.EventCentralUpdated, 3, 2))
+	_, err := hub.Poll()
+	require.NoError(t, err)
+
+	// Só o evento do site 2, com o corpo no formato dos webhooks
+	e := nextEvent(t, r)
+	assert.Equal(t, "3", e.ID)
+	assert.Equal(t, "central.updated", e.Event)
+	var payload map[string]any
+	require.NoError(t, json.Unmarshal([]byte(e.Data), &payload))
+	assert.Equal(t, "3", payload["id"])
+	assert.Equal(t, float64(3), payload["central_id"])
+	assert.Equal(t, "Portaria", payload["data"].(map[string]any)["name"])
+}
+
+func TestStreamEvents_ResumeWithLastEventID(t *testing.T) {
+	base, outbox, hub := setupEventServer(t)
+	for id := uint(1); id <= 3; id++ {
+		outbox.add(siteEvent(domain.EventCentralCreated, id, 1))
+	}
+
+	// Reenvia o que foi perdido e segue com os novos, sem repetir
+	r := openStream(t, base+"/centrals/events", "1")
+	outbox.add(siteEvent(domain.EventCentralDeleted, 1, 1))
+	_, err := hub.Poll()
+	require.NoError(t, err)
+
+	var ids []string
+	for range 3 {
+		ids = append(ids, nextEvent(t, r).ID)
+	}
+	assert.Equal(t, []string{"2", "3", "4"}, ids)
+}
+
+func TestStreamEvents_InvalidQuery(t *testing.T) {
+	app := fiber.New()
+	handler.RegisterEventRoutes(app, handler.NewEventHandler(stream.New(&memoryOutbox{}, stream.Options{})))
+
+	for _, path := range []string{"/centrals/events?status=sleeping", "/centrals/events?site_id=x", "/centrals/events?selector=%3Dprod"} {
+		resp, _ := app.Test(httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, path)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/centrals/events", nil)
+	req.Header.Set("Last-Event-ID", "abc")
+	resp, _ := app.Test(req)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
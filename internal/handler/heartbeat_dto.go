@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"api-golang/internal/domain"
+	"time"
+)
+
+// Corpo do heartbeat. Em POST /heartbeat, "mac" identifica a central.
+type HeartbeatRequest struct {
+	MAC string `json:"mac,omitempty"`
+
+	UptimeSeconds   int64              `json:"uptime_seconds" validate:"gte=0"`
+	FirmwareVersion string             `json:"firmware_version,omitempty"`
+	CPUPercent      *float64           `json:"cpu_percent,omitempty" validate:"omitempty,gte=0,lte=100"`
+	MemoryPercent   *float64           `json:"memory_percent,omitempty" validate:"omitempty,gte=0,lte=100"`
+	Metrics         map[string]float64 `json:"metrics,omitempty"`
+}
+
+func (r HeartbeatRequest) ToDomain(centralID uint) *domain.Heartbeat {
+	return &domain.Heartbeat{
+		CentralID:       centralID,
+		Uptime:          time.Duration(r.UptimeSeconds) * time.Second,
+		FirmwareVersion: r.FirmwareVersion,
+		CPUPercent:      r.CPUPercent,
+		MemoryPercent:   r.MemoryPercent,
+		Metrics:         r.Metrics,
+	}
+}
+
+type HeartbeatResponse struct {
+	ID         uint      `json:"id"`
+	CentralID  uint      `json:"central_id"`
+	ReceivedAt time.Time `json:"received_at"`
+
+	UptimeSeconds   int64              `json:"uptime_seconds"`
+	FirmwareVersion string             `json:"firmware_version,omitempty"`
+	CPUPercent      *float64           `json:"cpu_percent,omitempty"`
+	MemoryPercent   *float64           `json:"memory_percent,omitempty"`
+	Metrics         map[string]float64 `json:"metrics"`
+}
+
+func NewHeartbeatResponse(hb *domain.Heartbeat) HeartbeatResponse {
+	metrics := hb.Metrics
+	if metrics == nil {
+		metrics = map[string]float64{}
+	}
+	return HeartbeatResponse{
+		ID:              hb.ID,
+		CentralID:       hb.CentralID,
+		ReceivedAt:      hb.ReceivedAt,
+		UptimeSeconds:   int64(hb.Uptime / time.Second),
+		FirmwareVersion: hb.FirmwareVersion,
+		CPUPercent:      hb.CPUPercent,
+		MemoryPercent:   hb.MemoryPercent,
+		Metrics:         metrics,
+	}
+}
+
+func NewHeartbeatResponses(heartbeats []domain.Heartbeat) []HeartbeatResponse {
+	responses := make([]HeartbeatResponse, 0, len(heartbeats))
+	for i := range heartbeats {
+		responses = append(responses, NewHeartbeatResponse(&heartbeats[i]))
+	}
+	return responses
+}
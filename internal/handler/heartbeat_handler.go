@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"api-golang/internal/domain"
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type HeartbeatUseCase interface {
+	RecordHeartbeat(hb *domain.Heartbeat) error
+	RecordHeartbeatByMAC(mac string, hb *domain.Heartbeat) error
+	GetHeartbeats(centralID uint, limit int) ([]domain.Heartbeat, error)
+}
+
+type HeartbeatHandler struct {
+	UseCase   HeartbeatUseCase
+	Validator *validator.Validate
+}
+
+func NewHeartbeatHandler(uc HeartbeatUseCase) *HeartbeatHandler {
+	return &HeartbeatHandler{
+		UseCase:   uc,
+		Validator: validator.New(),
+	}
+}
+
+// Record Heartbeat of a Central
+func (h *HeartbeatHandler) RecordHeartbeat(c *fiber.Ctx) error {
+	id, _ := c.ParamsInt("id")
+	var req HeartbeatRequest
+	if err := parseBody(c, h.Validator, &req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	hb := req.ToDomain(uint(id))
+	if err := h.UseCase.RecordHeartbeat(hb); err != nil {
+		return errorResponse(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(NewHeartbeatResponse(hb))
+}
+
+// Record Heartbeat identified by MAC
+func (h *HeartbeatHandler) RecordHeartbeatByMAC(c *fiber.Ctx) error {
+	var req HeartbeatRequest
+	if err := parseBody(c, h.Validator, &req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	if req.MAC == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "mac is required"})
+	}
+
+	hb := req.ToDomain(0)
+	if err := h.UseCase.RecordHeartbeatByMAC(req.MAC, hb); err != nil {
+		return errorResponse(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(NewHeartbeatResponse(hb))
+}
+
+// Get Heartbeats of a Central
+func (h *HeartbeatHandler) GetHeartbeats(c *fiber.Ctx) error {
+	id, _ := c.ParamsInt("id")
+	limit := c.QueryInt("limit", 0)
+	if c.Query("limit") != "" && limit <= 0 {
+		return errorResponse(c, fmt.Errorf("%w: limit must be a positive integer", domain.ErrInvalid))
+	}
+
+	heartbeats, err := h.UseCase.GetHeartbeats(uint(id), limit)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+	return c.JSON(NewHeartbeatResponses(heartbeats))
+}
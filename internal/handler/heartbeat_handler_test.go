@@ -0,0 +1,73 @@
+package handler_test
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/handler"
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock do UseCase de heartbeats
+type MockHeartbeatUseCase struct {
+	mock.Mock
+}
+
+func (m *MockHeartbeatUseCase) RecordHeartbeat(hb *domain.Heartbeat) error {
+	args := m.Called(hb)
+	return args.Error(0)
+}
+
+func (m *MockHeartbeatUseCase) RecordHeartbeatByMAC(mac string, hb *domain.Heartbeat) error {
+	args := m.Called(mac, hb)
+	return args.Error(0)
+}
+
+func (m *MockHeartbeatUseCase) GetHeartbeats(centralID uint, limit int) ([]domain.Heartbeat, error) {
+	args := m.Called(centralID, limit)
+	return args.Get(0).([]domain.Heartbeat), args.Error(1)
+}
+
+func setupHeartbeatApp() (*fiber.App, *MockHeartbeatUseCase) {
+	mockUseCase := new(MockHeartbeatUseCase)
+	app := fiber.New()
+	handler.RegisterHeartbeatRoutes(app, handler.NewHeartbeatHandler(mockUseCase))
+	return app, mockUseCase
+}
+
+func postJSON(app *fiber.App, path, body string) *http.Response {
+	req := httptest.NewRequest(http.MethodPost, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req, -1)
+	return resp
+}
+
+func TestRecordHeartbeat(t *testing.T) {
+	app, mockUseCase := setupHeartbeatApp()
+
+	mockUseCase.On("RecordHeartbeat", mock.MatchedBy(func(hb *domain.Heartbeat) bool {
+		return hb.CentralID == 1 && hb.Uptime == time.Hour && *hb.CPUPercent == 42 && hb.Metrics["calls"] == 3
+	})).Return(nil)
+	mockUseCase.On("RecordHeartbeat", mock.Anything).Return(domain.ErrNotFound)
+
+	body := `{"uptime_seconds":3600,"firmware_version":"1.0","cpu_percent":42,"metrics":{"calls":3}}`
+	assert.Equal(t, http.StatusCreated, postJSON(app, "/central/1/heartbeat", body).StatusCode)
+	assert.Equal(t, http.StatusNotFound, postJSON(app, "/central/99/heartbeat", `{}`).StatusCode)
+	assert.Equal(t, http.StatusBadRequest, postJSON(app, "/central/1/heartbeat", `{"memory_percent":101}`).StatusCode)
+}
+
+func TestRecordHeartbeatByMAC(t *testing.T) {
+	app, mockUseCase := setupHeartbeatApp()
+
+	mockUseCase.On("RecordHeartbeatByMAC", "00:11:22:33:44:55", mock.Anything).Return(nil)
+
+	assert.Equal(t, http.StatusCreated, postJSON(app, "/heartbeat", `{"mac":"00:11:22:33:44:55"}`).StatusCode)
+	assert.Equal(t, http.StatusBadRequest, postJSON(app, "/heartbeat", `{"uptime_seconds":1}`).StatusCode)
+	mockUseCase.AssertExpectations(t)
+}
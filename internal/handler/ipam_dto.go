@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"api-golang/internal/domain"
+	"time"
+)
+
+// Faixa de endereços nos corpos e respostas do IPAM
+type AddressRangeDTO struct {
+	Start string `json:"start" validate:"required"`
+	End   string `json:"end" validate:"required"`
+}
+
+func toDomainRanges(ranges []AddressRangeDTO) []domain.AddressRange {
+	result := make([]domain.AddressRange, 0, len(ranges))
+	for _, r := range ranges {
+		result = append(result, domain.AddressRange{Start: r.Start, End: r.End})
+	}
+	return result
+}
+
+// Corpo aceito na criação de uma sub-rede
+type CreateSubnetRequest struct {
+	CIDR        string            `json:"cidr" validate:"required"`
+	Gateway     string            `json:"gateway,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Reserved    []AddressRangeDTO `json:"reserved,omitempty" validate:"dive"`
+}
+
+func (r CreateSubnetRequest) ToDomain() *domain.Subnet {
+	return &domain.Subnet{CIDR: r.CIDR, Gateway: r.Gateway, Description: r.Description, Reserved: toDomainRanges(r.Reserved)}
+}
+
+// Corpo aceito na atualização de uma sub-rede; o CIDR não muda
+type UpdateSubnetRequest struct {
+	Gateway     string            `json:"gateway,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Reserved    []AddressRangeDTO `json:"reserved,omitempty" validate:"dive"`
+}
+
+func (r UpdateSubnetRequest) ToDomain(id uint) *domain.Subnet {
+	return &domain.Subnet{ID: id, Gateway: r.Gateway, Description: r.Description, Reserved: toDomainRanges(r.Reserved)}
+}
+
+// Representação da sub-rede nas respostas da API
+type SubnetResponse struct {
+	ID          uint              `json:"id"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+	CIDR        string            `json:"cidr"`
+	Gateway     string            `json:"gateway,omitempty"`
+	Description string            `json:"description"`
+	Reserved    []AddressRangeDTO `json:"reserved"`
+}
+
+func NewSubnetResponse(subnet *domain.Subnet) SubnetResponse {
+	reserved := make([]AddressRangeDTO, 0, len(subnet.Reserved))
+	for _, r := range subnet.Reserved {
+		reserved = append(reserved, AddressRangeDTO{Start: r.Start, End: r.End})
+	}
+	return SubnetResponse{
+		ID:          subnet.ID,
+		CreatedAt:   subnet.CreatedAt,
+		UpdatedAt:   subnet.UpdatedAt,
+		CIDR:        subnet.CIDR,
+		Gateway:     subnet.Gateway,
+		Description: subnet.Description,
+		Reserved:    reserved,
+	}
+}
+
+func NewSubnetResponses(subnets []domain.Subnet) []SubnetResponse {
+	responses := make([]SubnetResponse, 0, len(subnets))
+	for i := range subnets {
+		responses = append(responses, NewSubnetResponse(&subnets[i]))
+	}
+	return responses
+}
+
+// Corpo aceito na criação e atualização de um pool
+type PoolRequest struct {
+	Name  string `json:"name" validate:"required"`
+	Start string `json:"start" validate:"required"`
+	End   string `json:"end" validate:"required"`
+}
+
+func (r PoolRequest) ToDomain(id, subnetID uint) *domain.Pool {
+	return &domain.Pool{ID: id, SubnetID: subnetID, Name: r.Name, Start: r.Start, End: r.End}
+}
+
+// Representação do pool nas respostas da API
+type PoolResponse struct {
+	ID        uint      `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	SubnetID  uint      `json:"subnet_id"`
+	Name      string    `json:"name"`
+	Start     string    `json:"start"`
+	End       string    `json:"end"`
+}
+
+func NewPoolResponse(pool *domain.Pool) PoolResponse {
+	return PoolResponse{
+		ID:        pool.ID,
+		CreatedAt: pool.CreatedAt,
+		UpdatedAt: pool.UpdatedAt,
+		SubnetID:  pool.SubnetID,
+		Name:      pool.Name,
+		Start:     pool.Start,
+		End:       pool.End,
+	}
+}
+
+func NewPoolResponses(pools []domain.Pool) []PoolResponse {
+	responses := make([]PoolResponse, 0, len(pools))
+	for i := range pools {
+		responses = append(responses, NewPoolResponse(&pools[i]))
+	}
+	return responses
+}
+
+// Contagem de endereços nos relatórios de utilização
+type UtilizationResponse struct {
+	Total    uint64  `json:"total"`
+	Reserved uint64  `json:"reserved"`
+	Used     uint64  `json:"used"`
+	Free     uint64  `json:"free"`
+	Percent  float64 `json:"percent"`
+}
+
+func newUtilizationResponse(u domain.Utilization) UtilizationResponse {
+	return UtilizationResponse{Total: u.Total, Reserved: u.Reserved, Used: u.Used, Free: u.Free, Percent: u.Percent}
+}
+
+type PoolUtilizationResponse struct {
+	PoolID uint   `json:"pool_id"`
+	Name   string `json:"name"`
+	UtilizationResponse
+}
+
+type SubnetUtilizationResponse struct {
+	SubnetID uint   `json:"subnet_id"`
+	CIDR     string `json:"cidr"`
+	UtilizationResponse
+	Pools []PoolUtilizationResponse `json:"pools"`
+}
+
+func NewSubnetUtilizationResponse(u *domain.SubnetUtilization) SubnetUtilizationResponse {
+	pools := make([]PoolUtilizationResponse, 0, len(u.Pools))
+	for _, p := range u.Pools {
+		pools = append(pools, PoolUtilizationResponse{PoolID: p.PoolID, Name: p.Name, UtilizationResponse: newUtilizationResponse(p.Utilization)})
+	}
+	return SubnetUtilizationResponse{
+		SubnetID:            u.SubnetID,
+		CIDR:                u.CIDR,
+		UtilizationResponse: newUtilizationResponse(u.Utilization),
+		Pools:               pools,
+	}
+}
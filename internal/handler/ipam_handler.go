@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"api-golang/internal/domain"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type IPAMUseCase interface {
+	CreateSubnet(subnet *domain.Subnet) error
+	GetSubnets() ([]domain.Subnet, error)
+	GetSubnetByID(id uint) (*domain.Subnet, error)
+	UpdateSubnet(subnet *domain.Subnet) error
+	DeleteSubnet(id uint) error
+
+	CreatePool(pool *domain.Pool) error
+	GetPools(subnetID uint) ([]domain.Pool, error)
+	GetPoolByID(id uint) (*domain.Pool, error)
+	UpdatePool(pool *domain.Pool) error
+	DeletePool(id uint) error
+
+	GetSubnetUtilization(id uint) (*domain.SubnetUtilization, error)
+	GetUtilization() ([]domain.SubnetUtilization, error)
+}
+
+type IPAMHandler struct {
+	UseCase   IPAMUseCase
+	Validator *validator.Validate
+}
+
+func NewIPAMHandler(uc IPAMUseCase) *IPAMHandler {
+	return &IPAMHandler{
+		UseCase:   uc,
+		Validator: validator.New(),
+	}
+}
+
+// Create Subnet
+func (h *IPAMHandler) CreateSubnet(c *fiber.Ctx) error {
+	var req CreateSubnetRequest
+	if err := parseBody(c, h.Validator, &req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	subnet := req.ToDomain()
+	if err := h.UseCase.CreateSubnet(subnet); err != nil {
+		return errorResponse(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(NewSubnetResponse(subnet))
+}
+
+// Get All Subnets
+func (h *IPAMHandler) GetSubnets(c *fiber.Ctx) error {
+	subnets, err := h.UseCase.GetSubnets()
+	if err != nil {
+		return errorResponse(c, err)
+	}
+	return c.JSON(NewSubnetResponses(subnets))
+}
+
+// Get Subnet by ID
+func (h *IPAMHandler) GetSubnetByID(c *fiber.Ctx) error {
+	id, _ := c.ParamsInt("id")
+	subnet, err := h.UseCase.GetSubnetByID(uint(id))
+	if err != nil {
+		return errorResponse(c, err)
+	}
+	return c.JSON(NewSubnetResponse(subnet))
+}
+
+// Update Subnet
+func (h *IPAMHandler) UpdateSubnet(c *fiber.Ctx) error {
+	id, _ := c.ParamsInt("id")
+	var req UpdateSubnetRequest
+	if err := parseBody(c, h.Validator, &req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	subnet := req.ToDomain(uint(id))
+	if err := h.UseCase.UpdateSubnet(subnet); err != nil {
+		return errorResponse(c, err)
+	}
+	return c.JSON(NewSubnetResponse(subnet))
+}
+
+// Delete Subnet
+func (h *IPAMHandler) DeleteSubnet(c *fiber.Ctx) error {
+	id, _ := c.ParamsInt("id")
+	if err := h.UseCase.DeleteSubnet(uint(id)); err != nil {
+		return errorResponse(c, err)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// Create Pool
+func (h *IPAMHandler) CreatePool(c *fiber.Ctx) error {
+	subnetID, _ := c.ParamsInt("id")
+	var req PoolRequest
+	if err := parseBody(c, h.Validator, &req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	pool := req.ToDomain(0, uint(subnetID))
+	if err := h.UseCase.CreatePool(pool); err != nil {
+		return errorResponse(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(NewPoolResponse(pool))
+}
+
+// Get Pools of a Subnet
+func (h *IPAMHandler) GetPools(c *fiber.Ctx) error {
+	subnetID, _ := c.ParamsInt("id")
+	pools, err := h.UseCase.GetPools(uint(subnetID))
+	if err != nil {
+		return errorResponse(c, err)
+	}
+	return c.JSON(NewPoolResponses(pools))
+}
+
+// Get Pool by ID
+func (h *IPAMHandler) GetPoolByID(c *fiber.Ctx) error {
+	id, _ := c.ParamsInt("id")
+	pool, err := h.UseCase.GetPoolByID(uint(id))
+	if err != nil {
+		return errorResponse(c, err)
+	}
+	return c.JSON(NewPoolResponse(pool))
+}
+
+// Update Pool
+func (h *IPAMHandler) UpdatePool(c *fiber.Ctx) error {
+	id, _ := c.ParamsInt("id")
+	var req PoolRequest
+	if err := parseBody(c, h.Validator, &req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	pool := req.ToDomain(uint(id), 0)
+	if err := h.UseCase.UpdatePool(pool); err != nil {
+		return errorResponse(c, err)
+	}
+	return c.JSON(NewPoolResponse(pool))
+}
+
+// Delete Pool. Os IPs já alocados continuam com as centrais.
+func (h *IPAMHandler) DeletePool(c *fiber.Ctx) error {
+	id, _ := c.ParamsInt("id")
+	if err := h.UseCase.DeletePool(uint(id)); err != nil {
+		return errorResponse(c, err)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// Get Utilization of a Subnet
+func (h *IPAMHandler) GetSubnetUtilization(c *fiber.Ctx) error {
+	id, _ := c.ParamsInt("id")
+	utilization, err := h.UseCase.GetSubnetUtilization(uint(id))
+	if err != nil {
+		return errorResponse(c, err)
+	}
+	return c.JSON(NewSubnetUtilizationResponse(utilization))
+}
+
+// Get Utilization of all Subnets
+func (h *IPAMHandler) GetUtilization(c *fiber.Ctx) error {
+	report, err := h.UseCase.GetUtilization()
+	if err != nil {
+		return errorResponse(c, err)
+	}
+	responses := make([]SubnetUtilizationResponse, 0, len(report))
+	for i := range report {
+		responses = append(responses, NewSubnetUtilizationResponse(&report[i]))
+	}
+	return c.JSON(responses)
+}
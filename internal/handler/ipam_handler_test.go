@@ -0,0 +1,152 @@
+package handler_test
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/handler"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock do UseCase do IPAM
+type MockIPAMUseCase struct {
+	mock.Mock
+}
+
+func (m *MockIPAMUseCase) CreateSubnet(subnet *domain.Subnet) error {
+	args := m.Called(subnet)
+	return args.Error(0)
+}
+
+func (m *MockIPAMUseCase) GetSubnets() ([]domain.Subnet, error) {
+	args := m.Called()
+	return args.Get(0).([]domain.Subnet), args.Error(1)
+}
+
+func (m *MockIPAMUseCase) GetSubnetByID(id uint) (*domain.Subnet, error) {
+	args := m.Called(id)
+	return args.Get(0).(*domain.Subnet), args.Error(1)
+}
+
+func (m *MockIPAMUseCase) UpdateSubnet(subnet *domain.Subnet) error {
+	args := m.Called(subnet)
+	return args.Error(0)
+}
+
+func (m *MockIPAMUseCase) DeleteSubnet(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockIPAMUseCase) CreatePool(pool *domain.Pool) error {
+	args := m.Called(pool)
+	return args.Error(0)
+}
+
+func (m *MockIPAMUseCase) GetPools(subnetID uint) ([]domain.Pool, error) {
+	args := m.Called(subnetID)
+	return args.Get(0).([]domain.Pool), args.Error(1)
+}
+
+func (m *MockIPAMUseCase) GetPoolByID(id uint) (*domain.Pool, error) {
+	args := m.Called(id)
+	return args.Get(0).(*domain.Pool), args.Error(1)
+}
+
+func (m *MockIPAMUseCase) UpdatePool(pool *domain.Pool) error {
+	args := m.Called(pool)
+	return args.Error(0)
+}
+
+func (m *MockIPAMUseCase) DeletePool(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockIPAMUseCase) GetSubnetUtilization(id uint) (*domain.SubnetUtilization, error) {
+	args := m.Called(id)
+	return args.Get(0).(*domain.SubnetUtilization), args.Error(1)
+}
+
+func (m *MockIPAMUseCase) GetUtilization() ([]domain.SubnetUtilization, error) {
+	args := m.Called()
+	return args.Get(0).([]domain.SubnetUtilization), args.Error(1)
+}
+
+func setupIPAMApp() (*fiber.App, *MockIPAMUseCase) {
+	mockUseCase := new(MockIPAMUseCase)
+	app := fiber.New()
+	handler.RegisterIPAMRoutes(app, handler.NewIPAMHandler(mockUseCase))
+	return app, mockUseCase
+}
+
+func TestCreateSubnet(t *testing.T) {
+	app, mockUseCase := setupIPAMApp()
+
+	payload := `{"cidr":"10.0.0.0/24","gateway":"10.0.0.1","reserved":[{"start":"10.0.0.2","end":"10.0.0.9"}]}`
+	mockUseCase.On("CreateSubnet", mock.MatchedBy(func(s *domain.Subnet) bool {
+		return s.CIDR == "10.0.0.0/24" && len(s.Reserved) == 1
+	})).Return(nil)
+	mockUseCase.On("CreateSubnet", mock.Anything).Return(domain.ErrConflict)
+
+	req := httptest.NewRequest(http.MethodPost, "/subnets", bytes.NewBufferString(payload))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req, -1)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	// Reserva sem fim
+	req = httptest.NewRequest(http.MethodPost, "/subnets", bytes.NewBufferString(`{"cidr":"10.0.0.0/24","reserved":[{"start":"10.0.0.2"}]}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ = app.Test(req, -1)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	req = httptest.NewRequest(http.MethodPost, "/subnets", bytes.NewBufferString(`{"cidr":"10.0.0.0/16"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ = app.Test(req, -1)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+}
+
+func TestCreatePool(t *testing.T) {
+	app, mockUseCase := setupIPAMApp()
+
+	payload, _ := json.Marshal(handler.PoolRequest{Name: "dhcp", Start: "10.0.0.100", End: "10.0.0.200"})
+	mockUseCase.On("CreatePool", mock.MatchedBy(func(p *domain.Pool) bool {
+		return p.SubnetID == 1 && p.Name == "dhcp"
+	})).Return(nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/subnets/1/pools", bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req, -1)
+
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestGetSubnetUtilization(t *testing.T) {
+	app, mockUseCase := setupIPAMApp()
+
+	mockUseCase.On("GetSubnetUtilization", uint(1)).Return(&domain.SubnetUtilization{
+		SubnetID:    1,
+		CIDR:        "10.0.0.0/24",
+		Utilization: domain.Utilization{Total: 254, Reserved: 1, Used: 10, Free: 243},
+		Pools:       []domain.PoolUtilization{{PoolID: 1, Name: "dhcp", Utilization: domain.Utilization{Total: 101, Used: 10, Free: 91}}},
+	}, nil)
+	mockUseCase.On("GetSubnetUtilization", uint(99)).Return((*domain.SubnetUtilization)(nil), domain.ErrNotFound)
+
+	resp, _ := app.Test(httptest.NewRequest(http.MethodGet, "/subnets/1/utilization", nil), -1)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var body handler.SubnetUtilizationResponse
+	json.NewDecoder(resp.Body).Decode(&body)
+	assert.Equal(t, uint64(243), body.Free)
+	assert.Len(t, body.Pools, 1)
+
+	resp, _ = app.Test(httptest.NewRequest(http.MethodGet, "/subnets/99/utilization", nil), -1)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
@@ -0,0 +1,28 @@
+package handler
+
+// Labels a adicionar em uma central
+type AddLabelsRequest struct {
+	Labels map[string]string `json:"labels" validate:"required,min=1"`
+}
+
+// Chaves a remover de uma central
+type RemoveLabelsRequest struct {
+	Keys []string `json:"keys" validate:"required,min=1"`
+}
+
+// Labels a adicionar em várias centrais de uma vez
+type BulkAddLabelsRequest struct {
+	IDs    []uint            `json:"ids" validate:"required,min=1"`
+	Labels map[string]string `json:"labels" validate:"required,min=1"`
+}
+
+// Chaves a remover de várias centrais de uma vez
+type BulkRemoveLabelsRequest struct {
+	IDs  []uint   `json:"ids" validate:"required,min=1"`
+	Keys []string `json:"keys" validate:"required,min=1"`
+}
+
+// Labels de uma central nas respostas da API
+type LabelsResponse struct {
+	Labels map[string]string `json:"labels"`
+}
@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type LabelUseCase interface {
+	GetLabels(centralID uint) (map[string]string, error)
+	AddLabels(centralIDs []uint, labels map[string]string) error
+	RemoveLabels(centralIDs []uint, keys []string) error
+}
+
+type LabelHandler struct {
+	UseCase   LabelUseCase
+	Validator *validator.Validate
+}
+
+func NewLabelHandler(uc LabelUseCase) *LabelHandler {
+	return &LabelHandler{
+		UseCase:   uc,
+		Validator: validator.New(),
+	}
+}
+
+// Get Labels of a Central
+func (h *LabelHandler) GetLabels(c *fiber.Ctx) error {
+	centralID, _ := c.ParamsInt("id")
+	labels, err := h.UseCase.GetLabels(uint(centralID))
+	if err != nil {
+		return errorResponse(c, err)
+	}
+	return c.JSON(LabelsResponse{Labels: labels})
+}
+
+// Add Labels to a Central
+func (h *LabelHandler) AddLabels(c *fiber.Ctx) error {
+	centralID, _ := c.ParamsInt("id")
+	var req AddLabelsRequest
+	if err := parseBody(c, h.Validator, &req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	if err := h.UseCase.AddLabels([]uint{uint(centralID)}, req.Labels); err != nil {
+		return errorResponse(c, err)
+	}
+	return h.GetLabels(c)
+}
+
+// Remove Labels from a Central
+func (h *LabelHandler) RemoveLabels(c *fiber.Ctx) error {
+	centralID, _ := c.ParamsInt("id")
+	var req RemoveLabelsRequest
+	if err := parseBody(c, h.Validator, &req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	if err := h.UseCase.RemoveLabels([]uint{uint(centralID)}, req.Keys); err != nil {
+		return errorResponse(c, err)
+	}
+	return h.GetLabels(c)
+}
+
+// Add Labels to many Centrals
+func (h *LabelHandler) BulkAddLabels(c *fiber.Ctx) error {
+	var req BulkAddLabelsRequest
+	if err := parseBody(c, h.Validator, &req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	if err := h.UseCase.AddLabels(req.IDs, req.Labels); err != nil {
+		return errorResponse(c, err)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// Remove Labels from many Centrals
+func (h *LabelHandler) BulkRemoveLabels(c *fiber.Ctx) error {
+	var req BulkRemoveLabelsRequest
+	if err := parseBody(c, h.Validator, &req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	if err := h.UseCase.RemoveLabels(req.IDs, req.Keys); err != nil {
+		return errorResponse(c, err)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
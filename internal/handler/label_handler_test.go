@@ -0,0 +1,88 @@
+package handler_test
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/handler"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock do UseCase de labels
+type MockLabelUseCase struct {
+	mock.Mock
+}
+
+func (m *MockLabelUseCase) GetLabels(centralID uint) (map[string]string, error) {
+	args := m.Called(centralID)
+	return args.Get(0).(map[string]string), args.Error(1)
+}
+
+func (m *MockLabelUseCase) AddLabels(centralIDs []uint, labels map[string]string) error {
+	args := m.Called(centralIDs, labels)
+	return args.Error(0)
+}
+
+func (m *MockLabelUseCase) RemoveLabels(centralIDs []uint, keys []string) error {
+	args := m.Called(centralIDs, keys)
+	return args.Error(0)
+}
+
+func setupLabelApp() (*fiber.App, *MockLabelUseCase) {
+	mockUseCase := new(MockLabelUseCase)
+	app := fiber.New()
+	handler.RegisterLabelRoutes(app, handler.NewLabelHandler(mockUseCase))
+	return app, mockUseCase
+}
+
+func TestAddLabels(t *testing.T) {
+	app, mockUseCase := setupLabelApp()
+
+	labels := map[string]string{"env": "prod"}
+	mockUseCase.On("AddLabels", []uint{1}, labels).Return(nil)
+	mockUseCase.On("GetLabels", uint(1)).Return(labels, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/central/1/labels", bytes.NewBufferString(`{"labels":{"env":"prod"}}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req, -1)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	var body handler.LabelsResponse
+	json.NewDecoder(resp.Body).Decode(&body)
+	assert.Equal(t, labels, body.Labels)
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestBulkRemoveLabels(t *testing.T) {
+	app, mockUseCase := setupLabelApp()
+
+	mockUseCase.On("RemoveLabels", []uint{1, 2}, []string{"env"}).Return(nil)
+	mockUseCase.On("RemoveLabels", []uint{3}, []string{"env"}).Return(domain.ErrNotFound)
+
+	req := httptest.NewRequest(http.MethodDelete, "/centrals/labels", bytes.NewBufferString(`{"ids":[1,2],"keys":["env"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req, -1)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	req = httptest.NewRequest(http.MethodDelete, "/centrals/labels", bytes.NewBufferString(`{"ids":[3],"keys":["env"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ = app.Test(req, -1)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestBulkAddLabels_InvalidData(t *testing.T) {
+	app, _ := setupLabelApp()
+
+	req := httptest.NewRequest(http.MethodPost, "/centrals/labels", bytes.NewBufferString(`{"ids":[],"labels":{"env":"prod"}}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req, -1)
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/utils"
+	"time"
+)
+
+// Corpo aceito na criação de uma interface de rede
+type CreateInterfaceRequest struct {
+	Name    string   `json:"name" validate:"required"`
+	MAC     string   `json:"mac" validate:"required"`
+	IPs     []string `json:"ips"`
+	VLAN    int      `json:"vlan"`
+	Primary bool     `json:"primary"`
+}
+
+func (r CreateInterfaceRequest) ToDomain(centralID uint) *domain.NetworkInterface {
+	return &domain.NetworkInterface{
+		CentralID: centralID,
+		Name:      r.Name,
+		MAC:       r.MAC,
+		IPs:       r.IPs,
+		VLAN:      r.VLAN,
+		Primary:   r.Primary,
+	}
+}
+
+// Corpo aceito na atualização de uma interface de rede
+type UpdateInterfaceRequest struct {
+	Name    string   `json:"name" validate:"required"`
+	MAC     string   `json:"mac" validate:"required"`
+	IPs     []string `json:"ips"`
+	VLAN    int      `json:"vlan"`
+	Primary bool     `json:"primary"`
+}
+
+func (r UpdateInterfaceRequest) ToDomain(centralID, id uint) *domain.NetworkInterface {
+	return &domain.NetworkInterface{
+		ID:        id,
+		CentralID: centralID,
+		Name:      r.Name,
+		MAC:       r.MAC,
+		IPs:       r.IPs,
+		VLAN:      r.VLAN,
+		Primary:   r.Primary,
+	}
+}
+
+// Representação da interface nas respostas da API
+type InterfaceResponse struct {
+	ID        uint      `json:"id"`
+	CentralID uint      `json:"central_id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Name      string    `json:"name"`
+	MAC       string    `json:"mac"`
+	Vendor    string    `json:"vendor,omitempty"`
+	// Indicações de MAC provavelmente cadastrado errado (ver MACWarning*)
+	MACWarnings []string `json:"mac_warnings,omitempty"`
+	IPs         []string `json:"ips"`
+	VLAN        int      `json:"vlan"`
+	Primary     bool     `json:"primary"`
+}
+
+// Monta a resposta com o MAC no formato pedido (ver utils.MACFormat*)
+func NewInterfaceResponse(iface *domain.NetworkInterface, macFormat string) InterfaceResponse {
+	mac, err := utils.FormatMAC(iface.MAC, macFormat)
+	if err != nil {
+		mac = iface.MAC
+	}
+	ips := iface.IPs
+	if ips == nil {
+		ips = []string{}
+	}
+	return InterfaceResponse{
+		ID:        iface.ID,
+		CentralID: iface.CentralID,
+		CreatedAt: iface.CreatedAt,
+		UpdatedAt: iface.UpdatedAt,
+		Name:      iface.Name,
+		MAC:       mac,
+		Vendor:    iface.Vendor,
+
+		MACWarnings: macWarnings(iface.MAC),
+		IPs:         ips,
+		VLAN:        iface.VLAN,
+		Primary:     iface.Primary,
+	}
+}
+
+func NewInterfaceResponses(ifaces []domain.NetworkInterface, macFormat string) []InterfaceResponse {
+	responses := make([]InterfaceResponse, 0, len(ifaces))
+	for i := range ifaces {
+		responses = append(responses, NewInterfaceResponse(&ifaces[i], macFormat))
+	}
+	return responses
+}
@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/utils"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type NetworkInterfaceUseCase interface {
+	CreateInterface(iface *domain.NetworkInterface) error
+	GetInterfaces(centralID uint) ([]domain.NetworkInterface, error)
+	GetInterfaceByID(centralID, id uint) (*domain.NetworkInterface, error)
+	UpdateInterface(iface *domain.NetworkInterface) error
+	DeleteInterface(centralID, id uint) error
+}
+
+type NetworkInterfaceHandler struct {
+	UseCase   NetworkInterfaceUseCase
+	Validator *validator.Validate
+}
+
+func NewNetworkInterfaceHandler(uc NetworkInterfaceUseCase) *NetworkInterfaceHandler {
+	return &NetworkInterfaceHandler{
+		UseCase:   uc,
+		Validator: validator.New(),
+	}
+}
+
+// Create Interface
+func (h *NetworkInterfaceHandler) CreateInterface(c *fiber.Ctx) error {
+	centralID, _ := c.ParamsInt("id")
+	var req CreateInterfaceRequest
+
+	// Parse JSON do corpo da requisição
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid payload"})
+	}
+
+	// Validação usando Validator
+	if err := h.Validator.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": utils.FormatValidationErrors(err).Error(),
+		})
+	}
+
+	macFormat, err := macFormatQuery(c)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	iface := req.ToDomain(uint(centralID))
+	if err := h.UseCase.CreateInterface(iface); err != nil {
+		return errorResponse(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(NewInterfaceResponse(iface, macFormat))
+}
+
+// Get Interfaces of a Central
+func (h *NetworkInterfaceHandler) GetInterfaces(c *fiber.Ctx) error {
+	centralID, _ := c.ParamsInt("id")
+	macFormat, err := macFormatQuery(c)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	ifaces, err := h.UseCase.GetInterfaces(uint(centralID))
+	if err != nil {
+		return errorResponse(c, err)
+	}
+	return c.JSON(NewInterfaceResponses(ifaces, macFormat))
+}
+
+// Get Interface by ID
+func (h *NetworkInterfaceHandler) GetInterfaceByID(c *fiber.Ctx) error {
+	centralID, _ := c.ParamsInt("id")
+	id, _ := c.ParamsInt("interfaceId")
+	macFormat, err := macFormatQuery(c)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	iface, err := h.UseCase.GetInterfaceByID(uint(centralID), uint(id))
+	if err != nil {
+		return errorResponse(c, err)
+	}
+	return c.JSON(NewInterfaceResponse(iface, macFormat))
+}
+
+// Update Interface
+func (h *NetworkInterfaceHandler) UpdateInterface(c *fiber.Ctx) error {
+	centralID, _ := c.ParamsInt("id")
+	id, _ := c.ParamsInt("interfaceId")
+	var req UpdateInterfaceRequest
+
+	// Parse JSON do corpo da requisição
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid payload"})
+	}
+
+	// Validação usando Validator
+	if err := h.Validator.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": utils.FormatValidationErrors(err).Error(),
+		})
+	}
+
+	macFormat, err := macFormatQuery(c)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	iface := req.ToDomain(uint(centralID), uint(id))
+	if err := h.UseCase.UpdateInterface(iface); err != nil {
+		return errorResponse(c, err)
+	}
+	return c.JSON(NewInterfaceResponse(iface, macFormat))
+}
+
+// Delete Interface
+func (h *NetworkInterfaceHandler) DeleteInterface(c *fiber.Ctx) error {
+	centralID, _ := c.ParamsInt("id")
+	id, _ := c.ParamsInt("interfaceId")
+	if err := h.UseCase.DeleteInterface(uint(centralID), uint(id)); err != nil {
+		return errorResponse(c, err)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,113 @@
+package handler_test
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/handler"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock do UseCase de interfaces
+type MockNetworkInterfaceUseCase struct {
+	mock.Mock
+}
+
+func (m *MockNetworkInterfaceUseCase) CreateInterface(iface *domain.NetworkInterface) error {
+	args := m.Called(iface)
+	return args.Error(0)
+}
+
+func (m *MockNetworkInterfaceUseCase) GetInterfaces(centralID uint) ([]domain.NetworkInterface, error) {
+	args := m.Called(centralID)
+	return args.Get(0).([]domain.NetworkInterface), args.Error(1)
+}
+
+func (m *MockNetworkInterfaceUseCase) GetInterfaceByID(centralID, id uint) (*domain.NetworkInterface, error) {
+	args := m.Called(centralID, id)
+	return args.Get(0).(*domain.NetworkInterface), args.Error(1)
+}
+
+func (m *MockNetworkInterfaceUseCase) UpdateInterface(iface *domain.NetworkInterface) error {
+	args := m.Called(iface)
+	return args.Error(0)
+}
+
+func (m *MockNetworkInterfaceUseCase) DeleteInterface(centralID, id uint) error {
+	args := m.Called(centralID, id)
+	return args.Error(0)
+}
+
+func setupInterfaceApp() (*fiber.App, *MockNetworkInterfaceUseCase) {
+	mockUseCase := new(MockNetworkInterfaceUseCase)
+	app := fiber.New()
+	handler.RegisterInterfaceRoutes(app, handler.NewNetworkInterfaceHandler(mockUseCase))
+	return app, mockUseCase
+}
+
+func TestCreateInterface(t *testing.T) {
+	app, mockUseCase := setupInterfaceApp()
+
+	data := handler.CreateInterfaceRequest{Name: "eth1", MAC: "00:11:22:33:44:66", IPs: []string{"10.0.0.1"}, VLAN: 10}
+	payload, _ := json.Marshal(data)
+
+	mockUseCase.On("CreateInterface", mock.MatchedBy(func(i *domain.NetworkInterface) bool {
+		return i.CentralID == 1 && i.Name == "eth1" && i.VLAN == 10
+	})).Return(nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/central/1/interfaces", bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req, -1)
+
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestCreateInterface_InvalidData(t *testing.T) {
+	app, _ := setupInterfaceApp()
+
+	// Nome e MAC são obrigatórios
+	req := httptest.NewRequest(http.MethodPost, "/central/1/interfaces", bytes.NewBufferString(`{"vlan":10}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req, -1)
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestGetInterfaces(t *testing.T) {
+	app, mockUseCase := setupInterfaceApp()
+
+	mockUseCase.On("GetInterfaces", uint(1)).Return([]domain.NetworkInterface{
+		{ID: 1, CentralID: 1, Name: "eth0", MAC: "00:11:22:33:44:55", IPs: []string{"192.168.0.1"}, Primary: true},
+	}, nil)
+	mockUseCase.On("GetInterfaces", uint(99)).Return([]domain.NetworkInterface(nil), domain.ErrNotFound)
+
+	resp, _ := app.Test(httptest.NewRequest(http.MethodGet, "/central/1/interfaces", nil), -1)
+	var body []handler.InterfaceResponse
+	json.NewDecoder(resp.Body).Decode(&body)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Len(t, body, 1)
+	assert.True(t, body[0].Primary)
+
+	resp, _ = app.Test(httptest.NewRequest(http.MethodGet, "/central/99/interfaces", nil), -1)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestDeleteInterface_Primary(t *testing.T) {
+	app, mockUseCase := setupInterfaceApp()
+
+	mockUseCase.On("DeleteInterface", uint(1), uint(1)).Return(domain.ErrConflict)
+	mockUseCase.On("DeleteInterface", uint(1), uint(2)).Return(nil)
+
+	resp, _ := app.Test(httptest.NewRequest(http.MethodDelete, "/central/1/interfaces/1", nil), -1)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	resp, _ = app.Test(httptest.NewRequest(http.MethodDelete, "/central/1/interfaces/2", nil), -1)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+}
@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"api-golang/internal/domain"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type OUIUseCase interface {
+	LookupOUI(prefix string) (*domain.OUIInfo, error)
+}
+
+type OUIHandler struct {
+	UseCase OUIUseCase
+}
+
+func NewOUIHandler(uc OUIUseCase) *OUIHandler {
+	return &OUIHandler{UseCase: uc}
+}
+
+// Fabricante e indicações de um prefixo de MAC
+type OUIResponse struct {
+	Prefix              string `json:"prefix"`
+	Vendor              string `json:"vendor,omitempty"`
+	LocallyAdministered bool   `json:"locally_administered"`
+	Multicast           bool   `json:"multicast"`
+}
+
+// Lookup OUI
+func (h *OUIHandler) LookupOUI(c *fiber.Ctx) error {
+	info, err := h.UseCase.LookupOUI(c.Params("prefix"))
+	if err != nil {
+		return errorResponse(c, err)
+	}
+	return c.JSON(OUIResponse{
+		Prefix:              info.Prefix,
+		Vendor:              info.Vendor,
+		LocallyAdministered: info.LocallyAdministered,
+		Multicast:           info.Multicast,
+	})
+}
@@ -0,0 +1,52 @@
+package handler_test
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/handler"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock do UseCase de OUI
+type MockOUIUseCase struct {
+	mock.Mock
+}
+
+func (m *MockOUIUseCase) LookupOUI(prefix string) (*domain.OUIInfo, error) {
+	args := m.Called(prefix)
+	return args.Get(0).(*domain.OUIInfo), args.Error(1)
+}
+
+func TestLookupOUI(t *testing.T) {
+	mockUseCase := new(MockOUIUseCase)
+	app := fiber.New()
+	handler.RegisterOUIRoutes(app, handler.NewOUIHandler(mockUseCase))
+
+	mockUseCase.On("LookupOUI", "00:50:56").Return(&domain.OUIInfo{Prefix: "00:50:56", Vendor: "VMware, Inc."}, nil)
+	mockUseCase.On("LookupOUI", "fcffff").Return((*domain.OUIInfo)(nil), domain.ErrNotFound)
+
+	resp, _ := app.Test(httptest.NewRequest(http.MethodGet, "/oui/00:50:56", nil), -1)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	var body handler.OUIResponse
+	json.NewDecoder(resp.Body).Decode(&body)
+	assert.Equal(t, "VMware, Inc.", body.Vendor)
+
+	resp, _ = app.Test(httptest.NewRequest(http.MethodGet, "/oui/fcffff", nil), -1)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestCentralResponse_MACWarnings(t *testing.T) {
+	// Bit U/L e bit I/G ligados
+	response := handler.NewCentralResponse(&domain.Central{MAC: "03:00:00:00:00:01"}, "")
+	assert.Equal(t, []string{handler.MACWarningLocallyAdministered, handler.MACWarningMulticast}, response.MACWarnings)
+
+	response = handler.NewCentralResponse(&domain.Central{MAC: "00:50:56:00:00:01", Vendor: "VMware, Inc."}, "")
+	assert.Empty(t, response.MACWarnings)
+	assert.Equal(t, "VMware, Inc.", response.Vendor)
+}
@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"github.com/gofiber/contrib/websocket"
+	"github.com/gofiber/fiber/v2"
+)
+
+// Registra as rotas de centrais no router informado
+func RegisterCentralRoutes(router fiber.Router, h *CentralHandler) {
+	router.Post("/central", h.CreateCentral)
+	router.Get("/centrals", h.GetAllCentrals)
+	router.Get("/central/:id", h.GetCentralByID)
+	router.Put("/central/:id", h.UpdateCentral)
+	router.Delete("/central/:id", h.DeleteCentral)
+}
+
+// Registra as rotas aninhadas de interfaces de rede
+func RegisterInterfaceRoutes(router fiber.Router, h *NetworkInterfaceHandler) {
+	router.Post("/central/:id/interfaces", h.CreateInterface)
+	router.Get("/central/:id/interfaces", h.GetInterfaces)
+	router.Get("/central/:id/interfaces/:interfaceId", h.GetInterfaceByID)
+	router.Put("/central/:id/interfaces/:interfaceId", h.UpdateInterface)
+	router.Delete("/central/:id/interfaces/:interfaceId", h.DeleteInterface)
+}
+
+// Registra as rotas de sites e localizações
+func RegisterSiteRoutes(router fiber.Router, h *SiteHandler) {
+	router.Post("/sites", h.CreateSite)
+	router.Get("/sites", h.GetSites)
+	router.Get("/sites/:id", h.GetSiteByID)
+	router.Put("/sites/:id", h.UpdateSite)
+	router.Delete("/sites/:id", h.DeleteSite)
+	router.Get("/sites/:id/centrals", h.GetSiteCentrals)
+	router.Post("/sites/:id/locations", h.CreateLocation)
+	router.Get("/sites/:id/locations", h.GetLocations)
+
+	router.Get("/locations/:id", h.GetLocationByID)
+	router.Put("/locations/:id", h.UpdateLocation)
+	router.Delete("/locations/:id", h.DeleteLocation)
+	router.Get("/locations/:id/centrals", h.GetLocationCentrals)
+}
+
+// Registra as rotas de labels, por central e em lote
+func RegisterLabelRoutes(router fiber.Router, h *LabelHandler) {
+	router.Get("/central/:id/labels", h.GetLabels)
+	router.Post("/central/:id/labels", h.AddLabels)
+	router.Delete("/central/:id/labels", h.RemoveLabels)
+	router.Post("/centrals/labels", h.BulkAddLabels)
+	router.Delete("/centrals/labels", h.BulkRemoveLabels)
+}
+
+// Registra a busca textual de centrais
+func RegisterSearchRoutes(router fiber.Router, h *SearchHandler) {
+	router.Get("/centrals/search", h.SearchCentrals)
+}
+
+// Registra as rotas do IPAM: sub-redes, pools e relatórios de utilização
+func RegisterIPAMRoutes(router fiber.Router, h *IPAMHandler) {
+	router.Post("/subnets", h.CreateSubnet)
+	router.Get("/subnets", h.GetSubnets)
+	router.Get("/subnets/:id", h.GetSubnetByID)
+	router.Put("/subnets/:id", h.UpdateSubnet)
+	router.Delete("/subnets/:id", h.DeleteSubnet)
+	router.Get("/subnets/:id/utilization", h.GetSubnetUtilization)
+	router.Post("/subnets/:id/pools", h.CreatePool)
+	router.Get("/subnets/:id/pools", h.GetPools)
+
+	router.Get("/pools/:id", h.GetPoolByID)
+	router.Put("/pools/:id", h.UpdatePool)
+	router.Delete("/pools/:id", h.DeletePool)
+
+	router.Get("/ipam/utilization", h.GetUtilization)
+}
+
+// Registra a consulta de fabricantes por OUI
+func RegisterOUIRoutes(router fiber.Router, h *OUIHandler) {
+	router.Get("/oui/:prefix", h.LookupOUI)
+}
+
+// Registra o recebimento de heartbeats e o histórico por central
+func RegisterHeartbeatRoutes(router fiber.Router, h *HeartbeatHandler) {
+	router.Post("/heartbeat", h.RecordHeartbeatByMAC)
+	router.Post("/central/:id/heartbeat", h.RecordHeartbeat)
+	router.Get("/central/:id/heartbeats", h.GetHeartbeats)
+}
+
+func RegisterTelemetryRoutes(router fiber.Router, h *TelemetryHandler) {
+	router.Get("/central/:id/metrics", h.GetMetrics)
+}
+
+func RegisterAlertRoutes(router fiber.Router, h *AlertHandler) {
+	router.Post("/alerts/rules", h.CreateRule)
+	router.Get("/alerts/rules", h.GetRules)
+	router.Get("/alerts/rules/:id", h.GetRuleByID)
+	router.Put("/alerts/rules/:id", h.UpdateRule)
+	router.Delete("/alerts/rules/:id", h.DeleteRule)
+
+	router.Post("/alerts/silences", h.CreateSilence)
+	router.Get("/alerts/silences", h.GetSilences)
+	router.Delete("/alerts/silences/:id", h.ExpireSilence)
+
+	router.Get("/alerts", h.GetAlerts)
+}
+
+func RegisterWebhookRoutes(router fiber.Router, h *WebhookHandler) {
+	router.Post("/webhooks", h.CreateWebhook)
+	router.Get("/webhooks", h.GetWebhooks)
+	router.Get("/webhooks/:id", h.GetWebhookByID)
+	router.Put("/webhooks/:id", h.UpdateWebhook)
+	router.Delete("/webhooks/:id", h.DeleteWebhook)
+
+	router.Get("/webhooks/:id/deliveries", h.GetDeliveries)
+	router.Get("/webhooks/:id/deliveries/:deliveryId", h.GetDelivery)
+	router.Post("/webhooks/:id/deliveries/:deliveryId/redeliver", h.Redeliver)
+}
+
+// Registra o stream SSE de eventos de centrais
+func RegisterEventRoutes(router fiber.Router, h *EventHandler) {
+	router.Get("/centrals/events", h.StreamEvents)
+}
+
+// Registra o WebSocket de eventos e comandos
+func RegisterWebSocketRoutes(router fiber.Router, h *WebSocketHandler) {
+	router.Get("/ws", h.Upgrade, websocket.New(h.Serve))
+}
+
+// Registra as credenciais SNMP e os atributos descobertos da central
+func RegisterSNMPRoutes(router fiber.Router, h *SNMPHandler) {
+	router.Put("/central/:id/snmp", h.SetCredentials)
+	router.Get("/central/:id/snmp", h.GetSNMP)
+	router.Delete("/central/:id/snmp", h.DeleteCredentials)
+}
+
+// Registra a fila de candidatos da descoberta de rede
+func RegisterDiscoveryRoutes(router fiber.Router, h *DiscoveryHandler) {
+	router.Get("/discovery/candidates", h.GetCandidates)
+	router.Get("/discovery/candidates/:id", h.GetCandidate)
+	router.Post("/discovery/candidates/:id/accept", h.AcceptCandidate)
+	router.Post("/discovery/candidates/:id/reject", h.RejectCandidate)
+}
@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"api-golang/internal/domain"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type SearchUseCase interface {
+	SearchCentrals(q string, limit int) ([]domain.CentralSearchResult, error)
+}
+
+type SearchHandler struct {
+	UseCase SearchUseCase
+}
+
+func NewSearchHandler(uc SearchUseCase) *SearchHandler {
+	return &SearchHandler{UseCase: uc}
+}
+
+// Central encontrada na busca, com a relevância
+type CentralSearchResponse struct {
+	CentralResponse
+	Score float64 `json:"score"`
+}
+
+// Search Centrals
+func (h *SearchHandler) SearchCentrals(c *fiber.Ctx) error {
+	macFormat, err := macFormatQuery(c)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+	limit := c.QueryInt("limit", 0)
+	if c.Query("limit") != "" && limit == 0 {
+		return errorResponse(c, fmt.Errorf("%w: limit must be a positive integer", domain.ErrInvalid))
+	}
+
+	results, err := h.UseCase.SearchCentrals(c.Query("q"), limit)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	responses := make([]CentralSearchResponse, 0, len(results))
+	for i := range results {
+		responses = append(responses, CentralSearchResponse{
+			CentralResponse: NewCentralResponse(&results[i].Central, macFormat),
+			Score:           results[i].Score,
+		})
+	}
+	return c.JSON(responses)
+}
@@ -0,0 +1,55 @@
+package handler_test
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/handler"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock do UseCase de busca
+type MockSearchUseCase struct {
+	mock.Mock
+}
+
+func (m *MockSearchUseCase) SearchCentrals(q string, limit int) ([]domain.CentralSearchResult, error) {
+	args := m.Called(q, limit)
+	return args.Get(0).([]domain.CentralSearchResult), args.Error(1)
+}
+
+func TestSearchCentrals(t *testing.T) {
+	mockUseCase := new(MockSearchUseCase)
+	app := fiber.New()
+	handler.RegisterSearchRoutes(app, handler.NewSearchHandler(mockUseCase))
+
+	mockUseCase.On("SearchCentrals", "portaria bloco B", 5).Return([]domain.CentralSearchResult{
+		{Central: domain.Central{ID: 1, Name: "Portaria Bloco B", MAC: "00:11:22:33:44:55", IPv4: "10.0.0.1"}, Score: 2.5},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/centrals/search?q=portaria%20bloco%20B&limit=5", nil)
+	resp, _ := app.Test(req, -1)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var results []handler.CentralSearchResponse
+	json.NewDecoder(resp.Body).Decode(&results)
+	assert.Len(t, results, 1)
+	assert.Equal(t, "Portaria Bloco B", results[0].Name)
+	assert.Equal(t, 2.5, results[0].Score)
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestSearchCentrals_InvalidLimit(t *testing.T) {
+	mockUseCase := new(MockSearchUseCase)
+	app := fiber.New()
+	handler.RegisterSearchRoutes(app, handler.NewSearchHandler(mockUseCase))
+
+	req := httptest.NewRequest(http.MethodGet, "/centrals/search?q=portaria&limit=abc", nil)
+	resp, _ := app.Test(req, -1)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
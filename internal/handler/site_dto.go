@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"api-golang/internal/domain"
+	"time"
+)
+
+// Corpo aceito na criação e atualização de um site
+type SiteRequest struct {
+	Name        string `json:"name" validate:"required"`
+	Description string `json:"description"`
+}
+
+func (r SiteRequest) ToDomain(id uint) *domain.Site {
+	return &domain.Site{ID: id, Name: r.Name, Description: r.Description}
+}
+
+// Representação do site nas respostas da API
+type SiteResponse struct {
+	ID          uint      `json:"id"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+}
+
+func NewSiteResponse(site *domain.Site) SiteResponse {
+	return SiteResponse{
+		ID:          site.ID,
+		CreatedAt:   site.CreatedAt,
+		UpdatedAt:   site.UpdatedAt,
+		Name:        site.Name,
+		Description: site.Description,
+	}
+}
+
+func NewSiteResponses(sites []domain.Site) []SiteResponse {
+	responses := make([]SiteResponse, 0, len(sites))
+	for i := range sites {
+		responses = append(responses, NewSiteResponse(&sites[i]))
+	}
+	return responses
+}
+
+// Corpo aceito na criação de uma localização
+type CreateLocationRequest struct {
+	Kind     string `json:"kind" validate:"required,oneof=building floor room"`
+	Name     string `json:"name" validate:"required"`
+	ParentID *uint  `json:"parent_id,omitempty"`
+}
+
+func (r CreateLocationRequest) ToDomain(siteID uint) *domain.Location {
+	return &domain.Location{SiteID: siteID, Kind: r.Kind, Name: r.Name, ParentID: r.ParentID}
+}
+
+// Corpo aceito na atualização de uma localização; tipo e site não mudam
+type UpdateLocationRequest struct {
+	Name     string `json:"name" validate:"required"`
+	ParentID *uint  `json:"parent_id,omitempty"`
+}
+
+func (r UpdateLocationRequest) ToDomain(id uint) *domain.Location {
+	return &domain.Location{ID: id, Name: r.Name, ParentID: r.ParentID}
+}
+
+// Representação da localização nas respostas da API
+type LocationResponse struct {
+	ID        uint      `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	SiteID    uint      `json:"site_id"`
+	ParentID  *uint     `json:"parent_id,omitempty"`
+	Kind      string    `json:"kind"`
+	Name      string    `json:"name"`
+}
+
+func NewLocationResponse(location *domain.Location) LocationResponse {
+	return LocationResponse{
+		ID:        location.ID,
+		CreatedAt: location.CreatedAt,
+		UpdatedAt: location.UpdatedAt,
+		SiteID:    location.SiteID,
+		ParentID:  location.ParentID,
+		Kind:      location.Kind,
+		Name:      location.Name,
+	}
+}
+
+func NewLocationResponses(locations []domain.Location) []LocationResponse {
+	responses := make([]LocationResponse, 0, len(locations))
+	for i := range locations {
+		responses = append(responses, NewLocationResponse(&locations[i]))
+	}
+	return responses
+}
@@ -0,0 +1,189 @@
+package handler
+
+import (
+	"api-golang/internal/domain"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type SiteUseCase interface {
+	CreateSite(site *domain.Site) error
+	GetSites() ([]domain.Site, error)
+	GetSiteByID(id uint) (*domain.Site, error)
+	UpdateSite(site *domain.Site) error
+	DeleteSite(id uint, opts domain.SiteDeleteOptions) error
+	GetSiteCentrals(siteID uint, unplaced bool) ([]domain.Central, error)
+
+	CreateLocation(location *domain.Location) error
+	GetLocations(siteID uint) ([]domain.Location, error)
+	GetLocationByID(id uint) (*domain.Location, error)
+	UpdateLocation(location *domain.Location) error
+	DeleteLocation(id uint) error
+	GetLocationCentrals(locationID uint, recursive bool) ([]domain.Central, error)
+}
+
+type SiteHandler struct {
+	UseCase   SiteUseCase
+	Validator *validator.Validate
+}
+
+func NewSiteHandler(uc SiteUseCase) *SiteHandler {
+	return &SiteHandler{
+		UseCase:   uc,
+		Validator: validator.New(),
+	}
+}
+
+// Create Site
+func (h *SiteHandler) CreateSite(c *fiber.Ctx) error {
+	var req SiteRequest
+	if err := parseBody(c, h.Validator, &req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	site := req.ToDomain(0)
+	if err := h.UseCase.CreateSite(site); err != nil {
+		return errorResponse(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(NewSiteResponse(site))
+}
+
+// Get All Sites
+func (h *SiteHandler) GetSites(c *fiber.Ctx) error {
+	sites, err := h.UseCase.GetSites()
+	if err != nil {
+		return errorResponse(c, err)
+	}
+	return c.JSON(NewSiteResponses(sites))
+}
+
+// Get Site by ID
+func (h *SiteHandler) GetSiteByID(c *fiber.Ctx) error {
+	id, _ := c.ParamsInt("id")
+	site, err := h.UseCase.GetSiteByID(uint(id))
+	if err != nil {
+		return errorResponse(c, err)
+	}
+	return c.JSON(NewSiteResponse(site))
+}
+
+// Update Site
+func (h *SiteHandler) UpdateSite(c *fiber.Ctx) error {
+	id, _ := c.ParamsInt("id")
+	var req SiteRequest
+	if err := parseBody(c, h.Validator, &req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	site := req.ToDomain(uint(id))
+	if err := h.UseCase.UpdateSite(site); err != nil {
+		return errorResponse(c, err)
+	}
+	return c.JSON(NewSiteResponse(site))
+}
+
+// Delete Site. Com centrais no site, exige ?cascade=true ou ?reassign_to=<id>
+func (h *SiteHandler) DeleteSite(c *fiber.Ctx) error {
+	id, _ := c.ParamsInt("id")
+	reassignTo, err := optionalUintQuery(c, "reassign_to")
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	opts := domain.SiteDeleteOptions{Cascade: c.QueryBool("cascade", false), ReassignTo: reassignTo}
+	if err := h.UseCase.DeleteSite(uint(id), opts); err != nil {
+		return errorResponse(c, err)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// Get Centrals of a Site. Inclui todas as localizações do site; com
+// ?unplaced=true, só as centrais sem localização.
+func (h *SiteHandler) GetSiteCentrals(c *fiber.Ctx) error {
+	id, _ := c.ParamsInt("id")
+	macFormat, err := macFormatQuery(c)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	centrals, err := h.UseCase.GetSiteCentrals(uint(id), c.QueryBool("unplaced", false))
+	if err != nil {
+		return errorResponse(c, err)
+	}
+	return c.JSON(NewCentralResponses(centrals, macFormat))
+}
+
+// Create Location
+func (h *SiteHandler) CreateLocation(c *fiber.Ctx) error {
+	siteID, _ := c.ParamsInt("id")
+	var req CreateLocationRequest
+	if err := parseBody(c, h.Validator, &req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	location := req.ToDomain(uint(siteID))
+	if err := h.UseCase.CreateLocation(location); err != nil {
+		return errorResponse(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(NewLocationResponse(location))
+}
+
+// Get Locations of a Site
+func (h *SiteHandler) GetLocations(c *fiber.Ctx) error {
+	siteID, _ := c.ParamsInt("id")
+	locations, err := h.UseCase.GetLocations(uint(siteID))
+	if err != nil {
+		return errorResponse(c, err)
+	}
+	return c.JSON(NewLocationResponses(locations))
+}
+
+// Get Location by ID
+func (h *SiteHandler) GetLocationByID(c *fiber.Ctx) error {
+	id, _ := c.ParamsInt("id")
+	location, err := h.UseCase.GetLocationByID(uint(id))
+	if err != nil {
+		return errorResponse(c, err)
+	}
+	return c.JSON(NewLocationResponse(location))
+}
+
+// Update Location
+func (h *SiteHandler) UpdateLocation(c *fiber.Ctx) error {
+	id, _ := c.ParamsInt("id")
+	var req UpdateLocationRequest
+	if err := parseBody(c, h.Validator, &req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	location := req.ToDomain(uint(id))
+	if err := h.UseCase.UpdateLocation(location); err != nil {
+		return errorResponse(c, err)
+	}
+	return c.JSON(NewLocationResponse(location))
+}
+
+// Delete Location
+func (h *SiteHandler) DeleteLocation(c *fiber.Ctx) error {
+	id, _ := c.ParamsInt("id")
+	if err := h.UseCase.DeleteLocation(uint(id)); err != nil {
+		return errorResponse(c, err)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// Get Centrals of a Location. Com ?recursive=true inclui as sublocalizações.
+func (h *SiteHandler) GetLocationCentrals(c *fiber.Ctx) error {
+	id, _ := c.ParamsInt("id")
+	macFormat, err := macFormatQuery(c)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	centrals, err := h.UseCase.GetLocationCentrals(uint(id), c.QueryBool("recursive", false))
+	if err != nil {
+		return errorResponse(c, err)
+	}
+	return c.JSON(NewCentralResponses(centrals, macFormat))
+}
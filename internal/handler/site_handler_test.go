@@ -0,0 +1,154 @@
+package handler_test
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/handler"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock do UseCase de sites
+type MockSiteUseCase struct {
+	mock.Mock
+}
+
+func (m *MockSiteUseCase) CreateSite(site *domain.Site) error {
+	args := m.Called(site)
+	return args.Error(0)
+}
+
+func (m *MockSiteUseCase) GetSites() ([]domain.Site, error) {
+	args := m.Called()
+	return args.Get(0).([]domain.Site), args.Error(1)
+}
+
+func (m *MockSiteUseCase) GetSiteByID(id uint) (*domain.Site, error) {
+	args := m.Called(id)
+	return args.Get(0).(*domain.Site), args.Error(1)
+}
+
+func (m *MockSiteUseCase) UpdateSite(site *domain.Site) error {
+	args := m.Called(site)
+	return args.Error(0)
+}
+
+func (m *MockSiteUseCase) DeleteSite(id uint, opts domain.SiteDeleteOptions) error {
+	args := m.Called(id, opts)
+	return args.Error(0)
+}
+
+func (m *MockSiteUseCase) GetSiteCentrals(siteID uint, unplaced bool) ([]domain.Central, error) {
+	args := m.Called(siteID, unplaced)
+	return args.Get(0).([]domain.Central), args.Error(1)
+}
+
+func (m *MockSiteUseCase) CreateLocation(location *domain.Location) error {
+	args := m.Called(location)
+	return args.Error(0)
+}
+
+func (m *MockSiteUseCase) GetLocations(siteID uint) ([]domain.Location, error) {
+	args := m.Called(siteID)
+	return args.Get(0).([]domain.Location), args.Error(1)
+}
+
+func (m *MockSiteUseCase) GetLocationByID(id uint) (*domain.Location, error) {
+	args := m.Called(id)
+	return args.Get(0).(*domain.Location), args.Error(1)
+}
+
+func (m *MockSiteUseCase) UpdateLocation(location *domain.Location) error {
+	args := m.Called(location)
+	return args.Error(0)
+}
+
+func (m *MockSiteUseCase) DeleteLocation(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockSiteUseCase) GetLocationCentrals(locationID uint, recursive bool) ([]domain.Central, error) {
+	args := m.Called(locationID, recursive)
+	return args.Get(0).([]domain.Central), args.Error(1)
+}
+
+func setupSiteApp() (*fiber.App, *MockSiteUseCase) {
+	mockUseCase := new(MockSiteUseCase)
+	app := fiber.New()
+	handler.RegisterSiteRoutes(app, handler.NewSiteHandler(mockUseCase))
+	return app, mockUseCase
+}
+
+func TestCreateSite(t *testing.T) {
+	app, mockUseCase := setupSiteApp()
+
+	payload, _ := json.Marshal(handler.SiteRequest{Name: "Matriz"})
+	mockUseCase.On("CreateSite", mock.MatchedBy(func(s *domain.Site) bool {
+		return s.Name == "Matriz"
+	})).Return(nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/sites", bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req, -1)
+
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestCreateLocation_InvalidKind(t *testing.T) {
+	app, _ := setupSiteApp()
+
+	req := httptest.NewRequest(http.MethodPost, "/sites/1/locations", bytes.NewBufferString(`{"kind":"wing","name":"Ala"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req, -1)
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestDeleteSite_Options(t *testing.T) {
+	app, mockUseCase := setupSiteApp()
+
+	target := uint(2)
+	mockUseCase.On("DeleteSite", uint(1), domain.SiteDeleteOptions{ReassignTo: &target}).Return(nil)
+	mockUseCase.On("DeleteSite", uint(3), domain.SiteDeleteOptions{}).Return(domain.ErrConflict)
+	mockUseCase.On("DeleteSite", uint(4), domain.SiteDeleteOptions{}).
+		Return(fmt.Errorf("%w: site still has 2 central(s); use cascade or reassign_to", domain.ErrPrecondition))
+
+	resp, _ := app.Test(httptest.NewRequest(http.MethodDelete, "/sites/1?reassign_to=2", nil), -1)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	resp, _ = app.Test(httptest.NewRequest(http.MethodDelete, "/sites/3", nil), -1)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	// Pré-condição também é 409 na API REST
+	resp, _ = app.Test(httptest.NewRequest(http.MethodDelete, "/sites/4", nil), -1)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	var body map[string]string
+	json.NewDecoder(resp.Body).Decode(&body)
+	assert.Equal(t, "conflict: site still has 2 central(s); use cascade or reassign_to", body["error"])
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestGetSiteCentrals_AllByDefault(t *testing.T) {
+	app, mockUseCase := setupSiteApp()
+
+	mockUseCase.On("GetSiteCentrals", uint(1), false).Return([]domain.Central{
+		{ID: 1, Name: "Central 1", MAC: "00:11:22:33:44:55", IPv4: "192.168.0.1"},
+	}, nil)
+
+	resp, _ := app.Test(httptest.NewRequest(http.MethodGet, "/sites/1/centrals", nil), -1)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var centrals []handler.CentralResponse
+	json.NewDecoder(resp.Body).Decode(&centrals)
+	assert.Len(t, centrals, 1)
+	mockUseCase.AssertExpectations(t)
+}
@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"api-golang/internal/domain"
+	"time"
+)
+
+// Credenciais SNMP da central. Na v2c só a comunidade é usada; na v3, o
+// usuário e os protocolos e senhas do nível de segurança desejado.
+type SNMPCredentialsRequest struct {
+	Version   string `json:"version" validate:"required,oneof=v2c v3"`
+	Port      int    `json:"port,omitempty" validate:"omitempty,min=1,max=65535"`
+	Community string `json:"community,omitempty"`
+
+	Username     string `json:"username,omitempty"`
+	AuthProtocol string `json:"auth_protocol,omitempty"`
+	AuthPassword string `json:"auth_password,omitempty"`
+	PrivProtocol string `json:"priv_protocol,omitempty"`
+	PrivPassword string `json:"priv_password,omitempty"`
+}
+
+func (r SNMPCredentialsRequest) ToDomain() domain.SNMPCredentials {
+	return domain.SNMPCredentials{
+		Version:      domain.SNMPVersion(r.Version),
+		Port:         r.Port,
+		Community:    r.Community,
+		Username:     r.Username,
+		AuthProtocol: r.AuthProtocol,
+		AuthPassword: r.AuthPassword,
+		PrivProtocol: r.PrivProtocol,
+		PrivPassword: r.PrivPassword,
+	}
+}
+
+type SNMPInterfaceResponse struct {
+	Index       int    `json:"index"`
+	Name        string `json:"name"`
+	Descr       string `json:"descr,omitempty"`
+	Type        int    `json:"type"`
+	MTU         int    `json:"mtu"`
+	Speed       uint64 `json:"speed"`
+	MAC         string `json:"mac,omitempty"`
+	AdminStatus string `json:"admin_status,omitempty"`
+	OperStatus  string `json:"oper_status,omitempty"`
+}
+
+// Atributos descobertos na última coleta bem-sucedida
+type SNMPInventoryResponse struct {
+	SysName          string                  `json:"sys_name"`
+	SysDescr         string                  `json:"sys_descr"`
+	SysUpTimeSeconds int64                   `json:"sys_uptime_seconds"`
+	Interfaces       []SNMPInterfaceResponse `json:"interfaces"`
+}
+
+// Configuração SNMP da central, sem a comunidade nem as senhas
+type CentralSNMPResponse struct {
+	CentralID     uint   `json:"central_id"`
+	Version       string `json:"version"`
+	Port          int    `json:"port"`
+	Username      string `json:"username,omitempty"`
+	AuthProtocol  string `json:"auth_protocol,omitempty"`
+	PrivProtocol  string `json:"priv_protocol,omitempty"`
+	SecurityLevel string `json:"security_level,omitempty"`
+
+	Discovered  *SNMPInventoryResponse `json:"discovered"`
+	CollectedAt *time.Time             `json:"collected_at"`
+	PolledAt    *time.Time             `json:"polled_at"`
+	LastError   string                 `json:"last_error,omitempty"`
+}
+
+func NewCentralSNMPResponse(s *domain.CentralSNMP) CentralSNMPResponse {
+	creds := s.Credentials
+	resp := CentralSNMPResponse{
+		CentralID:     s.CentralID,
+		Version:       string(creds.Version),
+		Port:          creds.Port,
+		Username:      creds.Username,
+		AuthProtocol:  creds.AuthProtocol,
+		PrivProtocol:  creds.PrivProtocol,
+		SecurityLevel: creds.SecurityLevel(),
+		CollectedAt:   s.CollectedAt,
+		PolledAt:      s.PolledAt,
+		LastError:     s.LastError,
+	}
+	if inv := s.Inventory; inv != nil {
+		interfaces := make([]SNMPInterfaceResponse, 0, len(inv.Interfaces))
+		for _, iface := range inv.Interfaces {
+			interfaces = append(interfaces, SNMPInterfaceResponse(iface))
+		}
+		resp.Discovered = &SNMPInventoryResponse{
+			SysName:          inv.SysName,
+			SysDescr:         inv.SysDescr,
+			SysUpTimeSeconds: int64(inv.SysUpTime / time.Second),
+			Interfaces:       interfaces,
+		}
+	}
+	return resp
+}
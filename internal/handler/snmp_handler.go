@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/utils"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type SNMPUseCase interface {
+	SetCredentials(centralID uint, creds domain.SNMPCredentials) (*domain.CentralSNMP, error)
+	GetSNMP(centralID uint) (*domain.CentralSNMP, error)
+	DeleteCredentials(centralID uint) error
+}
+
+type SNMPHandler struct {
+	UseCase   SNMPUseCase
+	Validator *validator.Validate
+}
+
+func NewSNMPHandler(uc SNMPUseCase) *SNMPHandler {
+	return &SNMPHandler{
+		UseCase:   uc,
+		Validator: validator.New(),
+	}
+}
+
+// Set SNMP Credentials of a Central
+func (h *SNMPHandler) SetCredentials(c *fiber.Ctx) error {
+	id, _ := c.ParamsInt("id")
+	var req SNMPCredentialsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid payload"})
+	}
+	if err := h.Validator.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": utils.FormatValidationErrors(err).Error()})
+	}
+
+	snmp, err := h.UseCase.SetCredentials(uint(id), req.ToDomain())
+	if err != nil {
+		return errorResponse(c, err)
+	}
+	return c.JSON(NewCentralSNMPResponse(snmp))
+}
+
+// Get SNMP configuration and discovered attributes of a Central
+func (h *SNMPHandler) GetSNMP(c *fiber.Ctx) error {
+	id, _ := c.ParamsInt("id")
+	snmp, err := h.UseCase.GetSNMP(uint(id))
+	if err != nil {
+		return errorResponse(c, err)
+	}
+	return c.JSON(NewCentralSNMPResponse(snmp))
+}
+
+// Delete SNMP Credentials of a Central
+func (h *SNMPHandler) DeleteCredentials(c *fiber.Ctx) error {
+	id, _ := c.ParamsInt("id")
+	if err := h.UseCase.DeleteCredentials(uint(id)); err != nil {
+		return errorResponse(c, err)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,105 @@
+package handler_test
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/handler"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock do UseCase de SNMP
+type MockSNMPUseCase struct {
+	mock.Mock
+}
+
+func (m *MockSNMPUseCase) SetCredentials(centralID uint, creds domain.SNMPCredentials) (*domain.CentralSNMP, error) {
+	args := m.Called(centralID, creds)
+	return &domain.CentralSNMP{CentralID: centralID, Credentials: creds}, args.Error(0)
+}
+
+func (m *MockSNMPUseCase) GetSNMP(centralID uint) (*domain.CentralSNMP, error) {
+	args := m.Called(centralID)
+	return args.Get(0).(*domain.CentralSNMP), args.Error(1)
+}
+
+func (m *MockSNMPUseCase) DeleteCredentials(centralID uint) error {
+	return m.Called(centralID).Error(0)
+}
+
+func setupSNMPApp() (*fiber.App, *MockSNMPUseCase) {
+	mockUseCase := new(MockSNMPUseCase)
+	app := fiber.New()
+	handler.RegisterSNMPRoutes(app, handler.NewSNMPHandler(mockUseCase))
+	return app, mockUseCase
+}
+
+func TestSetSNMPCredentials(t *testing.T) {
+	app, mockUseCase := setupSNMPApp()
+	creds := domain.SNMPCredentials{Version: domain.SNMPv3, Port: 1161, Username: "monitor",
+		AuthProtocol: "sha256", AuthPassword: "auth-pass-123", PrivProtocol: "aes", PrivPassword: "priv-pass-456"}
+	mockUseCase.On("SetCredentials", uint(1), creds).Return(nil)
+
+	put := func(body string) *http.Response {
+		req := httptest.NewRequest(http.MethodPut, "/central/1/snmp", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req, -1)
+		return resp
+	}
+	resp := put(`{"version":"v3","port":1161,"username":"monitor","auth_protocol":"sha256","auth_password":"auth-pass-123",` +
+		`"priv_protocol":"aes","priv_password":"priv-pass-456"}`)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// As senhas não voltam na resposta
+	var body map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&body)
+	assert.Equal(t, "authPriv", body["security_level"])
+	assert.NotContains(t, body, "auth_password")
+	assert.NotContains(t, body, "priv_password")
+
+	assert.Equal(t, http.StatusBadRequest, put(`{"version":"v1"}`).StatusCode)
+	assert.Equal(t, http.StatusBadRequest, put(`{"version":"v2c","community":"public","port":70000}`).StatusCode)
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestGetSNMP(t *testing.T) {
+	app, mockUseCase := setupSNMPApp()
+	collected := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	mockUseCase.On("GetSNMP", uint(1)).Return(&domain.CentralSNMP{
+		CentralID:   1,
+		Credentials: domain.SNMPCredentials{Version: domain.SNMPv2c, Port: 161},
+		Inventory: &domain.SNMPInventory{SysName: "central-sp-01", SysUpTime: time.Hour,
+			Interfaces: []domain.SNMPInterface{{Index: 1, Name: "eth0", Speed: 1_000_000_000, OperStatus: "up"}}},
+		CollectedAt: &collected, PolledAt: &collected,
+	}, nil)
+	mockUseCase.On("GetSNMP", uint(2)).Return((*domain.CentralSNMP)(nil), domain.ErrNotFound)
+
+	resp, _ := app.Test(httptest.NewRequest(http.MethodGet, "/central/1/snmp", nil), -1)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	var body handler.CentralSNMPResponse
+	json.NewDecoder(resp.Body).Decode(&body)
+	assert.Equal(t, "central-sp-01", body.Discovered.SysName)
+	assert.EqualValues(t, 3600, body.Discovered.SysUpTimeSeconds)
+	assert.Equal(t, "eth0", body.Discovered.Interfaces[0].Name)
+
+	resp, _ = app.Test(httptest.NewRequest(http.MethodGet, "/central/2/snmp", nil), -1)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestDeleteSNMPCredentials(t *testing.T) {
+	app, mockUseCase := setupSNMPApp()
+	mockUseCase.On("DeleteCredentials", uint(1)).Return(nil)
+	mockUseCase.On("DeleteCredentials", uint(2)).Return(domain.ErrNotFound)
+
+	resp, _ := app.Test(httptest.NewRequest(http.MethodDelete, "/central/1/snmp", nil), -1)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	resp, _ = app.Test(httptest.NewRequest(http.MethodDelete, "/central/2/snmp", nil), -1)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
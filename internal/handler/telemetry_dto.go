@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"api-golang/internal/domain"
+	"time"
+)
+
+type MetricPointResponse struct {
+	Timestamp time.Time `json:"timestamp"`
+	Count     int       `json:"count"`
+	Min       float64   `json:"min"`
+	Max       float64   `json:"max"`
+	Avg       float64   `json:"avg"`
+	P95       float64   `json:"p95"`
+}
+
+type MetricSeriesResponse struct {
+	CentralID   uint      `json:"central_id"`
+	Name        string    `json:"name"`
+	From        time.Time `json:"from"`
+	To          time.Time `json:"to"`
+	StepSeconds int64     `json:"step_seconds"`
+	// Origem dos agregados: "raw", "5m" ou "1h"
+	Resolution string                `json:"resolution"`
+	Points     []MetricPointResponse `json:"points"`
+}
+
+func NewMetricSeriesResponse(series *domain.MetricSeries) MetricSeriesResponse {
+	response := MetricSeriesResponse{
+		CentralID:   series.CentralID,
+		Name:        series.Name,
+		From:        series.From,
+		To:          series.To,
+		StepSeconds: int64(series.Step / time.Second),
+		Resolution:  resolutionName(series.Resolution),
+		Points:      make([]MetricPointResponse, 0, len(series.Buckets)),
+	}
+	for _, bucket := range series.Buckets {
+		response.Points = append(response.Points, MetricPointResponse{
+			Timestamp: bucket.Start,
+			Count:     bucket.Count,
+			Min:       bucket.Min,
+			Max:       bucket.Max,
+			Avg:       bucket.Avg,
+			P95:       bucket.P95,
+		})
+	}
+	return response
+}
+
+func resolutionName(resolution time.Duration) string {
+	switch resolution {
+	case domain.ResolutionRaw:
+		return "raw"
+	case domain.ResolutionFiveMinute:
+		return "5m"
+	case domain.ResolutionHour:
+		return "1h"
+	}
+	return resolution.String()
+}
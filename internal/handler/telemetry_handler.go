@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"api-golang/internal/domain"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type TelemetryUseCase interface {
+	GetMetrics(query domain.MetricQuery) (*domain.MetricSeries, error)
+}
+
+type TelemetryHandler struct {
+	UseCase TelemetryUseCase
+}
+
+func NewTelemetryHandler(uc TelemetryUseCase) *TelemetryHandler {
+	return &TelemetryHandler{UseCase: uc}
+}
+
+// Get aggregated Metric series of a Central
+func (h *TelemetryHandler) GetMetrics(c *fiber.Ctx) error {
+	id, _ := c.ParamsInt("id")
+	query := domain.MetricQuery{CentralID: uint(id), Name: c.Query("name")}
+
+	var err error
+	if query.From, err = parseTimeQuery(c, "from"); err != nil {
+		return errorResponse(c, err)
+	}
+	if query.To, err = parseTimeQuery(c, "to"); err != nil {
+		return errorResponse(c, err)
+	}
+	if query.Step, err = parseStep(c.Query("step")); err != nil {
+		return errorResponse(c, err)
+	}
+
+	series, err := h.UseCase.GetMetrics(query)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+	return c.JSON(NewMetricSeriesResponse(series))
+}
+
+// Instante em RFC 3339; vazio resulta no valor zero
+func parseTimeQuery(c *fiber.Ctx, key string) (time.Time, error) {
+	value := c.Query(key)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %s must be an RFC 3339 timestamp", domain.ErrInvalid, key)
+	}
+	return t.UTC(), nil
+}
+
+// Aceita segundos ("300") ou uma duração ("5m")
+func parseStep(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second, nil
+	}
+	step, err := time.ParseDuration(value)
+	if err != nil || step < time.Second {
+		return 0, fmt.Errorf("%w: step must be a duration of at least 1s", domain.ErrInvalid)
+	}
+	return step, nil
+}
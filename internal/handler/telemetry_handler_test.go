@@ -0,0 +1,74 @@
+package handler_test
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/handler"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock do UseCase de telemetria
+type MockTelemetryUseCase struct {
+	mock.Mock
+}
+
+func (m *MockTelemetryUseCase) GetMetrics(query domain.MetricQuery) (*domain.MetricSeries, error) {
+	args := m.Called(query)
+	series, _ := args.Get(0).(*domain.MetricSeries)
+	return series, args.Error(1)
+}
+
+func setupTelemetryApp() (*fiber.App, *MockTelemetryUseCase) {
+	mockUseCase := new(MockTelemetryUseCase)
+	app := fiber.New()
+	handler.RegisterTelemetryRoutes(app, handler.NewTelemetryHandler(mockUseCase))
+	return app, mockUseCase
+}
+
+func TestGetMetrics(t *testing.T) {
+	app, mockUseCase := setupTelemetryApp()
+
+	from := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	query := domain.MetricQuery{CentralID: 1, Name: "cpu_percent", From: from, To: from.Add(time.Hour), Step: 5 * time.Minute}
+	mockUseCase.On("GetMetrics", query).Return(&domain.MetricSeries{
+		MetricQuery: query,
+		Resolution:  domain.ResolutionFiveMinute,
+		Buckets:     []domain.MetricBucket{{Start: from, Count: 2, Min: 1, Max: 3, Avg: 2, P95: 2.9}},
+	}, nil)
+
+	resp, _ := app.Test(httptest.NewRequest(http.MethodGet,
+		"/central/1/metrics?name=cpu_percent&from=2024-01-01T12:00:00Z&to=2024-01-01T13:00:00Z&step=300", nil), -1)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var series handler.MetricSeriesResponse
+	json.NewDecoder(resp.Body).Decode(&series)
+	assert.Equal(t, "5m", series.Resolution)
+	assert.Equal(t, int64(300), series.StepSeconds)
+	assert.Len(t, series.Points, 1)
+	assert.Equal(t, 2.9, series.Points[0].P95)
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestGetMetrics_InvalidQuery(t *testing.T) {
+	app, mockUseCase := setupTelemetryApp()
+
+	mockUseCase.On("GetMetrics", mock.Anything).Return(nil, domain.ErrNotFound)
+
+	for _, url := range []string{
+		"/central/1/metrics?name=cpu_percent&from=yesterday",
+		"/central/1/metrics?name=cpu_percent&step=1ms",
+	} {
+		resp, _ := app.Test(httptest.NewRequest(http.MethodGet, url, nil), -1)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, url)
+	}
+
+	resp, _ := app.Test(httptest.NewRequest(http.MethodGet, "/central/99/metrics?name=cpu_percent&step=5m", nil), -1)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
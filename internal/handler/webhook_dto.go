@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"api-golang/internal/domain"
+	"encoding/json"
+	"time"
+)
+
+// Corpo aceito na criação e atualização de uma assinatura. Sem "events",
+// recebe todos; sem "secret", a criação gera um e a atualização mantém o atual.
+type WebhookRequest struct {
+	URL    string   `json:"url" validate:"required"`
+	Events []string `json:"events,omitempty"`
+	Secret string   `json:"secret,omitempty"`
+	Active *bool    `json:"active,omitempty"`
+}
+
+func (r WebhookRequest) ToDomain(id uint) *domain.Webhook {
+	events := make([]domain.EventType, 0, len(r.Events))
+	for _, event := range r.Events {
+		events = append(events, domain.EventType(event))
+	}
+	return &domain.Webhook{
+		ID:     id,
+		URL:    r.URL,
+		Events: events,
+		Secret: r.Secret,
+		Active: r.Active == nil || *r.Active,
+	}
+}
+
+// O segredo só é devolvido na criação
+type WebhookResponse struct {
+	ID        uint      `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	URL       string    `json:"url"`
+	Events    []string  `json:"events"`
+	Active    bool      `json:"active"`
+	Secret    string    `json:"secret,omitempty"`
+}
+
+func NewWebhookResponse(webhook *domain.Webhook) WebhookResponse {
+	events := make([]string, 0, len(webhook.Events))
+	for _, event := range webhook.Events {
+		events = append(events, string(event))
+	}
+	return WebhookResponse{
+		ID:        webhook.ID,
+		CreatedAt: webhook.CreatedAt,
+		UpdatedAt: webhook.UpdatedAt,
+		URL:       webhook.URL,
+		Events:    events,
+		Active:    webhook.Active,
+	}
+}
+
+func NewWebhookResponses(webhooks []domain.Webhook) []WebhookResponse {
+	responses := make([]WebhookResponse, 0, len(webhooks))
+	for i := range webhooks {
+		responses = append(responses, NewWebhookResponse(&webhooks[i]))
+	}
+	return responses
+}
+
+type DeliveryResponse struct {
+	ID             uint       `json:"id"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	WebhookID      uint       `json:"webhook_id"`
+	EventID        string     `json:"event_id"`
+	EventType      string     `json:"event_type"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at,omitempty"`
+	LastStatusCode int        `json:"last_status_code,omitempty"`
+	LastError      string     `json:"last_error,omitempty"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+}
+
+func NewDeliveryResponse(delivery *domain.WebhookDelivery) DeliveryResponse {
+	return DeliveryResponse{
+		ID:             delivery.ID,
+		CreatedAt:      delivery.CreatedAt,
+		UpdatedAt:      delivery.UpdatedAt,
+		WebhookID:      delivery.WebhookID,
+		EventID:        delivery.EventID,
+		EventType:      string(delivery.EventType),
+		Status:         string(delivery.Status),
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		LastStatusCode: delivery.LastStatusCode,
+		LastError:      delivery.LastError,
+		DeliveredAt:    delivery.DeliveredAt,
+	}
+}
+
+func NewDeliveryResponses(deliveries []domain.WebhookDelivery) []DeliveryResponse {
+	responses := make([]DeliveryResponse, 0, len(deliveries))
+	for i := range deliveries {
+		responses = append(responses, NewDeliveryResponse(&deliveries[i]))
+	}
+	return responses
+}
+
+// Entrega com o corpo enviado e o histórico de tentativas
+type DeliveryDetailResponse struct {
+	DeliveryResponse
+	Payload    json.RawMessage   `json:"payload"`
+	AttemptLog []AttemptResponse `json:"attempt_log"`
+}
+
+type AttemptResponse struct {
+	Number      int       `json:"number"`
+	AttemptedAt time.Time `json:"attempted_at"`
+	StatusCode  int       `json:"status_code,omitempty"`
+	Error       string    `json:"error,omitempty"`
+	DurationMS  int64     `json:"duration_ms"`
+}
+
+func NewDeliveryDetailResponse(delivery *domain.WebhookDelivery) DeliveryDetailResponse {
+	attempts := make([]AttemptResponse, 0, len(delivery.AttemptLog))
+	for _, a := range delivery.AttemptLog {
+		attempts = append(attempts, AttemptResponse{
+			Number:      a.Number,
+			AttemptedAt: a.AttemptedAt,
+			StatusCode:  a.StatusCode,
+			Error:       a.Error,
+			DurationMS:  a.Duration.Milliseconds(),
+		})
+	}
+	return DeliveryDetailResponse{
+		DeliveryResponse: NewDeliveryResponse(delivery),
+		Payload:          json.RawMessage(delivery.Payload),
+		AttemptLog:       attempts,
+	}
+}
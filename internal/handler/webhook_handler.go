@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"api-golang/internal/domain"
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type WebhookUseCase interface {
+	CreateWebhook(webhook *domain.Webhook) error
+	GetWebhooks() ([]domain.Webhook, error)
+	GetWebhookByID(id uint) (*domain.Webhook, error)
+	UpdateWebhook(webhook *domain.Webhook) error
+	DeleteWebhook(id uint) error
+
+	GetDeliveries(webhookID uint, status domain.DeliveryStatus, limit int) ([]domain.WebhookDelivery, error)
+	GetDelivery(webhookID, id uint) (*domain.WebhookDelivery, error)
+	Redeliver(webhookID, id uint) (*domain.WebhookDelivery, error)
+}
+
+type WebhookHandler struct {
+	UseCase   WebhookUseCase
+	Validator *validator.Validate
+}
+
+func NewWebhookHandler(uc WebhookUseCase) *WebhookHandler {
+	return &WebhookHandler{
+		UseCase:   uc,
+		Validator: validator.New(),
+	}
+}
+
+// Create Webhook. A resposta traz o segredo, que não é mais exibido depois.
+func (h *WebhookHandler) CreateWebhook(c *fiber.Ctx) error {
+	var req WebhookRequest
+	if err := parseBody(c, h.Validator, &req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	webhook := req.ToDomain(0)
+	if err := h.UseCase.CreateWebhook(webhook); err != nil {
+		return errorResponse(c, err)
+	}
+	response := NewWebhookResponse(webhook)
+	response.Secret = webhook.Secret
+	return c.Status(fiber.StatusCreated).JSON(response)
+}
+
+// Get All Webhooks
+func (h *WebhookHandler) GetWebhooks(c *fiber.Ctx) error {
+	webhooks, err := h.UseCase.GetWebhooks()
+	if err != nil {
+		return errorResponse(c, err)
+	}
+	return c.JSON(NewWebhookResponses(webhooks))
+}
+
+// Get Webhook by ID
+func (h *WebhookHandler) GetWebhookByID(c *fiber.Ctx) error {
+	id, _ := c.ParamsInt("id")
+	webhook, err := h.UseCase.GetWebhookByID(uint(id))
+	if err != nil {
+		return errorResponse(c, err)
+	}
+	return c.JSON(NewWebhookResponse(webhook))
+}
+
+// Update Webhook
+func (h *WebhookHandler) UpdateWebhook(c *fiber.Ctx) error {
+	id, _ := c.ParamsInt("id")
+	var req WebhookRequest
+	if err := parseBody(c, h.Validator, &req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	webhook := req.ToDomain(uint(id))
+	if err := h.UseCase.UpdateWebhook(webhook); err != nil {
+		return errorResponse(c, err)
+	}
+	return c.JSON(NewWebhookResponse(webhook))
+}
+
+// Delete Webhook
+func (h *WebhookHandler) DeleteWebhook(c *fiber.Ctx) error {
+	id, _ := c.ParamsInt("id")
+	if err := h.UseCase.DeleteWebhook(uint(id)); err != nil {
+		return errorResponse(c, err)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// Get Deliveries of a Webhook, opcionalmente por ?status=
+func (h *WebhookHandler) GetDeliveries(c *fiber.Ctx) error {
+	id, _ := c.ParamsInt("id")
+	limit := c.QueryInt("limit", 0)
+	if c.Query("limit") != "" && limit <= 0 {
+		return errorResponse(c, fmt.Errorf("%w: limit must be a positive integer", domain.ErrInvalid))
+	}
+
+	deliveries, err := h.UseCase.GetDeliveries(uint(id), domain.DeliveryStatus(c.Query("status")), limit)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+	return c.JSON(NewDeliveryResponses(deliveries))
+}
+
+// Get Delivery with its attempts
+func (h *WebhookHandler) GetDelivery(c *fiber.Ctx) error {
+	id, _ := c.ParamsInt("id")
+	deliveryID, _ := c.ParamsInt("deliveryId")
+	delivery, err := h.UseCase.GetDelivery(uint(id), uint(deliveryID))
+	if err != nil {
+		return errorResponse(c, err)
+	}
+	return c.JSON(NewDeliveryDetailResponse(delivery))
+}
+
+// Redeliver. A entrega volta para a fila e é enviada na próxima rodada.
+func (h *WebhookHandler) Redeliver(c *fiber.Ctx) error {
+	id, _ := c.ParamsInt("id")
+	deliveryID, _ := c.ParamsInt("deliveryId")
+	delivery, err := h.UseCase.Redeliver(uint(id), uint(deliveryID))
+	if err != nil {
+		return errorResponse(c, err)
+	}
+	return c.Status(fiber.StatusAccepted).JSON(NewDeliveryDetailResponse(delivery))
+}
@@ -0,0 +1,138 @@
+package handler_test
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/handler"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock do UseCase de webhooks
+type MockWebhookUseCase struct {
+	mock.Mock
+}
+
+func (m *MockWebhookUseCase) CreateWebhook(webhook *domain.Webhook) error {
+	args := m.Called(webhook)
+	return args.Error(0)
+}
+
+func (m *MockWebhookUseCase) GetWebhooks() ([]domain.Webhook, error) {
+	args := m.Called()
+	return args.Get(0).([]domain.Webhook), args.Error(1)
+}
+
+func (m *MockWebhookUseCase) GetWebhookByID(id uint) (*domain.Webhook, error) {
+	args := m.Called(id)
+	return args.Get(0).(*domain.Webhook), args.Error(1)
+}
+
+func (m *MockWebhookUseCase) UpdateWebhook(webhook *domain.Webhook) error {
+	args := m.Called(webhook)
+	return args.Error(0)
+}
+
+func (m *MockWebhookUseCase) DeleteWebhook(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockWebhookUseCase) GetDeliveries(webhookID uint, status domain.DeliveryStatus, limit int) ([]domain.WebhookDelivery, error) {
+	args := m.Called(webhookID, status, limit)
+	return args.Get(0).([]domain.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockWebhookUseCase) GetDelivery(webhookID, id uint) (*domain.WebhookDelivery, error) {
+	args := m.Called(webhookID, id)
+	return args.Get(0).(*domain.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockWebhookUseCase) Redeliver(webhookID, id uint) (*domain.WebhookDelivery, error) {
+	args := m.Called(webhookID, id)
+	return args.Get(0).(*domain.WebhookDelivery), args.Error(1)
+}
+
+func setupWebhookApp() (*fiber.App, *MockWebhookUseCase) {
+	mockUseCase := new(MockWebhookUseCase)
+	app := fiber.New()
+	handler.RegisterWebhookRoutes(app, handler.NewWebhookHandler(mockUseCase))
+	return app, mockUseCase
+}
+
+func TestCreateWebhook(t *testing.T) {
+	app, mockUseCase := setupWebhookApp()
+
+	mockUseCase.On("CreateWebhook", mock.MatchedBy(func(w *domain.Webhook) bool {
+		return w.URL == "https://cmdb.example.com/hooks" && w.Active &&
+			len(w.Events) == 1 && w.Events[0] == domain.EventCentralDeleted
+	})).Run(func(args mock.Arguments) {
+		w := args.Get(0).(*domain.Webhook)
+		w.ID, w.Secret = 1, "generated"
+	}).Return(nil)
+
+	resp := postJSON(app, "/webhooks", `{"url":"https://cmdb.example.com/hooks","events":["central.deleted"]}`)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	var webhook handler.WebhookResponse
+	json.NewDecoder(resp.Body).Decode(&webhook)
+	// O segredo gerado só aparece na criação
+	assert.Equal(t, "generated", webhook.Secret)
+
+	assert.Equal(t, http.StatusBadRequest, postJSON(app, "/webhooks", `{"events":["central.created"]}`).StatusCode)
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestGetWebhookByID_HidesSecret(t *testing.T) {
+	app, mockUseCase := setupWebhookApp()
+
+	mockUseCase.On("GetWebhookByID", uint(1)).Return(&domain.Webhook{ID: 1, URL: "https://x.example.com", Secret: "s", Active: true}, nil)
+
+	resp, _ := app.Test(httptest.NewRequest(http.MethodGet, "/webhooks/1", nil), -1)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	var body map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&body)
+	assert.NotContains(t, body, "secret")
+	assert.Equal(t, []interface{}{}, body["events"])
+}
+
+func TestWebhookDeliveries(t *testing.T) {
+	app, mockUseCase := setupWebhookApp()
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	delivery := &domain.WebhookDelivery{ID: 5, WebhookID: 1, EventID: "abc", EventType: domain.EventCentralCreated,
+		Payload: []byte(`{"id":"abc"}`), Status: domain.DeliveryDead, Attempts: 8, LastStatusCode: 500,
+		AttemptLog: []domain.DeliveryAttempt{{Number: 1, AttemptedAt: now, StatusCode: 500, Duration: 30 * time.Millisecond}}}
+	mockUseCase.On("GetDeliveries", uint(1), domain.DeliveryDead, 0).Return([]domain.WebhookDelivery{*delivery}, nil)
+	mockUseCase.On("GetDeliveries", uint(1), domain.DeliveryStatus("failed"), 0).Return([]domain.WebhookDelivery(nil), domain.ErrInvalid)
+	mockUseCase.On("GetDelivery", uint(1), uint(5)).Return(delivery, nil)
+	mockUseCase.On("Redeliver", uint(1), uint(5)).Return(&domain.WebhookDelivery{ID: 5, WebhookID: 1, Status: domain.DeliveryPending}, nil)
+	mockUseCase.On("Redeliver", uint(1), uint(9)).Return((*domain.WebhookDelivery)(nil), domain.ErrNotFound)
+
+	resp, _ := app.Test(httptest.NewRequest(http.MethodGet, "/webhooks/1/deliveries?status=dead", nil), -1)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	var deliveries []handler.DeliveryResponse
+	json.NewDecoder(resp.Body).Decode(&deliveries)
+	assert.Len(t, deliveries, 1)
+
+	resp, _ = app.Test(httptest.NewRequest(http.MethodGet, "/webhooks/1/deliveries?status=failed", nil), -1)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp, _ = app.Test(httptest.NewRequest(http.MethodGet, "/webhooks/1/deliveries/5", nil), -1)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	var detail handler.DeliveryDetailResponse
+	json.NewDecoder(resp.Body).Decode(&detail)
+	assert.JSONEq(t, `{"id":"abc"}`, string(detail.Payload))
+	assert.Equal(t, int64(30), detail.AttemptLog[0].DurationMS)
+
+	resp = postJSON(app, "/webhooks/1/deliveries/5/redeliver", "")
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+	resp = postJSON(app, "/webhooks/1/deliveries/9/redeliver", "")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	mockUseCase.AssertExpectations(t)
+}
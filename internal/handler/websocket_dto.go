@@ -0,0 +1,65 @@
+package handler
+
+import "encoding/json"
+
+// Comandos aceitos pelo WebSocket
+const (
+	CommandSubscribe   = "subscribe"
+	CommandUnsubscribe = "unsubscribe"
+	CommandGet         = "get"
+	CommandList        = "list"
+	CommandPing        = "ping"
+)
+
+// Tipos das mensagens enviadas pelo servidor
+const (
+	MessageResult = "result"
+	MessageError  = "error"
+	MessageEvent  = "event"
+	// A inscrição foi encerrada pelo servidor; com reason "lagged", o cliente
+	// pode se inscrever de novo com last_event_id para recuperar o que perdeu
+	MessageSubscriptionClosed = "subscription_closed"
+)
+
+// Comando enviado pelo cliente. O ID é devolvido na resposta para
+// correlacioná-la ao pedido.
+type WSRequest struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+
+	// get
+	CentralID uint `json:"central_id,omitempty"`
+	// unsubscribe
+	Subscription string `json:"subscription,omitempty"`
+
+	// Filtros de subscribe e list; centrals vale apenas no subscribe
+	Centrals []uint `json:"centrals,omitempty"`
+	SiteID   *uint  `json:"site_id,omitempty"`
+	Selector string `json:"selector,omitempty"`
+	Status   string `json:"status,omitempty"`
+	// subscribe: reenvia os eventos gravados depois deste ID
+	LastEventID *uint `json:"last_event_id,omitempty"`
+}
+
+// Mensagem enviada pelo servidor
+type WSMessage struct {
+	Type string `json:"type"`
+	// ID do comando respondido
+	ID           string `json:"id,omitempty"`
+	Subscription string `json:"subscription,omitempty"`
+	Result       any    `json:"result,omitempty"`
+	Error        string `json:"error,omitempty"`
+	// Status HTTP equivalente ao erro
+	Code        int             `json:"code,omitempty"`
+	Event       json.RawMessage `json:"event,omitempty"`
+	Reason      string          `json:"reason,omitempty"`
+	LastEventID uint            `json:"last_event_id,omitempty"`
+}
+
+type SubscribeResult struct {
+	Subscription string `json:"subscription"`
+}
+
+type PongResult struct {
+	Time string `json:"time"`
+}
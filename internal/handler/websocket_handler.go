@@ -0,0 +1,332 @@
+package handler
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/stream"
+	"api-golang/internal/utils"
+	"api-golang/internal/webhook"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/contrib/websocket"
+	"github.com/gofiber/fiber/v2"
+)
+
+type WebSocketHandler struct {
+	UseCase CentralUseCase
+	Stream  EventStream
+	// Tokens aceitos na conexão; sem nenhum, toda conexão é recusada
+	Tokens []string
+	// Intervalo dos pings do servidor; a conexão cai sem pong em dobro do
+	// intervalo
+	PingInterval time.Duration
+	// Mensagens aguardando envio por conexão
+	SendBuffer int
+}
+
+func NewWebSocketHandler(uc CentralUseCase, s EventStream, tokens []string) *WebSocketHandler {
+	return &WebSocketHandler{UseCase: uc, Stream: s, Tokens: tokens, PingInterval: 30 * time.Second, SendBuffer: 64}
+}
+
+// Tamanho máximo de um comando do cliente
+const wsReadLimit = 64 * 1024
+
+// Prazo para escrever uma mensagem antes de desistir da conexão
+const wsWriteTimeout = 10 * time.Second
+
+// Confere o pedido de upgrade e o token, enviado como "Authorization: Bearer"
+// ou em ?token= para navegadores, que não permitem cabeçalhos no WebSocket
+func (h *WebSocketHandler) Upgrade(c *fiber.Ctx) error {
+	if !websocket.IsWebSocketUpgrade(c) {
+		return c.Status(fiber.StatusUpgradeRequired).JSON(fiber.Map{"error": "websocket upgrade required"})
+	}
+	// Sem tokens configurados não há como autenticar: recusa em vez de abrir
+	if len(h.Tokens) == 0 {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"error": "websocket authentication is not configured"})
+	}
+	if !h.validToken(requestToken(c)) {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "invalid or missing token"})
+	}
+	return c.Next()
+}
+
+func requestToken(c *fiber.Ctx) string {
+	if token, ok := strings.CutPrefix(c.Get(fiber.HeaderAuthorization), "Bearer "); ok {
+		return token
+	}
+	return c.Query("token")
+}
+
+func (h *WebSocketHandler) validToken(token string) bool {
+	if token == "" {
+		return false
+	}
+	for _, t := range h.Tokens {
+		if subtle.ConstantTimeCompare([]byte(t), []byte(token)) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// Atende uma conexão até o cliente desconectar ou parar de responder
+func (h *WebSocketHandler) Serve(conn *websocket.Conn) {
+	s := &wsSession{
+		handler:       h,
+		conn:          conn,
+		out:           make(chan WSMessage, h.SendBuffer),
+		done:          make(chan struct{}),
+		stopped:       make(chan struct{}),
+		subscriptions: map[string]*stream.Subscription{},
+	}
+	go func() {
+		defer close(s.stopped)
+		s.writeLoop()
+	}()
+
+	s.readLoop()
+
+	close(s.done)
+	s.closeSubscriptions()
+	s.forwarders.Wait()
+	<-s.stopped
+	// A conexão volta ao pool quando Serve retorna
+	conn.Close()
+}
+
+// Estado de uma conexão. Só writeLoop escreve na conexão; as respostas e os
+// eventos passam pelo canal out.
+type wsSession struct {
+	handler *WebSocketHandler
+	conn    *websocket.Conn
+	out     chan WSMessage
+	// Fechado quando a leitura termina
+	done chan struct{}
+	// Fechado quando a escrita termina, por erro ou pelo fim da leitura
+	stopped chan struct{}
+
+	mu            sync.Mutex
+	subscriptions map[string]*stream.Subscription
+	nextID        int
+	forwarders    sync.WaitGroup
+}
+
+func (s *wsSession) readLoop() {
+	pongWait := 2 * s.handler.PingInterval
+	s.conn.SetReadLimit(wsReadLimit)
+	s.conn.SetReadDeadline(time.Now().Add(pongWait))
+	s.conn.SetPongHandler(func(string) error {
+		return s.conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+	for {
+		_, data, err := s.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		// Qualquer mensagem também mostra que o cliente está vivo
+		s.conn.SetReadDeadline(time.Now().Add(pongWait))
+
+		var req WSRequest
+		if err := json.Unmarshal(data, &req); err != nil {
+			s.send(WSMessage{Type: MessageError, Error: "invalid message", Code: fiber.StatusBadRequest})
+			continue
+		}
+		// A inscrição responde por conta própria, antes do primeiro evento
+		if req.Type == CommandSubscribe {
+			err = s.subscribe(&req)
+		} else {
+			var result any
+			if result, err = s.handle(&req); err == nil {
+				s.send(WSMessage{Type: MessageResult, ID: req.ID, Result: result})
+			}
+		}
+		if err != nil {
+			s.send(WSMessage{Type: MessageError, ID: req.ID, Error: err.Error(), Code: errorStatus(err)})
+		}
+	}
+}
+
+func (s *wsSession) writeLoop() {
+	ticker := time.NewTicker(s.handler.PingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case msg := <-s.out:
+			s.conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+			if err := s.conn.WriteJSON(msg); err != nil {
+				s.conn.Close()
+				return
+			}
+		case <-ticker.C:
+			deadline := time.Now().Add(wsWriteTimeout)
+			if err := s.conn.WriteControl(websocket.PingMessage, nil, deadline); err != nil {
+				s.conn.Close()
+				return
+			}
+		case <-s.done:
+			s.conn.WriteControl(websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(time.Second))
+			return
+		}
+	}
+}
+
+// Enfileira a mensagem; retorna false quando a conexão já terminou
+func (s *wsSession) send(msg WSMessage) bool {
+	select {
+	case s.out <- msg:
+		return true
+	case <-s.stopped:
+		return false
+	}
+}
+
+func (s *wsSession) handle(req *WSRequest) (any, error) {
+	switch req.Type {
+	case CommandUnsubscribe:
+		return s.unsubscribe(req.Subscription)
+	case CommandGet:
+		if req.CentralID == 0 {
+			return nil, fmt.Errorf("%w: central_id is required", domain.ErrInvalid)
+		}
+		central, err := s.handler.UseCase.GetCentralByID(req.CentralID)
+		if err != nil {
+			return nil, err
+		}
+		return NewCentralResponse(central, ""), nil
+	case CommandList:
+		filter, err := req.centralFilter()
+		if err != nil {
+			return nil, err
+		}
+		centrals, err := s.handler.UseCase.GetAllCentrals(filter)
+		if err != nil {
+			return nil, err
+		}
+		return NewCentralResponses(centrals, ""), nil
+	case CommandPing:
+		return PongResult{Time: time.Now().UTC().Format(time.RFC3339Nano)}, nil
+	}
+	return nil, fmt.Errorf("%w: unknown command %q", domain.ErrInvalid, req.Type)
+}
+
+// Inscreve nos eventos e responde com o ID da inscrição. Os eventos perdidos
+// desde last_event_id e os novos seguem depois da resposta.
+func (s *wsSession) subscribe(req *WSRequest) error {
+	filter, err := req.eventFilter()
+	if err != nil {
+		return err
+	}
+	sub, err := s.handler.Stream.Subscribe(filter)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.nextID++
+	id := "s" + strconv.Itoa(s.nextID)
+	s.subscriptions[id] = sub
+	s.mu.Unlock()
+
+	if !s.send(WSMessage{Type: MessageResult, ID: req.ID, Result: SubscribeResult{Subscription: id}}) {
+		sub.Close()
+		return nil
+	}
+	s.forwarders.Add(1)
+	go func() {
+		defer s.forwarders.Done()
+		s.forward(id, sub, filter, req.LastEventID)
+	}()
+	return nil
+}
+
+func (s *wsSession) unsubscribe(id string) (any, error) {
+	s.mu.Lock()
+	sub, ok := s.subscriptions[id]
+	delete(s.subscriptions, id)
+	s.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: subscription %q", domain.ErrNotFound, id)
+	}
+	sub.Close()
+	return SubscribeResult{Subscription: id}, nil
+}
+
+// Envia os eventos da inscrição, antes os perdidos desde lastEventID
+func (s *wsSession) forward(id string, sub *stream.Subscription, filter domain.EventFilter, lastEventID *uint) {
+	var last uint
+	send := func(event domain.Event) error {
+		if event.ID <= last {
+			return nil
+		}
+		data, err := webhook.Encode(strconv.FormatUint(uint64(event.ID), 10), event)
+		if err != nil {
+			return err
+		}
+		if !s.send(WSMessage{Type: MessageEvent, Subscription: id, Event: data}) {
+			return errSessionClosed
+		}
+		last = event.ID
+		return nil
+	}
+	if lastEventID != nil {
+		last = *lastEventID
+		if err := s.handler.Stream.Replay(last, sub.Since, filter, send); err != nil {
+			sub.Close()
+			return
+		}
+	}
+	for event := range sub.Events() {
+		if send(event) != nil {
+			sub.Close()
+			return
+		}
+	}
+	if sub.Lagged() {
+		s.mu.Lock()
+		delete(s.subscriptions, id)
+		s.mu.Unlock()
+		s.send(WSMessage{Type: MessageSubscriptionClosed, Subscription: id, Reason: "lagged", LastEventID: last})
+	}
+}
+
+func (s *wsSession) closeSubscriptions() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, sub := range s.subscriptions {
+		sub.Close()
+		delete(s.subscriptions, id)
+	}
+}
+
+var errSessionClosed = errors.New("connection closed")
+
+func (r *WSRequest) eventFilter() (domain.EventFilter, error) {
+	filter := domain.EventFilter{CentralIDs: r.Centrals, SiteID: r.SiteID, Status: domain.ReachabilityStatus(r.Status)}
+	if filter.Status != "" && !filter.Status.Valid() {
+		return filter, fmt.Errorf("%w: unknown status %q", domain.ErrInvalid, filter.Status)
+	}
+	var err error
+	if filter.Selector, err = utils.ParseLabelSelector(r.Selector); err != nil {
+		return filter, fmt.Errorf("%w: %v", domain.ErrInvalid, err)
+	}
+	return filter, nil
+}
+
+func (r *WSRequest) centralFilter() (domain.CentralFilter, error) {
+	filter := domain.CentralFilter{SiteID: r.SiteID, Status: domain.ReachabilityStatus(r.Status)}
+	if filter.Status != "" && !filter.Status.Valid() {
+		return filter, fmt.Errorf("%w: unknown status %q", domain.ErrInvalid, filter.Status)
+	}
+	var err error
+	if filter.Selector, err = utils.ParseLabelSelector(r.Selector); err != nil {
+		return filter, fmt.Errorf("%w: %v", domain.ErrInvalid, err)
+	}
+	return filter, nil
+}
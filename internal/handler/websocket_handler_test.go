@@ -0,0 +1,261 @@
+package handler_test
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/handler"
+	"api-golang/internal/stream"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fasthttp/websocket"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const wsToken = "s3cret"
+
+// Sobe o app com o WebSocket em uma porta local
+func setupWebSocketServer(t *testing.T, configure func(*handler.WebSocketHandler)) (string, *memoryOutbox, *stream.Hub, *MockCentralUseCase) {
+	outbox := &memoryOutbox{}
+	hub := stream.New(outbox, stream.Options{})
+	mockUseCase := new(MockCentralUseCase)
+	h := handler.NewWebSocketHandler(mockUseCase, hub, []string{wsToken})
+	if configure != nil {
+		configure(h)
+	}
+
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	handler.RegisterWebSocketRoutes(app, h)
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go app.Listener(ln)
+	t.Cleanup(func() { app.ShutdownWithTimeout(time.Second) })
+	return "ws://" + ln.Addr().String() + "/ws", outbox, hub, mockUseCase
+}
+
+func dialWebSocket(t *testing.T, url string) *websocket.Conn {
+	t.Helper()
+	header := http.Header{"Authorization": {"Bearer " + wsToken}}
+	conn, _, err := websocket.DefaultDialer.Dial(url, header)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func readMessage(t *testing.T, conn *websocket.Conn) handler.WSMessage {
+	t.Helper()
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var msg handler.WSMessage
+	require.NoError(t, conn.ReadJSON(&msg))
+	return msg
+}
+
+// Envia o comando e lê as mensagens até a resposta correspondente
+func command(t *testing.T, conn *websocket.Conn, req handler.WSRequest) handler.WSMessage {
+	t.Helper()
+	require.NoError(t, conn.WriteJSON(req))
+	for {
+		msg := readMessage(t, conn)
+		if msg.ID == req.ID {
+			return msg
+		}
+	}
+}
+
+func TestWebSocket_Authentication(t *testing.T) {
+	url, _, _, _ := setupWebSocketServer(t, nil)
+
+	for _, header := range []http.Header{nil, {"Authorization": {"Bearer wrong"}}} {
+		_, resp, err := websocket.DefaultDialer.Dial(url, header)
+		require.Error(t, err)
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	}
+
+	// Navegadores enviam o token na query string
+	conn, _, err := websocket.DefaultDialer.Dial(url+"?token="+wsToken, nil)
+	require.NoError(t, err)
+	conn.Close()
+
+	// Sem upgrade, a rota não atende
+	app := fiber.New()
+	handler.RegisterWebSocketRoutes(app, handler.NewWebSocketHandler(new(MockCentralUseCase), nil, nil))
+	resp, _ := app.Test(httptest.NewRequest(http.MethodGet, "/ws", nil))
+	assert.Equal(t, http.StatusUpgradeRequired, resp.StatusCode)
+
+	// Sem tokens configurados, nenhuma conexão é aceita
+	url, _, _, _ = setupWebSocketServer(t, func(h *handler.WebSocketHandler) { h.Tokens = nil })
+	_, resp, err = websocket.DefaultDialer.Dial(url, nil)
+	require.Error(t, err)
+	require.NotNil(t, resp)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+}
+
+func TestWebSocket_Commands(t *testing.T) {
+	url, _, _, mockUseCase := setupWebSocketServer(t, nil)
+	mockUseCase.On("GetCentralByID", uint(1)).Return(&domain.Central{ID: 1, Name: "Portaria", MAC: "00:11:22:33:44:55",
+		IPv4: "10.0.0.1", Status: domain.CentralStatus{Status: domain.StatusOnline}}, nil)
+	mockUseCase.On("GetCentralByID", uint(2)).Return((*domain.Central)(nil), fmt.Errorf("%w: central 2", domain.ErrNotFound))
+	mockUseCase.On("GetAllCentrals", domain.CentralFilter{Status: domain.StatusOffline}).Return([]domain.Central{{ID: 3, Name: "Depósito"}}, nil)
+	conn := dialWebSocket(t, url)
+
+	msg := command(t, conn, handler.WSRequest{ID: "a", Type: handler.CommandGet, CentralID: 1})
+	assert.Equal(t, handler.MessageResult, msg.Type)
+	central := msg.Result.(map[string]any)
+	assert.Equal(t, "Portaria", central["name"])
+	assert.Equal(t, "online", central["status"])
+
+	msg = command(t, conn, handler.WSRequest{ID: "b", Type: handler.CommandGet, CentralID: 2})
+	assert.Equal(t, handler.MessageError, msg.Type)
+	assert.Equal(t, http.StatusNotFound, msg.Code)
+
+	msg = command(t, conn, handler.WSRequest{ID: "c", Type: handler.CommandList, Status: "offline"})
+	assert.Len(t, msg.Result, 1)
+
+	msg = command(t, conn, handler.WSRequest{ID: "d", Type: "reboot"})
+	assert.Equal(t, http.StatusBadRequest, msg.Code)
+	msg = command(t, conn, handler.WSRequest{ID: "e", Type: handler.CommandSubscribe, Selector: "=x"})
+	assert.Equal(t, http.StatusBadRequest, msg.Code)
+	msg = command(t, conn, handler.WSRequest{ID: "f", Type: handler.CommandUnsubscribe, Subscription: "s9"})
+	assert.Equal(t, http.StatusNotFound, msg.Code)
+	msg = command(t, conn, handler.WSRequest{ID: "g", Type: handler.CommandPing})
+	assert.Equal(t, handler.MessageResult, msg.Type)
+
+	// Mensagens que não são JSON não derrubam a conexão
+	require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte("hello")))
+	assert.Equal(t, handler.MessageError, readMessage(t, conn).Type)
+	msg = command(t, conn, handler.WSRequest{ID: "h", Type: handler.CommandPing})
+	assert.Equal(t, handler.MessageResult, msg.Type)
+}
+
+func TestWebSocket_ConcurrentClients(t *testing.T) {
+	const clients = 50
+	url, outbox, hub, mockUseCase := setupWebSocketServer(t, nil)
+	mockUseCase.On("GetCentralByID", mock.AnythingOfType("uint")).Return(&domain.Central{Name: "x"}, nil)
+
+	// Cada cliente se inscreve na sua central e no site 1
+	conns := make([]*websocket.Conn, clients)
+	var wg sync.WaitGroup
+	for i := range conns {
+		conns[i] = dialWebSocket(t, url)
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			conn := conns[i]
+			msg := command(t, conn, handler.WSRequest{ID: "own", Type: handler.CommandSubscribe, Centrals: []uint{uint(i + 1)}})
+			assert.Equal(t, "s1", msg.Result.(map[string]any)["subscription"])
+			site := uint(1)
+			msg = command(t, conn, handler.WSRequest{ID: "site", Type: handler.CommandSubscribe, SiteID: &site})
+			assert.Equal(t, "s2", msg.Result.(map[string]any)["subscription"])
+		}(i)
+	}
+	wg.Wait()
+
+	for i := 1; i <= clients; i++ {
+		outbox.add(siteEvent(domain.EventCentralUpdated, uint(i), 2))
+	}
+	outbox.add(siteEvent(domain.EventCentralCreated, 99, 1))
+	_, err := hub.Poll()
+	require.NoError(t, err)
+
+	// Cada um recebe o evento da sua central e o do site, com os comandos
+	// respondidos no meio dos eventos
+	for i := range conns {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			conn := conns[i]
+			id := fmt.Sprintf("get-%d", i)
+			assert.NoError(t, conn.WriteJSON(handler.WSRequest{ID: id, Type: handler.CommandGet, CentralID: uint(i + 1)}))
+			events := map[string]float64{}
+			answered := false
+			for len(events) < 2 || !answered {
+				msg := readMessage(t, conn)
+				switch msg.Type {
+				case handler.MessageEvent:
+					var payload map[string]any
+					assert.NoError(t, json.Unmarshal(msg.Event, &payload))
+					events[msg.Subscription] = payload["central_id"].(float64)
+				case handler.MessageResult:
+					assert.Equal(t, id, msg.ID)
+					answered = true
+				}
+			}
+			assert.Equal(t, map[string]float64{"s1": float64(i + 1), "s2": 99}, events)
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestWebSocket_ResumeAndUnsubscribe(t *testing.T) {
+	url, outbox, hub, _ := setupWebSocketServer(t, nil)
+	for id := uint(1); id <= 3; id++ {
+		outbox.add(siteEvent(domain.EventCentralCreated, id, 1))
+	}
+	conn := dialWebSocket(t, url)
+
+	last := uint(1)
+	msg := command(t, conn, handler.WSRequest{ID: "a", Type: handler.CommandSubscribe, LastEventID: &last})
+	subscription := msg.Result.(map[string]any)["subscription"].(string)
+	for _, want := range []float64{2, 3} {
+		msg = readMessage(t, conn)
+		var payload map[string]any
+		require.NoError(t, json.Unmarshal(msg.Event, &payload))
+		assert.Equal(t, want, payload["central_id"])
+	}
+
+	msg = command(t, conn, handler.WSRequest{ID: "b", Type: handler.CommandUnsubscribe, Subscription: subscription})
+	assert.Equal(t, handler.MessageResult, msg.Type)
+	outbox.add(siteEvent(domain.EventCentralDeleted, 1, 1))
+	_, err := hub.Poll()
+	require.NoError(t, err)
+
+	// Depois de cancelar, a próxima mensagem é a resposta, não um evento
+	require.NoError(t, conn.WriteJSON(handler.WSRequest{ID: "c", Type: handler.CommandPing}))
+	assert.Equal(t, "c", readMessage(t, conn).ID)
+}
+
+func TestWebSocket_Heartbeats(t *testing.T) {
+	url, _, _, _ := setupWebSocketServer(t, func(h *handler.WebSocketHandler) {
+		h.PingInterval = 50 * time.Millisecond
+	})
+
+	// O cliente responde aos pings e continua conectado
+	alive := dialWebSocket(t, url)
+	var pings atomic.Int32
+	alive.SetPingHandler(func(data string) error {
+		pings.Add(1)
+		return alive.WriteControl(websocket.PongMessage, []byte(data), time.Now().Add(time.Second))
+	})
+	// Quem não responde é desconectado
+	silent := dialWebSocket(t, url)
+	silent.SetPingHandler(func(string) error { return nil })
+	disconnected := make(chan error, 1)
+	go func() {
+		silent.SetReadDeadline(time.Now().Add(5 * time.Second))
+		_, _, err := silent.ReadMessage()
+		disconnected <- err
+	}()
+
+	// Os pings só são tratados durante a leitura, então o cliente ativo
+	// segue enviando comandos enquanto o outro cai
+	for i := 0; ; i++ {
+		msg := command(t, alive, handler.WSRequest{ID: fmt.Sprint(i), Type: handler.CommandPing})
+		require.Equal(t, handler.MessageResult, msg.Type)
+		select {
+		case err := <-disconnected:
+			assert.Error(t, err)
+			assert.Positive(t, pings.Load())
+			return
+		case <-time.After(20 * time.Millisecond):
+		}
+	}
+}
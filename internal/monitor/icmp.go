@@ -0,0 +1,95 @@
+package monitor
+
+import (
+	"api-golang/internal/domain"
+	"context"
+	"net"
+	"net/netip"
+	"os"
+	"time"
+
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv4"
+	"golang.org/x/net/ipv6"
+)
+
+// Sonda por ICMP echo usando sockets sem privilégio ("ping" de datagrama),
+// que o Linux só libera para os grupos em net.ipv4.ping_group_range.
+// Perda parcial dos pacotes deixa a central degradada.
+type ICMPProber struct {
+	Count   int
+	Timeout time.Duration
+	// Latência média acima da qual a central é considerada degradada
+	DegradedRTT time.Duration
+}
+
+// Confere se o processo pode abrir sockets ICMP antes de usá-los
+func NewICMPProber(count int, timeout, degradedRTT time.Duration) (*ICMPProber, error) {
+	conn, err := icmp.ListenPacket("udp4", "0.0.0.0")
+	if err != nil {
+		return nil, err
+	}
+	conn.Close()
+	if count <= 0 {
+		count = 3
+	}
+	return &ICMPProber{Count: count, Timeout: timeout, DegradedRTT: degradedRTT}, nil
+}
+
+func (p *ICMPProber) Probe(ctx context.Context, addr netip.Addr) Result {
+	network, listen, proto := "udp4", "0.0.0.0", 1
+	var request, reply icmp.Type = ipv4.ICMPTypeEcho, ipv4.ICMPTypeEchoReply
+	if addr.Is6() {
+		network, listen, proto = "udp6", "::", 58
+		request, reply = ipv6.ICMPTypeEchoRequest, ipv6.ICMPTypeEchoReply
+	}
+	conn, err := icmp.ListenPacket(network, listen)
+	if err != nil {
+		return Result{Status: domain.StatusOffline}
+	}
+	defer conn.Close()
+
+	dst := &net.UDPAddr{IP: addr.AsSlice(), Zone: addr.Zone()}
+	answered := 0
+	var total time.Duration
+	buf := make([]byte, 1500)
+	for seq := 1; seq <= p.Count && ctx.Err() == nil; seq++ {
+		msg := icmp.Message{Type: request, Body: &icmp.Echo{ID: os.Getpid() & 0xffff, Seq: seq, Data: []byte("central-monitor")}}
+		data, err := msg.Marshal(nil)
+		if err != nil {
+			break
+		}
+		start := time.Now()
+		if _, err := conn.WriteTo(data, dst); err != nil {
+			continue
+		}
+		deadline := start.Add(p.Timeout)
+		if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+			deadline = d
+		}
+		conn.SetReadDeadline(deadline)
+		if p.awaitReply(conn, buf, proto, reply, seq) {
+			total += time.Since(start)
+			answered++
+		}
+	}
+	return classify(answered, p.Count, total, p.DegradedRTT)
+}
+
+// Lê até a resposta da sequência esperada ou o fim do prazo. Com sockets
+// de datagrama o kernel reescreve o ID, então só a sequência é conferida.
+func (p *ICMPProber) awaitReply(conn *icmp.PacketConn, buf []byte, proto int, reply icmp.Type, seq int) bool {
+	for {
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			return false
+		}
+		msg, err := icmp.ParseMessage(proto, buf[:n])
+		if err != nil || msg.Type != reply {
+			continue
+		}
+		if echo, ok := msg.Body.(*icmp.Echo); ok && echo.Seq == seq {
+			return true
+		}
+	}
+}
@@ -0,0 +1,135 @@
+// Package monitor verifica periodicamente se as centrais cadastradas
+// respondem na rede e grava a situação de cada uma.
+package monitor
+
+import (
+	"api-golang/internal/domain"
+	"context"
+	"log"
+	"net/netip"
+	"sync"
+	"time"
+)
+
+// Sonda um endereço e classifica a resposta
+type Prober interface {
+	Probe(ctx context.Context, addr netip.Addr) Result
+}
+
+// Resultado de uma sondagem; RTT só tem valor quando houve resposta
+type Result struct {
+	Status domain.ReachabilityStatus
+	RTT    time.Duration
+}
+
+// Origem dos alvos e destino dos resultados
+type Store interface {
+	ListProbeTargets() ([]domain.ProbeTarget, error)
+	// Retorna as centrais cuja situação mudou
+	SaveProbeResults(results []domain.ProbeResult) ([]domain.StatusChange, error)
+}
+
+type Options struct {
+	// Intervalo entre o início de duas rodadas
+	Interval time.Duration
+	// Sondagens simultâneas em uma rodada
+	Concurrency int
+}
+
+const defaultConcurrency = 16
+
+type Monitor struct {
+	Store   Store
+	Prober  Prober
+	Options Options
+	// Chamado após cada rodada com as mudanças de situação; opcional
+	OnChange func(changes []domain.StatusChange)
+
+	// Relógio, substituível nos testes
+	Now func() time.Time
+}
+
+func New(store Store, prober Prober, opts Options) *Monitor {
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = defaultConcurrency
+	}
+	return &Monitor{Store: store, Prober: prober, Options: opts, Now: time.Now}
+}
+
+// Executa uma rodada imediatamente e depois a cada intervalo, até o
+// contexto ser cancelado
+func (m *Monitor) Run(ctx context.Context) {
+	RunEvery(ctx, m.Options.Interval, "Reachability monitor", func() error {
+		return m.CheckAll(ctx)
+	})
+}
+
+// Executa a tarefa imediatamente e depois a cada intervalo, até o contexto
+// ser cancelado. Erros são registrados no log com o nome da tarefa.
+func RunEvery(ctx context.Context, interval time.Duration, name string, task func() error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := task(); err != nil && ctx.Err() == nil {
+			log.Printf("%s: %v", name, err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sonda todas as centrais uma vez e grava os resultados
+func (m *Monitor) CheckAll(ctx context.Context) error {
+	targets, err := m.Store.ListProbeTargets()
+	if err != nil {
+		return err
+	}
+
+	results := make([]domain.ProbeResult, len(targets))
+	sem := make(chan struct{}, m.Options.Concurrency)
+	var wg sync.WaitGroup
+	for i, target := range targets {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, target domain.ProbeTarget) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			result := m.Prober.Probe(ctx, target.Addr)
+			results[i] = domain.ProbeResult{
+				CentralID: target.CentralID,
+				Status:    result.Status,
+				RTT:       result.RTT,
+				CheckedAt: m.Now(),
+			}
+		}(i, target)
+	}
+	wg.Wait()
+
+	// Uma rodada interrompida não representa a situação real
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	changes, err := m.Store.SaveProbeResults(results)
+	if err != nil {
+		return err
+	}
+	if m.OnChange != nil && len(changes) > 0 {
+		m.OnChange(changes)
+	}
+	return nil
+}
+
+// Classifica pela quantidade de respostas e pela latência média
+func classify(answered, attempts int, total, degradedRTT time.Duration) Result {
+	if answered == 0 {
+		return Result{Status: domain.StatusOffline}
+	}
+	result := Result{Status: domain.StatusOnline, RTT: total / time.Duration(answered)}
+	if answered < attempts || (degradedRTT > 0 && result.RTT > degradedRTT) {
+		result.Status = domain.StatusDegraded
+	}
+	return result
+}
@@ -0,0 +1,168 @@
+package monitor_test
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/monitor"
+	"context"
+	"net"
+	"net/netip"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Store em memória
+type fakeStore struct {
+	mu      sync.Mutex
+	targets []domain.ProbeTarget
+	saved   map[uint]domain.ProbeResult
+	rounds  int
+}
+
+func (s *fakeStore) ListProbeTargets() ([]domain.ProbeTarget, error) {
+	return s.targets, nil
+}
+
+func (s *fakeStore) SaveProbeResults(results []domain.ProbeResult) ([]domain.StatusChange, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.saved == nil {
+		s.saved = make(map[uint]domain.ProbeResult)
+	}
+	var changes []domain.StatusChange
+	for _, r := range results {
+		if s.saved[r.CentralID].Status != r.Status {
+			changes = append(changes, domain.StatusChange{CentralID: r.CentralID, To: r.Status, At: r.CheckedAt})
+		}
+		s.saved[r.CentralID] = r
+	}
+	s.rounds++
+	return changes, nil
+}
+
+// Abre um listener local que faz as vezes de uma central
+func listen(t *testing.T) int {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+	return ln.Addr().(*net.TCPAddr).Port
+}
+
+// Porta que recusa conexões
+func closedPort(t *testing.T) int {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := ln.Addr().(*net.TCPAddr).Port
+	ln.Close()
+	return port
+}
+
+var loopback = netip.MustParseAddr("127.0.0.1")
+
+func TestTCPProber(t *testing.T) {
+	open1, open2, closed := listen(t), listen(t), closedPort(t)
+
+	prober := &monitor.TCPProber{Ports: []int{open1, open2}, Timeout: time.Second}
+	result := prober.Probe(context.Background(), loopback)
+	assert.Equal(t, domain.StatusOnline, result.Status)
+	assert.Greater(t, result.RTT, time.Duration(0))
+
+	// Só parte das portas responde
+	prober.Ports = []int{open1, closed}
+	assert.Equal(t, domain.StatusDegraded, prober.Probe(context.Background(), loopback).Status)
+
+	prober.Ports = []int{closed}
+	result = prober.Probe(context.Background(), loopback)
+	assert.Equal(t, domain.StatusOffline, result.Status)
+	assert.Zero(t, result.RTT)
+
+	// Latência acima do limite
+	prober = &monitor.TCPProber{Ports: []int{open1}, Timeout: time.Second, DegradedRTT: time.Nanosecond}
+	assert.Equal(t, domain.StatusDegraded, prober.Probe(context.Background(), loopback).Status)
+}
+
+func TestCheckAll(t *testing.T) {
+	port := listen(t)
+	store := &fakeStore{targets: []domain.ProbeTarget{
+		{CentralID: 1, Addr: loopback},
+		// Endereço de documentação, que não responde
+		{CentralID: 2, Addr: netip.MustParseAddr("192.0.2.1")},
+	}}
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	m := monitor.New(store, &monitor.TCPProber{Ports: []int{port}, Timeout: 200 * time.Millisecond}, monitor.Options{})
+	m.Now = func() time.Time { return now }
+	var changes []domain.StatusChange
+	m.OnChange = func(c []domain.StatusChange) { changes = append(changes, c...) }
+
+	require.NoError(t, m.CheckAll(context.Background()))
+	assert.Equal(t, domain.StatusOnline, store.saved[1].Status)
+	assert.Equal(t, now, store.saved[1].CheckedAt)
+	assert.Equal(t, domain.StatusOffline, store.saved[2].Status)
+	assert.Len(t, changes, 2)
+
+	// Sem mudança de situação, nada é reportado
+	changes = nil
+	require.NoError(t, m.CheckAll(context.Background()))
+	assert.Empty(t, changes)
+}
+
+func TestCheckAll_ManyCentrals(t *testing.T) {
+	ports := []int{listen(t)}
+	var targets []domain.ProbeTarget
+	for i := 1; i <= 50; i++ {
+		targets = append(targets, domain.ProbeTarget{CentralID: uint(i), Addr: loopback})
+	}
+	store := &fakeStore{targets: targets}
+	m := monitor.New(store, &monitor.TCPProber{Ports: ports, Timeout: time.Second}, monitor.Options{Concurrency: 4})
+
+	require.NoError(t, m.CheckAll(context.Background()))
+	assert.Len(t, store.saved, 50)
+	for _, r := range store.saved {
+		assert.Equal(t, domain.StatusOnline, r.Status)
+	}
+}
+
+func TestRun_StopsWithContext(t *testing.T) {
+	store := &fakeStore{targets: []domain.ProbeTarget{{CentralID: 1, Addr: loopback}}}
+	m := monitor.New(store, &monitor.TCPProber{Ports: []int{listen(t)}, Timeout: time.Second},
+		monitor.Options{Interval: 10 * time.Millisecond})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		m.Run(ctx)
+		close(done)
+	}()
+
+	assert.Eventually(t, func() bool {
+		store.mu.Lock()
+		defer store.mu.Unlock()
+		return store.rounds >= 2
+	}, time.Second, 5*time.Millisecond)
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("monitor did not stop")
+	}
+}
+
+func TestICMPProber_Loopback(t *testing.T) {
+	prober, err := monitor.NewICMPProber(2, time.Second, 0)
+	if err != nil {
+		t.Skipf("ICMP sockets not permitted: %v", err)
+	}
+	assert.Equal(t, domain.StatusOnline, prober.Probe(context.Background(), loopback).Status)
+}
@@ -0,0 +1,35 @@
+package monitor
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"strconv"
+	"time"
+)
+
+// Sonda por conexão TCP às portas configuradas. A central está online
+// quando todas aceitam conexão e degradada quando só parte delas aceita.
+type TCPProber struct {
+	Ports   []int
+	Timeout time.Duration
+	// Latência média acima da qual a central é considerada degradada
+	DegradedRTT time.Duration
+}
+
+func (p *TCPProber) Probe(ctx context.Context, addr netip.Addr) Result {
+	dialer := net.Dialer{Timeout: p.Timeout}
+	answered := 0
+	var total time.Duration
+	for _, port := range p.Ports {
+		start := time.Now()
+		conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(addr.String(), strconv.Itoa(port)))
+		if err != nil {
+			continue
+		}
+		total += time.Since(start)
+		answered++
+		conn.Close()
+	}
+	return classify(answered, len(p.Ports), total, p.DegradedRTT)
+}
@@ -0,0 +1,129 @@
+package openapi
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+)
+
+//go:embed openapi.yaml
+var spec []byte
+
+// Options controla o que o middleware valida
+type Options struct {
+	// ValidateResponses também confere as respostas dos handlers contra o
+	// contrato. Pensado para testes: uma divergência vira erro 500.
+	ValidateResponses bool
+}
+
+// Load carrega e valida o documento OpenAPI embutido
+func Load() (*openapi3.T, error) {
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData(spec)
+	if err != nil {
+		return nil, err
+	}
+	if err := doc.Validate(context.Background()); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// Spec retorna o documento OpenAPI embutido, sem processamento
+func Spec() []byte {
+	return spec
+}
+
+// Middleware valida as requisições (e opcionalmente as respostas) contra o
+// documento OpenAPI. Rotas que não constam no documento passam direto.
+func Middleware(doc *openapi3.T, opts Options) (fiber.Handler, error) {
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(c *fiber.Ctx) error {
+		req, err := adaptor.ConvertRequest(c, false)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		route, pathParams, err := router.FindRoute(req)
+		if errors.Is(err, routers.ErrPathNotFound) || errors.Is(err, routers.ErrMethodNotAllowed) {
+			return c.Next()
+		}
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		input := &openapi3filter.RequestValidationInput{
+			Request:    req,
+			PathParams: pathParams,
+			Route:      route,
+			Options:    &openapi3filter.Options{AuthenticationFunc: openapi3filter.NoopAuthenticationFunc},
+		}
+		if err := openapi3filter.ValidateRequest(c.UserContext(), input); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": requestErrorMessage(err)})
+		}
+
+		if err := c.Next(); err != nil || !opts.ValidateResponses {
+			return err
+		}
+		// Respostas em stream (SSE) não têm corpo completo para conferir
+		if c.Response().IsBodyStream() {
+			return nil
+		}
+
+		// Confere a resposta já escrita pelo handler
+		header := http.Header{}
+		c.Response().Header.VisitAll(func(key, value []byte) {
+			header.Add(string(key), string(value))
+		})
+		output := &openapi3filter.ResponseValidationInput{
+			RequestValidationInput: input,
+			Status:                 c.Response().StatusCode(),
+			Header:                 header,
+			Body:                   io.NopCloser(bytes.NewReader(c.Response().Body())),
+			Options:                &openapi3filter.Options{IncludeResponseStatus: true},
+		}
+		if err := openapi3filter.ValidateResponse(c.UserContext(), output); err != nil {
+			c.Response().Reset()
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "response does not match contract: " + err.Error(),
+			})
+		}
+		return nil
+	}, nil
+}
+
+// Mensagem curta para erros de requisição, sem o dump do schema
+func requestErrorMessage(err error) string {
+	var reqErr *openapi3filter.RequestError
+	if !errors.As(err, &reqErr) {
+		return err.Error()
+	}
+	reason := reqErr.Error()
+	var schemaErr *openapi3.SchemaError
+	if errors.As(reqErr.Err, &schemaErr) {
+		reason = schemaErr.Reason
+		if path := schemaErr.JSONPointer(); len(path) > 0 {
+			reason = fmt.Sprintf("field %s: %s", strings.Join(path, "."), reason)
+		}
+	}
+	if reqErr.Parameter != nil {
+		return fmt.Sprintf("invalid parameter %s: %s", reqErr.Parameter.Name, reason)
+	}
+	return "invalid payload: " + reason
+}
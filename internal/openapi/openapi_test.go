@@ -0,0 +1,92 @@
+package openapi_test
+
+import (
+	"api-golang/internal/openapi"
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Monta um app com o middleware e um handler fixo para cada rota
+func setupApp(t *testing.T, opts openapi.Options, centralBody fiber.Map) *fiber.App {
+	doc, err := openapi.Load()
+	require.NoError(t, err)
+	validator, err := openapi.Middleware(doc, opts)
+	require.NoError(t, err)
+
+	app := fiber.New()
+	app.Use(validator)
+	app.Post("/central", func(c *fiber.Ctx) error {
+		return c.Status(fiber.StatusCreated).JSON(centralBody)
+	})
+	app.Get("/health", func(c *fiber.Ctx) error {
+		return c.SendString("ok")
+	})
+	return app
+}
+
+var validCentral = fiber.Map{
+	"id":         1,
+	"name":       "Central 1",
+	"mac":        "00:11:22:33:44:55",
+	"ip":         "192.168.0.1",
+	"created_at": "2024-01-01T00:00:00Z",
+	"updated_at": "2024-01-01T00:00:00Z",
+}
+
+func post(app *fiber.App, body string) *http.Response {
+	req := httptest.NewRequest(http.MethodPost, "/central", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req, -1)
+	return resp
+}
+
+func TestLoad(t *testing.T) {
+	doc, err := openapi.Load()
+	assert.NoError(t, err)
+	assert.NotNil(t, doc.Paths.Find("/central/{id}"))
+}
+
+func TestMiddleware_ValidRequest(t *testing.T) {
+	app := setupApp(t, openapi.Options{ValidateResponses: true}, validCentral)
+
+	resp := post(app, `{"name":"Central 1","mac":"00:11:22:33:44:55","ip":"192.168.0.1"}`)
+
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+}
+
+func TestMiddleware_InvalidRequest(t *testing.T) {
+	app := setupApp(t, openapi.Options{}, validCentral)
+
+	// Falta o campo obrigatório "mac"
+	resp := post(app, `{"name":"Central 1","ip":"192.168.0.1"}`)
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestMiddleware_ResponseDrift(t *testing.T) {
+	// Resposta sem o campo "id" exigido pelo contrato
+	drifted := fiber.Map{"name": "Central 1", "mac": "00:11:22:33:44:55", "ip": "192.168.0.1"}
+	body := `{"name":"Central 1","mac":"00:11:22:33:44:55","ip":"192.168.0.1"}`
+
+	// Sem validação de resposta a divergência passa despercebida
+	app := setupApp(t, openapi.Options{}, drifted)
+	assert.Equal(t, http.StatusCreated, post(app, body).StatusCode)
+
+	app = setupApp(t, openapi.Options{ValidateResponses: true}, drifted)
+	assert.Equal(t, http.StatusInternalServerError, post(app, body).StatusCode)
+}
+
+func TestMiddleware_UnknownRoute(t *testing.T) {
+	app := setupApp(t, openapi.Options{ValidateResponses: true}, validCentral)
+
+	req := httptest.NewRequest(http.MethodGet, "/health", nil)
+	resp, _ := app.Test(req, -1)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
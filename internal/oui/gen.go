@@ -0,0 +1,89 @@
+//go:build ignore
+
+// Gera oui.tsv.gz a partir do registro MA-L do IEEE. Uso:
+//
+//	go generate ./internal/oui
+//	go run gen.go -in oui.txt -out oui.tsv.gz   # a partir de um arquivo já baixado
+package main
+
+import (
+	"api-golang/internal/oui"
+	"compress/gzip"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"time"
+)
+
+const ieeeURL = "https://standards-oui.ieee.org/oui/oui.txt"
+
+func main() {
+	source := flag.String("url", ieeeURL, "URL do oui.txt do IEEE")
+	in := flag.String("in", "", "oui.txt local, no lugar do download")
+	out := flag.String("out", "oui.tsv.gz", "arquivo gerado")
+	// O registro completo tem dezenas de milhares de prefixos; menos que
+	// isso indica um download truncado ou uma página de erro
+	minEntries := flag.Int("min", 30000, "mínimo de prefixos aceito")
+	flag.Parse()
+
+	registry, err := load(*source, *in)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if registry.Len() < *minEntries {
+		log.Fatalf("only %d OUI entries found, expected at least %d", registry.Len(), *minEntries)
+	}
+	if err := write(*out, registry); err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("%s: %d vendor prefixes", *out, registry.Len())
+}
+
+func load(source, in string) (*oui.Registry, error) {
+	if in != "" {
+		return oui.LoadFile(in)
+	}
+	req, err := http.NewRequest(http.MethodGet, source, nil)
+	if err != nil {
+		return nil, err
+	}
+	// O servidor do IEEE recusa clientes sem User-Agent de navegador
+	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; oui-gen)")
+	client := &http.Client{Timeout: 2 * time.Minute}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", source, resp.Status)
+	}
+	return oui.Parse(resp.Body)
+}
+
+// Grava num arquivo temporário e renomeia, para não deixar a base pela metade
+func write(path string, registry *oui.Registry) error {
+	tmp, err := os.CreateTemp(".", "oui-*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	zw, err := gzip.NewWriterLevel(tmp, gzip.BestCompression)
+	if err != nil {
+		return err
+	}
+	// Sem data no cabeçalho, a mesma entrada gera o mesmo arquivo
+	zw.ModTime = time.Time{}
+	if err := registry.WriteCompact(zw); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
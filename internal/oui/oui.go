@@ -0,0 +1,210 @@
+// Package oui identifica o fabricante de um MAC pelo OUI (os três primeiros
+// bytes), usando o registro MA-L publicado pelo IEEE.
+//
+// A base embutida, oui.tsv.gz, é gerada a partir do oui.txt do IEEE por
+// gen.go numa forma compacta (prefixo e fabricante por linha, comprimida).
+// Para atualizá-la, rode "go generate ./internal/oui" e recompile, ou aponte
+// OUI_FILE para um oui.txt baixado.
+package oui
+
+//go:generate go run gen.go -out oui.tsv.gz
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	_ "embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+)
+
+//go:embed oui.tsv.gz
+var embedded []byte
+
+// Prefixo de 24 bits atribuído pelo IEEE
+type Prefix [3]byte
+
+// Bits do primeiro byte do MAC
+const (
+	multicastBit = 0x01 // endereço de grupo
+	localBit     = 0x02 // administrado localmente
+)
+
+func (p Prefix) String() string {
+	return fmt.Sprintf("%02x:%02x:%02x", p[0], p[1], p[2])
+}
+
+// Indica se o bit U/L marca o endereço como administrado localmente, ou
+// seja, fora da numeração do IEEE
+func (p Prefix) LocallyAdministered() bool {
+	return p[0]&localBit != 0
+}
+
+// Indica se o bit I/G marca um endereço de grupo (multicast ou broadcast)
+func (p Prefix) Multicast() bool {
+	return p[0]&multicastBit != 0
+}
+
+// Converte o prefixo em qualquer notação comum de MAC (00:50:56, 00-50-56,
+// 0050.56, 005056) ou extrai o prefixo de um MAC completo
+func ParsePrefix(value string) (Prefix, error) {
+	digits := strings.NewReplacer(":", "", "-", "", ".", "").Replace(strings.TrimSpace(value))
+	if len(digits) != 6 && len(digits) != 12 {
+		return Prefix{}, fmt.Errorf("invalid OUI %q", value)
+	}
+	raw, err := hex.DecodeString(digits[:6])
+	if err != nil {
+		return Prefix{}, fmt.Errorf("invalid OUI %q", value)
+	}
+	if len(digits) == 12 {
+		if _, err := hex.DecodeString(digits[6:]); err != nil {
+			return Prefix{}, fmt.Errorf("invalid OUI %q", value)
+		}
+	}
+	return Prefix(raw), nil
+}
+
+// Base de fabricantes indexada pelo OUI
+type Registry struct {
+	vendors map[Prefix]string
+}
+
+// Lê o formato oui.txt do IEEE, usando as linhas "XX-XX-XX   (hex)   Fabricante".
+// As demais linhas (cabeçalho, "(base 16)" e endereços) são ignoradas.
+func Parse(r io.Reader) (*Registry, error) {
+	registry := &Registry{vendors: make(map[Prefix]string)}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		prefixPart, vendor, ok := strings.Cut(line, "(hex)")
+		if !ok {
+			continue
+		}
+		raw, err := hex.DecodeString(strings.ReplaceAll(strings.TrimSpace(prefixPart), "-", ""))
+		if err != nil || len(raw) != 3 {
+			return nil, fmt.Errorf("invalid OUI line %q", line)
+		}
+		registry.vendors[Prefix(raw)] = strings.TrimSpace(vendor)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(registry.vendors) == 0 {
+		return nil, errors.New("no OUI entries found")
+	}
+	return registry, nil
+}
+
+// Lê a forma compacta gerada por WriteCompact: "XXXXXX<tab>Fabricante"
+func ParseCompact(r io.Reader) (*Registry, error) {
+	registry := &Registry{vendors: make(map[Prefix]string)}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		prefixPart, vendor, ok := strings.Cut(line, "\t")
+		raw, err := hex.DecodeString(prefixPart)
+		if !ok || err != nil || len(raw) != 3 {
+			return nil, fmt.Errorf("invalid OUI line %q", line)
+		}
+		registry.vendors[Prefix(raw)] = vendor
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(registry.vendors) == 0 {
+		return nil, errors.New("no OUI entries found")
+	}
+	return registry, nil
+}
+
+// Grava a base na forma compacta, ordenada pelo prefixo
+func (r *Registry) WriteCompact(w io.Writer) error {
+	prefixes := make([]Prefix, 0, len(r.vendors))
+	for prefix := range r.vendors {
+		prefixes = append(prefixes, prefix)
+	}
+	slices.SortFunc(prefixes, func(a, b Prefix) int { return bytes.Compare(a[:], b[:]) })
+	bw := bufio.NewWriter(w)
+	for _, prefix := range prefixes {
+		fmt.Fprintf(bw, "%X\t%s\n", prefix[:], r.vendors[prefix])
+	}
+	return bw.Flush()
+}
+
+// Carrega uma versão mais nova do arquivo do IEEE
+func LoadFile(path string) (*Registry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Parse(f)
+}
+
+var (
+	embeddedOnce     sync.Once
+	embeddedRegistry *Registry
+)
+
+// Base do arquivo informado ou, sem ele, a embutida. É como o servidor e o
+// centralctl escolhem a base, para que atribuam os mesmos fabricantes.
+func Load(path string) (*Registry, error) {
+	if path == "" {
+		return Embedded(), nil
+	}
+	return LoadFile(path)
+}
+
+// Base embutida no binário
+func Embedded() *Registry {
+	embeddedOnce.Do(func() {
+		registry, err := parseEmbedded()
+		if err != nil {
+			panic(fmt.Sprintf("embedded oui.tsv.gz: %v", err))
+		}
+		embeddedRegistry = registry
+	})
+	return embeddedRegistry
+}
+
+func parseEmbedded() (*Registry, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(embedded))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	return ParseCompact(zr)
+}
+
+// Quantidade de OUIs conhecidos
+func (r *Registry) Len() int {
+	return len(r.vendors)
+}
+
+// Fabricante registrado para o prefixo. Endereços de grupo usam o OUI do
+// fabricante com o bit I/G ligado, então ele é ignorado na busca.
+func (r *Registry) Lookup(prefix Prefix) (string, bool) {
+	if prefix.LocallyAdministered() {
+		return "", false
+	}
+	prefix[0] &^= multicastBit
+	vendor, ok := r.vendors[prefix]
+	return vendor, ok
+}
+
+// Fabricante de um MAC canônico, ou vazio quando desconhecido
+func (r *Registry) VendorOf(mac string) string {
+	hw, err := net.ParseMAC(mac)
+	if err != nil || len(hw) < 3 {
+		return ""
+	}
+	vendor, _ := r.Lookup(Prefix(hw[:3]))
+	return vendor
+}
@@ -0,0 +1,91 @@
+package oui_test
+
+import (
+	"api-golang/internal/oui"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmbedded(t *testing.T) {
+	registry := oui.Embedded()
+	assert.Greater(t, registry.Len(), 0)
+
+	vendor, ok := registry.Lookup(oui.Prefix{0x00, 0x50, 0x56})
+	assert.True(t, ok)
+	assert.Equal(t, "VMware, Inc.", vendor)
+
+	assert.Equal(t, "Raspberry Pi Foundation", registry.VendorOf("b8:27:eb:12:34:56"))
+	assert.Equal(t, "Cisco Systems, Inc", registry.VendorOf("00:00:0c:07:ac:01"))
+	assert.Empty(t, registry.VendorOf("fc:ff:ff:00:00:01"))
+}
+
+func TestLookup_GroupAndLocalBits(t *testing.T) {
+	registry := oui.Embedded()
+
+	// Endereço de grupo derivado do OUI da Cisco (ex.: CDP)
+	vendor, ok := registry.Lookup(oui.Prefix{0x01, 0x00, 0x0c})
+	assert.True(t, ok)
+	assert.Equal(t, "Cisco Systems, Inc", vendor)
+
+	// Administrado localmente não pertence a nenhum fabricante
+	_, ok = registry.Lookup(oui.Prefix{0x02, 0x00, 0x0c})
+	assert.False(t, ok)
+
+	assert.True(t, oui.Prefix{0x02, 0x11, 0x22}.LocallyAdministered())
+	assert.False(t, oui.Prefix{0x02, 0x11, 0x22}.Multicast())
+	assert.True(t, oui.Prefix{0x01, 0x00, 0x5e}.Multicast())
+	assert.Equal(t, "00:50:56", oui.Prefix{0x00, 0x50, 0x56}.String())
+}
+
+func TestParse(t *testing.T) {
+	registry, err := oui.Parse(strings.NewReader(`OUI/MA-L     Organization
+
+3C-BB-CC   (hex)		Exemplo Ltda
+3CBBCC     (base 16)		Exemplo Ltda
+				Rua A, 1
+				BR
+`))
+	require.NoError(t, err)
+	assert.Equal(t, 1, registry.Len())
+	assert.Equal(t, "Exemplo Ltda", registry.VendorOf("3c:bb:cc:00:00:01"))
+
+	_, err = oui.Parse(strings.NewReader("ZZ-BB-CC   (hex)		Inválido\n"))
+	assert.Error(t, err)
+	_, err = oui.Parse(strings.NewReader("sem registros\n"))
+	assert.Error(t, err)
+}
+
+func TestCompact_RoundTrip(t *testing.T) {
+	registry, err := oui.Parse(strings.NewReader("3C-BB-CC   (hex)\t\tExemplo Ltda\n00-50-56   (hex)\t\tVMware, Inc.\n"))
+	require.NoError(t, err)
+
+	var buf strings.Builder
+	require.NoError(t, registry.WriteCompact(&buf))
+	// Ordenado pelo prefixo, um por linha
+	assert.Equal(t, "005056\tVMware, Inc.\n3CBBCC\tExemplo Ltda\n", buf.String())
+
+	compact, err := oui.ParseCompact(strings.NewReader(buf.String()))
+	require.NoError(t, err)
+	assert.Equal(t, 2, compact.Len())
+	assert.Equal(t, "Exemplo Ltda", compact.VendorOf("3c:bb:cc:00:00:01"))
+
+	_, err = oui.ParseCompact(strings.NewReader("3CBBCC Exemplo\n"))
+	assert.Error(t, err)
+	_, err = oui.ParseCompact(strings.NewReader(""))
+	assert.Error(t, err)
+}
+
+func TestParsePrefix(t *testing.T) {
+	for _, input := range []string{"00:50:56", "00-50-56", "0050.56", "005056", "00:50:56:c0:00:01", "0050.56c0.0001"} {
+		prefix, err := oui.ParsePrefix(input)
+		assert.NoError(t, err, input)
+		assert.Equal(t, oui.Prefix{0x00, 0x50, 0x56}, prefix, input)
+	}
+	for _, input := range []string{"", "0050", "00:50:5g", "00:50:56:c0:00", "00:50:56:c0:00:zz"} {
+		_, err := oui.ParsePrefix(input)
+		assert.Error(t, err, input)
+	}
+}
@@ -0,0 +1,157 @@
+// Package outbox publica os eventos gravados no outbox nos destinos
+// configurados (webhooks, NATS, log), pelo menos uma vez e na ordem de
+// gravação de cada central.
+package outbox
+
+import (
+	"api-golang/internal/domain"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Fila de eventos do outbox
+type Store interface {
+	// Pendentes, sem as centrais retidas por uma falha até now
+	PendingEvents(limit int, now time.Time) ([]domain.Event, error)
+	MarkPublished(ids []uint, now time.Time) error
+	RecordFailure(id uint, reason string, retryAt time.Time) error
+}
+
+// Destino dos eventos. Um mesmo evento pode chegar mais de uma vez, então o
+// destino deve tolerar repetições pelo ID.
+type Sink interface {
+	Name() string
+	Publish(ctx context.Context, event domain.Event) error
+}
+
+type Options struct {
+	// Eventos lidos por vez
+	BatchSize int
+	// Centrais publicadas simultaneamente
+	Concurrency int
+	// Tempo que uma central fica retida depois de uma falha; enquanto isso,
+	// os lotes seguem com as demais
+	RetryDelay time.Duration
+}
+
+const (
+	defaultBatchSize   = 100
+	defaultConcurrency = 8
+	defaultRetryDelay  = 30 * time.Second
+)
+
+type Relay struct {
+	Store   Store
+	Sinks   []Sink
+	Options Options
+
+	// Relógio, substituível nos testes
+	Now func() time.Time
+}
+
+func New(store Store, sinks []Sink, opts Options) *Relay {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaultBatchSize
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = defaultConcurrency
+	}
+	if opts.RetryDelay <= 0 {
+		opts.RetryDelay = defaultRetryDelay
+	}
+	return &Relay{Store: store, Sinks: sinks, Options: opts, Now: time.Now}
+}
+
+// Publica os eventos pendentes até esvaziar o outbox, parar de avançar ou o
+// contexto ser cancelado. Retorna quantos foram publicados.
+func (r *Relay) RelayPending(ctx context.Context) (int, error) {
+	total := 0
+	for ctx.Err() == nil {
+		events, err := r.Store.PendingEvents(r.Options.BatchSize, r.Now().UTC())
+		if err != nil {
+			return total, err
+		}
+		published, held, err := r.relayBatch(ctx, events)
+		total += published
+		if err != nil {
+			return total, err
+		}
+		// As centrais retidas saem da próxima leitura, então o lote seguinte
+		// avança mesmo quando este não publicou nada
+		if len(events) < r.Options.BatchSize || published+held == 0 {
+			break
+		}
+	}
+	return total, nil
+}
+
+// Publica as centrais em paralelo e os eventos de cada central em ordem. Na
+// primeira falha, a central fica retida por RetryDelay com os eventos
+// seguintes, para que nenhum passe à frente do que falhou. Retorna quantos
+// eventos foram publicados e quantas centrais ficaram retidas.
+func (r *Relay) relayBatch(ctx context.Context, events []domain.Event) (int, int, error) {
+	var order []uint
+	byCentral := make(map[uint][]domain.Event)
+	for _, event := range events {
+		if _, ok := byCentral[event.CentralID]; !ok {
+			order = append(order, event.CentralID)
+		}
+		byCentral[event.CentralID] = append(byCentral[event.CentralID], event)
+	}
+
+	var (
+		mu        sync.Mutex
+		published []uint
+		held      int
+		storeErr  error
+		wg        sync.WaitGroup
+	)
+	sem := make(chan struct{}, r.Options.Concurrency)
+	for _, centralID := range order {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(events []domain.Event) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			for _, event := range events {
+				if err := r.publish(ctx, event); err != nil {
+					if ctx.Err() != nil {
+						return
+					}
+					retryAt := r.Now().UTC().Add(r.Options.RetryDelay)
+					err := r.Store.RecordFailure(event.ID, err.Error(), retryAt)
+					mu.Lock()
+					if err != nil {
+						storeErr = err
+					} else {
+						held++
+					}
+					mu.Unlock()
+					return
+				}
+				mu.Lock()
+				published = append(published, event.ID)
+				mu.Unlock()
+			}
+		}(byCentral[centralID])
+	}
+	wg.Wait()
+
+	if err := r.Store.MarkPublished(published, r.Now().UTC()); err != nil {
+		return 0, 0, err
+	}
+	return len(published), held, storeErr
+}
+
+// Entrega o evento a todos os destinos. Se um falhar, o evento é repetido
+// em todos na próxima rodada.
+func (r *Relay) publish(ctx context.Context, event domain.Event) error {
+	for _, sink := range r.Sinks {
+		if err := sink.Publish(ctx, event); err != nil {
+			return fmt.Errorf("%s: %w", sink.Name(), err)
+		}
+	}
+	return nil
+}
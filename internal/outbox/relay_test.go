@@ -0,0 +1,228 @@
+package outbox_test
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/outbox"
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Outbox em memória
+type fakeStore struct {
+	mu        sync.Mutex
+	events    []domain.Event
+	published map[uint]bool
+	failures  map[uint][]string
+	retryAt   map[uint]time.Time
+}
+
+func newFakeStore(events ...domain.Event) *fakeStore {
+	return &fakeStore{events: events, published: map[uint]bool{}, failures: map[uint][]string{}, retryAt: map[uint]time.Time{}}
+}
+
+func (s *fakeStore) PendingEvents(limit int, now time.Time) ([]domain.Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var pending []domain.Event
+	held := make(map[uint]bool)
+	for _, e := range s.events {
+		if s.published[e.ID] {
+			continue
+		}
+		if retryAt, ok := s.retryAt[e.ID]; ok && retryAt.After(now) {
+			held[e.CentralID] = true
+		}
+		if !held[e.CentralID] && len(pending) < limit {
+			pending = append(pending, e)
+		}
+	}
+	return pending, nil
+}
+
+func (s *fakeStore) MarkPublished(ids []uint, now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, id := range ids {
+		s.published[id] = true
+	}
+	return nil
+}
+
+func (s *fakeStore) RecordFailure(id uint, reason string, retryAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures[id] = append(s.failures[id], reason)
+	s.retryAt[id] = retryAt
+	return nil
+}
+
+// Destino que guarda os eventos recebidos e falha nos IDs marcados
+type recordingSink struct {
+	mu       sync.Mutex
+	received []domain.Event
+	failing  map[uint]bool
+}
+
+func (s *recordingSink) Name() string { return "recording" }
+
+func (s *recordingSink) Publish(_ context.Context, event domain.Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failing[event.ID] {
+		return errors.New("unavailable")
+	}
+	s.received = append(s.received, event)
+	return nil
+}
+
+// IDs recebidos por central, na ordem de chegada
+func (s *recordingSink) byCentral() map[uint][]uint {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	result := make(map[uint][]uint)
+	for _, e := range s.received {
+		result[e.CentralID] = append(result[e.CentralID], e.ID)
+	}
+	return result
+}
+
+// Eventos intercalados de várias centrais
+func interleaved(centrals, perCentral int) []domain.Event {
+	var events []domain.Event
+	for i := 0; i < perCentral; i++ {
+		for c := 1; c <= centrals; c++ {
+			events = append(events, domain.Event{ID: uint(len(events) + 1), Type: domain.EventCentralUpdated, CentralID: uint(c)})
+		}
+	}
+	return events
+}
+
+func TestRelayPending_OrderPerCentral(t *testing.T) {
+	store := newFakeStore(interleaved(20, 15)...)
+	sink := &recordingSink{}
+	relay := outbox.New(store, []outbox.Sink{sink}, outbox.Options{BatchSize: 32, Concurrency: 4})
+
+	published, err := relay.RelayPending(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 300, published)
+
+	received := sink.byCentral()
+	assert.Len(t, received, 20)
+	for centralID, ids := range received {
+		assert.Len(t, ids, 15)
+		assert.True(t, sort.SliceIsSorted(ids, func(i, j int) bool { return ids[i] < ids[j] }), "central %d: %v", centralID, ids)
+	}
+}
+
+func TestRelayPending_FailureHoldsCentral(t *testing.T) {
+	store := newFakeStore(interleaved(3, 3)...)
+	// Evento 2 é o primeiro da central 2
+	sink := &recordingSink{failing: map[uint]bool{2: true}}
+	relay := outbox.New(store, []outbox.Sink{&outbox.LogSink{}, sink}, outbox.Options{RetryDelay: time.Minute})
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	relay.Now = func() time.Time { return now }
+
+	published, err := relay.RelayPending(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 6, published)
+	// Nada da central 2 passa à frente do evento que falhou
+	assert.NotContains(t, sink.byCentral(), uint(2))
+	assert.Equal(t, []string{"recording: unavailable"}, store.failures[2])
+
+	// Retida até o fim da espera
+	sink.failing = nil
+	published, err = relay.RelayPending(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, published)
+
+	// Pelo menos uma vez: depois da espera, publica o que ficou, em ordem
+	now = now.Add(time.Minute)
+	published, err = relay.RelayPending(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 3, published)
+	assert.Equal(t, []uint{2, 5, 8}, sink.byCentral()[2])
+}
+
+func TestRelayPending_FailingCentralDoesNotStarve(t *testing.T) {
+	// A central 1 acumula mais eventos que um lote inteiro, todos atrás de
+	// um que nunca é aceito
+	var events []domain.Event
+	for i := 1; i <= 10; i++ {
+		events = append(events, domain.Event{ID: uint(i), Type: domain.EventCentralUpdated, CentralID: 1})
+	}
+	for i := 11; i <= 20; i++ {
+		events = append(events, domain.Event{ID: uint(i), Type: domain.EventCentralUpdated, CentralID: uint(2 + i%2)})
+	}
+	store := newFakeStore(events...)
+	sink := &recordingSink{failing: map[uint]bool{1: true}}
+	relay := outbox.New(store, []outbox.Sink{sink}, outbox.Options{BatchSize: 4, RetryDelay: time.Minute})
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	relay.Now = func() time.Time { return now }
+
+	published, err := relay.RelayPending(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 10, published)
+	received := sink.byCentral()
+	assert.NotContains(t, received, uint(1))
+	assert.Len(t, received[2], 5)
+	assert.Len(t, received[3], 5)
+
+	// A cada espera, só o evento retido é tentado de novo
+	for round := 0; round < 3; round++ {
+		now = now.Add(time.Minute)
+		published, err = relay.RelayPending(context.Background())
+		require.NoError(t, err)
+		assert.Zero(t, published)
+	}
+	assert.Len(t, store.failures[1], 4)
+	assert.Empty(t, store.failures[2])
+}
+
+// Conexão NATS falsa
+type fakeNATS struct {
+	msgs    []*nats.Msg
+	flushes int
+}
+
+func (c *fakeNATS) PublishMsg(msg *nats.Msg) error {
+	c.msgs = append(c.msgs, msg)
+	return nil
+}
+
+func (c *fakeNATS) FlushTimeout(time.Duration) error {
+	c.flushes++
+	return nil
+}
+
+func TestNATSSink(t *testing.T) {
+	conn := &fakeNATS{}
+	sink := &outbox.NATSSink{Conn: conn, Prefix: "centrals"}
+
+	change := domain.StatusChange{CentralID: 3, From: domain.StatusOnline, To: domain.StatusOffline, At: time.Now()}
+	event := change.Event()
+	event.ID = 42
+	require.NoError(t, sink.Publish(context.Background(), event))
+
+	require.Len(t, conn.msgs, 1)
+	msg := conn.msgs[0]
+	assert.Equal(t, "centrals.central.status_changed", msg.Subject)
+	assert.Equal(t, "42", msg.Header.Get(nats.MsgIdHdr))
+	assert.Equal(t, 1, conn.flushes)
+
+	var payload struct {
+		ID        string `json:"id"`
+		CentralID uint   `json:"central_id"`
+	}
+	require.NoError(t, json.Unmarshal(msg.Data, &payload))
+	assert.Equal(t, "42", payload.ID)
+	assert.Equal(t, uint(3), payload.CentralID)
+}
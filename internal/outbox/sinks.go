@@ -0,0 +1,77 @@
+package outbox
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/webhook"
+	"context"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+// Registra cada evento no log
+type LogSink struct {
+	Logger *log.Logger
+}
+
+func (s *LogSink) Name() string { return "log" }
+
+func (s *LogSink) Publish(_ context.Context, event domain.Event) error {
+	logger := s.Logger
+	if logger == nil {
+		logger = log.Default()
+	}
+	logger.Printf("Event %d: %s of central %d", event.ID, event.Type, event.CentralID)
+	return nil
+}
+
+// Enfileira as entregas das assinaturas de webhook. O ID do outbox
+// identifica o evento, então repetições não duplicam entregas.
+type WebhookSink struct {
+	Dispatcher *webhook.Dispatcher
+}
+
+func (s *WebhookSink) Name() string { return "webhook" }
+
+func (s *WebhookSink) Publish(_ context.Context, event domain.Event) error {
+	return s.Dispatcher.Publish(event)
+}
+
+// Parte da conexão NATS usada pelo destino; satisfeita por *nats.Conn
+type NATSPublisher interface {
+	PublishMsg(msg *nats.Msg) error
+	FlushTimeout(timeout time.Duration) error
+}
+
+// Publica cada evento no assunto "<prefixo>.<tipo>", ex.
+// "centrals.central.created", com o mesmo corpo dos webhooks. O cabeçalho
+// Nats-Msg-Id permite ao JetStream descartar repetições.
+type NATSSink struct {
+	Conn    NATSPublisher
+	Prefix  string
+	Timeout time.Duration
+}
+
+func (s *NATSSink) Name() string { return "nats" }
+
+func (s *NATSSink) Publish(_ context.Context, event domain.Event) error {
+	id := strconv.FormatUint(uint64(event.ID), 10)
+	payload, err := webhook.Encode(id, event)
+	if err != nil {
+		return err
+	}
+	msg := nats.NewMsg(s.Prefix + "." + string(event.Type))
+	msg.Header.Set(nats.MsgIdHdr, id)
+	msg.Data = payload
+	if err := s.Conn.PublishMsg(msg); err != nil {
+		return err
+	}
+	// Só considera publicado depois de o servidor receber
+	timeout := s.Timeout
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+	return s.Conn.FlushTimeout(timeout)
+}
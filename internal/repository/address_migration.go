@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"api-golang/internal/utils"
+	"sort"
+
+	"gorm.io/gorm"
+)
+
+// Grupo de centrais cujo endereço só difere na notação
+type AddressDuplicate struct {
+	Field      string
+	Canonical  string
+	CentralIDs []uint
+	Values     []string
+}
+
+// Resultado da migração de normalização de endereços
+type AddressMigrationReport struct {
+	Normalized []uint
+	Duplicates []AddressDuplicate
+	Invalid    []uint
+}
+
+// Endereços canônicos calculados para uma central
+type canonicalAddresses struct {
+	mac, ipv4, ipv6 string
+}
+
+// NormalizeCentralAddresses grava MAC e IPs das centrais existentes na forma
+// canônica. Centrais que colidiriam após a normalização não são alteradas:
+// elas são apenas reportadas para resolução manual.
+func NormalizeCentralAddresses(db *gorm.DB) (AddressMigrationReport, error) {
+	var report AddressMigrationReport
+
+	var models []CentralModel
+	if err := db.Order("id").Find(&models).Error; err != nil {
+		return report, err
+	}
+
+	canonical := make(map[uint]canonicalAddresses, len(models))
+	for _, m := range models {
+		addrs, ok := canonicalize(m)
+		if !ok {
+			report.Invalid = append(report.Invalid, m.ID)
+			continue
+		}
+		canonical[m.ID] = addrs
+	}
+
+	report.Duplicates = append(report.Duplicates, findDuplicates("mac", models, canonical,
+		func(a canonicalAddresses) string { return a.mac }, func(m CentralModel) string { return m.MAC })...)
+	report.Duplicates = append(report.Duplicates, findDuplicates("ipv4", models, canonical,
+		func(a canonicalAddresses) string { return a.ipv4 }, func(m CentralModel) string { return rawIP(m, true) })...)
+	report.Duplicates = append(report.Duplicates, findDuplicates("ipv6", models, canonical,
+		func(a canonicalAddresses) string { return a.ipv6 }, func(m CentralModel) string { return rawIP(m, false) })...)
+
+	conflicting := make(map[uint]bool)
+	for _, d := range report.Duplicates {
+		for _, id := range d.CentralIDs {
+			conflicting[id] = true
+		}
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		for _, m := range models {
+			addrs, ok := canonical[m.ID]
+			if !ok || conflicting[m.ID] {
+				continue
+			}
+			if addrs.mac == m.MAC && addrs.ipv4 == stringValue(m.IPv4) && addrs.ipv6 == stringValue(m.IPv6) {
+				continue
+			}
+			updated := m
+			updated.MAC, updated.IPv4, updated.IPv6 = addrs.mac, nullableString(addrs.ipv4), nullableString(addrs.ipv6)
+			updated.setAddressBytes()
+			err := tx.Model(&CentralModel{ID: m.ID}).UpdateColumns(map[string]interface{}{
+				"mac":      updated.MAC,
+				"ipv4":     updated.IPv4,
+				"ipv6":     updated.IPv6,
+				"ipv4_bin": updated.IPv4Bin,
+				"ipv6_bin": updated.IPv6Bin,
+			}).Error
+			if err != nil {
+				return err
+			}
+			if err := syncPrimaryInterface(tx, &m, &updated); err != nil {
+				return err
+			}
+			report.Normalized = append(report.Normalized, m.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		return AddressMigrationReport{}, err
+	}
+	return report, nil
+}
+
+// Calcula a forma canônica, redistribuindo os IPs pela família real
+func canonicalize(m CentralModel) (canonicalAddresses, bool) {
+	mac, err := utils.NormalizeMAC(m.MAC)
+	if err != nil {
+		return canonicalAddresses{}, false
+	}
+	addrs := canonicalAddresses{mac: mac}
+	for _, value := range []*string{m.IPv4, m.IPv6} {
+		if value == nil {
+			continue
+		}
+		ip, err := utils.NormalizeIP(*value)
+		if err != nil {
+			return canonicalAddresses{}, false
+		}
+		target := &addrs.ipv6
+		if ip.Is4() {
+			target = &addrs.ipv4
+		}
+		if *target != "" {
+			return canonicalAddresses{}, false
+		}
+		*target = ip.String()
+	}
+	return addrs, true
+}
+
+// Valor gravado para a família, procurando nas duas colunas
+func rawIP(m CentralModel, v4 bool) string {
+	for _, value := range []*string{m.IPv4, m.IPv6} {
+		if value == nil {
+			continue
+		}
+		if ip, err := utils.NormalizeIP(*value); err == nil && ip.Is4() == v4 {
+			return *value
+		}
+	}
+	return ""
+}
+
+// Agrupa as centrais pelo valor canônico e devolve os grupos com mais de um membro
+func findDuplicates(field string, models []CentralModel, canonical map[uint]canonicalAddresses,
+	value func(canonicalAddresses) string, raw func(CentralModel) string) []AddressDuplicate {
+	groups := make(map[string]*AddressDuplicate)
+	for _, m := range models {
+		addrs, ok := canonical[m.ID]
+		if !ok || value(addrs) == "" {
+			continue
+		}
+		group, ok := groups[value(addrs)]
+		if !ok {
+			group = &AddressDuplicate{Field: field, Canonical: value(addrs)}
+			groups[value(addrs)] = group
+		}
+		group.CentralIDs = append(group.CentralIDs, m.ID)
+		group.Values = append(group.Values, raw(m))
+	}
+
+	var duplicates []AddressDuplicate
+	for _, group := range groups {
+		if len(group.CentralIDs) > 1 {
+			duplicates = append(duplicates, *group)
+		}
+	}
+	sort.Slice(duplicates, func(i, j int) bool {
+		return duplicates[i].Canonical < duplicates[j].Canonical
+	})
+	return duplicates
+}
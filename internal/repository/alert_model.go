@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"api-golang/internal/domain"
+	"time"
+)
+
+// Modelo de persistência da regra de alerta
+type AlertRuleModel struct {
+	ID         uint `gorm:"primaryKey"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	Name       string `gorm:"not null;uniqueIndex"`
+	Kind       string `gorm:"not null"`
+	Severity   string `gorm:"not null"`
+	Enabled    bool   `gorm:"not null"`
+	Selector   string
+	ForSeconds int64 `gorm:"column:for_s;not null"`
+
+	Status string
+
+	Metric        string
+	Aggregation   string
+	Operator      string
+	Threshold     float64
+	WindowSeconds int64 `gorm:"column:window_s;not null"`
+
+	MinFirmware string
+
+	Inhibits []uint `gorm:"serializer:json"`
+}
+
+func (AlertRuleModel) TableName() string {
+	return "alert_rules"
+}
+
+func newAlertRuleModel(rule *domain.AlertRule) *AlertRuleModel {
+	return &AlertRuleModel{
+		ID:            rule.ID,
+		CreatedAt:     rule.CreatedAt,
+		UpdatedAt:     rule.UpdatedAt,
+		Name:          rule.Name,
+		Kind:          string(rule.Kind),
+		Severity:      string(rule.Severity),
+		Enabled:       rule.Enabled,
+		Selector:      rule.Selector,
+		ForSeconds:    int64(rule.For / time.Second),
+		Status:        string(rule.Status),
+		Metric:        rule.Metric,
+		Aggregation:   rule.Aggregation,
+		Operator:      rule.Operator,
+		Threshold:     rule.Threshold,
+		WindowSeconds: int64(rule.Window / time.Second),
+		MinFirmware:   rule.MinFirmware,
+		Inhibits:      rule.Inhibits,
+	}
+}
+
+func (m *AlertRuleModel) toDomain() *domain.AlertRule {
+	return &domain.AlertRule{
+		ID:          m.ID,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+		Name:        m.Name,
+		Kind:        domain.AlertRuleKind(m.Kind),
+		Severity:    domain.AlertSeverity(m.Severity),
+		Enabled:     m.Enabled,
+		Selector:    m.Selector,
+		For:         time.Duration(m.ForSeconds) * time.Second,
+		Status:      domain.ReachabilityStatus(m.Status),
+		Metric:      m.Metric,
+		Aggregation: m.Aggregation,
+		Operator:    m.Operator,
+		Threshold:   m.Threshold,
+		Window:      time.Duration(m.WindowSeconds) * time.Second,
+		MinFirmware: m.MinFirmware,
+		Inhibits:    m.Inhibits,
+	}
+}
+
+// Alertas ativos e o histórico dos resolvidos
+type AlertModel struct {
+	ID        uint   `gorm:"primaryKey"`
+	RuleID    uint   `gorm:"not null;index:idx_alert_rule_central"`
+	CentralID uint   `gorm:"not null;index:idx_alert_rule_central"`
+	State     string `gorm:"not null;index"`
+	Severity  string `gorm:"not null"`
+	Summary   string
+	Value     *float64
+
+	ActiveSince     time.Time `gorm:"not null"`
+	FiredAt         *time.Time
+	ResolvedAt      *time.Time
+	LastEvaluatedAt time.Time `gorm:"not null"`
+
+	Silenced  bool `gorm:"not null"`
+	Inhibited bool `gorm:"not null"`
+}
+
+func (AlertModel) TableName() string {
+	return "alerts"
+}
+
+func newAlertModel(alert *domain.Alert) *AlertModel {
+	return &AlertModel{
+		ID:              alert.ID,
+		RuleID:          alert.RuleID,
+		CentralID:       alert.CentralID,
+		State:           string(alert.State),
+		Severity:        string(alert.Severity),
+		Summary:         alert.Summary,
+		Value:           alert.Value,
+		ActiveSince:     alert.ActiveSince,
+		FiredAt:         alert.FiredAt,
+		ResolvedAt:      alert.ResolvedAt,
+		LastEvaluatedAt: alert.LastEvaluatedAt,
+		Silenced:        alert.Silenced,
+		Inhibited:       alert.Inhibited,
+	}
+}
+
+func (m *AlertModel) toDomain() *domain.Alert {
+	return &domain.Alert{
+		ID:              m.ID,
+		RuleID:          m.RuleID,
+		CentralID:       m.CentralID,
+		State:           domain.AlertState(m.State),
+		Severity:        domain.AlertSeverity(m.Severity),
+		Summary:         m.Summary,
+		Value:           m.Value,
+		ActiveSince:     m.ActiveSince,
+		FiredAt:         m.FiredAt,
+		ResolvedAt:      m.ResolvedAt,
+		LastEvaluatedAt: m.LastEvaluatedAt,
+		Silenced:        m.Silenced,
+		Inhibited:       m.Inhibited,
+	}
+}
+
+// Modelo de persistência do silêncio
+type SilenceModel struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	RuleID    *uint     `gorm:"index"`
+	CentralID *uint     `gorm:"index"`
+	StartsAt  time.Time `gorm:"not null"`
+	EndsAt    time.Time `gorm:"not null;index"`
+	Comment   string
+	CreatedBy string
+}
+
+func (SilenceModel) TableName() string {
+	return "alert_silences"
+}
+
+func newSilenceModel(silence *domain.Silence) *SilenceModel {
+	return &SilenceModel{
+		ID:        silence.ID,
+		CreatedAt: silence.CreatedAt,
+		RuleID:    silence.RuleID,
+		CentralID: silence.CentralID,
+		StartsAt:  silence.StartsAt,
+		EndsAt:    silence.EndsAt,
+		Comment:   silence.Comment,
+		CreatedBy: silence.CreatedBy,
+	}
+}
+
+func (m *SilenceModel) toDomain() *domain.Silence {
+	return &domain.Silence{
+		ID:        m.ID,
+		CreatedAt: m.CreatedAt,
+		RuleID:    m.RuleID,
+		CentralID: m.CentralID,
+		StartsAt:  m.StartsAt,
+		EndsAt:    m.EndsAt,
+		Comment:   m.Comment,
+		CreatedBy: m.CreatedBy,
+	}
+}
@@ -0,0 +1,230 @@
+package repository
+
+import (
+	"api-golang/internal/domain"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Repositório das regras de alerta, dos alertas e dos silêncios
+type AlertRepository struct {
+	DB *gorm.DB
+}
+
+func NewAlertRepository(db *gorm.DB) *AlertRepository {
+	return &AlertRepository{DB: db}
+}
+
+var activeAlertStates = []string{string(domain.AlertPending), string(domain.AlertFiring)}
+
+func (r *AlertRepository) CreateRule(rule *domain.AlertRule) error {
+	model := newAlertRuleModel(rule)
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := checkInhibitedRules(tx, rule.ID, rule.Inhibits); err != nil {
+			return err
+		}
+		return tx.Create(model).Error
+	})
+	if err != nil {
+		return r.translate(err)
+	}
+	*rule = *model.toDomain()
+	return nil
+}
+
+func (r *AlertRepository) GetRules() ([]domain.AlertRule, error) {
+	var models []AlertRuleModel
+	if err := r.DB.Order("id").Find(&models).Error; err != nil {
+		return nil, err
+	}
+	rules := make([]domain.AlertRule, 0, len(models))
+	for i := range models {
+		rules = append(rules, *models[i].toDomain())
+	}
+	return rules, nil
+}
+
+func (r *AlertRepository) GetRuleByID(id uint) (*domain.AlertRule, error) {
+	var model AlertRuleModel
+	if err := r.DB.First(&model, id).Error; err != nil {
+		return nil, r.translate(err)
+	}
+	return model.toDomain(), nil
+}
+
+func (r *AlertRepository) UpdateRule(rule *domain.AlertRule) error {
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&AlertRuleModel{}, rule.ID).Error; err != nil {
+			return err
+		}
+		if err := checkInhibitedRules(tx, rule.ID, rule.Inhibits); err != nil {
+			return err
+		}
+		return tx.Model(&AlertRuleModel{ID: rule.ID}).
+			Select("name", "kind", "severity", "enabled", "selector", "for_s", "status", "metric",
+				"aggregation", "operator", "threshold", "window_s", "min_firmware", "inhibits", "updated_at").
+			Updates(newAlertRuleModel(rule)).Error
+	})
+	if err != nil {
+		return r.translate(err)
+	}
+
+	updated, err := r.GetRuleByID(rule.ID)
+	if err != nil {
+		return err
+	}
+	*rule = *updated
+	return nil
+}
+
+// Remove a regra junto com os seus alertas e silêncios
+func (r *AlertRepository) DeleteRule(id uint) error {
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&AlertRuleModel{}, id).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("rule_id = ?", id).Delete(&AlertModel{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("rule_id = ?", id).Delete(&SilenceModel{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&AlertRuleModel{}, id).Error
+	})
+	return r.translate(err)
+}
+
+// Alertas ativos por padrão; os resolvidos vêm do mais recente ao mais antigo
+func (r *AlertRepository) GetAlerts(filter domain.AlertFilter) ([]domain.Alert, error) {
+	query := r.DB.Model(&AlertModel{})
+	if filter.State == "" {
+		query = query.Where("state IN ?", activeAlertStates).Order("active_since, id")
+	} else {
+		query = query.Where("state = ?", string(filter.State))
+		if filter.State == domain.AlertResolved {
+			query = query.Order("resolved_at DESC, id DESC")
+		} else {
+			query = query.Order("active_since, id")
+		}
+	}
+	if filter.RuleID != 0 {
+		query = query.Where("rule_id = ?", filter.RuleID)
+	}
+	if filter.CentralID != 0 {
+		query = query.Where("central_id = ?", filter.CentralID)
+	}
+
+	var models []AlertModel
+	if err := query.Find(&models).Error; err != nil {
+		return nil, err
+	}
+	alerts := make([]domain.Alert, 0, len(models))
+	for i := range models {
+		alerts = append(alerts, *models[i].toDomain())
+	}
+	return alerts, nil
+}
+
+// Grava o resultado de uma avaliação: os alertas novos ou alterados e a
+// remoção dos pendentes cuja condição deixou de valer
+func (r *AlertRepository) SaveAlerts(alerts []domain.Alert, removed []uint) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		for i := range alerts {
+			model := newAlertModel(&alerts[i])
+			if err := tx.Save(model).Error; err != nil {
+				return err
+			}
+			alerts[i].ID = model.ID
+		}
+		if len(removed) == 0 {
+			return nil
+		}
+		return tx.Where("id IN ?", removed).Delete(&AlertModel{}).Error
+	})
+}
+
+func (r *AlertRepository) CreateSilence(silence *domain.Silence) error {
+	model := newSilenceModel(silence)
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		if silence.RuleID != nil {
+			if err := tx.First(&AlertRuleModel{}, *silence.RuleID).Error; errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("%w: alert rule %d not found", domain.ErrInvalid, *silence.RuleID)
+			} else if err != nil {
+				return err
+			}
+		}
+		if silence.CentralID != nil {
+			if err := tx.First(&CentralModel{}, *silence.CentralID).Error; errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("%w: central %d not found", domain.ErrInvalid, *silence.CentralID)
+			} else if err != nil {
+				return err
+			}
+		}
+		return tx.Create(model).Error
+	})
+	if err != nil {
+		return r.translate(err)
+	}
+	*silence = *model.toDomain()
+	return nil
+}
+
+// Silêncios que ainda não terminaram no instante, do que termina antes ao
+// que termina depois
+func (r *AlertRepository) GetSilences(now time.Time) ([]domain.Silence, error) {
+	var models []SilenceModel
+	if err := r.DB.Where("ends_at > ?", now).Order("ends_at, id").Find(&models).Error; err != nil {
+		return nil, err
+	}
+	silences := make([]domain.Silence, 0, len(models))
+	for i := range models {
+		silences = append(silences, *models[i].toDomain())
+	}
+	return silences, nil
+}
+
+// Encerra o silêncio no instante informado
+func (r *AlertRepository) ExpireSilence(id uint, now time.Time) error {
+	result := r.DB.Model(&SilenceModel{}).Where("id = ? AND ends_at > ?", id, now).Update("ends_at", now)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *AlertRepository) translate(err error) error {
+	if errors.Is(err, domain.ErrConflict) || errors.Is(err, domain.ErrInvalid) {
+		return err
+	}
+	err = translateError(r.DB, err)
+	if errors.Is(err, domain.ErrConflict) {
+		return fmt.Errorf("%w: an alert rule with this name already exists", domain.ErrConflict)
+	}
+	return err
+}
+
+// Confere as regras inibidas: precisam existir e não podem incluir a própria
+func checkInhibitedRules(tx *gorm.DB, ruleID uint, inhibits []uint) error {
+	if len(inhibits) == 0 {
+		return nil
+	}
+	for _, id := range inhibits {
+		if id == ruleID {
+			return fmt.Errorf("%w: a rule cannot inhibit itself", domain.ErrInvalid)
+		}
+	}
+	var count int64
+	if err := tx.Model(&AlertRuleModel{}).Where("id IN ?", inhibits).Count(&count).Error; err != nil {
+		return err
+	}
+	if int(count) != len(inhibits) {
+		return fmt.Errorf("%w: inhibited alert rules not found", domain.ErrInvalid)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package repository_test
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/repository"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlertRules(t *testing.T) {
+	repo := repository.NewAlertRepository(setupInMemoryDB())
+
+	offline := &domain.AlertRule{Name: "offline", Kind: domain.AlertKindStatus, Severity: domain.SeverityCritical,
+		Status: domain.StatusOffline, For: 5 * time.Minute, Enabled: true}
+	require.NoError(t, repo.CreateRule(offline))
+	rtt := &domain.AlertRule{Name: "rtt", Kind: domain.AlertKindMetric, Severity: domain.SeverityWarning,
+		Metric: "rtt_ms", Aggregation: domain.AggregationP95, Operator: domain.OperatorGreater, Threshold: 200, Window: time.Minute}
+	require.NoError(t, repo.CreateRule(rtt))
+
+	duplicate := &domain.AlertRule{Name: "offline", Kind: domain.AlertKindStatus, Status: domain.StatusOffline}
+	assert.ErrorIs(t, repo.CreateRule(duplicate), domain.ErrConflict)
+
+	offline.Inhibits = []uint{rtt.ID, 99}
+	assert.ErrorIs(t, repo.UpdateRule(offline), domain.ErrInvalid)
+	offline.Inhibits = []uint{offline.ID}
+	assert.ErrorIs(t, repo.UpdateRule(offline), domain.ErrInvalid)
+	offline.Inhibits = []uint{rtt.ID}
+	require.NoError(t, repo.UpdateRule(offline))
+
+	stored, err := repo.GetRuleByID(offline.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []uint{rtt.ID}, stored.Inhibits)
+	assert.Equal(t, 5*time.Minute, stored.For)
+
+	// Regras desabilitadas continuam desabilitadas
+	stored, err = repo.GetRuleByID(rtt.ID)
+	require.NoError(t, err)
+	assert.False(t, stored.Enabled)
+	assert.Equal(t, time.Minute, stored.Window)
+
+	missing := &domain.AlertRule{ID: 99, Name: "missing", Kind: domain.AlertKindStatus}
+	assert.ErrorIs(t, repo.UpdateRule(missing), domain.ErrNotFound)
+	assert.ErrorIs(t, repo.DeleteRule(99), domain.ErrNotFound)
+}
+
+func TestAlerts(t *testing.T) {
+	db := setupInMemoryDB()
+	repo := repository.NewAlertRepository(db)
+	centralRepo := repository.NewCentralRepository(db)
+	central := createCentral(t, centralRepo, "00:11:22:33:44:01", "10.0.0.1")
+	rule := &domain.AlertRule{Name: "offline", Kind: domain.AlertKindStatus, Status: domain.StatusOffline, Enabled: true}
+	require.NoError(t, repo.CreateRule(rule))
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	resolvedAt := now.Add(-time.Minute)
+	alerts := []domain.Alert{
+		{RuleID: rule.ID, CentralID: central.ID, State: domain.AlertFiring, ActiveSince: now, FiredAt: &now, LastEvaluatedAt: now},
+		{RuleID: rule.ID, CentralID: central.ID, State: domain.AlertResolved, ActiveSince: now.Add(-time.Hour),
+			ResolvedAt: &resolvedAt, LastEvaluatedAt: resolvedAt},
+		{RuleID: rule.ID, CentralID: central.ID, State: domain.AlertPending, ActiveSince: now, LastEvaluatedAt: now},
+	}
+	require.NoError(t, repo.SaveAlerts(alerts, nil))
+	for _, alert := range alerts {
+		assert.NotZero(t, alert.ID)
+	}
+
+	// Atualiza o disparado e descarta o pendente
+	alerts[0].Silenced = true
+	require.NoError(t, repo.SaveAlerts(alerts[:1], []uint{alerts[2].ID}))
+
+	active, err := repo.GetAlerts(domain.AlertFilter{})
+	require.NoError(t, err)
+	require.Len(t, active, 1)
+	assert.Equal(t, alerts[0].ID, active[0].ID)
+	assert.True(t, active[0].Silenced)
+
+	resolved, err := repo.GetAlerts(domain.AlertFilter{State: domain.AlertResolved, CentralID: central.ID})
+	require.NoError(t, err)
+	require.Len(t, resolved, 1)
+	assert.True(t, resolvedAt.Equal(*resolved[0].ResolvedAt))
+
+	// Alertas saem junto com a central
+	require.NoError(t, centralRepo.Delete(central.ID))
+	remaining, err := repo.GetAlerts(domain.AlertFilter{State: domain.AlertResolved})
+	require.NoError(t, err)
+	assert.Empty(t, remaining)
+}
+
+func TestSilences(t *testing.T) {
+	db := setupInMemoryDB()
+	repo := repository.NewAlertRepository(db)
+	central := createCentral(t, repository.NewCentralRepository(db), "00:11:22:33:44:01", "10.0.0.1")
+	rule := &domain.AlertRule{Name: "offline", Kind: domain.AlertKindStatus, Status: domain.StatusOffline, Enabled: true}
+	require.NoError(t, repo.CreateRule(rule))
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	silence := &domain.Silence{RuleID: &rule.ID, CentralID: &central.ID, StartsAt: now, EndsAt: now.Add(time.Hour), Comment: "manutenção"}
+	require.NoError(t, repo.CreateSilence(silence))
+	assert.NotZero(t, silence.ID)
+
+	missingRule := uint(99)
+	err := repo.CreateSilence(&domain.Silence{RuleID: &missingRule, StartsAt: now, EndsAt: now.Add(time.Hour)})
+	assert.ErrorIs(t, err, domain.ErrInvalid)
+
+	silences, err := repo.GetSilences(now.Add(time.Minute))
+	require.NoError(t, err)
+	require.Len(t, silences, 1)
+	assert.Equal(t, "manutenção", silences[0].Comment)
+
+	require.NoError(t, repo.ExpireSilence(silence.ID, now.Add(time.Minute)))
+	assert.ErrorIs(t, repo.ExpireSilence(silence.ID, now.Add(2*time.Minute)), domain.ErrNotFound)
+	silences, err = repo.GetSilences(now.Add(2 * time.Minute))
+	require.NoError(t, err)
+	assert.Empty(t, silences)
+
+	// Silêncios e alertas saem junto com a regra
+	require.NoError(t, repo.DeleteRule(rule.ID))
+	silences, _ = repo.GetSilences(now.Add(-time.Hour))
+	assert.Empty(t, silences)
+}
@@ -0,0 +1,207 @@
+package repository
+
+import (
+	"api-golang/internal/domain"
+	"net/netip"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Modelo de persistência da central. Os endereços são opcionais
+// individualmente e únicos dentro de cada família.
+type CentralModel struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Name      string  `gorm:"not null"`
+	MAC       string  `gorm:"unique;not null"`
+	IPv4      *string `gorm:"column:ipv4;uniqueIndex"`
+	IPv6      *string `gorm:"column:ipv6;uniqueIndex"`
+	Notes     string
+	Vendor    string `gorm:"index"`
+
+	// Endereços em binário (4 e 16 bytes), que ordenam como os IPs e
+	// permitem consultas por sub-rede e faixa usando índice
+	IPv4Bin []byte `gorm:"column:ipv4_bin;index"`
+	IPv6Bin []byte `gorm:"column:ipv6_bin;index"`
+
+	SiteID     *uint `gorm:"index"`
+	LocationID *uint `gorm:"index"`
+
+	// Prazo sem heartbeat, em segundos; zero usa o padrão
+	HeartbeatWindow int64 `gorm:"column:heartbeat_window_s;not null;default:0"`
+}
+
+func (CentralModel) TableName() string {
+	return "centrals"
+}
+
+func newCentralModel(central *domain.Central) *CentralModel {
+	model := &CentralModel{
+		ID:        central.ID,
+		CreatedAt: central.CreatedAt,
+		UpdatedAt: central.UpdatedAt,
+		Name:      central.Name,
+		MAC:       central.MAC,
+		IPv4:      nullableString(central.IPv4),
+		IPv6:      nullableString(central.IPv6),
+		Notes:     central.Notes,
+		Vendor:    central.Vendor,
+
+		SiteID:     central.SiteID,
+		LocationID: central.LocationID,
+
+		HeartbeatWindow: int64(central.HeartbeatWindow / time.Second),
+	}
+	model.setAddressBytes()
+	return model
+}
+
+// Recalcula a forma binária a partir dos endereços em texto. Um endereço
+// que não pertence à família da coluna fica sem forma binária.
+func (m *CentralModel) setAddressBytes() {
+	m.IPv4Bin = addressBytes(m.IPv4, true)
+	m.IPv6Bin = addressBytes(m.IPv6, false)
+}
+
+func addressBytes(value *string, v4 bool) []byte {
+	if value == nil {
+		return nil
+	}
+	addr, err := netip.ParseAddr(*value)
+	if err != nil {
+		return nil
+	}
+	addr = addr.Unmap()
+	if addr.Is4() != v4 {
+		return nil
+	}
+	return addr.AsSlice()
+}
+
+func (m *CentralModel) toDomain() *domain.Central {
+	return &domain.Central{
+		ID:        m.ID,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+		Name:      m.Name,
+		MAC:       m.MAC,
+		IPv4:      stringValue(m.IPv4),
+		IPv6:      stringValue(m.IPv6),
+		Notes:     m.Notes,
+		Vendor:    m.Vendor,
+
+		SiteID:     m.SiteID,
+		LocationID: m.LocationID,
+
+		HeartbeatWindow: time.Duration(m.HeartbeatWindow) * time.Second,
+	}
+}
+
+// Strings vazias viram NULL para não colidirem no índice único
+func nullableString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+// Migrate cria ou atualiza as tabelas usadas pelos repositórios
+func Migrate(db *gorm.DB) error {
+	if err := db.AutoMigrate(&CentralModel{}, &NetworkInterfaceModel{}, &SiteModel{}, &LocationModel{}, &CentralLabelModel{},
+		&SubnetModel{}, &PoolModel{}, &CentralStatusModel{}, &HeartbeatModel{}, &MetricPointModel{}, &MetricRollupModel{},
+		&AlertRuleModel{}, &AlertModel{}, &SilenceModel{},
+		&WebhookModel{}, &WebhookDeliveryModel{}, &WebhookAttemptModel{}, &OutboxEventModel{}, &CentralSNMPModel{}, &DiscoveryCandidateModel{}); err != nil {
+		return err
+	}
+	if err := migrateLegacyIPColumn(db); err != nil {
+		return err
+	}
+	if err := backfillAddressBytes(db); err != nil {
+		return err
+	}
+	if err := backfillHeartbeatMillis(db); err != nil {
+		return err
+	}
+	if err := migrateSearch(db); err != nil {
+		return err
+	}
+	return backfillPrimaryInterfaces(db)
+}
+
+// Move a antiga coluna "ip" (somente IPv4) para as colunas por família
+func migrateLegacyIPColumn(db *gorm.DB) error {
+	if !db.Migrator().HasColumn(&CentralModel{}, "ip") {
+		return nil
+	}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("UPDATE centrals SET ipv6 = ip WHERE ipv6 IS NULL AND ip LIKE '%:%'").Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("UPDATE centrals SET ipv4 = ip WHERE ipv4 IS NULL AND ip NOT LIKE '%:%'").Error; err != nil {
+			return err
+		}
+		// A restrição de unicidade da coluna precisa sair antes dela
+		if tx.Migrator().HasConstraint(&CentralModel{}, "uni_centrals_ip") {
+			if err := tx.Migrator().DropConstraint(&CentralModel{}, "uni_centrals_ip"); err != nil {
+				return err
+			}
+		}
+		return tx.Migrator().DropColumn(&CentralModel{}, "ip")
+	})
+	if err != nil {
+		return err
+	}
+	// Recria os índices caso a remoção da coluna tenha recriado a tabela
+	return db.AutoMigrate(&CentralModel{})
+}
+
+// Preenche o último heartbeat em milissegundos das situações anteriores à
+// coluna
+func backfillHeartbeatMillis(db *gorm.DB) error {
+	var models []CentralStatusModel
+	if err := db.Where("last_heartbeat_at IS NOT NULL AND last_heartbeat_ms IS NULL").Find(&models).Error; err != nil {
+		return err
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		for i := range models {
+			err := tx.Model(&CentralStatusModel{}).Where("central_id = ?", models[i].CentralID).
+				UpdateColumn("last_heartbeat_ms", models[i].LastHeartbeatAt.UnixMilli()).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Preenche a forma binária dos endereços das centrais anteriores a ela
+func backfillAddressBytes(db *gorm.DB) error {
+	var models []CentralModel
+	err := db.Where("(ipv4 IS NOT NULL AND ipv4_bin IS NULL) OR (ipv6 IS NOT NULL AND ipv6_bin IS NULL)").
+		Find(&models).Error
+	if err != nil {
+		return err
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		for i := range models {
+			models[i].setAddressBytes()
+			err := tx.Model(&CentralModel{ID: models[i].ID}).UpdateColumns(map[string]interface{}{
+				"ipv4_bin": models[i].IPv4Bin,
+				"ipv6_bin": models[i].IPv6Bin,
+			}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -2,6 +2,10 @@ package repository
 
 import (
 	"api-golang/internal/domain"
+	"errors"
+	"fmt"
+	"net/netip"
+	"strings"
 
 	"gorm.io/gorm"
 )
@@ -14,29 +18,288 @@ func NewCentralRepository(db *gorm.DB) *CentralRepository {
 	return &CentralRepository{DB: db}
 }
 
-func (r *CentralRepository) Create(user *domain.Central) error {
-	return r.DB.Create(user).Error
+// Cria a central junto com a sua interface principal. Com PoolID, o IP é
+// alocado do pool na mesma transação.
+func (r *CentralRepository) Create(central *domain.Central) error {
+	var (
+		model   *CentralModel
+		primary *NetworkInterfaceModel
+		err     error
+	)
+	for attempt := 0; attempt < maxAllocationAttempts; attempt++ {
+		model = newCentralModel(central)
+		var allocated netip.Addr
+		err = r.DB.Transaction(func(tx *gorm.DB) error {
+			if central.PoolID != nil {
+				var err error
+				if allocated, err = allocateFromPool(tx, *central.PoolID, model); err != nil {
+					return err
+				}
+			}
+			if err := resolvePlacement(tx, model); err != nil {
+				return err
+			}
+			if err := tx.Create(model).Error; err != nil {
+				return err
+			}
+			if err := upsertLabels(tx, []uint{model.ID}, central.Labels); err != nil {
+				return err
+			}
+			var err error
+			if primary, err = createPrimaryInterface(tx, model); err != nil {
+				return err
+			}
+			return appendCentralEvents(tx, domain.EventCentralCreated, []uint{model.ID}, model.CreatedAt)
+		})
+		// Só vale tentar de novo se outro cliente levou o IP alocado; MAC
+		// duplicado ou endereço informado em uso falham de primeira
+		if !allocated.IsValid() || !r.lostAllocation(err, allocated) {
+			break
+		}
+	}
+	if err != nil {
+		return r.translate(err)
+	}
+	labels := central.Labels
+	*central = *model.toDomain()
+	central.Labels = labels
+	central.PrimaryInterface = primary.toDomain()
+	return nil
+}
+
+// Indica se a criação falhou porque outro cliente gravou, nesse meio-tempo,
+// uma central com o endereço alocado. O driver não diz qual restrição foi
+// violada, então o endereço é conferido depois da falha.
+func (r *CentralRepository) lostAllocation(err error, allocated netip.Addr) bool {
+	if !isDuplicateKey(r.DB, err) {
+		return false
+	}
+	var count int64
+	if r.DB.Model(&CentralModel{}).Where(centralAddressColumn(allocated)+" = ?", allocated.String()).Count(&count).Error != nil {
+		return false
+	}
+	return count > 0
 }
 
-func (r *CentralRepository) GetAll() ([]domain.Central, error) {
-	var users []domain.Central
-	err := r.DB.Find(&users).Error
-	return users, err
+func (r *CentralRepository) GetAll(filter domain.CentralFilter) ([]domain.Central, error) {
+	query := r.DB
+	if filter.IP != "" {
+		query = query.Where("ipv4 = ? OR ipv6 = ?", filter.IP, filter.IP)
+	}
+	if filter.IPRange != nil {
+		column := "ipv6_bin"
+		if filter.IPRange.From.Is4() {
+			column = "ipv4_bin"
+		}
+		query = query.Where(column+" BETWEEN ? AND ?", filter.IPRange.From.AsSlice(), filter.IPRange.To.AsSlice())
+	}
+	if filter.SiteID != nil {
+		query = query.Where("site_id = ?", *filter.SiteID)
+	}
+	if filter.Unplaced {
+		query = query.Where("location_id IS NULL")
+	}
+	if filter.LocationID != nil {
+		if filter.Recursive {
+			query = query.Where("location_id IN ("+locationSubtreeSQL+")", *filter.LocationID)
+		} else {
+			query = query.Where("location_id = ?", *filter.LocationID)
+		}
+	}
+	if filter.Vendor != "" {
+		query = query.Where(`lower(vendor) LIKE ? ESCAPE '\'`, "%"+escapeLike(strings.ToLower(filter.Vendor))+"%")
+	}
+	query = applyStatusFilter(query, filter.Status)
+	query, err := applySelector(query, filter.Selector)
+	if err != nil {
+		return nil, err
+	}
+	if filter.AfterID > 0 {
+		query = query.Where("id > ?", filter.AfterID)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	var models []CentralModel
+	if err := query.Order("id").Find(&models).Error; err != nil {
+		return nil, err
+	}
+	return r.toDomainList(models)
 }
 
 func (r *CentralRepository) GetByID(id uint) (*domain.Central, error) {
-	var user domain.Central
-	err := r.DB.First(&user, id).Error
+	var model CentralModel
+	if err := r.DB.First(&model, id).Error; err != nil {
+		return nil, r.translate(err)
+	}
+	centrals, err := r.toDomainList([]CentralModel{model})
 	if err != nil {
 		return nil, err
 	}
-	return &user, err
+	return &centrals[0], nil
 }
 
-func (r *CentralRepository) Update(user *domain.Central) error {
-	return r.DB.Save(user).Error
+// Atualiza os campos editáveis preservando a data de criação. A interface
+// principal acompanha o novo MAC e os novos endereços.
+func (r *CentralRepository) Update(central *domain.Central) error {
+	model := newCentralModel(central)
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		var previous CentralModel
+		if err := tx.First(&previous, central.ID).Error; err != nil {
+			return err
+		}
+		if err := resolvePlacement(tx, model); err != nil {
+			return err
+		}
+		err := tx.Model(&CentralModel{ID: central.ID}).
+			Select("name", "mac", "vendor", "ipv4", "ipv6", "ipv4_bin", "ipv6_bin", "notes", "site_id", "location_id",
+				"heartbeat_window_s", "updated_at").
+			Updates(model).Error
+		if err != nil {
+			return err
+		}
+		if central.Labels != nil {
+			if err := replaceLabels(tx, central.ID, central.Labels); err != nil {
+				return err
+			}
+		}
+		if err := syncPrimaryInterface(tx, &previous, model); err != nil {
+			return err
+		}
+		return appendCentralEvents(tx, domain.EventCentralUpdated, []uint{central.ID}, model.UpdatedAt)
+	})
+	if err != nil {
+		return r.translate(err)
+	}
+
+	updated, err := r.GetByID(central.ID)
+	if err != nil {
+		return err
+	}
+	*central = *updated
+	return nil
 }
 
 func (r *CentralRepository) Delete(id uint) error {
-	return r.DB.Delete(&domain.Central{}, id).Error
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		return deleteCentrals(tx, tx.Where("id = ?", id))
+	})
+}
+
+// Remove as centrais selecionadas pela condição, com suas dependências. O
+// último estado de cada uma vai para o outbox.
+func deleteCentrals(tx *gorm.DB, where *gorm.DB) error {
+	var removed []uint
+	if err := tx.Model(&CentralModel{}).Where(where).Order("id").Pluck("id", &removed).Error; err != nil {
+		return err
+	}
+	if err := appendCentralEvents(tx, domain.EventCentralDeleted, removed, tx.NowFunc()); err != nil {
+		return err
+	}
+	ids := tx.Model(&CentralModel{}).Select("id").Where(where)
+	if err := tx.Where("central_id IN (?)", ids).Delete(&NetworkInterfaceModel{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("central_id IN (?)", ids).Delete(&CentralLabelModel{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("central_id IN (?)", ids).Delete(&CentralStatusModel{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("central_id IN (?)", ids).Delete(&HeartbeatModel{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("central_id IN (?)", ids).Delete(&MetricPointModel{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("central_id IN (?)", ids).Delete(&MetricRollupModel{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("central_id IN (?)", ids).Delete(&AlertModel{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("central_id IN (?)", ids).Delete(&SilenceModel{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("central_id IN (?)", ids).Delete(&CentralSNMPModel{}).Error; err != nil {
+		return err
+	}
+	return tx.Where(where).Delete(&CentralModel{}).Error
+}
+
+// Confere site e localização da central. A localização determina o site,
+// então basta informar uma das duas.
+func resolvePlacement(tx *gorm.DB, model *CentralModel) error {
+	if model.LocationID != nil {
+		var location LocationModel
+		if err := tx.First(&location, *model.LocationID).Error; errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("%w: location %d not found", domain.ErrInvalid, *model.LocationID)
+		} else if err != nil {
+			return err
+		}
+		if model.SiteID != nil && *model.SiteID != location.SiteID {
+			return fmt.Errorf("%w: location %d belongs to another site", domain.ErrInvalid, location.ID)
+		}
+		model.SiteID = &location.SiteID
+		return nil
+	}
+	if model.SiteID != nil {
+		if err := tx.First(&SiteModel{}, *model.SiteID).Error; errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("%w: site %d not found", domain.ErrInvalid, *model.SiteID)
+		} else if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Converte os modelos anexando a interface principal e os labels de cada central
+func (r *CentralRepository) toDomainList(models []CentralModel) ([]domain.Central, error) {
+	return loadCentrals(r.DB, models)
+}
+
+// Completa as centrais com interface principal, labels e situação
+func loadCentrals(db *gorm.DB, models []CentralModel) ([]domain.Central, error) {
+	ids := make([]uint, 0, len(models))
+	for _, m := range models {
+		ids = append(ids, m.ID)
+	}
+	primaries, err := loadPrimaryInterfaces(db, ids)
+	if err != nil {
+		return nil, err
+	}
+	labels, err := loadLabels(db, ids)
+	if err != nil {
+		return nil, err
+	}
+	statuses, err := loadStatuses(db, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	centrals := make([]domain.Central, 0, len(models))
+	for i := range models {
+		central := models[i].toDomain()
+		central.PrimaryInterface = primaries[central.ID]
+		central.Labels = labels[central.ID]
+		central.Status = statuses[central.ID]
+		if central.Status.Status == "" {
+			central.Status.Status = domain.StatusUnknown
+		}
+		centrals = append(centrals, *central)
+	}
+	return centrals, nil
+}
+
+// Traduz erros do banco, detalhando violações de unicidade
+func (r *CentralRepository) translate(err error) error {
+	if errors.Is(err, domain.ErrInvalid) || errors.Is(err, domain.ErrConflict) {
+		return err
+	}
+	err = translateError(r.DB, err)
+	if errors.Is(err, domain.ErrConflict) {
+		return fmt.Errorf("%w: a central or interface with this MAC or IP address already exists", domain.ErrConflict)
+	}
+	return err
 }
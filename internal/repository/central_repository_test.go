@@ -18,7 +18,7 @@ func setupInMemoryDB() *gorm.DB {
 	}
 
 	// Cria a tabela Central
-	err = db.AutoMigrate(&domain.Central{})
+	err = repository.Migrate(db)
 	if err != nil {
 		panic("failed to migrate database")
 	}
@@ -26,6 +26,10 @@ func setupInMemoryDB() *gorm.DB {
 	return db
 }
 
+func ptr(value string) *string {
+	return &value
+}
+
 func TestCreateCentral(t *testing.T) {
 	db := setupInMemoryDB()
 	repo := repository.NewCentralRepository(db)
@@ -33,19 +37,103 @@ func TestCreateCentral(t *testing.T) {
 	central := &domain.Central{
 		Name: "Central Test",
 		MAC:  "00:11:22:33:44:55",
-		IP:   "192.168.0.1",
+		IPv4: "192.168.0.1",
 	}
 
 	err := repo.Create(central)
 	assert.NoError(t, err)
 
 	// Verifica se foi salvo corretamente
-	var result domain.Central
+	var result repository.CentralModel
 	err = db.First(&result, central.ID).Error
 	assert.NoError(t, err)
 	assert.Equal(t, central.Name, result.Name)
 	assert.Equal(t, central.MAC, result.MAC)
-	assert.Equal(t, central.IP, result.IP)
+	assert.Equal(t, central.IPv4, *result.IPv4)
+}
+
+func TestCreateCentral_Duplicate(t *testing.T) {
+	db := setupInMemoryDB()
+	repo := repository.NewCentralRepository(db)
+
+	err := repo.Create(&domain.Central{Name: "Central 1", MAC: "00:11:22:33:44:55", IPv4: "192.168.0.1"})
+	assert.NoError(t, err)
+
+	// Mesmo MAC deve resultar em conflito
+	err = repo.Create(&domain.Central{Name: "Central 2", MAC: "00:11:22:33:44:55", IPv4: "192.168.0.2"})
+	assert.ErrorIs(t, err, domain.ErrConflict)
+}
+
+func TestCreateCentral_DualStack(t *testing.T) {
+	db := setupInMemoryDB()
+	repo := repository.NewCentralRepository(db)
+
+	// Dual-stack, somente IPv6 e somente IPv4 convivem
+	err := repo.Create(&domain.Central{Name: "Central 1", MAC: "00:11:22:33:44:55", IPv4: "192.168.0.1", IPv6: "2001:db8::1"})
+	assert.NoError(t, err)
+	err = repo.Create(&domain.Central{Name: "Central 2", MAC: "00:11:22:33:44:56", IPv6: "2001:db8::2"})
+	assert.NoError(t, err)
+	err = repo.Create(&domain.Central{Name: "Central 3", MAC: "00:11:22:33:44:57", IPv4: "192.168.0.3"})
+	assert.NoError(t, err)
+
+	// A unicidade vale dentro de cada família
+	err = repo.Create(&domain.Central{Name: "Central 4", MAC: "00:11:22:33:44:58", IPv6: "2001:db8::1"})
+	assert.ErrorIs(t, err, domain.ErrConflict)
+
+	central, err := repo.GetByID(2)
+	assert.NoError(t, err)
+	assert.Empty(t, central.IPv4)
+	assert.Equal(t, "2001:db8::2", central.IPv6)
+}
+
+func TestGetAllCentrals_FilterByIP(t *testing.T) {
+	db := setupInMemoryDB()
+	repo := repository.NewCentralRepository(db)
+
+	repo.Create(&domain.Central{Name: "Central 1", MAC: "00:11:22:33:44:55", IPv4: "192.168.0.1", IPv6: "2001:db8::1"})
+	repo.Create(&domain.Central{Name: "Central 2", MAC: "00:11:22:33:44:56", IPv4: "192.168.0.2"})
+
+	// O filtro aceita qualquer uma das famílias
+	centrals, err := repo.GetAll(domain.CentralFilter{IP: "2001:db8::1"})
+	assert.NoError(t, err)
+	assert.Len(t, centrals, 1)
+	assert.Equal(t, "Central 1", centrals[0].Name)
+
+	centrals, err = repo.GetAll(domain.CentralFilter{IP: "192.168.0.2"})
+	assert.NoError(t, err)
+	assert.Len(t, centrals, 1)
+	assert.Equal(t, "Central 2", centrals[0].Name)
+}
+
+func TestMigrate_LegacyIPColumn(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+
+	// Esquema anterior ao dual-stack, com uma única coluna "ip"
+	type legacyCentral struct {
+		ID   uint   `gorm:"primaryKey"`
+		Name string `gorm:"not null"`
+		MAC  string `gorm:"unique;not null"`
+		IP   string `gorm:"unique;not null"`
+	}
+	assert.NoError(t, db.Table("centrals").AutoMigrate(&legacyCentral{}))
+	db.Table("centrals").Create(&legacyCentral{Name: "Central 1", MAC: "00:11:22:33:44:55", IP: "192.168.0.1"})
+	db.Table("centrals").Create(&legacyCentral{Name: "Central 2", MAC: "00:11:22:33:44:56", IP: "2001:db8::2"})
+
+	assert.NoError(t, repository.Migrate(db))
+	assert.False(t, db.Migrator().HasColumn(&repository.CentralModel{}, "ip"))
+
+	repo := repository.NewCentralRepository(db)
+	central, err := repo.GetByID(1)
+	assert.NoError(t, err)
+	assert.Equal(t, "192.168.0.1", central.IPv4)
+	central, err = repo.GetByID(2)
+	assert.NoError(t, err)
+	assert.Equal(t, "2001:db8::2", central.IPv6)
+
+	// Os índices únicos continuam valendo após a migração
+	err = repo.Create(&domain.Central{Name: "Central 3", MAC: "00:11:22:33:44:57", IPv4: "192.168.0.1"})
+	assert.ErrorIs(t, err, domain.ErrConflict)
 }
 
 func TestGetAllCentrals(t *testing.T) {
@@ -53,22 +141,38 @@ func TestGetAllCentrals(t *testing.T) {
 	repo := repository.NewCentralRepository(db)
 
 	// Adiciona dados de teste
-	db.Create(&domain.Central{Name: "Central 1", MAC: "00:11:22:33:44:55", IP: "192.168.0.1"})
-	db.Create(&domain.Central{Name: "Central 2", MAC: "00:11:22:33:44:56", IP: "192.168.0.2"})
+	db.Create(&repository.CentralModel{Name: "Central 1", MAC: "00:11:22:33:44:55", IPv4: ptr("192.168.0.1")})
+	db.Create(&repository.CentralModel{Name: "Central 2", MAC: "00:11:22:33:44:56", IPv4: ptr("192.168.0.2")})
 
-	centrals, err := repo.GetAll()
+	centrals, err := repo.GetAll(domain.CentralFilter{})
 	assert.NoError(t, err)
 	assert.Len(t, centrals, 2)
 	assert.Equal(t, "Central 1", centrals[0].Name)
 	assert.Equal(t, "Central 2", centrals[1].Name)
 }
 
+func TestGetAllCentrals_Pagination(t *testing.T) {
+	repo := repository.NewCentralRepository(setupInMemoryDB())
+	for _, mac := range []string{"00:11:22:33:44:01", "00:11:22:33:44:02", "00:11:22:33:44:03"} {
+		createCentral(t, repo, mac, "")
+	}
+
+	page, err := repo.GetAll(domain.CentralFilter{Limit: 2})
+	assert.NoError(t, err)
+	assert.Len(t, page, 2)
+
+	page, err = repo.GetAll(domain.CentralFilter{AfterID: page[1].ID, Limit: 2})
+	assert.NoError(t, err)
+	assert.Len(t, page, 1)
+	assert.Equal(t, "00:11:22:33:44:03", page[0].MAC)
+}
+
 func TestGetCentralByID(t *testing.T) {
 	db := setupInMemoryDB()
 	repo := repository.NewCentralRepository(db)
 
 	// Adiciona dado de teste
-	db.Create(&domain.Central{Name: "Central Test", MAC: "00:11:22:33:44:55", IP: "192.168.0.1"})
+	db.Create(&repository.CentralModel{Name: "Central Test", MAC: "00:11:22:33:44:55", IPv4: ptr("192.168.0.1")})
 
 	central, err := repo.GetByID(1)
 	assert.NoError(t, err)
@@ -79,7 +183,7 @@ func TestGetCentralByID(t *testing.T) {
 	central, err = repo.GetByID(99)
 	assert.Nil(t, central)
 	assert.Error(t, err)
-	assert.Equal(t, gorm.ErrRecordNotFound, err)
+	assert.Equal(t, domain.ErrNotFound, err)
 }
 
 func TestUpdateCentral(t *testing.T) {
@@ -87,18 +191,26 @@ func TestUpdateCentral(t *testing.T) {
 	repo := repository.NewCentralRepository(db)
 
 	// Adiciona dado de teste
-	db.Create(&domain.Central{Name: "Central Old", MAC: "00:11:22:33:44:55", IP: "192.168.0.1"})
+	db.Create(&repository.CentralModel{Name: "Central Old", MAC: "00:11:22:33:44:55", IPv4: ptr("192.168.0.1")})
 
-	central := &domain.Central{ID: 1, Name: "Central Updated", MAC: "00:11:22:33:44:55", IP: "192.168.0.2"}
+	central := &domain.Central{ID: 1, Name: "Central Updated", MAC: "00:11:22:33:44:55", IPv4: "192.168.0.2"}
 	err := repo.Update(central)
 	assert.NoError(t, err)
 
 	// Verifica atualização
-	var result domain.Central
+	var result repository.CentralModel
 	err = db.First(&result, 1).Error
 	assert.NoError(t, err)
 	assert.Equal(t, "Central Updated", result.Name)
-	assert.Equal(t, "192.168.0.2", result.IP)
+	assert.Equal(t, "192.168.0.2", *result.IPv4)
+
+	// A data de criação é preservada e devolvida na entidade
+	assert.False(t, central.CreatedAt.IsZero())
+	assert.Equal(t, result.CreatedAt.Unix(), central.CreatedAt.Unix())
+
+	// Testa atualização de ID inexistente
+	err = repo.Update(&domain.Central{ID: 99, Name: "Ghost", MAC: "00:11:22:33:44:99", IPv4: "192.168.0.99"})
+	assert.Equal(t, domain.ErrNotFound, err)
 }
 
 func TestDeleteCentral(t *testing.T) {
@@ -106,13 +218,13 @@ func TestDeleteCentral(t *testing.T) {
 	repo := repository.NewCentralRepository(db)
 
 	// Adiciona dado de teste
-	db.Create(&domain.Central{Name: "Central To Delete", MAC: "00:11:22:33:44:55", IP: "192.168.0.1"})
+	db.Create(&repository.CentralModel{Name: "Central To Delete", MAC: "00:11:22:33:44:55", IPv4: ptr("192.168.0.1")})
 
 	err := repo.Delete(1)
 	assert.NoError(t, err)
 
 	// Verifica se foi deletado
-	var result domain.Central
+	var result repository.CentralModel
 	err = db.First(&result, 1).Error
 	assert.Error(t, err) // Registro deve estar ausente
 	assert.Equal(t, gorm.ErrRecordNotFound, err)
@@ -122,3 +234,34 @@ func TestDeleteCentral(t *testing.T) {
 	fmt.Println(err)
 	assert.NoError(t, err) // GORM não retorna erro para exclusões de IDs inexistentes
 }
+
+func TestNormalizeCentralAddresses(t *testing.T) {
+	db := setupInMemoryDB()
+
+	// Dados legados gravados em notações diferentes
+	db.Create(&repository.CentralModel{Name: "Central 1", MAC: "00:11:22:33:44:55", IPv4: ptr("192.168.0.1")})
+	db.Create(&repository.CentralModel{Name: "Central 2", MAC: "00-11-22-33-44-55", IPv4: ptr("192.168.0.2")})
+	db.Create(&repository.CentralModel{Name: "Central 3", MAC: "AABB.CCDD.EEFF", IPv4: ptr("::ffff:192.168.0.3")})
+	db.Create(&repository.CentralModel{Name: "Central 4", MAC: "invalid", IPv4: ptr("192.168.0.4")})
+
+	report, err := repository.NormalizeCentralAddresses(db)
+	assert.NoError(t, err)
+
+	// Apenas a central sem conflito é normalizada
+	assert.Equal(t, []uint{3}, report.Normalized)
+	assert.Equal(t, []uint{4}, report.Invalid)
+	assert.Len(t, report.Duplicates, 1)
+	assert.Equal(t, "mac", report.Duplicates[0].Field)
+	assert.Equal(t, "00:11:22:33:44:55", report.Duplicates[0].Canonical)
+	assert.Equal(t, []uint{1, 2}, report.Duplicates[0].CentralIDs)
+
+	var result repository.CentralModel
+	db.First(&result, 3)
+	assert.Equal(t, "aa:bb:cc:dd:ee:ff", result.MAC)
+	assert.Equal(t, "192.168.0.3", *result.IPv4)
+
+	// Centrais duplicadas permanecem intocadas
+	var duplicate repository.CentralModel
+	db.First(&duplicate, 2)
+	assert.Equal(t, "00-11-22-33-44-55", duplicate.MAC)
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"api-golang/internal/domain"
+	"time"
+)
+
+// Candidato da descoberta de rede. Os hosts com MAC são identificados por
+// ele, acompanhando trocas de IP; os sem MAC, pelo IP.
+type DiscoveryCandidateModel struct {
+	ID          uint `gorm:"primaryKey"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	IP          string `gorm:"column:ip;not null;index"`
+	MAC         string `gorm:"not null;index"`
+	Vendor      string
+	OpenPorts   []int  `gorm:"serializer:json"`
+	Fingerprint string `gorm:"not null"`
+	Status      string `gorm:"not null;index"`
+
+	FirstSeenAt time.Time `gorm:"not null"`
+	LastSeenAt  time.Time `gorm:"not null"`
+	DecidedAt   *time.Time
+	CentralID   *uint
+}
+
+func (DiscoveryCandidateModel) TableName() string {
+	return "discovery_candidates"
+}
+
+func (m *DiscoveryCandidateModel) toDomain() *domain.DiscoveryCandidate {
+	ports := m.OpenPorts
+	if ports == nil {
+		ports = []int{}
+	}
+	return &domain.DiscoveryCandidate{
+		ID:          m.ID,
+		IP:          m.IP,
+		MAC:         m.MAC,
+		Vendor:      m.Vendor,
+		OpenPorts:   ports,
+		Fingerprint: m.Fingerprint,
+		Status:      domain.CandidateStatus(m.Status),
+		FirstSeenAt: m.FirstSeenAt,
+		LastSeenAt:  m.LastSeenAt,
+		DecidedAt:   m.DecidedAt,
+		CentralID:   m.CentralID,
+	}
+}
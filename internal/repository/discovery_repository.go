@@ -0,0 +1,243 @@
+package repository
+
+import (
+	"api-golang/internal/domain"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Prazo de um aceite em andamento: o candidato reservado sem central ligada
+// depois disso é tido como abandonado (ex.: o processo caiu no meio) e a
+// varredura pode devolvê-lo à fila
+const acceptClaimTimeout = 5 * time.Minute
+
+type DiscoveryRepository struct {
+	DB *gorm.DB
+}
+
+func NewDiscoveryRepository(db *gorm.DB) *DiscoveryRepository {
+	return &DiscoveryRepository{DB: db}
+}
+
+// Grava o resultado de uma varredura. Hosts cujo MAC ou IP já pertence a
+// uma central ou interface são ignorados; os demais viram candidatos
+// pendentes ou atualizam o candidato existente. Rejeitados continuam
+// rejeitados, e aceitos cuja central foi removida voltam para a fila.
+// Pendentes que foram cadastrados por outro caminho saem da fila.
+func (r *DiscoveryRepository) SaveDiscoveredHosts(hosts []domain.DiscoveredHost) (int, error) {
+	created := 0
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		registered, err := loadRegisteredAddresses(tx)
+		if err != nil {
+			return err
+		}
+		var pending []DiscoveryCandidateModel
+		if err := tx.Where("status = ?", string(domain.CandidatePending)).Find(&pending).Error; err != nil {
+			return err
+		}
+		var stale []uint
+		for _, c := range pending {
+			if registered.contains(c.MAC, c.IP) {
+				stale = append(stale, c.ID)
+			}
+		}
+		if len(stale) > 0 {
+			if err := tx.Delete(&DiscoveryCandidateModel{}, stale).Error; err != nil {
+				return err
+			}
+		}
+
+		for _, host := range hosts {
+			ip := host.IP.String()
+			if registered.contains(host.MAC, ip) {
+				continue
+			}
+			model, err := findCandidate(tx, host.MAC, ip)
+			if err != nil {
+				return err
+			}
+			if model == nil {
+				model = &DiscoveryCandidateModel{Status: string(domain.CandidatePending), FirstSeenAt: host.SeenAt}
+				created++
+			}
+			model.IP = ip
+			model.MAC = host.MAC
+			model.Vendor = host.Vendor
+			model.OpenPorts = host.OpenPorts
+			model.Fingerprint = host.Fingerprint
+			model.LastSeenAt = host.SeenAt
+			if model.Status == string(domain.CandidateAccepted) && !acceptInProgress(model, host.SeenAt) {
+				model.Status = string(domain.CandidatePending)
+				model.DecidedAt, model.CentralID = nil, nil
+			}
+			if err := tx.Save(model).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return created, nil
+}
+
+// Indica se o candidato aceito ainda espera a criação da sua central
+func acceptInProgress(model *DiscoveryCandidateModel, now time.Time) bool {
+	return model.CentralID == nil && model.DecidedAt != nil && now.Sub(*model.DecidedAt) < acceptClaimTimeout
+}
+
+// Candidato do host: pelo MAC quando conhecido, adotando o candidato sem
+// MAC do mesmo IP visto antes; sem MAC, pelo IP. Retorna nil sem candidato.
+func findCandidate(tx *gorm.DB, mac, ip string) (*DiscoveryCandidateModel, error) {
+	var model DiscoveryCandidateModel
+	var err error
+	if mac != "" {
+		err = tx.Where("mac = ?", mac).Take(&model).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err = tx.Where("mac = '' AND ip = ?", ip).Take(&model).Error
+		}
+	} else {
+		err = tx.Where("mac = '' AND ip = ?", ip).Take(&model).Error
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &model, nil
+}
+
+// Pendentes por padrão; os decididos vêm do mais recente ao mais antigo
+func (r *DiscoveryRepository) GetCandidates(filter domain.CandidateFilter) ([]domain.DiscoveryCandidate, error) {
+	status := filter.Status
+	if status == "" {
+		status = domain.CandidatePending
+	}
+	query := r.DB.Where("status = ?", string(status))
+	if status == domain.CandidatePending {
+		query = query.Order("id")
+	} else {
+		query = query.Order("decided_at DESC, id DESC")
+	}
+	var models []DiscoveryCandidateModel
+	if err := query.Find(&models).Error; err != nil {
+		return nil, err
+	}
+	candidates := make([]domain.DiscoveryCandidate, 0, len(models))
+	for i := range models {
+		candidates = append(candidates, *models[i].toDomain())
+	}
+	return candidates, nil
+}
+
+func (r *DiscoveryRepository) GetCandidate(id uint) (*domain.DiscoveryCandidate, error) {
+	var model DiscoveryCandidateModel
+	if err := r.DB.First(&model, id).Error; err != nil {
+		return nil, translateError(r.DB, err)
+	}
+	return model.toDomain(), nil
+}
+
+// Reserva o candidato pendente para o aceite, marcando-o como aceito ainda
+// sem central. Decisões simultâneas falham aqui, antes de a central existir.
+func (r *DiscoveryRepository) ClaimCandidate(id uint, at time.Time) (*domain.DiscoveryCandidate, error) {
+	return r.decide(id, map[string]interface{}{
+		"status":     string(domain.CandidateAccepted),
+		"decided_at": at,
+	})
+}
+
+// Liga a central criada ao candidato reservado
+func (r *DiscoveryRepository) LinkCandidate(id, centralID uint) (*domain.DiscoveryCandidate, error) {
+	result := r.DB.Model(&DiscoveryCandidateModel{}).
+		Where("id = ? AND status = ? AND central_id IS NULL", id, string(domain.CandidateAccepted)).
+		Update("central_id", centralID)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	candidate, err := r.GetCandidate(id)
+	if err != nil {
+		return nil, err
+	}
+	if result.RowsAffected == 0 {
+		return nil, fmt.Errorf("%w: candidate %d is no longer reserved for acceptance", domain.ErrPrecondition, id)
+	}
+	return candidate, nil
+}
+
+// Devolve à fila o candidato reservado cuja central não pôde ser criada
+func (r *DiscoveryRepository) ReleaseCandidate(id uint) error {
+	return r.DB.Model(&DiscoveryCandidateModel{}).
+		Where("id = ? AND status = ? AND central_id IS NULL", id, string(domain.CandidateAccepted)).
+		Updates(map[string]interface{}{"status": string(domain.CandidatePending), "decided_at": nil}).Error
+}
+
+// Marca o candidato pendente como rejeitado; as próximas varreduras não o
+// propõem de novo
+func (r *DiscoveryRepository) RejectCandidate(id uint, at time.Time) (*domain.DiscoveryCandidate, error) {
+	return r.decide(id, map[string]interface{}{
+		"status":     string(domain.CandidateRejected),
+		"decided_at": at,
+	})
+}
+
+// Aplica a decisão só se o candidato ainda estiver pendente, para que duas
+// decisões simultâneas não se sobreponham
+func (r *DiscoveryRepository) decide(id uint, columns map[string]interface{}) (*domain.DiscoveryCandidate, error) {
+	result := r.DB.Model(&DiscoveryCandidateModel{}).
+		Where("id = ? AND status = ?", id, string(domain.CandidatePending)).
+		Updates(columns)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	candidate, err := r.GetCandidate(id)
+	if err != nil {
+		return nil, err
+	}
+	if result.RowsAffected == 0 {
+		return nil, fmt.Errorf("%w: candidate %d is already %s", domain.ErrPrecondition, id, candidate.Status)
+	}
+	return candidate, nil
+}
+
+// MACs e IPs das centrais e das interfaces cadastradas
+type registeredAddresses struct {
+	macs map[string]bool
+	ips  map[string]bool
+}
+
+func (a registeredAddresses) contains(mac, ip string) bool {
+	return (mac != "" && a.macs[mac]) || a.ips[ip]
+}
+
+func loadRegisteredAddresses(tx *gorm.DB) (registeredAddresses, error) {
+	addrs := registeredAddresses{macs: map[string]bool{}, ips: map[string]bool{}}
+	var centrals []CentralModel
+	if err := tx.Select("mac", "ipv4", "ipv6").Find(&centrals).Error; err != nil {
+		return addrs, err
+	}
+	for _, c := range centrals {
+		addrs.macs[c.MAC] = true
+		for _, ip := range []*string{c.IPv4, c.IPv6} {
+			if ip != nil {
+				addrs.ips[*ip] = true
+			}
+		}
+	}
+	var interfaces []NetworkInterfaceModel
+	if err := tx.Select("mac", "ips").Find(&interfaces).Error; err != nil {
+		return addrs, err
+	}
+	for _, iface := range interfaces {
+		addrs.macs[iface.MAC] = true
+		for _, ip := range iface.IPs {
+			addrs.ips[ip] = true
+		}
+	}
+	return addrs, nil
+}
@@ -0,0 +1,144 @@
+package repository_test
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/repository"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func discoveredHost(ip, mac string, seenAt time.Time, ports ...int) domain.DiscoveredHost {
+	return domain.DiscoveredHost{IP: netip.MustParseAddr(ip), MAC: mac, OpenPorts: ports,
+		Fingerprint: domain.FingerprintUnknown, SeenAt: seenAt}
+}
+
+func TestSaveDiscoveredHosts(t *testing.T) {
+	db := setupInMemoryDB()
+	centralRepo := repository.NewCentralRepository(db)
+	registered := createCentral(t, centralRepo, "00:11:22:33:44:01", "10.0.0.1")
+	require.NoError(t, repository.NewNetworkInterfaceRepository(db).Create(&domain.NetworkInterface{
+		CentralID: registered.ID, Name: "eth1", MAC: "00:11:22:33:44:02", IPs: []string{"10.0.0.2"}}))
+	repo := repository.NewDiscoveryRepository(db)
+
+	t1 := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	created, err := repo.SaveDiscoveredHosts([]domain.DiscoveredHost{
+		// Já cadastrados: MAC da central, IP de uma interface
+		discoveredHost("10.0.0.50", "00:11:22:33:44:01", t1),
+		discoveredHost("10.0.0.2", "", t1),
+		discoveredHost("10.0.0.10", "00:aa:bb:cc:dd:01", t1, 80, 5060),
+		discoveredHost("10.0.0.11", "", t1, 22),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, created)
+
+	pending, err := repo.GetCandidates(domain.CandidateFilter{})
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+	assert.Equal(t, "10.0.0.10", pending[0].IP)
+	assert.Equal(t, []int{80, 5060}, pending[0].OpenPorts)
+	assert.Equal(t, domain.CandidatePending, pending[0].Status)
+
+	// Nova varredura: o host com MAC trocou de IP e o sem MAC passou a ter
+	t2 := t1.Add(time.Hour)
+	created, err = repo.SaveDiscoveredHosts([]domain.DiscoveredHost{
+		discoveredHost("10.0.0.20", "00:aa:bb:cc:dd:01", t2, 80),
+		discoveredHost("10.0.0.11", "00:aa:bb:cc:dd:02", t2, 22),
+	})
+	require.NoError(t, err)
+	assert.Zero(t, created)
+	pending, err = repo.GetCandidates(domain.CandidateFilter{})
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+	assert.Equal(t, "10.0.0.20", pending[0].IP)
+	assert.Equal(t, t1, pending[0].FirstSeenAt.UTC())
+	assert.Equal(t, t2, pending[0].LastSeenAt.UTC())
+	assert.Equal(t, "00:aa:bb:cc:dd:02", pending[1].MAC)
+
+	// Cadastrado por outro caminho: sai da fila
+	createCentral(t, centralRepo, "00:aa:bb:cc:dd:02", "10.0.0.11")
+	_, err = repo.SaveDiscoveredHosts(nil)
+	require.NoError(t, err)
+	pending, err = repo.GetCandidates(domain.CandidateFilter{})
+	require.NoError(t, err)
+	assert.Len(t, pending, 1)
+}
+
+func TestDecideCandidate(t *testing.T) {
+	db := setupInMemoryDB()
+	centralRepo := repository.NewCentralRepository(db)
+	repo := repository.NewDiscoveryRepository(db)
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	_, err := repo.SaveDiscoveredHosts([]domain.DiscoveredHost{
+		discoveredHost("10.0.0.10", "00:aa:bb:cc:dd:01", now),
+		discoveredHost("10.0.0.11", "00:aa:bb:cc:dd:02", now),
+	})
+	require.NoError(t, err)
+	pending, err := repo.GetCandidates(domain.CandidateFilter{})
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+
+	// Reservado para o aceite: uma decisão simultânea falha antes de a
+	// central existir
+	claimed, err := repo.ClaimCandidate(pending[0].ID, now)
+	require.NoError(t, err)
+	assert.Equal(t, domain.CandidateAccepted, claimed.Status)
+	assert.Nil(t, claimed.CentralID)
+	_, err = repo.ClaimCandidate(pending[0].ID, now)
+	assert.ErrorIs(t, err, domain.ErrPrecondition)
+
+	// A varredura não devolve à fila um aceite em andamento
+	_, err = repo.SaveDiscoveredHosts([]domain.DiscoveredHost{discoveredHost("10.0.0.10", "00:aa:bb:cc:dd:01", now.Add(time.Minute))})
+	require.NoError(t, err)
+	claimed, err = repo.GetCandidate(pending[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.CandidateAccepted, claimed.Status)
+
+	// A criação falhou: o candidato volta à fila e pode ser reservado de novo
+	require.NoError(t, repo.ReleaseCandidate(pending[0].ID))
+	released, err := repo.GetCandidate(pending[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.CandidatePending, released.Status)
+	assert.Nil(t, released.DecidedAt)
+	_, err = repo.ClaimCandidate(pending[0].ID, now)
+	require.NoError(t, err)
+
+	central := createCentral(t, centralRepo, "00:aa:bb:cc:dd:01", "10.0.0.10")
+	accepted, err := repo.LinkCandidate(pending[0].ID, central.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.CandidateAccepted, accepted.Status)
+	assert.Equal(t, central.ID, *accepted.CentralID)
+	_, err = repo.LinkCandidate(pending[1].ID, central.ID)
+	assert.ErrorIs(t, err, domain.ErrPrecondition)
+
+	rejected, err := repo.RejectCandidate(pending[1].ID, now)
+	require.NoError(t, err)
+	assert.Equal(t, domain.CandidateRejected, rejected.Status)
+
+	// Decisões valem uma vez só
+	_, err = repo.RejectCandidate(pending[0].ID, now)
+	assert.ErrorIs(t, err, domain.ErrPrecondition)
+	_, err = repo.RejectCandidate(999, now)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	// O rejeitado continua rejeitado; o aceito volta à fila quando a
+	// central é removida
+	require.NoError(t, centralRepo.Delete(central.ID))
+	created, err := repo.SaveDiscoveredHosts([]domain.DiscoveredHost{
+		discoveredHost("10.0.0.10", "00:aa:bb:cc:dd:01", now.Add(time.Hour)),
+		discoveredHost("10.0.0.11", "00:aa:bb:cc:dd:02", now.Add(time.Hour)),
+	})
+	require.NoError(t, err)
+	assert.Zero(t, created)
+	pending, err = repo.GetCandidates(domain.CandidateFilter{})
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, "00:aa:bb:cc:dd:01", pending[0].MAC)
+	assert.Nil(t, pending[0].CentralID)
+	rejectedList, err := repo.GetCandidates(domain.CandidateFilter{Status: domain.CandidateRejected})
+	require.NoError(t, err)
+	assert.Len(t, rejectedList, 1)
+}
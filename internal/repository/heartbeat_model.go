@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"api-golang/internal/domain"
+	"time"
+)
+
+// Histórico de heartbeats recebidos
+type HeartbeatModel struct {
+	ID         uint      `gorm:"primaryKey"`
+	CentralID  uint      `gorm:"not null;index:idx_heartbeat_central_received"`
+	ReceivedAt time.Time `gorm:"not null;index:idx_heartbeat_central_received"`
+
+	UptimeSeconds   int64 `gorm:"column:uptime_s;not null;default:0"`
+	FirmwareVersion string
+	CPUPercent      *float64           `gorm:"column:cpu_percent"`
+	MemoryPercent   *float64           `gorm:"column:memory_percent"`
+	Metrics         map[string]float64 `gorm:"serializer:json"`
+}
+
+func (HeartbeatModel) TableName() string {
+	return "central_heartbeats"
+}
+
+func newHeartbeatModel(hb *domain.Heartbeat) *HeartbeatModel {
+	return &HeartbeatModel{
+		ID:              hb.ID,
+		CentralID:       hb.CentralID,
+		ReceivedAt:      hb.ReceivedAt,
+		UptimeSeconds:   int64(hb.Uptime / time.Second),
+		FirmwareVersion: hb.FirmwareVersion,
+		CPUPercent:      hb.CPUPercent,
+		MemoryPercent:   hb.MemoryPercent,
+		Metrics:         hb.Metrics,
+	}
+}
+
+func (m *HeartbeatModel) toDomain() *domain.Heartbeat {
+	metrics := m.Metrics
+	if metrics == nil {
+		metrics = map[string]float64{}
+	}
+	return &domain.Heartbeat{
+		ID:              m.ID,
+		CentralID:       m.CentralID,
+		ReceivedAt:      m.ReceivedAt,
+		Uptime:          time.Duration(m.UptimeSeconds) * time.Second,
+		FirmwareVersion: m.FirmwareVersion,
+		CPUPercent:      m.CPUPercent,
+		MemoryPercent:   m.MemoryPercent,
+		Metrics:         metrics,
+	}
+}
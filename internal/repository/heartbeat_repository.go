@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"api-golang/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type HeartbeatRepository struct {
+	DB *gorm.DB
+}
+
+func NewHeartbeatRepository(db *gorm.DB) *HeartbeatRepository {
+	return &HeartbeatRepository{DB: db}
+}
+
+// Grava o heartbeat e marca a central como online, vista no momento do
+// recebimento. O RTT da última sondagem ativa é preservado. Retorna a
+// mudança de situação, quando a central não constava como online.
+func (r *HeartbeatRepository) Record(hb *domain.Heartbeat) (*domain.StatusChange, error) {
+	model := newHeartbeatModel(hb)
+	var change *domain.StatusChange
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&CentralModel{}, hb.CentralID).Error; err != nil {
+			return err
+		}
+		previous, err := currentStatuses(tx, []uint{hb.CentralID})
+		if err != nil {
+			return err
+		}
+		if from, ok := previous[hb.CentralID]; !ok || from != domain.StatusOnline {
+			if !ok {
+				from = domain.StatusUnknown
+			}
+			change = &domain.StatusChange{CentralID: hb.CentralID, From: from, To: domain.StatusOnline, At: hb.ReceivedAt}
+		}
+		if err := tx.Create(model).Error; err != nil {
+			return err
+		}
+		received, receivedMS := hb.ReceivedAt, hb.ReceivedAt.UnixMilli()
+		columns := []string{"status", "last_seen_at", "checked_at", "last_heartbeat_at", "last_heartbeat_ms"}
+		// Heartbeats sem firmware mantêm a versão já conhecida
+		if hb.FirmwareVersion != "" {
+			columns = append(columns, "firmware_version")
+		}
+		err = tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "central_id"}},
+			DoUpdates: clause.AssignmentColumns(columns),
+		}).Create(&CentralStatusModel{
+			CentralID:       hb.CentralID,
+			Status:          string(domain.StatusOnline),
+			LastSeenAt:      &received,
+			CheckedAt:       &received,
+			LastHeartbeatAt: &received,
+			LastHeartbeatMS: &receivedMS,
+			FirmwareVersion: hb.FirmwareVersion,
+		}).Error
+		if err != nil || change == nil {
+			return err
+		}
+		return appendStatusEvents(tx, []domain.StatusChange{*change})
+	})
+	if err != nil {
+		return nil, translateError(r.DB, err)
+	}
+	*hb = *model.toDomain()
+	return change, nil
+}
+
+// Heartbeats mais recentes da central, do mais novo para o mais antigo
+func (r *HeartbeatRepository) List(centralID uint, limit int) ([]domain.Heartbeat, error) {
+	if err := r.DB.First(&CentralModel{}, centralID).Error; err != nil {
+		return nil, translateError(r.DB, err)
+	}
+
+	var models []HeartbeatModel
+	err := r.DB.Where("central_id = ?", centralID).Order("received_at DESC, id DESC").Limit(limit).Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+	heartbeats := make([]domain.Heartbeat, 0, len(models))
+	for i := range models {
+		heartbeats = append(heartbeats, *models[i].toDomain())
+	}
+	return heartbeats, nil
+}
+
+// Central dona do MAC, seja o da central ou o de uma das suas interfaces
+func (r *HeartbeatRepository) FindCentralIDByMAC(mac string) (uint, error) {
+	var iface NetworkInterfaceModel
+	if err := r.DB.Select("central_id").Where("mac = ?", mac).First(&iface).Error; err != nil {
+		return 0, translateError(r.DB, err)
+	}
+	return iface.CentralID, nil
+}
+
+// Marca como offline as centrais que se reportam e estão sem heartbeat há
+// mais que o seu prazo (ou o padrão). Retorna as mudanças de situação.
+func (r *HeartbeatRepository) ExpireHeartbeats(now time.Time, defaultWindow time.Duration) ([]domain.StatusChange, error) {
+	type row struct {
+		CentralID       uint
+		Status          string
+		LastHeartbeatMS int64 `gorm:"column:last_heartbeat_ms"`
+		Window          int64 `gorm:"column:heartbeat_window_s"`
+	}
+	var changes []domain.StatusChange
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		var rows []row
+		err := tx.Table("central_statuses").
+			Select("central_statuses.central_id, central_statuses.status, central_statuses.last_heartbeat_ms, centrals.heartbeat_window_s").
+			Joins("JOIN centrals ON centrals.id = central_statuses.central_id").
+			Where("central_statuses.last_heartbeat_ms IS NOT NULL AND central_statuses.status <> ?", string(domain.StatusOffline)).
+			Scan(&rows).Error
+		if err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			window := defaultWindow
+			if row.Window > 0 {
+				window = time.Duration(row.Window) * time.Second
+			}
+			deadline := now.Add(-window).UnixMilli()
+			if row.LastHeartbeatMS >= deadline {
+				continue
+			}
+			// O prazo é conferido de novo no UPDATE: um heartbeat gravado
+			// depois da leitura mantém a central online
+			result := tx.Model(&CentralStatusModel{}).
+				Where("central_id = ? AND status <> ? AND last_heartbeat_ms < ?",
+					row.CentralID, string(domain.StatusOffline), deadline).
+				Updates(map[string]interface{}{"status": string(domain.StatusOffline), "checked_at": now})
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				continue
+			}
+			changes = append(changes, domain.StatusChange{
+				CentralID: row.CentralID, From: domain.ReachabilityStatus(row.Status), To: domain.StatusOffline, At: now,
+			})
+		}
+		return appendStatusEvents(tx, changes)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
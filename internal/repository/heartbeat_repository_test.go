@@ -0,0 +1,195 @@
+package repository_test
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/repository"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// Grava o heartbeat e retorna a mudança de situação
+func record(t *testing.T, repo *repository.HeartbeatRepository, hb *domain.Heartbeat) *domain.StatusChange {
+	t.Helper()
+	change, err := repo.Record(hb)
+	require.NoError(t, err)
+	return change
+}
+
+func TestRecordHeartbeat(t *testing.T) {
+	db := setupInMemoryDB()
+	centralRepo := repository.NewCentralRepository(db)
+	repo := repository.NewHeartbeatRepository(db)
+	central := createCentral(t, centralRepo, "00:11:22:33:44:01", "10.0.0.1")
+
+	received := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	cpu := 12.5
+	for i := 0; i < 3; i++ {
+		hb := &domain.Heartbeat{CentralID: central.ID, ReceivedAt: received.Add(time.Duration(i) * time.Minute),
+			Uptime: time.Hour, FirmwareVersion: "1.2.3", CPUPercent: &cpu, Metrics: map[string]float64{"calls": float64(i)}}
+		change := record(t, repo, hb)
+		assert.NotZero(t, hb.ID)
+		// Só o primeiro heartbeat muda a situação
+		if i == 0 {
+			assert.Equal(t, &domain.StatusChange{CentralID: central.ID, From: domain.StatusUnknown, To: domain.StatusOnline, At: received}, change)
+		} else {
+			assert.Nil(t, change)
+		}
+	}
+
+	heartbeats, err := repo.List(central.ID, 2)
+	require.NoError(t, err)
+	require.Len(t, heartbeats, 2)
+	assert.Equal(t, float64(2), heartbeats[0].Metrics["calls"])
+	assert.Equal(t, time.Hour, heartbeats[0].Uptime)
+
+	updated, err := centralRepo.GetByID(central.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusOnline, updated.Status.Status)
+	assert.True(t, received.Add(2*time.Minute).Equal(*updated.Status.LastSeenAt))
+	assert.True(t, received.Add(2*time.Minute).Equal(*updated.Status.LastHeartbeatAt))
+	assert.Equal(t, "1.2.3", updated.Status.FirmwareVersion)
+
+	// Heartbeat sem firmware mantém a versão conhecida
+	record(t, repo, &domain.Heartbeat{CentralID: central.ID, ReceivedAt: received.Add(3 * time.Minute)})
+	updated, _ = centralRepo.GetByID(central.ID)
+	assert.Equal(t, "1.2.3", updated.Status.FirmwareVersion)
+
+	// Centrais que se reportam não são sondadas pelo monitor
+	targets, err := repository.NewStatusRepository(db).ListProbeTargets()
+	require.NoError(t, err)
+	assert.Empty(t, targets)
+
+	_, err = repo.Record(&domain.Heartbeat{CentralID: 99, ReceivedAt: received})
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	_, err = repo.List(99, 10)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestFindCentralIDByMAC(t *testing.T) {
+	db := setupInMemoryDB()
+	centralRepo := repository.NewCentralRepository(db)
+	repo := repository.NewHeartbeatRepository(db)
+	central := createCentral(t, centralRepo, "00:11:22:33:44:01", "10.0.0.1")
+	require.NoError(t, repository.NewNetworkInterfaceRepository(db).Create(
+		&domain.NetworkInterface{CentralID: central.ID, Name: "eth1", MAC: "00:11:22:33:44:02"}))
+
+	for _, mac := range []string{"00:11:22:33:44:01", "00:11:22:33:44:02"} {
+		id, err := repo.FindCentralIDByMAC(mac)
+		assert.NoError(t, err)
+		assert.Equal(t, central.ID, id)
+	}
+	_, err := repo.FindCentralIDByMAC("00:11:22:33:44:99")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestExpireHeartbeats(t *testing.T) {
+	db := setupInMemoryDB()
+	centralRepo := repository.NewCentralRepository(db)
+	repo := repository.NewHeartbeatRepository(db)
+
+	// Prazo próprio de 1 minuto e prazo padrão
+	short := &domain.Central{Name: "short", MAC: "00:11:22:33:44:01", IPv4: "10.0.0.1", HeartbeatWindow: time.Minute}
+	require.NoError(t, centralRepo.Create(short))
+	standard := createCentral(t, centralRepo, "00:11:22:33:44:02", "10.0.0.2")
+	polled := createCentral(t, centralRepo, "00:11:22:33:44:03", "10.0.0.3")
+
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	record(t, repo, &domain.Heartbeat{CentralID: short.ID, ReceivedAt: start})
+	record(t, repo, &domain.Heartbeat{CentralID: standard.ID, ReceivedAt: start})
+	_, err := repository.NewStatusRepository(db).SaveProbeResults([]domain.ProbeResult{
+		{CentralID: polled.ID, Status: domain.StatusOnline, CheckedAt: start},
+	})
+	require.NoError(t, err)
+
+	expired, err := repo.ExpireHeartbeats(start.Add(2*time.Minute), 5*time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.StatusChange{
+		{CentralID: short.ID, From: domain.StatusOnline, To: domain.StatusOffline, At: start.Add(2 * time.Minute)},
+	}, expired)
+
+	expired, err = repo.ExpireHeartbeats(start.Add(10*time.Minute), 5*time.Minute)
+	require.NoError(t, err)
+	// A já expirada não é reportada de novo e a sondada não é afetada
+	require.Len(t, expired, 1)
+	assert.Equal(t, standard.ID, expired[0].CentralID)
+
+	central, err := centralRepo.GetByID(short.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusOffline, central.Status.Status)
+	assert.True(t, start.Equal(*central.Status.LastSeenAt))
+	assert.Equal(t, time.Minute, central.HeartbeatWindow)
+
+	// Um novo heartbeat traz a central de volta
+	change := record(t, repo, &domain.Heartbeat{CentralID: short.ID, ReceivedAt: start.Add(11 * time.Minute)})
+	assert.Equal(t, domain.StatusOffline, change.From)
+	central, _ = centralRepo.GetByID(short.ID)
+	assert.Equal(t, domain.StatusOnline, central.Status.Status)
+}
+
+func TestExpireHeartbeats_HeartbeatDuringExpiry(t *testing.T) {
+	db := setupInMemoryDB()
+	centralRepo := repository.NewCentralRepository(db)
+	repo := repository.NewHeartbeatRepository(db)
+	late := createCentral(t, centralRepo, "00:11:22:33:44:01", "10.0.0.1")
+	idle := createCentral(t, centralRepo, "00:11:22:33:44:02", "10.0.0.2")
+
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	record(t, repo, &domain.Heartbeat{CentralID: late.ID, ReceivedAt: start})
+	record(t, repo, &domain.Heartbeat{CentralID: idle.ID, ReceivedAt: start})
+
+	// Heartbeat da primeira central chegando entre a leitura dos prazos e o
+	// UPDATE, simulado antes da primeira atualização
+	now := start.Add(10 * time.Minute)
+	arrived := false
+	require.NoError(t, db.Callback().Update().Before("gorm:update").Register("test:late_heartbeat", func(tx *gorm.DB) {
+		if tx.Statement.Table != "central_statuses" || arrived {
+			return
+		}
+		arrived = true
+		tx.Session(&gorm.Session{NewDB: true}).Exec(
+			"UPDATE central_statuses SET status = ?, last_heartbeat_at = ?, last_heartbeat_ms = ? WHERE central_id = ?",
+			string(domain.StatusOnline), now, now.UnixMilli(), late.ID)
+	}))
+
+	expired, err := repo.ExpireHeartbeats(now, 5*time.Minute)
+	require.NoError(t, err)
+	require.True(t, arrived)
+	require.Len(t, expired, 1)
+	assert.Equal(t, idle.ID, expired[0].CentralID)
+
+	central, err := centralRepo.GetByID(late.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusOnline, central.Status.Status)
+	events, err := repository.NewOutboxRepository(db).PendingEvents(100, time.Now())
+	require.NoError(t, err)
+	offline := 0
+	for _, event := range events {
+		if event.StatusChange != nil && event.StatusChange.To == domain.StatusOffline {
+			assert.Equal(t, idle.ID, event.CentralID)
+			offline++
+		}
+	}
+	assert.Equal(t, 1, offline)
+}
+
+func TestMigrate_BackfillsHeartbeatMillis(t *testing.T) {
+	db := setupInMemoryDB()
+	centralRepo := repository.NewCentralRepository(db)
+	repo := repository.NewHeartbeatRepository(db)
+	central := createCentral(t, centralRepo, "00:11:22:33:44:01", "10.0.0.1")
+
+	// Situação gravada antes da coluna em milissegundos
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	record(t, repo, &domain.Heartbeat{CentralID: central.ID, ReceivedAt: start})
+	require.NoError(t, db.Model(&repository.CentralStatusModel{}).Where("1 = 1").Update("last_heartbeat_ms", nil).Error)
+	require.NoError(t, repository.Migrate(db))
+
+	expired, err := repo.ExpireHeartbeats(start.Add(10*time.Minute), 5*time.Minute)
+	require.NoError(t, err)
+	require.Len(t, expired, 1)
+	assert.Equal(t, central.ID, expired[0].CentralID)
+}
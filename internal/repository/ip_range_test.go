@@ -0,0 +1,59 @@
+package repository_test
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/repository"
+	"api-golang/internal/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Nomes das centrais dentro da faixa
+func rangeNames(t *testing.T, repo *repository.CentralRepository, r domain.IPRange) []string {
+	centrals, err := repo.GetAll(domain.CentralFilter{IPRange: &r})
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(centrals))
+	for _, c := range centrals {
+		names = append(names, c.Name)
+	}
+	return names
+}
+
+func TestGetAllCentrals_FilterByIPRange(t *testing.T) {
+	repo := repository.NewCentralRepository(setupInMemoryDB())
+
+	require.NoError(t, repo.Create(&domain.Central{Name: "A", MAC: "00:11:22:33:44:01", IPv4: "10.20.0.9"}))
+	require.NoError(t, repo.Create(&domain.Central{Name: "B", MAC: "00:11:22:33:44:02", IPv4: "10.20.255.1", IPv6: "2001:db8::1"}))
+	require.NoError(t, repo.Create(&domain.Central{Name: "C", MAC: "00:11:22:33:44:03", IPv4: "10.3.0.1"}))
+	require.NoError(t, repo.Create(&domain.Central{Name: "D", MAC: "00:11:22:33:44:04", IPv6: "2001:db9::1"}))
+
+	subnet, err := utils.SubnetRange("10.20.0.0/16")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"A", "B"}, rangeNames(t, repo, subnet))
+
+	// A comparação é numérica: 10.3.0.1 < 10.20.0.9, ao contrário do texto
+	addresses, err := utils.AddressRange("10.0.0.0", "10.20.0.9")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"A", "C"}, rangeNames(t, repo, addresses))
+
+	subnet, err = utils.SubnetRange("2001:db8::/32")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"B"}, rangeNames(t, repo, subnet))
+}
+
+func TestMigrate_BackfillsAddressBytes(t *testing.T) {
+	db := setupInMemoryDB()
+	repo := repository.NewCentralRepository(db)
+
+	// Centrais gravadas antes da forma binária
+	require.NoError(t, db.Create(&repository.CentralModel{Name: "Antiga", MAC: "00:11:22:33:44:55", IPv4: ptr("192.168.0.1")}).Error)
+	require.NoError(t, db.Model(&repository.CentralModel{}).Where("1 = 1").Update("ipv4_bin", nil).Error)
+	require.NoError(t, repository.Migrate(db))
+
+	subnet, err := utils.SubnetRange("192.168.0.0/24")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Antiga"}, rangeNames(t, repo, subnet))
+}
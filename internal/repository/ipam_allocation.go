@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/utils"
+	"bytes"
+	"errors"
+	"fmt"
+	"net/netip"
+
+	"gorm.io/gorm"
+)
+
+// Tentativas de criação quando uma alocação concorrente escolhe o mesmo IP.
+// A unicidade dos endereços das centrais é quem garante a exclusividade: a
+// transação que perde a corrida falha e tenta de novo com o próximo livre.
+const maxAllocationAttempts = 5
+
+// Aloca o menor endereço livre do pool e grava na família correspondente
+// da central, devolvendo o endereço escolhido. Endereços em uso por outras
+// centrais, o gateway e as faixas reservadas da sub-rede são pulados.
+func allocateFromPool(tx *gorm.DB, poolID uint, model *CentralModel) (netip.Addr, error) {
+	var pool PoolModel
+	if err := tx.First(&pool, poolID).Error; errors.Is(err, gorm.ErrRecordNotFound) {
+		return netip.Addr{}, fmt.Errorf("%w: pool %d not found", domain.ErrInvalid, poolID)
+	} else if err != nil {
+		return netip.Addr{}, err
+	}
+	var subnet SubnetModel
+	if err := tx.First(&subnet, pool.SubnetID).Error; err != nil {
+		return netip.Addr{}, err
+	}
+
+	candidates, ok := poolRange(&subnet, &pool)
+	if !ok {
+		return netip.Addr{}, fmt.Errorf("%w: pool %d has no allocatable addresses", domain.ErrExhausted, poolID)
+	}
+	if (candidates.From.Is4() && model.IPv4 != nil) || (candidates.From.Is6() && model.IPv6 != nil) {
+		return netip.Addr{}, fmt.Errorf("%w: pool %d allocates the address family already informed", domain.ErrInvalid, poolID)
+	}
+
+	column := addressColumn(candidates.From)
+	var used [][]byte
+	err := tx.Model(&CentralModel{}).
+		Where(column+" BETWEEN ? AND ?", candidates.From.AsSlice(), candidates.To.AsSlice()).
+		Order(column).Pluck(column, &used).Error
+	if err != nil {
+		return netip.Addr{}, err
+	}
+
+	addr, ok := nextFree(candidates, used, excludedRanges(&subnet))
+	if !ok {
+		return netip.Addr{}, fmt.Errorf("%w: pool %d is exhausted", domain.ErrExhausted, poolID)
+	}
+	value := addr.String()
+	if addr.Is4() {
+		model.IPv4 = &value
+	} else {
+		model.IPv6 = &value
+	}
+	model.setAddressBytes()
+	return addr, nil
+}
+
+// Percorre a faixa pulando os endereços usados (ordenados) e os excluídos
+func nextFree(candidates domain.IPRange, used [][]byte, excluded []domain.IPRange) (netip.Addr, bool) {
+	addr := candidates.From
+	i := 0
+	for addr.IsValid() && !candidates.To.Less(addr) {
+		if skip, ok := containingRange(excluded, addr); ok {
+			addr = skip.To.Next()
+			continue
+		}
+		for i < len(used) && bytes.Compare(used[i], addr.AsSlice()) < 0 {
+			i++
+		}
+		if i < len(used) && bytes.Equal(used[i], addr.AsSlice()) {
+			addr = addr.Next()
+			continue
+		}
+		return addr, true
+	}
+	return netip.Addr{}, false
+}
+
+func containingRange(ranges []domain.IPRange, addr netip.Addr) (domain.IPRange, bool) {
+	for _, r := range ranges {
+		if utils.RangeContains(r, addr) {
+			return r, true
+		}
+	}
+	return domain.IPRange{}, false
+}
+
+// Endereços alocáveis do pool: sua faixa dentro da parte utilizável da sub-rede
+func poolRange(subnet *SubnetModel, pool *PoolModel) (domain.IPRange, bool) {
+	usable, err := utils.UsableRange(subnet.CIDR)
+	if err != nil {
+		return domain.IPRange{}, false
+	}
+	r, err := utils.AddressRange(pool.Start, pool.End)
+	if err != nil {
+		return domain.IPRange{}, false
+	}
+	return utils.RangeIntersection(usable, r)
+}
+
+// Gateway e faixas reservadas da sub-rede
+func excludedRanges(subnet *SubnetModel) []domain.IPRange {
+	var ranges []domain.IPRange
+	if gateway, err := utils.NormalizeIP(subnet.Gateway); err == nil {
+		ranges = append(ranges, domain.IPRange{From: gateway, To: gateway})
+	}
+	for _, reserved := range subnet.Reserved {
+		if r, err := utils.AddressRange(reserved.Start, reserved.End); err == nil {
+			ranges = append(ranges, r)
+		}
+	}
+	return ranges
+}
+
+// Coluna textual da central com o endereço da família de addr
+func centralAddressColumn(addr netip.Addr) string {
+	if addr.Is4() {
+		return "ipv4"
+	}
+	return "ipv6"
+}
+
+// Coluna binária da família do endereço
+func addressColumn(addr netip.Addr) string {
+	if addr.Is4() {
+		return "ipv4_bin"
+	}
+	return "ipv6_bin"
+}
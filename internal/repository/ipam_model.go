@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"api-golang/internal/domain"
+	"time"
+)
+
+// Faixa reservada gravada como JSON na sub-rede
+type reservedRange struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// Modelo de persistência da sub-rede
+type SubnetModel struct {
+	ID          uint `gorm:"primaryKey"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	CIDR        string `gorm:"column:cidr;not null;uniqueIndex"`
+	Gateway     string
+	Description string
+	Reserved    []reservedRange `gorm:"serializer:json"`
+}
+
+func (SubnetModel) TableName() string {
+	return "subnets"
+}
+
+func newSubnetModel(subnet *domain.Subnet) *SubnetModel {
+	reserved := make([]reservedRange, 0, len(subnet.Reserved))
+	for _, r := range subnet.Reserved {
+		reserved = append(reserved, reservedRange{Start: r.Start, End: r.End})
+	}
+	return &SubnetModel{
+		ID:          subnet.ID,
+		CreatedAt:   subnet.CreatedAt,
+		UpdatedAt:   subnet.UpdatedAt,
+		CIDR:        subnet.CIDR,
+		Gateway:     subnet.Gateway,
+		Description: subnet.Description,
+		Reserved:    reserved,
+	}
+}
+
+func (m *SubnetModel) toDomain() *domain.Subnet {
+	reserved := make([]domain.AddressRange, 0, len(m.Reserved))
+	for _, r := range m.Reserved {
+		reserved = append(reserved, domain.AddressRange{Start: r.Start, End: r.End})
+	}
+	return &domain.Subnet{
+		ID:          m.ID,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+		CIDR:        m.CIDR,
+		Gateway:     m.Gateway,
+		Description: m.Description,
+		Reserved:    reserved,
+	}
+}
+
+// Modelo de persistência do pool de alocação
+type PoolModel struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	SubnetID  uint   `gorm:"not null;index"`
+	Name      string `gorm:"not null"`
+	Start     string `gorm:"column:range_start;not null"`
+	End       string `gorm:"column:range_end;not null"`
+}
+
+func (PoolModel) TableName() string {
+	return "pools"
+}
+
+func newPoolModel(pool *domain.Pool) *PoolModel {
+	return &PoolModel{
+		ID:        pool.ID,
+		CreatedAt: pool.CreatedAt,
+		UpdatedAt: pool.UpdatedAt,
+		SubnetID:  pool.SubnetID,
+		Name:      pool.Name,
+		Start:     pool.Start,
+		End:       pool.End,
+	}
+}
+
+func (m *PoolModel) toDomain() *domain.Pool {
+	return &domain.Pool{
+		ID:        m.ID,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+		SubnetID:  m.SubnetID,
+		Name:      m.Name,
+		Start:     m.Start,
+		End:       m.End,
+	}
+}
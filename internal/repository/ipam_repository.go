@@ -0,0 +1,262 @@
+package repository
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/utils"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// Repositório de sub-redes e pools do IPAM
+type IPAMRepository struct {
+	DB *gorm.DB
+}
+
+func NewIPAMRepository(db *gorm.DB) *IPAMRepository {
+	return &IPAMRepository{DB: db}
+}
+
+func (r *IPAMRepository) CreateSubnet(subnet *domain.Subnet) error {
+	model := newSubnetModel(subnet)
+	if err := r.DB.Create(model).Error; err != nil {
+		return r.translate(err)
+	}
+	*subnet = *model.toDomain()
+	return nil
+}
+
+func (r *IPAMRepository) GetSubnets() ([]domain.Subnet, error) {
+	var models []SubnetModel
+	if err := r.DB.Order("id").Find(&models).Error; err != nil {
+		return nil, err
+	}
+	subnets := make([]domain.Subnet, 0, len(models))
+	for i := range models {
+		subnets = append(subnets, *models[i].toDomain())
+	}
+	return subnets, nil
+}
+
+func (r *IPAMRepository) GetSubnetByID(id uint) (*domain.Subnet, error) {
+	var model SubnetModel
+	if err := r.DB.First(&model, id).Error; err != nil {
+		return nil, r.translate(err)
+	}
+	return model.toDomain(), nil
+}
+
+// Atualiza gateway, descrição e reservas; o CIDR não muda
+func (r *IPAMRepository) UpdateSubnet(subnet *domain.Subnet) error {
+	result := r.DB.Model(&SubnetModel{ID: subnet.ID}).
+		Select("gateway", "description", "reserved", "updated_at").
+		Updates(newSubnetModel(subnet))
+	if result.Error != nil {
+		return r.translate(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+
+	updated, err := r.GetSubnetByID(subnet.ID)
+	if err != nil {
+		return err
+	}
+	*subnet = *updated
+	return nil
+}
+
+// Remove a sub-rede e seus pools. As centrais mantêm os IPs já alocados.
+func (r *IPAMRepository) DeleteSubnet(id uint) error {
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&SubnetModel{}, id).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("subnet_id = ?", id).Delete(&PoolModel{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&SubnetModel{}, id).Error
+	})
+	return r.translate(err)
+}
+
+func (r *IPAMRepository) CreatePool(pool *domain.Pool) error {
+	model := newPoolModel(pool)
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&SubnetModel{}, pool.SubnetID).Error; err != nil {
+			return err
+		}
+		return tx.Create(model).Error
+	})
+	if err != nil {
+		return r.translate(err)
+	}
+	*pool = *model.toDomain()
+	return nil
+}
+
+func (r *IPAMRepository) GetPools(subnetID uint) ([]domain.Pool, error) {
+	if err := r.DB.First(&SubnetModel{}, subnetID).Error; err != nil {
+		return nil, r.translate(err)
+	}
+
+	var models []PoolModel
+	if err := r.DB.Where("subnet_id = ?", subnetID).Order("id").Find(&models).Error; err != nil {
+		return nil, err
+	}
+	pools := make([]domain.Pool, 0, len(models))
+	for i := range models {
+		pools = append(pools, *models[i].toDomain())
+	}
+	return pools, nil
+}
+
+func (r *IPAMRepository) GetPoolByID(id uint) (*domain.Pool, error) {
+	var model PoolModel
+	if err := r.DB.First(&model, id).Error; err != nil {
+		return nil, r.translate(err)
+	}
+	return model.toDomain(), nil
+}
+
+func (r *IPAMRepository) UpdatePool(pool *domain.Pool) error {
+	result := r.DB.Model(&PoolModel{ID: pool.ID}).
+		Select("name", "range_start", "range_end", "updated_at").
+		Updates(newPoolModel(pool))
+	if result.Error != nil {
+		return r.translate(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+
+	updated, err := r.GetPoolByID(pool.ID)
+	if err != nil {
+		return err
+	}
+	*pool = *updated
+	return nil
+}
+
+func (r *IPAMRepository) DeletePool(id uint) error {
+	result := r.DB.Delete(&PoolModel{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// Utilização da sub-rede e de cada um dos seus pools
+func (r *IPAMRepository) GetSubnetUtilization(id uint) (*domain.SubnetUtilization, error) {
+	var model SubnetModel
+	if err := r.DB.First(&model, id).Error; err != nil {
+		return nil, r.translate(err)
+	}
+	return r.subnetUtilization(&model)
+}
+
+// Utilização de todas as sub-redes
+func (r *IPAMRepository) GetUtilization() ([]domain.SubnetUtilization, error) {
+	var models []SubnetModel
+	if err := r.DB.Order("id").Find(&models).Error; err != nil {
+		return nil, err
+	}
+	report := make([]domain.SubnetUtilization, 0, len(models))
+	for i := range models {
+		u, err := r.subnetUtilization(&models[i])
+		if err != nil {
+			return nil, err
+		}
+		report = append(report, *u)
+	}
+	return report, nil
+}
+
+func (r *IPAMRepository) subnetUtilization(subnet *SubnetModel) (*domain.SubnetUtilization, error) {
+	usable, err := utils.UsableRange(subnet.CIDR)
+	if err != nil {
+		return nil, err
+	}
+	excluded := excludedRanges(subnet)
+
+	u, err := r.rangeUtilization(usable, excluded)
+	if err != nil {
+		return nil, err
+	}
+	report := &domain.SubnetUtilization{SubnetID: subnet.ID, CIDR: subnet.CIDR, Utilization: u}
+
+	var pools []PoolModel
+	if err := r.DB.Where("subnet_id = ?", subnet.ID).Order("id").Find(&pools).Error; err != nil {
+		return nil, err
+	}
+	report.Pools = make([]domain.PoolUtilization, 0, len(pools))
+	for i := range pools {
+		pu := domain.PoolUtilization{PoolID: pools[i].ID, Name: pools[i].Name}
+		if candidates, ok := poolRange(subnet, &pools[i]); ok {
+			if pu.Utilization, err = r.rangeUtilization(candidates, excluded); err != nil {
+				return nil, err
+			}
+		}
+		report.Pools = append(report.Pools, pu)
+	}
+	return report, nil
+}
+
+// Conta endereços totais, reservados e em uso dentro da faixa. Centrais em
+// endereços reservados contam só como reservadas, e reservas sobrepostas
+// contam uma vez.
+func (r *IPAMRepository) rangeUtilization(candidates domain.IPRange, excluded []domain.IPRange) (domain.Utilization, error) {
+	u := domain.Utilization{Total: utils.RangeSize(candidates)}
+	var reserved []domain.IPRange
+	for _, e := range excluded {
+		if overlap, ok := utils.RangeIntersection(candidates, e); ok {
+			reserved = append(reserved, overlap)
+		}
+	}
+	reserved = utils.MergeRanges(reserved)
+
+	column := addressColumn(candidates.From)
+	query := r.DB.Model(&CentralModel{}).
+		Where(column+" BETWEEN ? AND ?", candidates.From.AsSlice(), candidates.To.AsSlice())
+	for _, e := range reserved {
+		u.Reserved = saturatingAdd(u.Reserved, utils.RangeSize(e))
+		query = query.Where(column+" NOT BETWEEN ? AND ?", e.From.AsSlice(), e.To.AsSlice())
+	}
+
+	var used int64
+	err := query.Count(&used).Error
+	if err != nil {
+		return u, err
+	}
+	u.Used = uint64(used)
+
+	allocatable := u.Total - min(u.Reserved, u.Total)
+	u.Free = allocatable - min(u.Used, allocatable)
+	if allocatable > 0 {
+		u.Percent = float64(u.Used) / float64(allocatable) * 100
+	}
+	return u, nil
+}
+
+func saturatingAdd(a, b uint64) uint64 {
+	if a+b < a {
+		return ^uint64(0)
+	}
+	return a + b
+}
+
+// Traduz erros do banco, detalhando violações de unicidade
+func (r *IPAMRepository) translate(err error) error {
+	if errors.Is(err, domain.ErrConflict) || errors.Is(err, domain.ErrInvalid) {
+		return err
+	}
+	err = translateError(r.DB, err)
+	if errors.Is(err, domain.ErrConflict) {
+		return fmt.Errorf("%w: a subnet with this CIDR already exists", domain.ErrConflict)
+	}
+	return err
+}
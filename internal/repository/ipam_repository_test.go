@@ -0,0 +1,205 @@
+package repository_test
+
+import (
+	"api-golang/internal/config"
+	"api-golang/internal/domain"
+	"api-golang/internal/repository"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// Sub-rede /29 com gateway e uma reserva, e um pool cobrindo a sub-rede toda
+func seedIPAM(t *testing.T, repo *repository.IPAMRepository) (*domain.Subnet, *domain.Pool) {
+	subnet := &domain.Subnet{
+		CIDR:     "10.0.0.0/29",
+		Gateway:  "10.0.0.1",
+		Reserved: []domain.AddressRange{{Start: "10.0.0.3", End: "10.0.0.4"}},
+	}
+	require.NoError(t, repo.CreateSubnet(subnet))
+	pool := &domain.Pool{SubnetID: subnet.ID, Name: "dhcp", Start: "10.0.0.0", End: "10.0.0.7"}
+	require.NoError(t, repo.CreatePool(pool))
+	return subnet, pool
+}
+
+func createFromPool(repo *repository.CentralRepository, poolID uint, mac string) (*domain.Central, error) {
+	central := &domain.Central{Name: "Central " + mac, MAC: mac, PoolID: &poolID}
+	err := repo.Create(central)
+	return central, err
+}
+
+func TestAllocateFromPool(t *testing.T) {
+	db := setupInMemoryDB()
+	centralRepo := repository.NewCentralRepository(db)
+	_, pool := seedIPAM(t, repository.NewIPAMRepository(db))
+
+	// Pula rede, gateway e reservas: sobram .2, .5 e .6
+	var allocated []string
+	for i := 1; i <= 3; i++ {
+		central, err := createFromPool(centralRepo, pool.ID, fmt.Sprintf("00:11:22:33:44:0%d", i))
+		require.NoError(t, err)
+		allocated = append(allocated, central.IPv4)
+	}
+	assert.Equal(t, []string{"10.0.0.2", "10.0.0.5", "10.0.0.6"}, allocated)
+
+	_, err := createFromPool(centralRepo, pool.ID, "00:11:22:33:44:04")
+	assert.ErrorIs(t, err, domain.ErrConflict)
+	assert.ErrorIs(t, err, domain.ErrExhausted)
+
+	// O endereço de uma central removida volta a ficar livre
+	centrals, err := centralRepo.GetAll(domain.CentralFilter{IP: "10.0.0.5"})
+	require.NoError(t, err)
+	require.NoError(t, centralRepo.Delete(centrals[0].ID))
+	central, err := createFromPool(centralRepo, pool.ID, "00:11:22:33:44:05")
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.5", central.IPv4)
+}
+
+func TestAllocateFromPool_Invalid(t *testing.T) {
+	db := setupInMemoryDB()
+	centralRepo := repository.NewCentralRepository(db)
+	_, pool := seedIPAM(t, repository.NewIPAMRepository(db))
+
+	_, err := createFromPool(centralRepo, 99, "00:11:22:33:44:01")
+	assert.ErrorIs(t, err, domain.ErrInvalid)
+
+	// O pool é IPv4 e o IPv4 já foi informado
+	err = centralRepo.Create(&domain.Central{Name: "Central", MAC: "00:11:22:33:44:02", IPv4: "10.0.0.2", PoolID: &pool.ID})
+	assert.ErrorIs(t, err, domain.ErrInvalid)
+}
+
+func TestAllocateFromPool_DuplicateMAC(t *testing.T) {
+	db := setupInMemoryDB()
+	centralRepo := repository.NewCentralRepository(db)
+	_, pool := seedIPAM(t, repository.NewIPAMRepository(db))
+	_, err := createFromPool(centralRepo, pool.ID, "00:11:22:33:44:01")
+	require.NoError(t, err)
+
+	// Conta as inserções de centrais para ver quantas tentativas houve
+	attempts := 0
+	require.NoError(t, db.Callback().Create().Before("gorm:create").Register("test:count_centrals", func(tx *gorm.DB) {
+		if tx.Statement.Table == "centrals" {
+			attempts++
+		}
+	}))
+
+	// MAC repetido não é corrida pelo IP: falha sem tentar de novo
+	_, err = createFromPool(centralRepo, pool.ID, "00:11:22:33:44:01")
+	assert.ErrorIs(t, err, domain.ErrConflict)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestAllocateFromPool_Concurrent(t *testing.T) {
+	// Banco em arquivo, aberto como em produção, para que as conexões
+	// concorrentes vejam os mesmos dados
+	db, err := config.OpenDB(filepath.Join(t.TempDir(), "ipam.db"))
+	require.NoError(t, err)
+	require.NoError(t, repository.Migrate(db))
+
+	ipamRepo := repository.NewIPAMRepository(db)
+	subnet := &domain.Subnet{CIDR: "10.1.0.0/24"}
+	require.NoError(t, ipamRepo.CreateSubnet(subnet))
+	pool := &domain.Pool{SubnetID: subnet.ID, Name: "dhcp", Start: "10.1.0.10", End: "10.1.0.200"}
+	require.NoError(t, ipamRepo.CreatePool(pool))
+
+	centralRepo := repository.NewCentralRepository(db)
+	const clients = 20
+	ips := make([]string, clients)
+	errs := make([]error, clients)
+	var wg sync.WaitGroup
+	for i := 0; i < clients; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			central, err := createFromPool(centralRepo, pool.ID, fmt.Sprintf("00:11:22:33:45:%02x", i))
+			ips[i], errs[i] = central.IPv4, err
+		}(i)
+	}
+	wg.Wait()
+
+	seen := make(map[string]bool)
+	for i := range ips {
+		require.NoError(t, errs[i])
+		assert.False(t, seen[ips[i]], "IP alocado duas vezes: %s", ips[i])
+		seen[ips[i]] = true
+	}
+}
+
+func TestSubnetUtilization(t *testing.T) {
+	db := setupInMemoryDB()
+	ipamRepo := repository.NewIPAMRepository(db)
+	centralRepo := repository.NewCentralRepository(db)
+	subnet, pool := seedIPAM(t, ipamRepo)
+
+	_, err := createFromPool(centralRepo, pool.ID, "00:11:22:33:44:01")
+	require.NoError(t, err)
+
+	u, err := ipamRepo.GetSubnetUtilization(subnet.ID)
+	require.NoError(t, err)
+	// 6 utilizáveis (.1 a .6), 3 reservados (gateway, .3 e .4), 1 em uso
+	assert.Equal(t, uint64(6), u.Total)
+	assert.Equal(t, uint64(3), u.Reserved)
+	assert.Equal(t, uint64(1), u.Used)
+	assert.Equal(t, uint64(2), u.Free)
+	assert.InDelta(t, 33.33, u.Percent, 0.01)
+	require.Len(t, u.Pools, 1)
+	assert.Equal(t, "dhcp", u.Pools[0].Name)
+	assert.Equal(t, uint64(1), u.Pools[0].Used)
+
+	report, err := ipamRepo.GetUtilization()
+	require.NoError(t, err)
+	assert.Len(t, report, 1)
+
+	_, err = ipamRepo.GetSubnetUtilization(99)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestSubnetUtilization_Reserved(t *testing.T) {
+	db := setupInMemoryDB()
+	ipamRepo := repository.NewIPAMRepository(db)
+	centralRepo := repository.NewCentralRepository(db)
+
+	// Reservas sobrepostas, uma delas cobrindo o gateway
+	subnet := &domain.Subnet{
+		CIDR:    "10.0.0.0/29",
+		Gateway: "10.0.0.1",
+		Reserved: []domain.AddressRange{
+			{Start: "10.0.0.1", End: "10.0.0.3"},
+			{Start: "10.0.0.3", End: "10.0.0.4"},
+		},
+	}
+	require.NoError(t, ipamRepo.CreateSubnet(subnet))
+	// Centrais no gateway e numa reserva não contam como em uso
+	for i, ip := range []string{"10.0.0.1", "10.0.0.3", "10.0.0.5"} {
+		require.NoError(t, centralRepo.Create(&domain.Central{Name: ip, MAC: fmt.Sprintf("00:11:22:33:44:0%d", i+1), IPv4: ip}))
+	}
+
+	u, err := ipamRepo.GetSubnetUtilization(subnet.ID)
+	require.NoError(t, err)
+	// 6 utilizáveis (.1 a .6), 4 reservados (.1 a .4), 1 em uso (.5)
+	assert.Equal(t, uint64(6), u.Total)
+	assert.Equal(t, uint64(4), u.Reserved)
+	assert.Equal(t, uint64(1), u.Used)
+	assert.Equal(t, uint64(1), u.Free)
+	assert.InDelta(t, 50, u.Percent, 0.01)
+}
+
+func TestDeleteSubnet_RemovesPools(t *testing.T) {
+	db := setupInMemoryDB()
+	repo := repository.NewIPAMRepository(db)
+	subnet, pool := seedIPAM(t, repo)
+
+	// CIDR duplicado
+	err := repo.CreateSubnet(&domain.Subnet{CIDR: subnet.CIDR})
+	assert.ErrorIs(t, err, domain.ErrConflict)
+
+	require.NoError(t, repo.DeleteSubnet(subnet.ID))
+	_, err = repo.GetPoolByID(pool.ID)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.ErrorIs(t, repo.DeleteSubnet(subnet.ID), domain.ErrNotFound)
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"api-golang/internal/domain"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Modelo de persistência de um label de central. O índice por chave e
+// valor atende as subconsultas geradas pelos seletores.
+type CentralLabelModel struct {
+	CentralID uint   `gorm:"primaryKey;autoIncrement:false"`
+	Key       string `gorm:"column:label_key;primaryKey;index:idx_label_key_value,priority:1"`
+	Value     string `gorm:"column:label_value;not null;index:idx_label_key_value,priority:2"`
+}
+
+func (CentralLabelModel) TableName() string {
+	return "central_labels"
+}
+
+// Carrega os labels das centrais informadas, indexados pelo ID da central
+func loadLabels(db *gorm.DB, centralIDs []uint) (map[uint]map[string]string, error) {
+	labels := make(map[uint]map[string]string, len(centralIDs))
+	if len(centralIDs) == 0 {
+		return labels, nil
+	}
+
+	var models []CentralLabelModel
+	if err := db.Where("central_id IN ?", centralIDs).Order("label_key").Find(&models).Error; err != nil {
+		return nil, err
+	}
+	for _, m := range models {
+		if labels[m.CentralID] == nil {
+			labels[m.CentralID] = make(map[string]string)
+		}
+		labels[m.CentralID][m.Key] = m.Value
+	}
+	return labels, nil
+}
+
+// Grava os labels nas centrais, sobrescrevendo o valor das chaves existentes
+func upsertLabels(tx *gorm.DB, centralIDs []uint, labels map[string]string) error {
+	if len(centralIDs) == 0 || len(labels) == 0 {
+		return nil
+	}
+	models := make([]CentralLabelModel, 0, len(centralIDs)*len(labels))
+	for _, id := range centralIDs {
+		for key, value := range labels {
+			models = append(models, CentralLabelModel{CentralID: id, Key: key, Value: value})
+		}
+	}
+	return tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "central_id"}, {Name: "label_key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"label_value"}),
+	}).Create(&models).Error
+}
+
+// Substitui todos os labels da central
+func replaceLabels(tx *gorm.DB, centralID uint, labels map[string]string) error {
+	if err := tx.Where("central_id = ?", centralID).Delete(&CentralLabelModel{}).Error; err != nil {
+		return err
+	}
+	return upsertLabels(tx, []uint{centralID}, labels)
+}
+
+// Traduz cada condição do seletor em uma subconsulta sobre central_labels.
+// Como no Kubernetes, != e notin também casam com centrais sem a chave.
+func applySelector(query *gorm.DB, selector domain.LabelSelector) (*gorm.DB, error) {
+	const labelQuery = "SELECT central_id FROM central_labels WHERE label_key = ?"
+	for _, req := range selector {
+		switch req.Operator {
+		case domain.SelectorEquals:
+			query = query.Where("id IN ("+labelQuery+" AND label_value = ?)", req.Key, req.Values[0])
+		case domain.SelectorNotEquals:
+			query = query.Where("id NOT IN ("+labelQuery+" AND label_value = ?)", req.Key, req.Values[0])
+		case domain.SelectorIn:
+			query = query.Where("id IN ("+labelQuery+" AND label_value IN ?)", req.Key, req.Values)
+		case domain.SelectorNotIn:
+			query = query.Where("id NOT IN ("+labelQuery+" AND label_value IN ?)", req.Key, req.Values)
+		case domain.SelectorExists:
+			query = query.Where("id IN ("+labelQuery+")", req.Key)
+		case domain.SelectorNotExists:
+			query = query.Where("id NOT IN ("+labelQuery+")", req.Key)
+		default:
+			return nil, fmt.Errorf("%w: unknown selector operator %q", domain.ErrInvalid, req.Operator)
+		}
+	}
+	return query, nil
+}
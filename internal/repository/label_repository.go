@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"api-golang/internal/domain"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// Repositório de labels das centrais, com operações em lote
+type LabelRepository struct {
+	DB *gorm.DB
+}
+
+func NewLabelRepository(db *gorm.DB) *LabelRepository {
+	return &LabelRepository{DB: db}
+}
+
+// Labels de uma central
+func (r *LabelRepository) GetLabels(centralID uint) (map[string]string, error) {
+	if err := r.DB.First(&CentralModel{}, centralID).Error; err != nil {
+		return nil, translateError(r.DB, err)
+	}
+	labels, err := loadLabels(r.DB, []uint{centralID})
+	if err != nil {
+		return nil, err
+	}
+	if labels[centralID] == nil {
+		return map[string]string{}, nil
+	}
+	return labels[centralID], nil
+}
+
+// Adiciona (ou sobrescreve) os labels em todas as centrais informadas
+func (r *LabelRepository) AddLabels(centralIDs []uint, labels map[string]string) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := ensureCentralsExist(tx, centralIDs); err != nil {
+			return err
+		}
+		if err := upsertLabels(tx, centralIDs, labels); err != nil {
+			return err
+		}
+		return touchCentrals(tx, centralIDs)
+	})
+}
+
+// Remove as chaves informadas de todas as centrais informadas
+func (r *LabelRepository) RemoveLabels(centralIDs []uint, keys []string) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := ensureCentralsExist(tx, centralIDs); err != nil {
+			return err
+		}
+		// Só as centrais que tinham alguma das chaves mudam
+		var changed []uint
+		err := tx.Model(&CentralLabelModel{}).Distinct("central_id").
+			Where("central_id IN ? AND label_key IN ?", centralIDs, keys).Pluck("central_id", &changed).Error
+		if err != nil {
+			return err
+		}
+		if err := tx.Where("central_id IN ? AND label_key IN ?", changed, keys).Delete(&CentralLabelModel{}).Error; err != nil {
+			return err
+		}
+		return touchCentrals(tx, changed)
+	})
+}
+
+// Marca as centrais como alteradas e grava o evento de cada uma no outbox
+func touchCentrals(tx *gorm.DB, centralIDs []uint) error {
+	if len(centralIDs) == 0 {
+		return nil
+	}
+	now := tx.NowFunc()
+	if err := tx.Model(&CentralModel{}).Where("id IN ?", centralIDs).Update("updated_at", now).Error; err != nil {
+		return err
+	}
+	return appendCentralEvents(tx, domain.EventCentralUpdated, centralIDs, now)
+}
+
+// Falha com ErrNotFound se algum dos IDs não corresponder a uma central
+func ensureCentralsExist(tx *gorm.DB, centralIDs []uint) error {
+	var found []uint
+	if err := tx.Model(&CentralModel{}).Where("id IN ?", centralIDs).Pluck("id", &found).Error; err != nil {
+		return err
+	}
+	existing := make(map[uint]bool, len(found))
+	for _, id := range found {
+		existing[id] = true
+	}
+	for _, id := range centralIDs {
+		if !existing[id] {
+			return fmt.Errorf("%w: central %d", domain.ErrNotFound, id)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package repository_test
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/repository"
+	"api-golang/internal/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Nomes das centrais que satisfazem o seletor
+func selectNames(t *testing.T, repo *repository.CentralRepository, selector string) []string {
+	parsed, err := utils.ParseLabelSelector(selector)
+	require.NoError(t, err)
+	centrals, err := repo.GetAll(domain.CentralFilter{Selector: parsed})
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(centrals))
+	for _, c := range centrals {
+		names = append(names, c.Name)
+	}
+	return names
+}
+
+func TestGetAllCentrals_LabelSelector(t *testing.T) {
+	repo := repository.NewCentralRepository(setupInMemoryDB())
+
+	require.NoError(t, repo.Create(&domain.Central{Name: "A", MAC: "00:11:22:33:44:01", IPv4: "10.0.0.1",
+		Labels: map[string]string{"env": "prod", "vendor": "intelbras", "tier": "a"}}))
+	require.NoError(t, repo.Create(&domain.Central{Name: "B", MAC: "00:11:22:33:44:02", IPv4: "10.0.0.2",
+		Labels: map[string]string{"env": "prod", "vendor": "acme", "tier": "b"}}))
+	require.NoError(t, repo.Create(&domain.Central{Name: "C", MAC: "00:11:22:33:44:03", IPv4: "10.0.0.3",
+		Labels: map[string]string{"env": "dev", "tier": "c"}}))
+
+	assert.Equal(t, []string{"A", "B"}, selectNames(t, repo, "env=prod"))
+	assert.Equal(t, []string{"A"}, selectNames(t, repo, "env=prod,vendor!=acme"))
+	assert.Equal(t, []string{"A", "C"}, selectNames(t, repo, "vendor!=acme"))
+	assert.Equal(t, []string{"A", "B"}, selectNames(t, repo, "tier in (a,b)"))
+	assert.Equal(t, []string{"C"}, selectNames(t, repo, "tier notin (a,b)"))
+	assert.Equal(t, []string{"A", "B"}, selectNames(t, repo, "vendor"))
+	assert.Equal(t, []string{"C"}, selectNames(t, repo, "!vendor"))
+}
+
+func TestLabels_BulkAddAndRemove(t *testing.T) {
+	db := setupInMemoryDB()
+	centralRepo := repository.NewCentralRepository(db)
+	repo := repository.NewLabelRepository(db)
+
+	a := createCentral(t, centralRepo, "00:11:22:33:44:01", "10.0.0.1")
+	b := createCentral(t, centralRepo, "00:11:22:33:44:02", "10.0.0.2")
+
+	require.NoError(t, repo.AddLabels([]uint{a.ID, b.ID}, map[string]string{"env": "dev", "site": "sul"}))
+	// Chave existente tem o valor sobrescrito
+	require.NoError(t, repo.AddLabels([]uint{a.ID}, map[string]string{"env": "prod"}))
+
+	labels, err := repo.GetLabels(a.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"env": "prod", "site": "sul"}, labels)
+
+	require.NoError(t, repo.RemoveLabels([]uint{a.ID, b.ID}, []string{"site"}))
+	labels, err = repo.GetLabels(b.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"env": "dev"}, labels)
+
+	// Um ID inexistente aborta o lote inteiro
+	err = repo.AddLabels([]uint{a.ID, 999}, map[string]string{"tier": "a"})
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	labels, _ = repo.GetLabels(a.ID)
+	assert.NotContains(t, labels, "tier")
+}
+
+func TestUpdateCentral_ReplacesLabels(t *testing.T) {
+	repo := repository.NewCentralRepository(setupInMemoryDB())
+
+	central := &domain.Central{Name: "A", MAC: "00:11:22:33:44:01", IPv4: "10.0.0.1", Labels: map[string]string{"env": "dev"}}
+	require.NoError(t, repo.Create(central))
+
+	// Sem labels na atualização, os atuais são mantidos
+	central.Labels = nil
+	require.NoError(t, repo.Update(central))
+	assert.Equal(t, map[string]string{"env": "dev"}, central.Labels)
+
+	central.Labels = map[string]string{"env": "prod"}
+	require.NoError(t, repo.Update(central))
+	assert.Equal(t, map[string]string{"env": "prod"}, central.Labels)
+}
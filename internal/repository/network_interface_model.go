@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"api-golang/internal/domain"
+	"errors"
+	"fmt"
+	"net/netip"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Modelo de persistência das interfaces de rede. O índice único em "mac"
+// garante a unicidade global, já que o MAC da central é o da interface
+// principal.
+type NetworkInterfaceModel struct {
+	ID        uint `gorm:"primaryKey"`
+	CentralID uint `gorm:"not null;uniqueIndex:idx_interface_central_name"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Name      string `gorm:"not null;uniqueIndex:idx_interface_central_name"`
+	MAC       string `gorm:"not null;uniqueIndex"`
+	Vendor    string
+	IPs       []string `gorm:"column:ips;serializer:json"`
+	VLAN      int      `gorm:"column:vlan;not null;default:0"`
+	Primary   bool     `gorm:"column:is_primary;not null;default:false"`
+}
+
+func (NetworkInterfaceModel) TableName() string {
+	return "network_interfaces"
+}
+
+func newNetworkInterfaceModel(iface *domain.NetworkInterface) *NetworkInterfaceModel {
+	return &NetworkInterfaceModel{
+		ID:        iface.ID,
+		CentralID: iface.CentralID,
+		CreatedAt: iface.CreatedAt,
+		UpdatedAt: iface.UpdatedAt,
+		Name:      iface.Name,
+		MAC:       iface.MAC,
+		Vendor:    iface.Vendor,
+		IPs:       iface.IPs,
+		VLAN:      iface.VLAN,
+		Primary:   iface.Primary,
+	}
+}
+
+func (m *NetworkInterfaceModel) toDomain() *domain.NetworkInterface {
+	ips := m.IPs
+	if ips == nil {
+		ips = []string{}
+	}
+	return &domain.NetworkInterface{
+		ID:        m.ID,
+		CentralID: m.CentralID,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+		Name:      m.Name,
+		MAC:       m.MAC,
+		Vendor:    m.Vendor,
+		IPs:       ips,
+		VLAN:      m.VLAN,
+		Primary:   m.Primary,
+	}
+}
+
+// Cria a interface principal de uma central recém-criada
+func createPrimaryInterface(tx *gorm.DB, central *CentralModel) (*NetworkInterfaceModel, error) {
+	iface := &NetworkInterfaceModel{
+		CentralID: central.ID,
+		Name:      domain.DefaultInterfaceName,
+		MAC:       central.MAC,
+		Vendor:    central.Vendor,
+		IPs:       centralIPs(central),
+		Primary:   true,
+	}
+	if err := tx.Create(iface).Error; err != nil {
+		return nil, err
+	}
+	return iface, nil
+}
+
+// Atualiza a interface principal após uma alteração da central, trocando
+// os endereços antigos pelos novos e preservando os demais IPs
+func syncPrimaryInterface(tx *gorm.DB, previous, current *CentralModel) error {
+	var iface NetworkInterfaceModel
+	err := tx.Where("central_id = ? AND is_primary = ?", current.ID, true).First(&iface).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		_, err = createPrimaryInterface(tx, current)
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	old := make(map[string]bool)
+	for _, ip := range centralIPs(previous) {
+		old[ip] = true
+	}
+	ips := centralIPs(current)
+	for _, ip := range iface.IPs {
+		if !old[ip] && !slices.Contains(ips, ip) {
+			ips = append(ips, ip)
+		}
+	}
+
+	return tx.Model(&iface).Select("mac", "vendor", "ips", "updated_at").
+		Updates(&NetworkInterfaceModel{MAC: current.MAC, Vendor: current.Vendor, IPs: ips}).Error
+}
+
+// Copia MAC e endereços da interface principal para a central. Como a
+// central precisa de ao menos um IPv4 ou IPv6, uma interface sem endereços
+// não pode ser a principal.
+func mirrorPrimaryOnCentral(tx *gorm.DB, iface *NetworkInterfaceModel) error {
+	var ipv4, ipv6 string
+	for _, value := range iface.IPs {
+		ip, err := netip.ParseAddr(value)
+		if err != nil {
+			continue
+		}
+		if ip.Is4() && ipv4 == "" {
+			ipv4 = value
+		} else if ip.Is6() && ipv6 == "" {
+			ipv6 = value
+		}
+	}
+	if ipv4 == "" && ipv6 == "" {
+		return fmt.Errorf("%w: the primary interface needs at least one IP address", domain.ErrInvalid)
+	}
+	mirrored := &CentralModel{MAC: iface.MAC, Vendor: iface.Vendor, IPv4: nullableString(ipv4), IPv6: nullableString(ipv6)}
+	mirrored.setAddressBytes()
+	result := tx.Model(&CentralModel{ID: iface.CentralID}).
+		Select("mac", "vendor", "ipv4", "ipv6", "ipv4_bin", "ipv6_bin", "updated_at").
+		Updates(mirrored)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+	return appendCentralEvents(tx, domain.EventCentralUpdated, []uint{iface.CentralID}, tx.NowFunc())
+}
+
+// Carrega as interfaces principais das centrais informadas
+func loadPrimaryInterfaces(db *gorm.DB, centralIDs []uint) (map[uint]*domain.NetworkInterface, error) {
+	primaries := make(map[uint]*domain.NetworkInterface, len(centralIDs))
+	if len(centralIDs) == 0 {
+		return primaries, nil
+	}
+	var models []NetworkInterfaceModel
+	err := db.Where("central_id IN ? AND is_primary = ?", centralIDs, true).Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+	for i := range models {
+		primaries[models[i].CentralID] = models[i].toDomain()
+	}
+	return primaries, nil
+}
+
+// Cria a interface principal das centrais que ainda não têm interfaces
+func backfillPrimaryInterfaces(db *gorm.DB) error {
+	var centrals []CentralModel
+	err := db.Where("NOT EXISTS (SELECT 1 FROM network_interfaces WHERE network_interfaces.central_id = centrals.id)").
+		Find(&centrals).Error
+	if err != nil {
+		return err
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		for i := range centrals {
+			if _, err := createPrimaryInterface(tx, &centrals[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func centralIPs(central *CentralModel) []string {
+	ips := []string{}
+	for _, ip := range []*string{central.IPv4, central.IPv6} {
+		if ip != nil {
+			ips = append(ips, *ip)
+		}
+	}
+	return ips
+}
@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"api-golang/internal/domain"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+type NetworkInterfaceRepository struct {
+	DB *gorm.DB
+}
+
+func NewNetworkInterfaceRepository(db *gorm.DB) *NetworkInterfaceRepository {
+	return &NetworkInterfaceRepository{DB: db}
+}
+
+func (r *NetworkInterfaceRepository) Create(iface *domain.NetworkInterface) error {
+	model := newNetworkInterfaceModel(iface)
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&CentralModel{}, iface.CentralID).Error; err != nil {
+			return err
+		}
+		if model.Primary {
+			if err := clearPrimary(tx, model.CentralID); err != nil {
+				return err
+			}
+		}
+		if err := tx.Create(model).Error; err != nil {
+			return err
+		}
+		if model.Primary {
+			return mirrorPrimaryOnCentral(tx, model)
+		}
+		return nil
+	})
+	if err != nil {
+		return r.translate(err)
+	}
+	*iface = *model.toDomain()
+	return nil
+}
+
+func (r *NetworkInterfaceRepository) GetAll(centralID uint) ([]domain.NetworkInterface, error) {
+	if err := r.DB.First(&CentralModel{}, centralID).Error; err != nil {
+		return nil, r.translate(err)
+	}
+
+	var models []NetworkInterfaceModel
+	if err := r.DB.Where("central_id = ?", centralID).Order("id").Find(&models).Error; err != nil {
+		return nil, err
+	}
+	ifaces := make([]domain.NetworkInterface, 0, len(models))
+	for i := range models {
+		ifaces = append(ifaces, *models[i].toDomain())
+	}
+	return ifaces, nil
+}
+
+// Interfaces de várias centrais numa única consulta, ordenadas por central;
+// centrais inexistentes são ignoradas
+func (r *NetworkInterfaceRepository) GetByCentrals(centralIDs []uint) ([]domain.NetworkInterface, error) {
+	var models []NetworkInterfaceModel
+	if err := r.DB.Where("central_id IN ?", centralIDs).Order("central_id, id").Find(&models).Error; err != nil {
+		return nil, err
+	}
+	ifaces := make([]domain.NetworkInterface, 0, len(models))
+	for i := range models {
+		ifaces = append(ifaces, *models[i].toDomain())
+	}
+	return ifaces, nil
+}
+
+func (r *NetworkInterfaceRepository) GetByID(centralID, id uint) (*domain.NetworkInterface, error) {
+	var model NetworkInterfaceModel
+	if err := r.DB.Where("central_id = ?", centralID).First(&model, id).Error; err != nil {
+		return nil, r.translate(err)
+	}
+	return model.toDomain(), nil
+}
+
+// Atualiza a interface. A interface principal só deixa de ser principal
+// quando outra é promovida no seu lugar.
+func (r *NetworkInterfaceRepository) Update(iface *domain.NetworkInterface) error {
+	model := newNetworkInterfaceModel(iface)
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		var current NetworkInterfaceModel
+		if err := tx.Where("central_id = ?", iface.CentralID).First(&current, iface.ID).Error; err != nil {
+			return err
+		}
+		if current.Primary && !model.Primary {
+			return fmt.Errorf("%w: promote another interface to primary instead", domain.ErrPrecondition)
+		}
+		if model.Primary && !current.Primary {
+			if err := clearPrimary(tx, model.CentralID); err != nil {
+				return err
+			}
+		}
+		err := tx.Model(&current).Select("name", "mac", "vendor", "ips", "vlan", "is_primary", "updated_at").
+			Updates(model).Error
+		if err != nil {
+			return err
+		}
+		if model.Primary {
+			return mirrorPrimaryOnCentral(tx, model)
+		}
+		return nil
+	})
+	if err != nil {
+		return r.translate(err)
+	}
+
+	updated, err := r.GetByID(iface.CentralID, iface.ID)
+	if err != nil {
+		return err
+	}
+	*iface = *updated
+	return nil
+}
+
+// Remove a interface. A interface principal não pode ser removida.
+func (r *NetworkInterfaceRepository) Delete(centralID, id uint) error {
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		var current NetworkInterfaceModel
+		if err := tx.Where("central_id = ?", centralID).First(&current, id).Error; err != nil {
+			return err
+		}
+		if current.Primary {
+			return fmt.Errorf("%w: the primary interface cannot be deleted", domain.ErrPrecondition)
+		}
+		return tx.Delete(&current).Error
+	})
+	return r.translate(err)
+}
+
+// Rebaixa a interface principal atual da central
+func clearPrimary(tx *gorm.DB, centralID uint) error {
+	return tx.Model(&NetworkInterfaceModel{}).
+		Where("central_id = ? AND is_primary = ?", centralID, true).
+		Update("is_primary", false).Error
+}
+
+// Traduz erros do banco, detalhando violações de unicidade
+func (r *NetworkInterfaceRepository) translate(err error) error {
+	if errors.Is(err, domain.ErrConflict) {
+		return err
+	}
+	err = translateError(r.DB, err)
+	if errors.Is(err, domain.ErrConflict) {
+		return fmt.Errorf("%w: the interface conflicts with an existing MAC address, interface name or IP address", domain.ErrConflict)
+	}
+	return err
+}
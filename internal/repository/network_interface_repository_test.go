@@ -0,0 +1,210 @@
+package repository_test
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/repository"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Cria uma central (e sua interface principal) para os testes
+func createCentral(t *testing.T, repo *repository.CentralRepository, mac, ipv4 string) *domain.Central {
+	central := &domain.Central{Name: "Central " + mac, MAC: mac, IPv4: ipv4}
+	require.NoError(t, repo.Create(central))
+	return central
+}
+
+func TestCentralCreate_CreatesPrimaryInterface(t *testing.T) {
+	db := setupInMemoryDB()
+	centralRepo := repository.NewCentralRepository(db)
+	repo := repository.NewNetworkInterfaceRepository(db)
+
+	central := createCentral(t, centralRepo, "00:11:22:33:44:55", "192.168.0.1")
+	assert.NotNil(t, central.PrimaryInterface)
+
+	ifaces, err := repo.GetAll(central.ID)
+	assert.NoError(t, err)
+	assert.Len(t, ifaces, 1)
+	assert.Equal(t, domain.DefaultInterfaceName, ifaces[0].Name)
+	assert.Equal(t, "00:11:22:33:44:55", ifaces[0].MAC)
+	assert.Equal(t, []string{"192.168.0.1"}, ifaces[0].IPs)
+	assert.True(t, ifaces[0].Primary)
+}
+
+func TestCentralUpdate_SyncsPrimaryInterface(t *testing.T) {
+	db := setupInMemoryDB()
+	centralRepo := repository.NewCentralRepository(db)
+	repo := repository.NewNetworkInterfaceRepository(db)
+
+	central := createCentral(t, centralRepo, "00:11:22:33:44:55", "192.168.0.1")
+
+	// IP extra na interface principal deve ser preservado
+	primary := central.PrimaryInterface
+	primary.IPs = append(primary.IPs, "10.0.0.1")
+	require.NoError(t, repo.Update(primary))
+
+	central.MAC = "00:11:22:33:44:66"
+	central.IPv4 = "192.168.0.2"
+	require.NoError(t, centralRepo.Update(central))
+
+	iface, err := repo.GetByID(central.ID, primary.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "00:11:22:33:44:66", iface.MAC)
+	assert.Equal(t, []string{"192.168.0.2", "10.0.0.1"}, iface.IPs)
+}
+
+func TestCreateInterface_GlobalMACUniqueness(t *testing.T) {
+	db := setupInMemoryDB()
+	centralRepo := repository.NewCentralRepository(db)
+	repo := repository.NewNetworkInterfaceRepository(db)
+
+	first := createCentral(t, centralRepo, "00:11:22:33:44:55", "192.168.0.1")
+	second := createCentral(t, centralRepo, "00:11:22:33:44:66", "192.168.0.2")
+
+	// MAC da interface principal de outra central
+	err := repo.Create(&domain.NetworkInterface{CentralID: second.ID, Name: "eth1", MAC: first.MAC})
+	assert.ErrorIs(t, err, domain.ErrConflict)
+
+	// MAC de uma interface secundária também não pode virar MAC de central
+	err = repo.Create(&domain.NetworkInterface{CentralID: second.ID, Name: "eth1", MAC: "00:11:22:33:44:77"})
+	assert.NoError(t, err)
+	err = centralRepo.Create(&domain.Central{Name: "Central 3", MAC: "00:11:22:33:44:77", IPv4: "192.168.0.3"})
+	assert.ErrorIs(t, err, domain.ErrConflict)
+
+	// Central inexistente
+	err = repo.Create(&domain.NetworkInterface{CentralID: 99, Name: "eth1", MAC: "00:11:22:33:44:88"})
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestCreateInterface_PromotesPrimary(t *testing.T) {
+	db := setupInMemoryDB()
+	centralRepo := repository.NewCentralRepository(db)
+	repo := repository.NewNetworkInterfaceRepository(db)
+
+	central := createCentral(t, centralRepo, "00:11:22:33:44:55", "192.168.0.1")
+
+	iface := &domain.NetworkInterface{
+		CentralID: central.ID,
+		Name:      "eth1",
+		MAC:       "00:11:22:33:44:66",
+		IPs:       []string{"2001:db8::1", "10.0.0.1"},
+		VLAN:      10,
+		Primary:   true,
+	}
+	require.NoError(t, repo.Create(iface))
+
+	// A central passa a espelhar a nova interface principal
+	updated, err := centralRepo.GetByID(central.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "00:11:22:33:44:66", updated.MAC)
+	assert.Equal(t, "10.0.0.1", updated.IPv4)
+	assert.Equal(t, "2001:db8::1", updated.IPv6)
+	assert.Equal(t, iface.ID, updated.PrimaryInterface.ID)
+
+	// A antiga principal foi rebaixada
+	old, err := repo.GetByID(central.ID, central.PrimaryInterface.ID)
+	assert.NoError(t, err)
+	assert.False(t, old.Primary)
+}
+
+func TestPromotePrimary_RequiresAddress(t *testing.T) {
+	db := setupInMemoryDB()
+	centralRepo := repository.NewCentralRepository(db)
+	repo := repository.NewNetworkInterfaceRepository(db)
+
+	central := createCentral(t, centralRepo, "00:11:22:33:44:55", "192.168.0.1")
+
+	// Sem endereços, a central ficaria sem IPv4 e IPv6
+	err := repo.Create(&domain.NetworkInterface{CentralID: central.ID, Name: "eth1", MAC: "00:11:22:33:44:66", Primary: true})
+	assert.ErrorIs(t, err, domain.ErrInvalid)
+
+	iface := &domain.NetworkInterface{CentralID: central.ID, Name: "eth2", MAC: "00:11:22:33:44:77"}
+	require.NoError(t, repo.Create(iface))
+	iface.Primary = true
+	assert.ErrorIs(t, repo.Update(iface), domain.ErrInvalid)
+
+	// Nada mudou: a central e a principal continuam as mesmas
+	unchanged, err := centralRepo.GetByID(central.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "192.168.0.1", unchanged.IPv4)
+	assert.Equal(t, "00:11:22:33:44:55", unchanged.MAC)
+	assert.Equal(t, central.PrimaryInterface.ID, unchanged.PrimaryInterface.ID)
+}
+
+func TestUpdateInterface_CannotUnsetPrimary(t *testing.T) {
+	db := setupInMemoryDB()
+	centralRepo := repository.NewCentralRepository(db)
+	repo := repository.NewNetworkInterfaceRepository(db)
+
+	central := createCentral(t, centralRepo, "00:11:22:33:44:55", "192.168.0.1")
+
+	primary := central.PrimaryInterface
+	primary.Primary = false
+	err := repo.Update(primary)
+	assert.ErrorIs(t, err, domain.ErrConflict)
+}
+
+func TestDeleteInterface(t *testing.T) {
+	db := setupInMemoryDB()
+	centralRepo := repository.NewCentralRepository(db)
+	repo := repository.NewNetworkInterfaceRepository(db)
+
+	central := createCentral(t, centralRepo, "00:11:22:33:44:55", "192.168.0.1")
+	secondary := &domain.NetworkInterface{CentralID: central.ID, Name: "eth1", MAC: "00:11:22:33:44:66"}
+	require.NoError(t, repo.Create(secondary))
+
+	// A interface principal não pode ser removida
+	err := repo.Delete(central.ID, central.PrimaryInterface.ID)
+	assert.ErrorIs(t, err, domain.ErrConflict)
+
+	assert.NoError(t, repo.Delete(central.ID, secondary.ID))
+	_, err = repo.GetByID(central.ID, secondary.ID)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	// Interfaces de outra central não são encontradas
+	err = repo.Delete(99, central.PrimaryInterface.ID)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestGetInterfacesByCentrals(t *testing.T) {
+	db := setupInMemoryDB()
+	centralRepo := repository.NewCentralRepository(db)
+	repo := repository.NewNetworkInterfaceRepository(db)
+
+	first := createCentral(t, centralRepo, "00:11:22:33:44:55", "192.168.0.1")
+	second := createCentral(t, centralRepo, "00:11:22:33:44:66", "192.168.0.2")
+	createCentral(t, centralRepo, "00:11:22:33:44:77", "192.168.0.3")
+	require.NoError(t, repo.Create(&domain.NetworkInterface{CentralID: first.ID, Name: "eth1", MAC: "00:11:22:33:44:88"}))
+
+	ifaces, err := repo.GetByCentrals([]uint{second.ID, first.ID, 99})
+	require.NoError(t, err)
+	require.Len(t, ifaces, 3)
+	assert.Equal(t, []uint{first.ID, first.ID, second.ID}, []uint{ifaces[0].CentralID, ifaces[1].CentralID, ifaces[2].CentralID})
+	assert.Equal(t, "eth1", ifaces[1].Name)
+}
+
+func TestCentralDelete_RemovesInterfaces(t *testing.T) {
+	db := setupInMemoryDB()
+	centralRepo := repository.NewCentralRepository(db)
+
+	central := createCentral(t, centralRepo, "00:11:22:33:44:55", "192.168.0.1")
+	require.NoError(t, centralRepo.Delete(central.ID))
+
+	// O MAC fica livre para uma nova central
+	createCentral(t, centralRepo, "00:11:22:33:44:55", "192.168.0.1")
+}
+
+func TestMigrate_BackfillsPrimaryInterfaces(t *testing.T) {
+	db := setupInMemoryDB()
+
+	// Central gravada antes da existência de interfaces
+	db.Create(&repository.CentralModel{Name: "Central 1", MAC: "00:11:22:33:44:55", IPv4: ptr("192.168.0.1")})
+	require.NoError(t, repository.Migrate(db))
+
+	ifaces, err := repository.NewNetworkInterfaceRepository(db).GetAll(1)
+	assert.NoError(t, err)
+	assert.Len(t, ifaces, 1)
+	assert.True(t, ifaces[0].Primary)
+}
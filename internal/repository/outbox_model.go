@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"api-golang/internal/domain"
+	"encoding/json"
+	"time"
+)
+
+// Evento de domínio aguardando publicação. O ID autoincremental dá a ordem
+// de gravação, que o relay preserva por central.
+type OutboxEventModel struct {
+	ID          uint       `gorm:"primaryKey"`
+	Type        string     `gorm:"not null"`
+	CentralID   uint       `gorm:"not null;index"`
+	OccurredAt  time.Time  `gorm:"not null"`
+	Payload     []byte     `gorm:"not null"`
+	PublishedAt *time.Time `gorm:"index"`
+	Attempts    int        `gorm:"not null;default:0"`
+	LastError   string
+	// Depois de uma falha, o evento e os seguintes da central só voltam a
+	// ser lidos a partir deste instante
+	RetryAt *time.Time `gorm:"index"`
+}
+
+func (OutboxEventModel) TableName() string {
+	return "outbox_events"
+}
+
+// Conteúdo gravado em Payload: o estado da central ou a mudança de situação
+type outboxPayload struct {
+	Central      *centralSnapshot      `json:"central,omitempty"`
+	StatusChange *statusChangeSnapshot `json:"status_change,omitempty"`
+}
+
+type centralSnapshot struct {
+	ID              uint              `json:"id"`
+	CreatedAt       time.Time         `json:"created_at"`
+	UpdatedAt       time.Time         `json:"updated_at"`
+	Name            string            `json:"name"`
+	MAC             string            `json:"mac"`
+	IPv4            string            `json:"ipv4,omitempty"`
+	IPv6            string            `json:"ipv6,omitempty"`
+	Notes           string            `json:"notes,omitempty"`
+	Vendor          string            `json:"vendor,omitempty"`
+	SiteID          *uint             `json:"site_id,omitempty"`
+	LocationID      *uint             `json:"location_id,omitempty"`
+	HeartbeatWindow int64             `json:"heartbeat_window_s,omitempty"`
+	Labels          map[string]string `json:"labels,omitempty"`
+
+	Status          string     `json:"status"`
+	LastSeenAt      *time.Time `json:"last_seen_at,omitempty"`
+	RTT             int64      `json:"rtt_ns,omitempty"`
+	CheckedAt       *time.Time `json:"checked_at,omitempty"`
+	LastHeartbeatAt *time.Time `json:"last_heartbeat_at,omitempty"`
+	FirmwareVersion string     `json:"firmware_version,omitempty"`
+}
+
+type statusChangeSnapshot struct {
+	From string    `json:"from"`
+	To   string    `json:"to"`
+	At   time.Time `json:"at"`
+}
+
+func newOutboxEventModel(event *domain.Event) (*OutboxEventModel, error) {
+	var payload outboxPayload
+	if c := event.Central; c != nil {
+		payload.Central = &centralSnapshot{
+			ID: c.ID, CreatedAt: c.CreatedAt, UpdatedAt: c.UpdatedAt, Name: c.Name, MAC: c.MAC, IPv4: c.IPv4, IPv6: c.IPv6,
+			Notes: c.Notes, Vendor: c.Vendor, SiteID: c.SiteID, LocationID: c.LocationID,
+			HeartbeatWindow: int64(c.HeartbeatWindow / time.Second), Labels: c.Labels,
+			Status: string(c.Status.Status), LastSeenAt: c.Status.LastSeenAt, RTT: int64(c.Status.RTT),
+			CheckedAt: c.Status.CheckedAt, LastHeartbeatAt: c.Status.LastHeartbeatAt, FirmwareVersion: c.Status.FirmwareVersion,
+		}
+	}
+	if c := event.StatusChange; c != nil {
+		payload.StatusChange = &statusChangeSnapshot{From: string(c.From), To: string(c.To), At: c.At}
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	return &OutboxEventModel{
+		ID:         event.ID,
+		Type:       string(event.Type),
+		CentralID:  event.CentralID,
+		OccurredAt: event.OccurredAt,
+		Payload:    data,
+	}, nil
+}
+
+func (m *OutboxEventModel) toDomain() (*domain.Event, error) {
+	var payload outboxPayload
+	if err := json.Unmarshal(m.Payload, &payload); err != nil {
+		return nil, err
+	}
+	event := &domain.Event{
+		ID:         m.ID,
+		Type:       domain.EventType(m.Type),
+		CentralID:  m.CentralID,
+		OccurredAt: m.OccurredAt,
+	}
+	if c := payload.Central; c != nil {
+		event.Central = &domain.Central{
+			ID: c.ID, CreatedAt: c.CreatedAt, UpdatedAt: c.UpdatedAt, Name: c.Name, MAC: c.MAC, IPv4: c.IPv4, IPv6: c.IPv6,
+			Notes: c.Notes, Vendor: c.Vendor, SiteID: c.SiteID, LocationID: c.LocationID,
+			HeartbeatWindow: time.Duration(c.HeartbeatWindow) * time.Second, Labels: c.Labels,
+			Status: domain.CentralStatus{
+				Status: domain.ReachabilityStatus(c.Status), LastSeenAt: c.LastSeenAt, RTT: time.Duration(c.RTT),
+				CheckedAt: c.CheckedAt, LastHeartbeatAt: c.LastHeartbeatAt, FirmwareVersion: c.FirmwareVersion,
+			},
+		}
+	}
+	if c := payload.StatusChange; c != nil {
+		event.StatusChange = &domain.StatusChange{
+			CentralID: m.CentralID, From: domain.ReachabilityStatus(c.From), To: domain.ReachabilityStatus(c.To), At: c.At,
+		}
+	}
+	return event, nil
+}
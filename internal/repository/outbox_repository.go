@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"api-golang/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Leitura do outbox pelo relay
+type OutboxRepository struct {
+	DB *gorm.DB
+}
+
+func NewOutboxRepository(db *gorm.DB) *OutboxRepository {
+	return &OutboxRepository{DB: db}
+}
+
+// Eventos ainda não publicados, na ordem de gravação. Ficam de fora as
+// centrais com um evento que falhou aguardando nova tentativa: o evento e
+// os seguintes a ele, para que uma central retida não ocupe o lote das
+// demais.
+func (r *OutboxRepository) PendingEvents(limit int, now time.Time) ([]domain.Event, error) {
+	var models []OutboxEventModel
+	err := r.DB.Where("published_at IS NULL").
+		Where(`NOT EXISTS (SELECT 1 FROM outbox_events held
+			WHERE held.central_id = outbox_events.central_id AND held.id <= outbox_events.id
+			AND held.published_at IS NULL AND held.retry_at > ?)`, now).
+		Order("id").Limit(limit).Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+	return outboxEvents(models)
+}
+
+// Eventos gravados depois do informado, publicados ou não, na ordem de
+// gravação. Usado pelo stream de eventos para acompanhar e retomar o outbox.
+func (r *OutboxRepository) EventsAfter(afterID uint, limit int) ([]domain.Event, error) {
+	var models []OutboxEventModel
+	if err := r.DB.Where("id > ?", afterID).Order("id").Limit(limit).Find(&models).Error; err != nil {
+		return nil, err
+	}
+	return outboxEvents(models)
+}
+
+// ID do último evento gravado; zero com o outbox vazio. Os IDs não são
+// reaproveitados depois da limpeza.
+func (r *OutboxRepository) LastEventID() (uint, error) {
+	var id uint
+	err := r.DB.Raw("SELECT COALESCE(MAX(id), 0) FROM outbox_events").Scan(&id).Error
+	return id, err
+}
+
+func (r *OutboxRepository) MarkPublished(ids []uint, now time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.DB.Model(&OutboxEventModel{}).Where("id IN ?", ids).
+		Updates(map[string]interface{}{"published_at": now, "last_error": "", "retry_at": nil}).Error
+}
+
+// Registra a falha de publicação; o evento continua pendente e a central
+// fica retida até retryAt
+func (r *OutboxRepository) RecordFailure(id uint, reason string, retryAt time.Time) error {
+	return r.DB.Model(&OutboxEventModel{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"attempts": gorm.Expr("attempts + 1"), "last_error": reason, "retry_at": retryAt}).Error
+}
+
+// Remove os eventos publicados antes do corte
+func (r *OutboxRepository) PrunePublished(before time.Time) (int64, error) {
+	result := r.DB.Where("published_at < ?", before).Delete(&OutboxEventModel{})
+	return result.RowsAffected, result.Error
+}
+
+func outboxEvents(models []OutboxEventModel) ([]domain.Event, error) {
+	events := make([]domain.Event, 0, len(models))
+	for i := range models {
+		event, err := models[i].toDomain()
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, *event)
+	}
+	return events, nil
+}
+
+// Grava os eventos no outbox; deve ser chamada dentro da transação da
+// alteração que os originou
+func appendOutbox(tx *gorm.DB, events ...domain.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	models := make([]*OutboxEventModel, 0, len(events))
+	for i := range events {
+		model, err := newOutboxEventModel(&events[i])
+		if err != nil {
+			return err
+		}
+		models = append(models, model)
+	}
+	return tx.Create(models).Error
+}
+
+// Grava no outbox o estado atual das centrais, lido dentro da transação
+func appendCentralEvents(tx *gorm.DB, eventType domain.EventType, ids []uint, occurredAt time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	var models []CentralModel
+	if err := tx.Where("id IN ?", ids).Order("id").Find(&models).Error; err != nil {
+		return err
+	}
+	centrals, err := loadCentrals(tx, models)
+	if err != nil {
+		return err
+	}
+	events := make([]domain.Event, 0, len(centrals))
+	for i := range centrals {
+		events = append(events, domain.Event{
+			Type: eventType, CentralID: centrals[i].ID, OccurredAt: occurredAt, Central: &centrals[i],
+		})
+	}
+	return appendOutbox(tx, events...)
+}
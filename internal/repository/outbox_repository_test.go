@@ -0,0 +1,218 @@
+package repository_test
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/repository"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func eventTypes(events []domain.Event) []domain.EventType {
+	types := make([]domain.EventType, 0, len(events))
+	for _, e := range events {
+		types = append(types, e.Type)
+	}
+	return types
+}
+
+func TestOutbox_CentralLifecycle(t *testing.T) {
+	db := setupInMemoryDB()
+	centralRepo := repository.NewCentralRepository(db)
+	repo := repository.NewOutboxRepository(db)
+
+	central := &domain.Central{Name: "Portaria", MAC: "00:11:22:33:44:01", IPv4: "10.0.0.1", Labels: map[string]string{"env": "prod"}}
+	require.NoError(t, centralRepo.Create(central))
+	central.Name = "Portaria B"
+	central.Labels = nil
+	require.NoError(t, centralRepo.Update(central))
+	require.NoError(t, centralRepo.Delete(central.ID))
+
+	// Alterações que falham não deixam evento
+	other := createCentral(t, centralRepo, "00:11:22:33:44:03", "10.0.0.3")
+	require.Error(t, centralRepo.Create(&domain.Central{Name: "x", MAC: "00:11:22:33:44:02", IPv4: "10.0.0.3"}))
+	missingSite := uint(99)
+	assert.Error(t, centralRepo.Update(&domain.Central{ID: other.ID, Name: "x", MAC: "00:11:22:33:44:03", IPv4: "10.0.0.3", SiteID: &missingSite}))
+
+	events, err := repo.PendingEvents(10, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, []domain.EventType{domain.EventCentralCreated, domain.EventCentralUpdated, domain.EventCentralDeleted,
+		domain.EventCentralCreated}, eventTypes(events))
+
+	created := events[0]
+	assert.Equal(t, central.ID, created.CentralID)
+	assert.Equal(t, "Portaria", created.Central.Name)
+	assert.Equal(t, "prod", created.Central.Labels["env"])
+	assert.Equal(t, domain.StatusUnknown, created.Central.Status.Status)
+	// Labels mantidos na atualização aparecem no evento
+	assert.Equal(t, "Portaria B", events[1].Central.Name)
+	assert.Equal(t, "prod", events[1].Central.Labels["env"])
+	// A remoção leva o último estado
+	assert.Equal(t, "Portaria B", events[2].Central.Name)
+	assert.Less(t, events[0].ID, events[1].ID)
+}
+
+func TestOutbox_SiteCascadeAndStatus(t *testing.T) {
+	db := setupInMemoryDB()
+	centralRepo := repository.NewCentralRepository(db)
+	repo := repository.NewOutboxRepository(db)
+	siteRepo := repository.NewSiteRepository(db)
+
+	site := &domain.Site{Name: "Matriz"}
+	require.NoError(t, siteRepo.CreateSite(site))
+	first := &domain.Central{Name: "a", MAC: "00:11:22:33:44:01", IPv4: "10.0.0.1", SiteID: &site.ID}
+	second := &domain.Central{Name: "b", MAC: "00:11:22:33:44:02", IPv4: "10.0.0.2", SiteID: &site.ID}
+	require.NoError(t, centralRepo.Create(first))
+	require.NoError(t, centralRepo.Create(second))
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	_, err := repository.NewStatusRepository(db).SaveProbeResults([]domain.ProbeResult{
+		{CentralID: first.ID, Status: domain.StatusOnline, CheckedAt: now},
+	})
+	require.NoError(t, err)
+	_, err = repository.NewHeartbeatRepository(db).Record(&domain.Heartbeat{CentralID: second.ID, ReceivedAt: now})
+	require.NoError(t, err)
+	_, err = repository.NewHeartbeatRepository(db).ExpireHeartbeats(now.Add(time.Hour), 5*time.Minute)
+	require.NoError(t, err)
+
+	require.NoError(t, siteRepo.DeleteSite(site.ID, domain.SiteDeleteOptions{Cascade: true}))
+
+	events, err := repo.PendingEvents(100, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, []domain.EventType{
+		domain.EventCentralCreated, domain.EventCentralCreated,
+		domain.EventCentralStatusChanged, domain.EventCentralStatusChanged, domain.EventCentralStatusChanged,
+		domain.EventCentralDeleted, domain.EventCentralDeleted,
+	}, eventTypes(events))
+
+	probe := events[2].StatusChange
+	assert.Equal(t, domain.StatusChange{CentralID: first.ID, From: domain.StatusUnknown, To: domain.StatusOnline, At: now}, *probe)
+	// A mudança de situação leva o estado já atualizado da central
+	assert.Equal(t, domain.StatusOnline, events[2].Central.Status.Status)
+	assert.Equal(t, &site.ID, events[2].Central.SiteID)
+	expired := events[4].StatusChange
+	assert.Equal(t, second.ID, expired.CentralID)
+	assert.Equal(t, domain.StatusOffline, expired.To)
+	assert.Equal(t, domain.StatusOffline, events[6].Central.Status.Status)
+}
+
+func TestOutbox_Publishing(t *testing.T) {
+	db := setupInMemoryDB()
+	centralRepo := repository.NewCentralRepository(db)
+	repo := repository.NewOutboxRepository(db)
+	for _, mac := range []string{"00:11:22:33:44:01", "00:11:22:33:44:02", "00:11:22:33:44:03"} {
+		createCentral(t, centralRepo, mac, "")
+	}
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	events, err := repo.PendingEvents(2, now)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	require.NoError(t, repo.MarkPublished([]uint{events[0].ID}, now))
+	require.NoError(t, repo.RecordFailure(events[1].ID, "nats: timeout", now.Add(time.Minute)))
+
+	// A central do evento que falhou fica de fora até a nova tentativa,
+	// inclusive com eventos mais novos
+	held, err := centralRepo.GetByID(events[1].CentralID)
+	require.NoError(t, err)
+	held.Name = "Renomeada"
+	require.NoError(t, centralRepo.Update(held))
+	pending, err := repo.PendingEvents(10, now)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.NotEqual(t, events[1].CentralID, pending[0].CentralID)
+
+	pending, err = repo.PendingEvents(10, now.Add(time.Minute))
+	require.NoError(t, err)
+	require.Len(t, pending, 3)
+	assert.Equal(t, events[1].ID, pending[0].ID)
+
+	// Só os publicados antes do corte saem
+	deleted, err := repo.PrunePublished(now.Add(time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+	pending, _ = repo.PendingEvents(10, now.Add(time.Minute))
+	assert.Len(t, pending, 3)
+}
+
+func TestOutbox_EventsAfter(t *testing.T) {
+	db := setupInMemoryDB()
+	centralRepo := repository.NewCentralRepository(db)
+	repo := repository.NewOutboxRepository(db)
+
+	last, err := repo.LastEventID()
+	require.NoError(t, err)
+	assert.Zero(t, last)
+
+	for _, mac := range []string{"00:11:22:33:44:01", "00:11:22:33:44:02", "00:11:22:33:44:03"} {
+		createCentral(t, centralRepo, mac, "")
+	}
+	all, err := repo.EventsAfter(0, 10)
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+	last, err = repo.LastEventID()
+	require.NoError(t, err)
+	assert.Equal(t, all[2].ID, last)
+
+	after, err := repo.EventsAfter(all[0].ID, 1)
+	require.NoError(t, err)
+	require.Len(t, after, 1)
+	assert.Equal(t, all[1].ID, after[0].ID)
+
+	// Publicados continuam legíveis até a limpeza, e os IDs não voltam atrás
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, repo.MarkPublished([]uint{all[0].ID, all[1].ID, all[2].ID}, now))
+	after, _ = repo.EventsAfter(0, 10)
+	assert.Len(t, after, 3)
+	_, err = repo.PrunePublished(now.Add(time.Second))
+	require.NoError(t, err)
+	createCentral(t, centralRepo, "00:11:22:33:44:04", "")
+	after, err = repo.EventsAfter(last, 10)
+	require.NoError(t, err)
+	require.Len(t, after, 1)
+	assert.Greater(t, after[0].ID, last)
+}
+
+func TestOutbox_IndirectCentralChanges(t *testing.T) {
+	db := setupInMemoryDB()
+	centralRepo := repository.NewCentralRepository(db)
+	labelRepo := repository.NewLabelRepository(db)
+	siteRepo := repository.NewSiteRepository(db)
+	repo := repository.NewOutboxRepository(db)
+
+	site, target := &domain.Site{Name: "Matriz"}, &domain.Site{Name: "Filial"}
+	require.NoError(t, siteRepo.CreateSite(site))
+	require.NoError(t, siteRepo.CreateSite(target))
+	first := &domain.Central{Name: "a", MAC: "00:11:22:33:44:01", IPv4: "10.0.0.1", SiteID: &site.ID}
+	require.NoError(t, centralRepo.Create(first))
+	second := createCentral(t, centralRepo, "00:11:22:33:44:02", "10.0.0.2")
+	last, err := repo.LastEventID()
+	require.NoError(t, err)
+
+	// Labels em lote: a remoção só alcança quem tinha a chave
+	require.NoError(t, labelRepo.AddLabels([]uint{first.ID, second.ID}, map[string]string{"env": "prod"}))
+	require.NoError(t, labelRepo.RemoveLabels([]uint{first.ID}, []string{"env"}))
+	require.NoError(t, labelRepo.RemoveLabels([]uint{first.ID}, []string{"env"}))
+	// Realocação ao remover o site
+	require.NoError(t, siteRepo.DeleteSite(site.ID, domain.SiteDeleteOptions{ReassignTo: &target.ID}))
+	// Nova interface principal espelhada na central
+	require.NoError(t, repository.NewNetworkInterfaceRepository(db).Create(&domain.NetworkInterface{
+		CentralID: second.ID, Name: "eth1", MAC: "00:11:22:33:44:12", IPs: []string{"10.0.0.12"}, Primary: true}))
+
+	events, err := repo.EventsAfter(last, 100)
+	require.NoError(t, err)
+	require.Len(t, events, 5)
+	for _, event := range events {
+		assert.Equal(t, domain.EventCentralUpdated, event.Type)
+	}
+	assert.Equal(t, []uint{first.ID, second.ID, first.ID, first.ID, second.ID},
+		[]uint{events[0].CentralID, events[1].CentralID, events[2].CentralID, events[3].CentralID, events[4].CentralID})
+	assert.Equal(t, "prod", events[1].Central.Labels["env"])
+	assert.Empty(t, events[2].Central.Labels)
+	assert.Equal(t, &target.ID, events[3].Central.SiteID)
+	assert.Equal(t, "10.0.0.12", events[4].Central.IPv4)
+	assert.Equal(t, "00:11:22:33:44:12", events[4].Central.MAC)
+}
@@ -0,0 +1,16 @@
+//go:build sqlite_fts5
+
+package repository_test
+
+import (
+	"api-golang/internal/repository"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Com a tag, a busca precisa usar a FTS5, e não cair no LIKE em silêncio
+func TestSearch_EngineFTS5(t *testing.T) {
+	repo := repository.NewSearchRepository(setupInMemoryDB())
+	assert.Equal(t, "fts5", repo.Engine())
+}
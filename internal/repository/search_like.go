@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"api-golang/internal/domain"
+	"strings"
+)
+
+// Pesos por campo na busca de contingência, na mesma ordem de importância
+// usada no bm25 da busca FTS5
+const (
+	likeWeightName    = 10.0
+	likeWeightLabels  = 5.0
+	likeWeightAddress = 3.0
+	likeWeightNotes   = 2.0
+)
+
+// Busca de contingência para bancos sem FTS. Como no FTS5, os termos casam
+// com o início das palavras de nome, observações e labels; MACs casam por
+// trecho e IPs por prefixo. A pontuação é calculada em Go.
+func (r *SearchRepository) searchLike(terms []string) (map[uint]float64, error) {
+	query := r.DB.Model(&CentralModel{})
+	for _, term := range terms {
+		prefix := escapeLike(term) + "%"
+		word := "% " + escapeLike(term) + "%"
+		fragment := "%" + escapeLike(compactMAC(term)) + "%"
+		query = query.Where(`(lower(name) LIKE ? ESCAPE '\' OR lower(name) LIKE ? ESCAPE '\'
+			OR lower(notes) LIKE ? ESCAPE '\' OR lower(notes) LIKE ? ESCAPE '\'
+			OR replace(mac, ':', '') LIKE ? ESCAPE '\'
+			OR ipv4 LIKE ? ESCAPE '\' OR ipv6 LIKE ? ESCAPE '\'
+			OR id IN (SELECT central_id FROM central_labels
+				WHERE lower(label_key) LIKE ? ESCAPE '\' OR lower(label_value) LIKE ? ESCAPE '\'))`,
+			prefix, word, prefix, word, fragment, prefix, prefix, prefix, prefix)
+	}
+
+	var models []CentralModel
+	if err := query.Find(&models).Error; err != nil {
+		return nil, err
+	}
+	centrals, err := r.centrals.toDomainList(models)
+	if err != nil {
+		return nil, err
+	}
+
+	scores := make(map[uint]float64, len(centrals))
+	for i := range centrals {
+		scores[centrals[i].ID] = likeScore(&centrals[i], terms)
+	}
+	return scores, nil
+}
+
+// Soma os pesos dos campos em que cada termo aparece
+func likeScore(central *domain.Central, terms []string) float64 {
+	labels := make([]string, 0, len(central.Labels)*2)
+	for key, value := range central.Labels {
+		labels = append(labels, key, value)
+	}
+	label := strings.ToLower(strings.Join(labels, " "))
+	name := strings.ToLower(central.Name)
+	notes := strings.ToLower(central.Notes)
+
+	var score float64
+	for _, term := range terms {
+		score += wordScore(name, term, likeWeightName)
+		score += wordScore(label, term, likeWeightLabels)
+		score += wordScore(notes, term, likeWeightNotes)
+		if strings.Contains(compactMAC(central.MAC), compactMAC(term)) ||
+			(central.IPv4 != "" && strings.HasPrefix(central.IPv4, term)) ||
+			(central.IPv6 != "" && strings.HasPrefix(central.IPv6, term)) {
+			score += likeWeightAddress
+		}
+	}
+	return score
+}
+
+// Peso cheio quando o termo é uma palavra do campo e metade quando é
+// apenas o início de uma delas
+func wordScore(field, term string, weight float64) float64 {
+	var score float64
+	for _, word := range strings.Fields(field) {
+		switch {
+		case word == term:
+			return weight
+		case strings.HasPrefix(word, term):
+			score = weight / 2
+		}
+	}
+	return score
+}
+
+// MAC sem separadores, para casar trechos digitados em qualquer notação
+func compactMAC(value string) string {
+	return strings.NewReplacer(":", "", "-", "", ".", "").Replace(strings.ToLower(value))
+}
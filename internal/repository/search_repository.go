@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"api-golang/internal/domain"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// Estratégias de busca textual conforme o banco em uso
+const (
+	searchEngineFTS5 = "fts5"
+	searchEngineLike = "like"
+)
+
+// Repositório de busca textual em centrais. Usa uma tabela FTS5 mantida por
+// triggers quando o SQLite foi compilado com ela (tag sqlite_fts5); sem ela,
+// cai numa busca por LIKE com pontuação em Go.
+type SearchRepository struct {
+	DB       *gorm.DB
+	centrals *CentralRepository
+	engine   string
+}
+
+func NewSearchRepository(db *gorm.DB) *SearchRepository {
+	return &SearchRepository{DB: db, centrals: NewCentralRepository(db), engine: searchEngine(db)}
+}
+
+// Engine informa a estratégia de busca escolhida para o banco
+func (r *SearchRepository) Engine() string {
+	return r.engine
+}
+
+// Busca centrais por nome, labels, observações, trechos de MAC e prefixos
+// de IP. Todos os termos precisam casar; o resultado vem ordenado pela
+// relevância.
+func (r *SearchRepository) Search(terms []string, limit int) ([]domain.CentralSearchResult, error) {
+	var (
+		scores map[uint]float64
+		err    error
+	)
+	switch r.engine {
+	case searchEngineFTS5:
+		scores, err = r.searchFTS5(terms)
+	default:
+		scores, err = r.searchLike(terms)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r.rank(scores, limit)
+}
+
+// Carrega as centrais pontuadas e devolve as mais relevantes
+func (r *SearchRepository) rank(scores map[uint]float64, limit int) ([]domain.CentralSearchResult, error) {
+	ids := make([]uint, 0, len(scores))
+	for id := range scores {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if scores[ids[i]] != scores[ids[j]] {
+			return scores[ids[i]] > scores[ids[j]]
+		}
+		return ids[i] < ids[j]
+	})
+	if limit > 0 && len(ids) > limit {
+		ids = ids[:limit]
+	}
+	if len(ids) == 0 {
+		return []domain.CentralSearchResult{}, nil
+	}
+
+	var models []CentralModel
+	if err := r.DB.Where("id IN ?", ids).Find(&models).Error; err != nil {
+		return nil, err
+	}
+	centrals, err := r.centrals.toDomainList(models)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]domain.Central, len(centrals))
+	for _, c := range centrals {
+		byID[c.ID] = c
+	}
+
+	results := make([]domain.CentralSearchResult, 0, len(ids))
+	for _, id := range ids {
+		if central, ok := byID[id]; ok {
+			results = append(results, domain.CentralSearchResult{Central: central, Score: scores[id]})
+		}
+	}
+	return results, nil
+}
+
+// Escolhe a estratégia pela presença do FTS5 no SQLite
+func searchEngine(db *gorm.DB) string {
+	if db.Dialector.Name() == "sqlite" && hasFTS5(db) {
+		return searchEngineFTS5
+	}
+	return searchEngineLike
+}
+
+func hasFTS5(db *gorm.DB) bool {
+	var enabled int
+	if err := db.Raw("SELECT sqlite_compileoption_used('ENABLE_FTS5')").Scan(&enabled).Error; err != nil {
+		return false
+	}
+	return enabled == 1
+}
+
+// Prepara a estrutura de busca do banco: a tabela FTS5 com seus triggers
+func migrateSearch(db *gorm.DB) error {
+	if searchEngine(db) == searchEngineFTS5 {
+		return migrateFTS5(db)
+	}
+	return nil
+}
+
+// Escapa os curingas do LIKE
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
@@ -0,0 +1,72 @@
+package repository_test
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/repository"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Nomes das centrais encontradas, na ordem de relevância
+func searchNames(t *testing.T, repo *repository.SearchRepository, terms ...string) []string {
+	results, err := repo.Search(terms, 10)
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(results))
+	for _, r := range results {
+		names = append(names, r.Central.Name)
+	}
+	return names
+}
+
+func seedSearch(t *testing.T, repo *repository.CentralRepository) {
+	require.NoError(t, repo.Create(&domain.Central{Name: "Portaria Bloco B", MAC: "00:11:22:33:44:01", IPv4: "10.20.1.1",
+		Labels: map[string]string{"vendor": "intelbras"}}))
+	require.NoError(t, repo.Create(&domain.Central{Name: "Portaria Bloco A", MAC: "00:11:22:33:44:02", IPv4: "10.30.1.1",
+		Notes: "Próxima ao estacionamento"}))
+	require.NoError(t, repo.Create(&domain.Central{Name: "Garagem", MAC: "aa:bb:cc:dd:ee:ff", IPv6: "2001:db8::10",
+		Notes: "Acesso pela portaria"}))
+}
+
+func TestSearch(t *testing.T) {
+	db := setupInMemoryDB()
+	centralRepo := repository.NewCentralRepository(db)
+	repo := repository.NewSearchRepository(db)
+	seedSearch(t, centralRepo)
+
+	// Nome pesa mais que observações
+	names := searchNames(t, repo, "portaria")
+	assert.Len(t, names, 3)
+	assert.Equal(t, "Garagem", names[2])
+	// "b" também é prefixo de "bloco", mas a palavra exata pesa mais
+	assert.Equal(t, []string{"Portaria Bloco B", "Portaria Bloco A"}, searchNames(t, repo, "portaria", "bloco", "b"))
+	assert.Equal(t, []string{"Portaria Bloco B"}, searchNames(t, repo, "intelbras"))
+	assert.Equal(t, []string{"Portaria Bloco B"}, searchNames(t, repo, "10.20."))
+	assert.Equal(t, []string{"Garagem"}, searchNames(t, repo, "ddeeff"))
+	assert.Equal(t, []string{"Garagem"}, searchNames(t, repo, "2001:db8"))
+	assert.Empty(t, searchNames(t, repo, "inexistente"))
+}
+
+func TestSearch_FollowsChanges(t *testing.T) {
+	db := setupInMemoryDB()
+	centralRepo := repository.NewCentralRepository(db)
+	labelRepo := repository.NewLabelRepository(db)
+	repo := repository.NewSearchRepository(db)
+	seedSearch(t, centralRepo)
+
+	centrals, err := centralRepo.GetAll(domain.CentralFilter{IP: "10.30.1.1"})
+	require.NoError(t, err)
+	central := centrals[0]
+
+	central.Name = "Recepção"
+	require.NoError(t, centralRepo.Update(&central))
+	assert.Equal(t, []string{"Recepção"}, searchNames(t, repo, "recepção"))
+
+	require.NoError(t, labelRepo.AddLabels([]uint{central.ID}, map[string]string{"tier": "gold"}))
+	assert.Equal(t, []string{"Recepção"}, searchNames(t, repo, "gold"))
+
+	require.NoError(t, centralRepo.Delete(central.ID))
+	assert.Empty(t, searchNames(t, repo, "recepção"))
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// Conteúdo indexado de uma central: nome, observações, labels e endereços.
+// O MAC entra com dois-pontos, com pontos e como os sufixos de cada byte
+// sem separador, para que a busca por prefixo do FTS5 encontre trechos
+// digitados em qualquer notação (ex.: "ddeeff" em aa:bb:cc:dd:ee:ff).
+var ftsRowSQL = `
+SELECT c.id, c.name, COALESCE(c.notes, ''),
+	COALESCE((SELECT group_concat(l.label_key || ' ' || l.label_value, ' ')
+		FROM central_labels l WHERE l.central_id = c.id), ''),
+	c.mac || ' ' ||
+		substr(replace(c.mac, ':', ''), 1, 4) || '.' ||
+		substr(replace(c.mac, ':', ''), 5, 4) || '.' ||
+		substr(replace(c.mac, ':', ''), 9, 4) || ' ' ||
+		` + macSuffixesSQL("c.mac") + ` || ' ' ||
+		COALESCE(c.ipv4, '') || ' ' || COALESCE(c.ipv6, '')
+FROM centrals c`
+
+// Sufixos do MAC sem separador, um por byte
+func macSuffixesSQL(column string) string {
+	suffixes := make([]string, 0, 6)
+	for start := 1; start <= 11; start += 2 {
+		suffixes = append(suffixes, fmt.Sprintf("substr(replace(%s, ':', ''), %d)", column, start))
+	}
+	return strings.Join(suffixes, " || ' ' || ")
+}
+
+// Triggers que mantêm centrals_fts em sincronia com centrais e labels
+var ftsTriggers = map[string]string{
+	"centrals_fts_ai": `AFTER INSERT ON centrals BEGIN %s END`,
+	"centrals_fts_au": `AFTER UPDATE ON centrals BEGIN %s END`,
+	"centrals_fts_ad": `AFTER DELETE ON centrals BEGIN
+		DELETE FROM centrals_fts WHERE rowid = OLD.id; END`,
+	"central_labels_fts_ai": `AFTER INSERT ON central_labels BEGIN %s END`,
+	"central_labels_fts_au": `AFTER UPDATE ON central_labels BEGIN %s END`,
+	"central_labels_fts_ad": `AFTER DELETE ON central_labels BEGIN %s END`,
+}
+
+// Reindexa a central referenciada pelo trigger (NEW ou OLD)
+func ftsRefreshSQL(idExpr string) string {
+	return fmt.Sprintf(`DELETE FROM centrals_fts WHERE rowid = %[1]s;
+		INSERT INTO centrals_fts (rowid, name, notes, labels, addresses) %[2]s WHERE c.id = %[1]s;`,
+		idExpr, ftsRowSQL)
+}
+
+// Cria a tabela FTS5 e os triggers. Se a tabela ou algum trigger faltava
+// (por exemplo, após uma migração que recriou a tabela de centrais), o
+// índice é reconstruído a partir dos dados atuais.
+func migrateFTS5(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		rebuild := !tx.Migrator().HasTable("centrals_fts")
+		err := tx.Exec(`CREATE VIRTUAL TABLE IF NOT EXISTS centrals_fts USING fts5(
+			name, notes, labels, addresses,
+			tokenize = 'unicode61 remove_diacritics 2')`).Error
+		if err != nil {
+			return err
+		}
+
+		for name, body := range ftsTriggers {
+			var count int64
+			if err := tx.Raw("SELECT count(*) FROM sqlite_master WHERE type = 'trigger' AND name = ?", name).
+				Scan(&count).Error; err != nil {
+				return err
+			}
+			if count > 0 {
+				continue
+			}
+			rebuild = true
+
+			idExpr := "NEW.id"
+			if strings.HasPrefix(name, "central_labels") {
+				idExpr = "NEW.central_id"
+				if strings.HasSuffix(name, "_ad") {
+					idExpr = "OLD.central_id"
+				}
+			}
+			if strings.Contains(body, "%s") {
+				body = fmt.Sprintf(body, ftsRefreshSQL(idExpr))
+			}
+			if err := tx.Exec(fmt.Sprintf("CREATE TRIGGER %s %s", name, body)).Error; err != nil {
+				return err
+			}
+		}
+
+		if !rebuild {
+			return nil
+		}
+		if err := tx.Exec("DELETE FROM centrals_fts").Error; err != nil {
+			return err
+		}
+		return tx.Exec("INSERT INTO centrals_fts (rowid, name, notes, labels, addresses) " + ftsRowSQL).Error
+	})
+}
+
+// Consulta a tabela FTS5. Cada termo vira uma busca por prefixo e a
+// relevância vem do bm25, com peso maior para o nome e os labels.
+func (r *SearchRepository) searchFTS5(terms []string) (map[uint]float64, error) {
+	quoted := make([]string, 0, len(terms))
+	for _, term := range terms {
+		quoted = append(quoted, `"`+strings.ReplaceAll(term, `"`, `""`)+`"*`)
+	}
+
+	var rows []struct {
+		ID    uint
+		Score float64
+	}
+	err := r.DB.Raw(`SELECT rowid AS id, -bm25(centrals_fts, 10.0, 2.0, 5.0, 3.0) AS score
+		FROM centrals_fts WHERE centrals_fts MATCH ?`, strings.Join(quoted, " AND ")).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	scores := make(map[uint]float64, len(rows))
+	for _, row := range rows {
+		scores[row.ID] = row.Score
+	}
+	return scores, nil
+}
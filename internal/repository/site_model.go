@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"api-golang/internal/domain"
+	"time"
+)
+
+// Modelo de persistência do site
+type SiteModel struct {
+	ID          uint `gorm:"primaryKey"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	Name        string `gorm:"not null;uniqueIndex"`
+	Description string
+}
+
+func (SiteModel) TableName() string {
+	return "sites"
+}
+
+func newSiteModel(site *domain.Site) *SiteModel {
+	return &SiteModel{
+		ID:          site.ID,
+		CreatedAt:   site.CreatedAt,
+		UpdatedAt:   site.UpdatedAt,
+		Name:        site.Name,
+		Description: site.Description,
+	}
+}
+
+func (m *SiteModel) toDomain() *domain.Site {
+	return &domain.Site{
+		ID:          m.ID,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+		Name:        m.Name,
+		Description: m.Description,
+	}
+}
+
+// Modelo de persistência da localização (prédio, andar ou sala)
+type LocationModel struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	SiteID    uint   `gorm:"not null;index"`
+	ParentID  *uint  `gorm:"index"`
+	Kind      string `gorm:"not null"`
+	Name      string `gorm:"not null"`
+}
+
+func (LocationModel) TableName() string {
+	return "locations"
+}
+
+func newLocationModel(location *domain.Location) *LocationModel {
+	return &LocationModel{
+		ID:        location.ID,
+		CreatedAt: location.CreatedAt,
+		UpdatedAt: location.UpdatedAt,
+		SiteID:    location.SiteID,
+		ParentID:  location.ParentID,
+		Kind:      location.Kind,
+		Name:      location.Name,
+	}
+}
+
+func (m *LocationModel) toDomain() *domain.Location {
+	return &domain.Location{
+		ID:        m.ID,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+		SiteID:    m.SiteID,
+		ParentID:  m.ParentID,
+		Kind:      m.Kind,
+		Name:      m.Name,
+	}
+}
+
+// Subconsulta com a localização informada e todas as suas descendentes
+const locationSubtreeSQL = `WITH RECURSIVE subtree(id) AS (
+	SELECT id FROM locations WHERE id = ?
+	UNION ALL
+	SELECT locations.id FROM locations JOIN subtree ON locations.parent_id = subtree.id
+) SELECT id FROM subtree`
@@ -0,0 +1,239 @@
+package repository
+
+import (
+	"api-golang/internal/domain"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// Repositório de sites e das localizações dentro deles
+type SiteRepository struct {
+	DB *gorm.DB
+}
+
+func NewSiteRepository(db *gorm.DB) *SiteRepository {
+	return &SiteRepository{DB: db}
+}
+
+func (r *SiteRepository) CreateSite(site *domain.Site) error {
+	model := newSiteModel(site)
+	if err := r.DB.Create(model).Error; err != nil {
+		return r.translate(err)
+	}
+	*site = *model.toDomain()
+	return nil
+}
+
+func (r *SiteRepository) GetSites() ([]domain.Site, error) {
+	var models []SiteModel
+	if err := r.DB.Order("name").Find(&models).Error; err != nil {
+		return nil, err
+	}
+	sites := make([]domain.Site, 0, len(models))
+	for i := range models {
+		sites = append(sites, *models[i].toDomain())
+	}
+	return sites, nil
+}
+
+func (r *SiteRepository) GetSiteByID(id uint) (*domain.Site, error) {
+	var model SiteModel
+	if err := r.DB.First(&model, id).Error; err != nil {
+		return nil, r.translate(err)
+	}
+	return model.toDomain(), nil
+}
+
+// Sites com os IDs informados, numa única consulta; IDs inexistentes são
+// ignorados
+func (r *SiteRepository) GetSitesByIDs(ids []uint) ([]domain.Site, error) {
+	var models []SiteModel
+	if err := r.DB.Where("id IN ?", ids).Order("id").Find(&models).Error; err != nil {
+		return nil, err
+	}
+	sites := make([]domain.Site, 0, len(models))
+	for i := range models {
+		sites = append(sites, *models[i].toDomain())
+	}
+	return sites, nil
+}
+
+func (r *SiteRepository) UpdateSite(site *domain.Site) error {
+	result := r.DB.Model(&SiteModel{ID: site.ID}).
+		Select("name", "description", "updated_at").
+		Updates(newSiteModel(site))
+	if result.Error != nil {
+		return r.translate(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+
+	updated, err := r.GetSiteByID(site.ID)
+	if err != nil {
+		return err
+	}
+	*site = *updated
+	return nil
+}
+
+// Remove o site e suas localizações. Se ainda houver centrais no site, a
+// remoção só acontece com cascata ou realocação para outro site.
+func (r *SiteRepository) DeleteSite(id uint, opts domain.SiteDeleteOptions) error {
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&SiteModel{}, id).Error; err != nil {
+			return err
+		}
+
+		var centrals int64
+		if err := tx.Model(&CentralModel{}).Where("site_id = ?", id).Count(&centrals).Error; err != nil {
+			return err
+		}
+		if centrals > 0 {
+			switch {
+			case opts.ReassignTo != nil:
+				if *opts.ReassignTo == id {
+					return fmt.Errorf("%w: cannot reassign centrals to the site being deleted", domain.ErrInvalid)
+				}
+				if err := tx.First(&SiteModel{}, *opts.ReassignTo).Error; errors.Is(err, gorm.ErrRecordNotFound) {
+					return fmt.Errorf("%w: target site %d not found", domain.ErrInvalid, *opts.ReassignTo)
+				} else if err != nil {
+					return err
+				}
+				var reassigned []uint
+				if err := tx.Model(&CentralModel{}).Where("site_id = ?", id).Pluck("id", &reassigned).Error; err != nil {
+					return err
+				}
+				now := tx.NowFunc()
+				err := tx.Model(&CentralModel{}).Where("id IN ?", reassigned).
+					Updates(map[string]interface{}{"site_id": *opts.ReassignTo, "location_id": nil, "updated_at": now}).Error
+				if err != nil {
+					return err
+				}
+				if err := appendCentralEvents(tx, domain.EventCentralUpdated, reassigned, now); err != nil {
+					return err
+				}
+			case opts.Cascade:
+				if err := deleteCentrals(tx, tx.Where("site_id = ?", id)); err != nil {
+					return err
+				}
+			default:
+				return fmt.Errorf("%w: site still has %d central(s); use cascade or reassign_to", domain.ErrPrecondition, centrals)
+			}
+		}
+
+		if err := tx.Where("site_id = ?", id).Delete(&LocationModel{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&SiteModel{}, id).Error
+	})
+	return r.translate(err)
+}
+
+func (r *SiteRepository) CreateLocation(location *domain.Location) error {
+	model := newLocationModel(location)
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&SiteModel{}, location.SiteID).Error; err != nil {
+			return err
+		}
+		return tx.Create(model).Error
+	})
+	if err != nil {
+		return r.translate(err)
+	}
+	*location = *model.toDomain()
+	return nil
+}
+
+func (r *SiteRepository) GetLocations(siteID uint) ([]domain.Location, error) {
+	if err := r.DB.First(&SiteModel{}, siteID).Error; err != nil {
+		return nil, r.translate(err)
+	}
+
+	var models []LocationModel
+	if err := r.DB.Where("site_id = ?", siteID).Order("id").Find(&models).Error; err != nil {
+		return nil, err
+	}
+	locations := make([]domain.Location, 0, len(models))
+	for i := range models {
+		locations = append(locations, *models[i].toDomain())
+	}
+	return locations, nil
+}
+
+func (r *SiteRepository) GetLocationByID(id uint) (*domain.Location, error) {
+	var model LocationModel
+	if err := r.DB.First(&model, id).Error; err != nil {
+		return nil, r.translate(err)
+	}
+	return model.toDomain(), nil
+}
+
+// Localizações com os IDs informados, numa única consulta; IDs inexistentes
+// são ignorados
+func (r *SiteRepository) GetLocationsByIDs(ids []uint) ([]domain.Location, error) {
+	var models []LocationModel
+	if err := r.DB.Where("id IN ?", ids).Order("id").Find(&models).Error; err != nil {
+		return nil, err
+	}
+	locations := make([]domain.Location, 0, len(models))
+	for i := range models {
+		locations = append(locations, *models[i].toDomain())
+	}
+	return locations, nil
+}
+
+func (r *SiteRepository) UpdateLocation(location *domain.Location) error {
+	result := r.DB.Model(&LocationModel{ID: location.ID}).
+		Select("name", "parent_id", "updated_at").
+		Updates(newLocationModel(location))
+	if result.Error != nil {
+		return r.translate(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+
+	updated, err := r.GetLocationByID(location.ID)
+	if err != nil {
+		return err
+	}
+	*location = *updated
+	return nil
+}
+
+// Remove a localização se ela não tiver sublocalizações nem centrais
+func (r *SiteRepository) DeleteLocation(id uint) error {
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&LocationModel{}, id).Error; err != nil {
+			return err
+		}
+
+		var children, centrals int64
+		if err := tx.Model(&LocationModel{}).Where("parent_id = ?", id).Count(&children).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&CentralModel{}).Where("location_id = ?", id).Count(&centrals).Error; err != nil {
+			return err
+		}
+		if children > 0 || centrals > 0 {
+			return fmt.Errorf("%w: location still has %d sub-location(s) and %d central(s)", domain.ErrPrecondition, children, centrals)
+		}
+		return tx.Delete(&LocationModel{}, id).Error
+	})
+	return r.translate(err)
+}
+
+// Traduz erros do banco, detalhando violações de unicidade
+func (r *SiteRepository) translate(err error) error {
+	if errors.Is(err, domain.ErrConflict) || errors.Is(err, domain.ErrInvalid) {
+		return err
+	}
+	err = translateError(r.DB, err)
+	if errors.Is(err, domain.ErrConflict) {
+		return fmt.Errorf("%w: a site with this name already exists", domain.ErrConflict)
+	}
+	return err
+}
@@ -0,0 +1,142 @@
+package repository_test
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/repository"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Monta um site com prédio > andar > sala para os testes
+func createSiteTree(t *testing.T, repo *repository.SiteRepository, name string) (*domain.Site, []*domain.Location) {
+	site := &domain.Site{Name: name}
+	require.NoError(t, repo.CreateSite(site))
+
+	building := &domain.Location{SiteID: site.ID, Kind: domain.LocationBuilding, Name: "Bloco B"}
+	require.NoError(t, repo.CreateLocation(building))
+	floor := &domain.Location{SiteID: site.ID, ParentID: &building.ID, Kind: domain.LocationFloor, Name: "Térreo"}
+	require.NoError(t, repo.CreateLocation(floor))
+	room := &domain.Location{SiteID: site.ID, ParentID: &floor.ID, Kind: domain.LocationRoom, Name: "Portaria"}
+	require.NoError(t, repo.CreateLocation(room))
+
+	return site, []*domain.Location{building, floor, room}
+}
+
+func TestCreateSite_DuplicateName(t *testing.T) {
+	repo := repository.NewSiteRepository(setupInMemoryDB())
+
+	require.NoError(t, repo.CreateSite(&domain.Site{Name: "Matriz"}))
+	err := repo.CreateSite(&domain.Site{Name: "Matriz"})
+	assert.ErrorIs(t, err, domain.ErrConflict)
+}
+
+func TestGetSitesAndLocationsByIDs(t *testing.T) {
+	repo := repository.NewSiteRepository(setupInMemoryDB())
+	matriz, locations := createSiteTree(t, repo, "Matriz")
+	filial, _ := createSiteTree(t, repo, "Filial")
+
+	sites, err := repo.GetSitesByIDs([]uint{filial.ID, matriz.ID, 99})
+	require.NoError(t, err)
+	require.Len(t, sites, 2)
+	assert.Equal(t, "Matriz", sites[0].Name)
+	assert.Equal(t, "Filial", sites[1].Name)
+
+	found, err := repo.GetLocationsByIDs([]uint{locations[2].ID, locations[0].ID})
+	require.NoError(t, err)
+	require.Len(t, found, 2)
+	assert.Equal(t, domain.LocationBuilding, found[0].Kind)
+	assert.Equal(t, locations[1].ID, *found[1].ParentID)
+}
+
+func TestCreateCentral_PlacementFromLocation(t *testing.T) {
+	db := setupInMemoryDB()
+	repo := repository.NewSiteRepository(db)
+	centralRepo := repository.NewCentralRepository(db)
+
+	site, locations := createSiteTree(t, repo, "Matriz")
+	room := locations[2]
+
+	// O site é derivado da localização
+	central := &domain.Central{Name: "Central 1", MAC: "00:11:22:33:44:55", IPv4: "192.168.0.1", LocationID: &room.ID}
+	require.NoError(t, centralRepo.Create(central))
+	require.NotNil(t, central.SiteID)
+	assert.Equal(t, site.ID, *central.SiteID)
+
+	// Localização de outro site é rejeitada
+	other := &domain.Site{Name: "Filial"}
+	require.NoError(t, repo.CreateSite(other))
+	invalid := &domain.Central{Name: "Central 2", MAC: "00:11:22:33:44:56", IPv4: "192.168.0.2", SiteID: &other.ID, LocationID: &room.ID}
+	assert.ErrorIs(t, centralRepo.Create(invalid), domain.ErrInvalid)
+}
+
+func TestGetAllCentrals_FilterBySubtree(t *testing.T) {
+	db := setupInMemoryDB()
+	repo := repository.NewSiteRepository(db)
+	centralRepo := repository.NewCentralRepository(db)
+
+	site, locations := createSiteTree(t, repo, "Matriz")
+	building, room := locations[0], locations[2]
+
+	require.NoError(t, centralRepo.Create(&domain.Central{Name: "No site", MAC: "00:11:22:33:44:01", IPv4: "10.0.0.1", SiteID: &site.ID}))
+	require.NoError(t, centralRepo.Create(&domain.Central{Name: "No prédio", MAC: "00:11:22:33:44:02", IPv4: "10.0.0.2", LocationID: &building.ID}))
+	require.NoError(t, centralRepo.Create(&domain.Central{Name: "Na sala", MAC: "00:11:22:33:44:03", IPv4: "10.0.0.3", LocationID: &room.ID}))
+
+	// O site traz todas as suas centrais; sem localização, só a ligada direto
+	all, err := centralRepo.GetAll(domain.CentralFilter{SiteID: &site.ID})
+	assert.NoError(t, err)
+	assert.Len(t, all, 3)
+
+	direct, err := centralRepo.GetAll(domain.CentralFilter{SiteID: &site.ID, Unplaced: true})
+	assert.NoError(t, err)
+	require.Len(t, direct, 1)
+	assert.Equal(t, "No site", direct[0].Name)
+
+	inBuilding, err := centralRepo.GetAll(domain.CentralFilter{LocationID: &building.ID})
+	assert.NoError(t, err)
+	assert.Len(t, inBuilding, 1)
+
+	subtree, err := centralRepo.GetAll(domain.CentralFilter{LocationID: &building.ID, Recursive: true})
+	assert.NoError(t, err)
+	assert.Len(t, subtree, 2)
+}
+
+func TestDeleteSite_WithCentrals(t *testing.T) {
+	db := setupInMemoryDB()
+	repo := repository.NewSiteRepository(db)
+	centralRepo := repository.NewCentralRepository(db)
+
+	site, locations := createSiteTree(t, repo, "Matriz")
+	other := &domain.Site{Name: "Filial"}
+	require.NoError(t, repo.CreateSite(other))
+	require.NoError(t, centralRepo.Create(&domain.Central{Name: "Central 1", MAC: "00:11:22:33:44:55", IPv4: "10.0.0.1", LocationID: &locations[2].ID}))
+
+	// Sem cascata nem realocação, a remoção é recusada
+	assert.ErrorIs(t, repo.DeleteSite(site.ID, domain.SiteDeleteOptions{}), domain.ErrPrecondition)
+
+	// Realocação move as centrais e limpa a localização
+	require.NoError(t, repo.DeleteSite(site.ID, domain.SiteDeleteOptions{ReassignTo: &other.ID}))
+	moved, err := centralRepo.GetAll(domain.CentralFilter{SiteID: &other.ID})
+	assert.NoError(t, err)
+	assert.Len(t, moved, 1)
+	assert.Nil(t, moved[0].LocationID)
+
+	_, err = repo.GetLocationByID(locations[0].ID)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	// Cascata remove as centrais junto com o site
+	require.NoError(t, repo.DeleteSite(other.ID, domain.SiteDeleteOptions{Cascade: true}))
+	remaining, err := centralRepo.GetAll(domain.CentralFilter{})
+	assert.NoError(t, err)
+	assert.Empty(t, remaining)
+}
+
+func TestDeleteLocation_WithChildren(t *testing.T) {
+	repo := repository.NewSiteRepository(setupInMemoryDB())
+
+	_, locations := createSiteTree(t, repo, "Matriz")
+
+	assert.ErrorIs(t, repo.DeleteLocation(locations[0].ID), domain.ErrPrecondition)
+	assert.NoError(t, repo.DeleteLocation(locations[2].ID))
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"api-golang/internal/domain"
+	"time"
+)
+
+// Credenciais SNMP de uma central e o inventário da última coleta. A
+// comunidade e as senhas ficam cifradas.
+type CentralSNMPModel struct {
+	CentralID uint   `gorm:"primaryKey;autoIncrement:false"`
+	Version   string `gorm:"not null"`
+	Port      int    `gorm:"not null"`
+
+	Community    string
+	Username     string
+	AuthProtocol string
+	AuthPassword string
+	PrivProtocol string
+	PrivPassword string
+
+	SysName          string
+	SysDescr         string
+	SysUpTimeSeconds int64                 `gorm:"column:sys_uptime_s;not null;default:0"`
+	Interfaces       []snmpInterfaceRecord `gorm:"serializer:json"`
+	CollectedAt      *time.Time
+	PolledAt         *time.Time
+	LastError        string
+}
+
+func (CentralSNMPModel) TableName() string {
+	return "central_snmp"
+}
+
+// Interface descoberta, gravada em JSON junto com o inventário
+type snmpInterfaceRecord struct {
+	Index       int    `json:"index"`
+	Name        string `json:"name"`
+	Descr       string `json:"descr,omitempty"`
+	Type        int    `json:"type"`
+	MTU         int    `json:"mtu"`
+	Speed       uint64 `json:"speed"`
+	MAC         string `json:"mac,omitempty"`
+	AdminStatus string `json:"admin_status"`
+	OperStatus  string `json:"oper_status"`
+}
+
+// Converte para o domínio sem a comunidade e as senhas
+func (m *CentralSNMPModel) toDomain() *domain.CentralSNMP {
+	snmp := &domain.CentralSNMP{
+		CentralID: m.CentralID,
+		Credentials: domain.SNMPCredentials{
+			Version:      domain.SNMPVersion(m.Version),
+			Port:         m.Port,
+			Username:     m.Username,
+			AuthProtocol: m.AuthProtocol,
+			PrivProtocol: m.PrivProtocol,
+		},
+		CollectedAt: m.CollectedAt,
+		PolledAt:    m.PolledAt,
+		LastError:   m.LastError,
+	}
+	if m.CollectedAt == nil {
+		return snmp
+	}
+	interfaces := make([]domain.SNMPInterface, 0, len(m.Interfaces))
+	for _, record := range m.Interfaces {
+		interfaces = append(interfaces, domain.SNMPInterface(record))
+	}
+	snmp.Inventory = &domain.SNMPInventory{
+		SysName:    m.SysName,
+		SysDescr:   m.SysDescr,
+		SysUpTime:  time.Duration(m.SysUpTimeSeconds) * time.Second,
+		Interfaces: interfaces,
+	}
+	return snmp
+}
+
+func newSNMPInterfaceRecords(interfaces []domain.SNMPInterface) []snmpInterfaceRecord {
+	records := make([]snmpInterfaceRecord, 0, len(interfaces))
+	for _, iface := range interfaces {
+		records = append(records, snmpInterfaceRecord(iface))
+	}
+	return records
+}
@@ -0,0 +1,262 @@
+package repository
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/secrets"
+	"fmt"
+	"net/netip"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Métrica com o uptime informado pelo agente SNMP, a mesma dos heartbeats
+const UptimeMetric = "uptime_seconds"
+
+// Erro gravado quando as credenciais não podem ser decifradas, como após
+// trocar a chave
+const undecryptableCredentials = "unable to decrypt the SNMP credentials; set them again"
+
+// Credenciais e inventário SNMP das centrais. A comunidade e as senhas são
+// cifradas com Secrets antes de gravar.
+type SNMPRepository struct {
+	DB      *gorm.DB
+	Secrets *secrets.Box
+}
+
+func NewSNMPRepository(db *gorm.DB, box *secrets.Box) *SNMPRepository {
+	return &SNMPRepository{DB: db, Secrets: box}
+}
+
+// Grava as credenciais da central, substituindo as anteriores. O inventário
+// já coletado é mantido até a próxima coleta.
+func (r *SNMPRepository) SaveCredentials(centralID uint, creds domain.SNMPCredentials) (*domain.CentralSNMP, error) {
+	model := CentralSNMPModel{
+		CentralID:    centralID,
+		Version:      string(creds.Version),
+		Port:         creds.Port,
+		Username:     creds.Username,
+		AuthProtocol: creds.AuthProtocol,
+		PrivProtocol: creds.PrivProtocol,
+	}
+	secretFields := []struct {
+		dst   *string
+		value string
+		name  string
+	}{
+		{&model.Community, creds.Community, "community"},
+		{&model.AuthPassword, creds.AuthPassword, "auth_password"},
+		{&model.PrivPassword, creds.PrivPassword, "priv_password"},
+	}
+	for _, field := range secretFields {
+		sealed, err := r.Secrets.Seal(field.value, secretContext(centralID, field.name))
+		if err != nil {
+			return nil, err
+		}
+		*field.dst = sealed
+	}
+
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&CentralModel{}, centralID).Error; err != nil {
+			return err
+		}
+		return tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "central_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"version", "port", "community", "username",
+				"auth_protocol", "auth_password", "priv_protocol", "priv_password"}),
+		}).Create(&model).Error
+	})
+	if err != nil {
+		return nil, translateError(r.DB, err)
+	}
+	return r.Get(centralID)
+}
+
+// Configuração SNMP da central, sem a comunidade e as senhas
+func (r *SNMPRepository) Get(centralID uint) (*domain.CentralSNMP, error) {
+	var model CentralSNMPModel
+	if err := r.DB.First(&model, "central_id = ?", centralID).Error; err != nil {
+		return nil, translateError(r.DB, err)
+	}
+	return model.toDomain(), nil
+}
+
+// Remove as credenciais e o inventário; a central volta a ser sondada pelo
+// monitor de alcance
+func (r *SNMPRepository) Delete(centralID uint) error {
+	result := r.DB.Where("central_id = ?", centralID).Delete(&CentralSNMPModel{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// Centrais com credenciais SNMP, com as credenciais decifradas e o endereço
+// principal. As que não podem ser decifradas ficam de fora, com o erro
+// gravado para aparecer na API.
+func (r *SNMPRepository) ListSNMPTargets() ([]domain.SNMPTarget, error) {
+	var models []CentralSNMPModel
+	if err := r.DB.Order("central_id").Find(&models).Error; err != nil {
+		return nil, err
+	}
+	if len(models) == 0 {
+		return nil, nil
+	}
+	ids := make([]uint, 0, len(models))
+	for _, m := range models {
+		ids = append(ids, m.CentralID)
+	}
+	var centrals []CentralModel
+	if err := r.DB.Select("id", "ipv4", "ipv6").Where("id IN ?", ids).Find(&centrals).Error; err != nil {
+		return nil, err
+	}
+	addrs := make(map[uint]netip.Addr, len(centrals))
+	for _, m := range centrals {
+		central := m.toDomain()
+		if addr, err := netip.ParseAddr(central.PrimaryIP()); err == nil {
+			addrs[m.ID] = addr
+		}
+	}
+
+	targets := make([]domain.SNMPTarget, 0, len(models))
+	var undecryptable []uint
+	for i := range models {
+		addr, ok := addrs[models[i].CentralID]
+		if !ok {
+			continue
+		}
+		creds, err := r.openCredentials(&models[i])
+		if err != nil {
+			if models[i].LastError != undecryptableCredentials {
+				undecryptable = append(undecryptable, models[i].CentralID)
+			}
+			continue
+		}
+		targets = append(targets, domain.SNMPTarget{CentralID: models[i].CentralID, Addr: addr, Credentials: creds})
+	}
+	if len(undecryptable) > 0 {
+		err := r.DB.Model(&CentralSNMPModel{}).Where("central_id IN ?", undecryptable).
+			Update("last_error", undecryptableCredentials).Error
+		if err != nil {
+			return nil, err
+		}
+	}
+	return targets, nil
+}
+
+// Grava o resultado das coletas: o inventário das bem-sucedidas, o erro das
+// demais e a situação de alcance, como o monitor. Centrais que enviam
+// heartbeats têm a situação definida por eles e só recebem o inventário. O
+// uptime do agente vira a métrica "uptime_seconds". Retorna as centrais cuja
+// situação mudou, também gravadas no outbox.
+func (r *SNMPRepository) SaveSNMPResults(results []domain.SNMPResult) ([]domain.StatusChange, error) {
+	if len(results) == 0 {
+		return nil, nil
+	}
+	var changes []domain.StatusChange
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		ids := make([]uint, 0, len(results))
+		for _, result := range results {
+			ids = append(ids, result.CentralID)
+		}
+		var reporting []uint
+		err := tx.Model(&CentralStatusModel{}).Where("central_id IN ? AND last_heartbeat_at IS NOT NULL", ids).
+			Pluck("central_id", &reporting).Error
+		if err != nil {
+			return err
+		}
+		heartbeats := make(map[uint]bool, len(reporting))
+		for _, id := range reporting {
+			heartbeats[id] = true
+		}
+
+		probes := make([]domain.ProbeResult, 0, len(results))
+		for _, result := range results {
+			checkedAt := result.CheckedAt
+			model := CentralSNMPModel{PolledAt: &checkedAt, LastError: result.Error}
+			columns := []string{"polled_at", "last_error"}
+			if inv := result.Inventory; inv != nil {
+				model.SysName = inv.SysName
+				model.SysDescr = inv.SysDescr
+				model.SysUpTimeSeconds = int64(inv.SysUpTime / time.Second)
+				model.Interfaces = newSNMPInterfaceRecords(inv.Interfaces)
+				model.CollectedAt = &checkedAt
+				columns = append(columns, "sys_name", "sys_descr", "sys_uptime_s", "interfaces", "collected_at")
+			}
+			// Credenciais removidas durante a coleta não voltam a existir
+			update := tx.Model(&CentralSNMPModel{}).Where("central_id = ?", result.CentralID).
+				Select(columns).Updates(&model)
+			if update.Error != nil {
+				return update.Error
+			}
+			if update.RowsAffected == 0 {
+				continue
+			}
+			if result.Inventory != nil {
+				err := tx.Create(&MetricPointModel{
+					CentralID:   result.CentralID,
+					Name:        UptimeMetric,
+					TimestampMS: checkedAt.UnixMilli(),
+					Value:       result.Inventory.SysUpTime.Seconds(),
+				}).Error
+				if err != nil {
+					return err
+				}
+			}
+			if !heartbeats[result.CentralID] {
+				probes = append(probes, domain.ProbeResult{
+					CentralID: result.CentralID,
+					Status:    result.Status,
+					RTT:       result.RTT,
+					CheckedAt: checkedAt,
+				})
+			}
+		}
+		if len(probes) == 0 {
+			return nil
+		}
+		changes, err = saveProbeResults(tx, probes)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+func (r *SNMPRepository) openCredentials(m *CentralSNMPModel) (domain.SNMPCredentials, error) {
+	creds := domain.SNMPCredentials{
+		Version:      domain.SNMPVersion(m.Version),
+		Port:         m.Port,
+		Username:     m.Username,
+		AuthProtocol: m.AuthProtocol,
+		PrivProtocol: m.PrivProtocol,
+	}
+	secretFields := []struct {
+		dst   *string
+		value string
+		name  string
+	}{
+		{&creds.Community, m.Community, "community"},
+		{&creds.AuthPassword, m.AuthPassword, "auth_password"},
+		{&creds.PrivPassword, m.PrivPassword, "priv_password"},
+	}
+	for _, field := range secretFields {
+		value, err := r.Secrets.Open(field.value, secretContext(m.CentralID, field.name))
+		if err != nil {
+			return domain.SNMPCredentials{}, err
+		}
+		*field.dst = value
+	}
+	return creds, nil
+}
+
+// Contexto autenticado junto de cada segredo, que o prende à central e ao
+// campo
+func secretContext(centralID uint, field string) string {
+	return fmt.Sprintf("central/%d/snmp/%s", centralID, field)
+}
@@ -0,0 +1,158 @@
+package repository_test
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/repository"
+	"api-golang/internal/secrets"
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSecretsBox(t *testing.T, fill byte) *secrets.Box {
+	box, err := secrets.NewBox(bytes.Repeat([]byte{fill}, secrets.KeySize))
+	require.NoError(t, err)
+	return box
+}
+
+var v3Credentials = domain.SNMPCredentials{
+	Version: domain.SNMPv3, Port: 1161, Username: "monitor",
+	AuthProtocol: "sha256", AuthPassword: "auth-pass-123",
+	PrivProtocol: "aes", PrivPassword: "priv-pass-456",
+}
+
+func TestSaveSNMPCredentials_Encrypted(t *testing.T) {
+	db := setupInMemoryDB()
+	central := createCentral(t, repository.NewCentralRepository(db), "00:11:22:33:44:01", "10.0.0.1")
+	repo := repository.NewSNMPRepository(db, newSecretsBox(t, 1))
+
+	saved, err := repo.SaveCredentials(central.ID, v3Credentials)
+	require.NoError(t, err)
+	// As senhas não voltam nas consultas
+	assert.Equal(t, domain.SNMPCredentials{Version: domain.SNMPv3, Port: 1161, Username: "monitor",
+		AuthProtocol: "sha256", PrivProtocol: "aes"}, saved.Credentials)
+	assert.Nil(t, saved.Inventory)
+
+	// No banco, só o texto cifrado
+	var model repository.CentralSNMPModel
+	require.NoError(t, db.First(&model, "central_id = ?", central.ID).Error)
+	for _, stored := range []string{model.AuthPassword, model.PrivPassword} {
+		assert.NotEmpty(t, stored)
+		assert.NotContains(t, stored, "pass")
+	}
+	assert.Empty(t, model.Community)
+
+	targets, err := repo.ListSNMPTargets()
+	require.NoError(t, err)
+	require.Len(t, targets, 1)
+	assert.Equal(t, "10.0.0.1", targets[0].Addr.String())
+	assert.Equal(t, v3Credentials, targets[0].Credentials)
+
+	// Trocar para v2c substitui as credenciais
+	_, err = repo.SaveCredentials(central.ID, domain.SNMPCredentials{Version: domain.SNMPv2c, Port: 161, Community: "public"})
+	require.NoError(t, err)
+	targets, err = repo.ListSNMPTargets()
+	require.NoError(t, err)
+	assert.Equal(t, domain.SNMPCredentials{Version: domain.SNMPv2c, Port: 161, Community: "public"}, targets[0].Credentials)
+
+	_, err = repo.SaveCredentials(99, v3Credentials)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestListSNMPTargets_WrongKey(t *testing.T) {
+	db := setupInMemoryDB()
+	central := createCentral(t, repository.NewCentralRepository(db), "00:11:22:33:44:01", "10.0.0.1")
+	_, err := repository.NewSNMPRepository(db, newSecretsBox(t, 1)).SaveCredentials(central.ID, v3Credentials)
+	require.NoError(t, err)
+
+	// Com outra chave, a central fica de fora e o erro aparece na consulta
+	repo := repository.NewSNMPRepository(db, newSecretsBox(t, 2))
+	targets, err := repo.ListSNMPTargets()
+	require.NoError(t, err)
+	assert.Empty(t, targets)
+	snmp, err := repo.Get(central.ID)
+	require.NoError(t, err)
+	assert.Contains(t, snmp.LastError, "unable to decrypt")
+}
+
+func TestSaveSNMPResults(t *testing.T) {
+	db := setupInMemoryDB()
+	centralRepo := repository.NewCentralRepository(db)
+	repo := repository.NewSNMPRepository(db, newSecretsBox(t, 1))
+	polled := createCentral(t, centralRepo, "00:11:22:33:44:01", "10.0.0.1")
+	reporting := createCentral(t, centralRepo, "00:11:22:33:44:02", "10.0.0.2")
+	unconfigured := createCentral(t, centralRepo, "00:11:22:33:44:03", "10.0.0.3")
+	for _, central := range []*domain.Central{polled, reporting} {
+		_, err := repo.SaveCredentials(central.ID, domain.SNMPCredentials{Version: domain.SNMPv2c, Port: 161, Community: "public"})
+		require.NoError(t, err)
+	}
+	hb := &domain.Heartbeat{CentralID: reporting.ID, ReceivedAt: time.Now().UTC()}
+	_, err := repository.NewHeartbeatRepository(db).Record(hb)
+	require.NoError(t, err)
+
+	// Centrais coletadas por SNMP não são sondadas pelo monitor
+	probeTargets, err := repository.NewStatusRepository(db).ListProbeTargets()
+	require.NoError(t, err)
+	require.Len(t, probeTargets, 1)
+	assert.Equal(t, unconfigured.ID, probeTargets[0].CentralID)
+
+	at := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	inventory := &domain.SNMPInventory{
+		SysName: "pabx-01", SysDescr: "Acme PBX 2.1", SysUpTime: 90 * time.Minute,
+		Interfaces: []domain.SNMPInterface{{Index: 1, Name: "eth0", Descr: "Ethernet 0", Type: 6, MTU: 1500,
+			Speed: 1e9, MAC: "00:11:22:33:44:01", AdminStatus: "up", OperStatus: "up"}},
+	}
+	changes, err := repo.SaveSNMPResults([]domain.SNMPResult{
+		{CentralID: polled.ID, Inventory: inventory, Status: domain.StatusOnline, RTT: 2 * time.Millisecond, CheckedAt: at},
+		{CentralID: reporting.ID, Inventory: inventory, Status: domain.StatusOnline, CheckedAt: at},
+		// Sem credenciais: ignorada
+		{CentralID: unconfigured.ID, Status: domain.StatusOffline, CheckedAt: at},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []domain.StatusChange{
+		{CentralID: polled.ID, From: domain.StatusUnknown, To: domain.StatusOnline, At: at},
+	}, changes)
+
+	snmp, err := repo.Get(polled.ID)
+	require.NoError(t, err)
+	assert.Equal(t, inventory, snmp.Inventory)
+	assert.True(t, at.Equal(*snmp.CollectedAt))
+	assert.Empty(t, snmp.LastError)
+
+	points, err := repository.NewTelemetryRepository(db).SeriesPoints(polled.ID, repository.UptimeMetric, at, at.Add(time.Second))
+	require.NoError(t, err)
+	require.Len(t, points, 1)
+	assert.Equal(t, 5400.0, points[0].Value)
+
+	// Uma falha mantém o último inventário e registra o erro
+	later := at.Add(time.Minute)
+	changes, err = repo.SaveSNMPResults([]domain.SNMPResult{
+		{CentralID: polled.ID, Error: "request timeout", Status: domain.StatusOffline, CheckedAt: later},
+		{CentralID: reporting.ID, Error: "request timeout", Status: domain.StatusOffline, CheckedAt: later},
+	})
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, domain.StatusOffline, changes[0].To)
+
+	snmp, err = repo.Get(polled.ID)
+	require.NoError(t, err)
+	assert.Equal(t, inventory, snmp.Inventory)
+	assert.True(t, at.Equal(*snmp.CollectedAt))
+	assert.True(t, later.Equal(*snmp.PolledAt))
+	assert.Equal(t, "request timeout", snmp.LastError)
+
+	// A situação de quem envia heartbeats não muda pela coleta
+	central, err := centralRepo.GetByID(reporting.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusOnline, central.Status.Status)
+
+	// Remover a central remove as credenciais
+	require.NoError(t, centralRepo.Delete(polled.ID))
+	_, err = repo.Get(polled.ID)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.ErrorIs(t, repo.Delete(polled.ID), domain.ErrNotFound)
+	require.NoError(t, repo.Delete(reporting.ID))
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"api-golang/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Última verificação de alcance de cada central. Centrais sem linha aqui
+// nunca foram verificadas.
+type CentralStatusModel struct {
+	CentralID  uint   `gorm:"primaryKey;autoIncrement:false"`
+	Status     string `gorm:"not null;index"`
+	LastSeenAt *time.Time
+	RTT        int64 `gorm:"column:rtt_ns;not null;default:0"`
+	CheckedAt  *time.Time
+
+	LastHeartbeatAt *time.Time `gorm:"index"`
+	// O mesmo instante em milissegundos, comparado na expiração sem depender
+	// das funções de data de cada banco
+	LastHeartbeatMS *int64 `gorm:"column:last_heartbeat_ms;index"`
+	FirmwareVersion string
+}
+
+func (CentralStatusModel) TableName() string {
+	return "central_statuses"
+}
+
+func (m *CentralStatusModel) toDomain() domain.CentralStatus {
+	return domain.CentralStatus{
+		Status:     domain.ReachabilityStatus(m.Status),
+		LastSeenAt: m.LastSeenAt,
+		RTT:        time.Duration(m.RTT),
+		CheckedAt:  m.CheckedAt,
+
+		LastHeartbeatAt: m.LastHeartbeatAt,
+		FirmwareVersion: m.FirmwareVersion,
+	}
+}
+
+// Carrega a situação das centrais informadas; as nunca verificadas ficam
+// como desconhecidas
+func loadStatuses(db *gorm.DB, centralIDs []uint) (map[uint]domain.CentralStatus, error) {
+	statuses := make(map[uint]domain.CentralStatus, len(centralIDs))
+	if len(centralIDs) == 0 {
+		return statuses, nil
+	}
+
+	var models []CentralStatusModel
+	if err := db.Where("central_id IN ?", centralIDs).Find(&models).Error; err != nil {
+		return nil, err
+	}
+	for i := range models {
+		statuses[models[i].CentralID] = models[i].toDomain()
+	}
+	return statuses, nil
+}
+
+// Restringe a consulta às centrais na situação informada
+func applyStatusFilter(query *gorm.DB, status domain.ReachabilityStatus) *gorm.DB {
+	switch status {
+	case "":
+		return query
+	case domain.StatusUnknown:
+		return query.Where("id NOT IN (SELECT central_id FROM central_statuses)")
+	}
+	return query.Where("id IN (SELECT central_id FROM central_statuses WHERE status = ?)", string(status))
+}
@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"api-golang/internal/domain"
+	"net/netip"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Métrica com o tempo de resposta das sondagens, em milissegundos
+const RTTMetric = "rtt_ms"
+
+// Repositório usado pelo monitor de alcance
+type StatusRepository struct {
+	DB *gorm.DB
+}
+
+func NewStatusRepository(db *gorm.DB) *StatusRepository {
+	return &StatusRepository{DB: db}
+}
+
+// Endereço a sondar de cada central: o IPv4 quando houver, senão o IPv6.
+// Centrais que enviam heartbeats ou coletadas por SNMP não são sondadas.
+func (r *StatusRepository) ListProbeTargets() ([]domain.ProbeTarget, error) {
+	var models []CentralModel
+	err := r.DB.Select("id", "ipv4", "ipv6").
+		Where("id NOT IN (SELECT central_id FROM central_statuses WHERE last_heartbeat_at IS NOT NULL)").
+		Where("id NOT IN (SELECT central_id FROM central_snmp)").
+		Order("id").Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+	targets := make([]domain.ProbeTarget, 0, len(models))
+	for _, m := range models {
+		central := m.toDomain()
+		addr, err := netip.ParseAddr(central.PrimaryIP())
+		if err != nil {
+			continue
+		}
+		targets = append(targets, domain.ProbeTarget{CentralID: m.ID, Addr: addr})
+	}
+	return targets, nil
+}
+
+// Grava os resultados. O "visto por último" só avança quando a central
+// respondeu; centrais removidas durante a rodada são ignoradas. O RTT das
+// respostas também vira a métrica de telemetria "rtt_ms". Retorna as
+// centrais cuja situação mudou, também gravadas no outbox.
+func (r *StatusRepository) SaveProbeResults(results []domain.ProbeResult) ([]domain.StatusChange, error) {
+	if len(results) == 0 {
+		return nil, nil
+	}
+	var changes []domain.StatusChange
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		changes, err = saveProbeResults(tx, results)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// Grava os resultados na transação, como SaveProbeResults
+func saveProbeResults(tx *gorm.DB, results []domain.ProbeResult) ([]domain.StatusChange, error) {
+	ids := make([]uint, 0, len(results))
+	for _, result := range results {
+		ids = append(ids, result.CentralID)
+	}
+	var existing []uint
+	if err := tx.Model(&CentralModel{}).Where("id IN ?", ids).Pluck("id", &existing).Error; err != nil {
+		return nil, err
+	}
+	alive := make(map[uint]bool, len(existing))
+	for _, id := range existing {
+		alive[id] = true
+	}
+	previous, err := currentStatuses(tx, existing)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []domain.StatusChange
+	for _, result := range results {
+		if !alive[result.CentralID] {
+			continue
+		}
+		checkedAt := result.CheckedAt
+		from, ok := previous[result.CentralID]
+		if !ok {
+			from = domain.StatusUnknown
+		}
+		if from != result.Status {
+			changes = append(changes, domain.StatusChange{CentralID: result.CentralID, From: from, To: result.Status, At: checkedAt})
+		}
+		model := CentralStatusModel{
+			CentralID: result.CentralID,
+			Status:    string(result.Status),
+			RTT:       int64(result.RTT),
+			CheckedAt: &checkedAt,
+		}
+		columns := []string{"status", "rtt_ns", "checked_at"}
+		if result.Status != domain.StatusOffline {
+			model.LastSeenAt = &checkedAt
+			columns = append(columns, "last_seen_at")
+		}
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "central_id"}},
+			DoUpdates: clause.AssignmentColumns(columns),
+		}).Create(&model).Error
+		if err != nil {
+			return nil, err
+		}
+		if result.Status != domain.StatusOffline && result.RTT > 0 {
+			err := tx.Create(&MetricPointModel{
+				CentralID:   result.CentralID,
+				Name:        RTTMetric,
+				TimestampMS: checkedAt.UnixMilli(),
+				Value:       float64(result.RTT.Microseconds()) / 1000,
+			}).Error
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := appendStatusEvents(tx, changes); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// Grava as mudanças de situação no outbox, com o estado da central já
+// atualizado na transação
+func appendStatusEvents(tx *gorm.DB, changes []domain.StatusChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	ids := make([]uint, 0, len(changes))
+	for _, change := range changes {
+		ids = append(ids, change.CentralID)
+	}
+	var models []CentralModel
+	if err := tx.Where("id IN ?", ids).Find(&models).Error; err != nil {
+		return err
+	}
+	centrals, err := loadCentrals(tx, models)
+	if err != nil {
+		return err
+	}
+	byID := make(map[uint]*domain.Central, len(centrals))
+	for i := range centrals {
+		byID[centrals[i].ID] = &centrals[i]
+	}
+	events := make([]domain.Event, 0, len(changes))
+	for _, change := range changes {
+		event := change.Event()
+		event.Central = byID[change.CentralID]
+		events = append(events, event)
+	}
+	return appendOutbox(tx, events...)
+}
+
+// Situação gravada de cada central; as nunca verificadas ficam de fora
+func currentStatuses(tx *gorm.DB, ids []uint) (map[uint]domain.ReachabilityStatus, error) {
+	var models []CentralStatusModel
+	if err := tx.Select("central_id", "status").Where("central_id IN ?", ids).Find(&models).Error; err != nil {
+		return nil, err
+	}
+	statuses := make(map[uint]domain.ReachabilityStatus, len(models))
+	for _, m := range models {
+		statuses[m.CentralID] = domain.ReachabilityStatus(m.Status)
+	}
+	return statuses, nil
+}
@@ -0,0 +1,86 @@
+package repository_test
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/repository"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListProbeTargets(t *testing.T) {
+	db := setupInMemoryDB()
+	centralRepo := repository.NewCentralRepository(db)
+	require.NoError(t, centralRepo.Create(&domain.Central{Name: "v4", MAC: "00:11:22:33:44:01", IPv4: "10.0.0.1", IPv6: "2001:db8::1"}))
+	require.NoError(t, centralRepo.Create(&domain.Central{Name: "v6", MAC: "00:11:22:33:44:02", IPv6: "2001:db8::2"}))
+
+	targets, err := repository.NewStatusRepository(db).ListProbeTargets()
+	require.NoError(t, err)
+	require.Len(t, targets, 2)
+	assert.Equal(t, "10.0.0.1", targets[0].Addr.String())
+	assert.Equal(t, "2001:db8::2", targets[1].Addr.String())
+}
+
+func TestSaveProbeResults(t *testing.T) {
+	db := setupInMemoryDB()
+	centralRepo := repository.NewCentralRepository(db)
+	repo := repository.NewStatusRepository(db)
+	online := createCentral(t, centralRepo, "00:11:22:33:44:01", "10.0.0.1")
+	offline := createCentral(t, centralRepo, "00:11:22:33:44:02", "10.0.0.2")
+	createCentral(t, centralRepo, "00:11:22:33:44:03", "10.0.0.3")
+
+	first := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	changes, err := repo.SaveProbeResults([]domain.ProbeResult{
+		{CentralID: online.ID, Status: domain.StatusOnline, RTT: 3 * time.Millisecond, CheckedAt: first},
+		{CentralID: offline.ID, Status: domain.StatusOnline, RTT: time.Millisecond, CheckedAt: first},
+		// Central removida durante a rodada
+		{CentralID: 99, Status: domain.StatusOnline, CheckedAt: first},
+	})
+	require.NoError(t, err)
+	assert.Len(t, changes, 2)
+	second := first.Add(time.Minute)
+	changes, err = repo.SaveProbeResults([]domain.ProbeResult{
+		{CentralID: online.ID, Status: domain.StatusOnline, RTT: 3 * time.Millisecond, CheckedAt: second},
+		{CentralID: offline.ID, Status: domain.StatusOffline, CheckedAt: second},
+	})
+	require.NoError(t, err)
+	// Só a central que deixou de responder mudou de situação
+	assert.Equal(t, []domain.StatusChange{
+		{CentralID: offline.ID, From: domain.StatusOnline, To: domain.StatusOffline, At: second},
+	}, changes)
+
+	central, err := centralRepo.GetByID(offline.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusOffline, central.Status.Status)
+	// Continua com o último momento em que respondeu
+	assert.True(t, first.Equal(*central.Status.LastSeenAt))
+	assert.True(t, second.Equal(*central.Status.CheckedAt))
+
+	central, err = centralRepo.GetByID(online.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 3*time.Millisecond, central.Status.RTT)
+
+	// O RTT das respostas também fica na telemetria
+	rtts, err := repository.NewTelemetryRepository(db).MetricValues(repository.RTTMetric, first, second.Add(time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, map[uint][]float64{online.ID: {3, 3}, offline.ID: {1}}, rtts)
+
+	names := func(status domain.ReachabilityStatus) []string {
+		centrals, err := centralRepo.GetAll(domain.CentralFilter{Status: status})
+		require.NoError(t, err)
+		var result []string
+		for _, c := range centrals {
+			result = append(result, c.IPv4)
+		}
+		return result
+	}
+	assert.Equal(t, []string{"10.0.0.2"}, names(domain.StatusOffline))
+	assert.Equal(t, []string{"10.0.0.1"}, names(domain.StatusOnline))
+	assert.Equal(t, []string{"10.0.0.3"}, names(domain.StatusUnknown))
+
+	// A situação sai junto com a central
+	require.NoError(t, centralRepo.Delete(offline.ID))
+	assert.Empty(t, names(domain.StatusOffline))
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"api-golang/internal/domain"
+	"time"
+)
+
+// Os instantes da telemetria são gravados em milissegundos Unix, para que
+// comparações e agrupamentos não dependam do formato de data do banco.
+
+// Pontos brutos, mantidos pela retenção mais curta
+type MetricPointModel struct {
+	ID          uint    `gorm:"primaryKey"`
+	CentralID   uint    `gorm:"not null;index:idx_metric_point_series,priority:1"`
+	Name        string  `gorm:"not null;index:idx_metric_point_series,priority:2"`
+	TimestampMS int64   `gorm:"column:ts_ms;not null;index:idx_metric_point_series,priority:3;index:idx_metric_point_ts"`
+	Value       float64 `gorm:"not null"`
+}
+
+func (MetricPointModel) TableName() string {
+	return "metric_points"
+}
+
+// Agregados de 5 minutos e de 1 hora
+type MetricRollupModel struct {
+	CentralID         uint   `gorm:"primaryKey;autoIncrement:false"`
+	Name              string `gorm:"primaryKey"`
+	ResolutionSeconds int64  `gorm:"column:resolution_s;primaryKey;autoIncrement:false;index:idx_metric_rollup_start,priority:1"`
+	StartMS           int64  `gorm:"column:start_ms;primaryKey;autoIncrement:false;index:idx_metric_rollup_start,priority:2"`
+
+	Count int     `gorm:"not null"`
+	Sum   float64 `gorm:"not null"`
+	Min   float64 `gorm:"not null"`
+	Max   float64 `gorm:"not null"`
+	P95   float64 `gorm:"column:p95;not null"`
+}
+
+func (MetricRollupModel) TableName() string {
+	return "metric_rollups"
+}
+
+func newMetricPointModel(point domain.MetricPoint) MetricPointModel {
+	return MetricPointModel{
+		CentralID:   point.CentralID,
+		Name:        point.Name,
+		TimestampMS: point.Timestamp.UnixMilli(),
+		Value:       point.Value,
+	}
+}
+
+func (m *MetricPointModel) toDomain() domain.MetricPoint {
+	return domain.MetricPoint{
+		CentralID: m.CentralID,
+		Name:      m.Name,
+		Timestamp: time.UnixMilli(m.TimestampMS).UTC(),
+		Value:     m.Value,
+	}
+}
+
+func newMetricRollupModel(rollup domain.MetricRollup) MetricRollupModel {
+	return MetricRollupModel{
+		CentralID:         rollup.CentralID,
+		Name:              rollup.Name,
+		ResolutionSeconds: int64(rollup.Resolution / time.Second),
+		StartMS:           rollup.Start.UnixMilli(),
+		Count:             rollup.Count,
+		Sum:               rollup.Sum,
+		Min:               rollup.Min,
+		Max:               rollup.Max,
+		P95:               rollup.P95,
+	}
+}
+
+func (m *MetricRollupModel) toDomain() domain.MetricRollup {
+	return domain.MetricRollup{
+		CentralID:  m.CentralID,
+		Name:       m.Name,
+		Resolution: time.Duration(m.ResolutionSeconds) * time.Second,
+		Start:      time.UnixMilli(m.StartMS).UTC(),
+		Count:      m.Count,
+		Sum:        m.Sum,
+		Min:        m.Min,
+		Max:        m.Max,
+		P95:        m.P95,
+	}
+}
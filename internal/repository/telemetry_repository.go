@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"api-golang/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TelemetryRepository struct {
+	DB *gorm.DB
+}
+
+func NewTelemetryRepository(db *gorm.DB) *TelemetryRepository {
+	return &TelemetryRepository{DB: db}
+}
+
+func (r *TelemetryRepository) WritePoints(points []domain.MetricPoint) error {
+	if len(points) == 0 {
+		return nil
+	}
+	models := make([]MetricPointModel, 0, len(points))
+	for _, point := range points {
+		models = append(models, newMetricPointModel(point))
+	}
+	return r.DB.CreateInBatches(models, 100).Error
+}
+
+// Pontos brutos de todas as centrais no intervalo [from, to)
+func (r *TelemetryRepository) ListPoints(from, to time.Time) ([]domain.MetricPoint, error) {
+	var models []MetricPointModel
+	err := r.DB.Where("ts_ms >= ? AND ts_ms < ?", from.UnixMilli(), to.UnixMilli()).
+		Order("central_id, name, ts_ms").Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+	return pointsToDomain(models), nil
+}
+
+// Pontos brutos de uma métrica da central no intervalo [from, to)
+func (r *TelemetryRepository) SeriesPoints(centralID uint, name string, from, to time.Time) ([]domain.MetricPoint, error) {
+	if err := r.DB.First(&CentralModel{}, centralID).Error; err != nil {
+		return nil, translateError(r.DB, err)
+	}
+
+	var models []MetricPointModel
+	err := r.DB.Where("central_id = ? AND name = ? AND ts_ms >= ? AND ts_ms < ?", centralID, name, from.UnixMilli(), to.UnixMilli()).
+		Order("ts_ms").Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+	return pointsToDomain(models), nil
+}
+
+// Valores de uma métrica no intervalo [from, to), agrupados por central
+func (r *TelemetryRepository) MetricValues(name string, from, to time.Time) (map[uint][]float64, error) {
+	var models []MetricPointModel
+	err := r.DB.Select("central_id", "value").
+		Where("name = ? AND ts_ms >= ? AND ts_ms < ?", name, from.UnixMilli(), to.UnixMilli()).
+		Order("central_id, ts_ms").Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[uint][]float64)
+	for _, m := range models {
+		values[m.CentralID] = append(values[m.CentralID], m.Value)
+	}
+	return values, nil
+}
+
+// Rollups de uma métrica da central que começam no intervalo [from, to)
+func (r *TelemetryRepository) SeriesRollups(centralID uint, name string, resolution time.Duration, from, to time.Time) ([]domain.MetricRollup, error) {
+	if err := r.DB.First(&CentralModel{}, centralID).Error; err != nil {
+		return nil, translateError(r.DB, err)
+	}
+
+	var models []MetricRollupModel
+	err := r.DB.Where("central_id = ? AND name = ? AND resolution_s = ? AND start_ms >= ? AND start_ms < ?",
+		centralID, name, int64(resolution/time.Second), from.UnixMilli(), to.UnixMilli()).
+		Order("start_ms").Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+	rollups := make([]domain.MetricRollup, 0, len(models))
+	for i := range models {
+		rollups = append(rollups, models[i].toDomain())
+	}
+	return rollups, nil
+}
+
+// Grava os rollups, substituindo os já existentes para o mesmo intervalo
+func (r *TelemetryRepository) SaveRollups(rollups []domain.MetricRollup) error {
+	if len(rollups) == 0 {
+		return nil
+	}
+	models := make([]MetricRollupModel, 0, len(rollups))
+	for _, rollup := range rollups {
+		models = append(models, newMetricRollupModel(rollup))
+	}
+	return r.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "central_id"}, {Name: "name"}, {Name: "resolution_s"}, {Name: "start_ms"}},
+		DoUpdates: clause.AssignmentColumns([]string{"count", "sum", "min", "max", "p95"}),
+	}).CreateInBatches(models, 100).Error
+}
+
+// Início do rollup mais recente da resolução; nil quando não há nenhum
+func (r *TelemetryRepository) LatestRollup(resolution time.Duration) (*time.Time, error) {
+	var start *int64
+	err := r.DB.Model(&MetricRollupModel{}).Where("resolution_s = ?", int64(resolution/time.Second)).
+		Select("MAX(start_ms)").Scan(&start).Error
+	return millisToTime(start), err
+}
+
+// Instante do ponto bruto mais antigo; nil quando não há nenhum
+func (r *TelemetryRepository) EarliestPoint() (*time.Time, error) {
+	var ts *int64
+	err := r.DB.Model(&MetricPointModel{}).Select("MIN(ts_ms)").Scan(&ts).Error
+	return millisToTime(ts), err
+}
+
+// Remove os dados anteriores aos limites de cada resolução; limites zerados
+// mantêm os dados para sempre. O histórico de heartbeats segue a retenção dos
+// pontos brutos.
+func (r *TelemetryRepository) Prune(cutoffs domain.RetentionCutoffs) (int64, error) {
+	var deleted int64
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		if !cutoffs.Raw.IsZero() {
+			result := tx.Where("ts_ms < ?", cutoffs.Raw.UnixMilli()).Delete(&MetricPointModel{})
+			if result.Error != nil {
+				return result.Error
+			}
+			deleted += result.RowsAffected
+			result = tx.Where("received_at < ?", cutoffs.Raw).Delete(&HeartbeatModel{})
+			if result.Error != nil {
+				return result.Error
+			}
+			deleted += result.RowsAffected
+		}
+		for resolution, cutoff := range map[time.Duration]time.Time{
+			domain.ResolutionFiveMinute: cutoffs.FiveMinute,
+			domain.ResolutionHour:       cutoffs.Hour,
+		} {
+			if cutoff.IsZero() {
+				continue
+			}
+			result := tx.Where("resolution_s = ? AND start_ms < ?", int64(resolution/time.Second), cutoff.UnixMilli()).
+				Delete(&MetricRollupModel{})
+			if result.Error != nil {
+				return result.Error
+			}
+			deleted += result.RowsAffected
+		}
+		return nil
+	})
+	return deleted, err
+}
+
+func pointsToDomain(models []MetricPointModel) []domain.MetricPoint {
+	points := make([]domain.MetricPoint, 0, len(models))
+	for i := range models {
+		points = append(points, models[i].toDomain())
+	}
+	return points
+}
+
+func millisToTime(ms *int64) *time.Time {
+	if ms == nil {
+		return nil
+	}
+	t := time.UnixMilli(*ms).UTC()
+	return &t
+}
@@ -0,0 +1,109 @@
+package repository_test
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/repository"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTelemetryPoints(t *testing.T) {
+	db := setupInMemoryDB()
+	repo := repository.NewTelemetryRepository(db)
+	central := createCentral(t, repository.NewCentralRepository(db), "00:11:22:33:44:01", "10.0.0.1")
+
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, repo.WritePoints([]domain.MetricPoint{
+		{CentralID: central.ID, Name: "cpu_percent", Timestamp: start, Value: 10},
+		{CentralID: central.ID, Name: "cpu_percent", Timestamp: start.Add(time.Minute), Value: 20},
+		{CentralID: central.ID, Name: "memory_percent", Timestamp: start.Add(time.Minute), Value: 50},
+		{CentralID: central.ID, Name: "cpu_percent", Timestamp: start.Add(5 * time.Minute), Value: 30},
+	}))
+
+	points, err := repo.SeriesPoints(central.ID, "cpu_percent", start, start.Add(5*time.Minute))
+	require.NoError(t, err)
+	require.Len(t, points, 2)
+	assert.True(t, start.Add(time.Minute).Equal(points[1].Timestamp))
+	assert.Equal(t, float64(20), points[1].Value)
+
+	all, err := repo.ListPoints(start, start.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Len(t, all, 4)
+
+	earliest, err := repo.EarliestPoint()
+	require.NoError(t, err)
+	assert.True(t, start.Equal(*earliest))
+
+	_, err = repo.SeriesPoints(99, "cpu_percent", start, start.Add(time.Hour))
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestTelemetryRollups(t *testing.T) {
+	db := setupInMemoryDB()
+	repo := repository.NewTelemetryRepository(db)
+	central := createCentral(t, repository.NewCentralRepository(db), "00:11:22:33:44:01", "10.0.0.1")
+
+	latest, err := repo.LatestRollup(domain.ResolutionFiveMinute)
+	require.NoError(t, err)
+	assert.Nil(t, latest)
+
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	rollup := domain.MetricRollup{CentralID: central.ID, Name: "cpu_percent", Resolution: domain.ResolutionFiveMinute,
+		Start: start, Count: 2, Sum: 30, Min: 10, Max: 20, P95: 19.5}
+	require.NoError(t, repo.SaveRollups([]domain.MetricRollup{rollup}))
+
+	// Regravar o mesmo intervalo substitui o agregado
+	rollup.Count, rollup.Sum, rollup.Max = 3, 60, 30
+	next := rollup
+	next.Start = start.Add(5 * time.Minute)
+	require.NoError(t, repo.SaveRollups([]domain.MetricRollup{rollup, next}))
+
+	rollups, err := repo.SeriesRollups(central.ID, "cpu_percent", domain.ResolutionFiveMinute, start, start.Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, rollups, 2)
+	assert.Equal(t, 3, rollups[0].Count)
+	assert.Equal(t, float64(30), rollups[0].Max)
+
+	latest, err = repo.LatestRollup(domain.ResolutionFiveMinute)
+	require.NoError(t, err)
+	assert.True(t, next.Start.Equal(*latest))
+
+	hourly, err := repo.SeriesRollups(central.ID, "cpu_percent", domain.ResolutionHour, start, start.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, hourly)
+}
+
+func TestTelemetryPrune(t *testing.T) {
+	db := setupInMemoryDB()
+	repo := repository.NewTelemetryRepository(db)
+	central := createCentral(t, repository.NewCentralRepository(db), "00:11:22:33:44:01", "10.0.0.1")
+	heartbeats := repository.NewHeartbeatRepository(db)
+
+	old := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	recent := old.Add(48 * time.Hour)
+	require.NoError(t, repo.WritePoints([]domain.MetricPoint{
+		{CentralID: central.ID, Name: "cpu_percent", Timestamp: old, Value: 1},
+		{CentralID: central.ID, Name: "cpu_percent", Timestamp: recent, Value: 2},
+	}))
+	record(t, heartbeats, &domain.Heartbeat{CentralID: central.ID, ReceivedAt: old})
+	record(t, heartbeats, &domain.Heartbeat{CentralID: central.ID, ReceivedAt: recent})
+	require.NoError(t, repo.SaveRollups([]domain.MetricRollup{
+		{CentralID: central.ID, Name: "cpu_percent", Resolution: domain.ResolutionFiveMinute, Start: old, Count: 1},
+		{CentralID: central.ID, Name: "cpu_percent", Resolution: domain.ResolutionHour, Start: old, Count: 1},
+	}))
+
+	// Sem limite para os rollups de 1 hora
+	deleted, err := repo.Prune(domain.RetentionCutoffs{Raw: recent.Add(-time.Hour), FiveMinute: recent})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), deleted)
+
+	points, _ := repo.ListPoints(old, recent.Add(time.Hour))
+	assert.Len(t, points, 1)
+	remaining, _ := heartbeats.List(central.ID, 10)
+	assert.Len(t, remaining, 1)
+	hourly, _ := repo.SeriesRollups(central.ID, "cpu_percent", domain.ResolutionHour, old, recent)
+	assert.Len(t, hourly, 1)
+}
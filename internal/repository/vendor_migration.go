@@ -0,0 +1,39 @@
+package repository
+
+import "gorm.io/gorm"
+
+// RefreshVendors recalcula o fabricante das centrais e interfaces, para que
+// uma base de OUIs atualizada alcance também os registros antigos. Retorna
+// quantos registros mudaram.
+func RefreshVendors(db *gorm.DB, vendorOf func(mac string) string) (int, error) {
+	changed := 0
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var centrals []CentralModel
+		if err := tx.Select("id", "mac", "vendor").Find(&centrals).Error; err != nil {
+			return err
+		}
+		for _, m := range centrals {
+			if vendor := vendorOf(m.MAC); vendor != m.Vendor {
+				if err := tx.Model(&CentralModel{ID: m.ID}).UpdateColumn("vendor", vendor).Error; err != nil {
+					return err
+				}
+				changed++
+			}
+		}
+
+		var ifaces []NetworkInterfaceModel
+		if err := tx.Select("id", "mac", "vendor").Find(&ifaces).Error; err != nil {
+			return err
+		}
+		for _, m := range ifaces {
+			if vendor := vendorOf(m.MAC); vendor != m.Vendor {
+				if err := tx.Model(&NetworkInterfaceModel{ID: m.ID}).UpdateColumn("vendor", vendor).Error; err != nil {
+					return err
+				}
+				changed++
+			}
+		}
+		return nil
+	})
+	return changed, err
+}
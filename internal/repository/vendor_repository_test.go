@@ -0,0 +1,57 @@
+package repository_test
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/repository"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAllCentrals_FilterByVendor(t *testing.T) {
+	db := setupInMemoryDB()
+	repo := repository.NewCentralRepository(db)
+
+	require.NoError(t, repo.Create(&domain.Central{Name: "VM", MAC: "00:50:56:00:00:01", IPv4: "10.0.0.1", Vendor: "VMware, Inc."}))
+	require.NoError(t, repo.Create(&domain.Central{Name: "Pi", MAC: "b8:27:eb:00:00:01", IPv4: "10.0.0.2", Vendor: "Raspberry Pi Foundation"}))
+	require.NoError(t, repo.Create(&domain.Central{Name: "Desconhecida", MAC: "fc:ff:ff:00:00:01", IPv4: "10.0.0.3"}))
+
+	centrals, err := repo.GetAll(domain.CentralFilter{Vendor: "vmware"})
+	require.NoError(t, err)
+	require.Len(t, centrals, 1)
+	assert.Equal(t, "VM", centrals[0].Name)
+	assert.Equal(t, "VMware, Inc.", centrals[0].PrimaryInterface.Vendor)
+
+	// Curingas do LIKE são tratados como texto
+	centrals, err = repo.GetAll(domain.CentralFilter{Vendor: "%"})
+	require.NoError(t, err)
+	assert.Empty(t, centrals)
+}
+
+func TestRefreshVendors(t *testing.T) {
+	db := setupInMemoryDB()
+	repo := repository.NewCentralRepository(db)
+	central := createCentral(t, repo, "00:50:56:00:00:01", "10.0.0.1")
+
+	// Base atualizada passa a conhecer o prefixo
+	vendorOf := func(mac string) string {
+		if mac == "00:50:56:00:00:01" {
+			return "VMware, Inc."
+		}
+		return ""
+	}
+	changed, err := repository.RefreshVendors(db, vendorOf)
+	require.NoError(t, err)
+	assert.Equal(t, 2, changed)
+
+	updated, err := repo.GetByID(central.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "VMware, Inc.", updated.Vendor)
+	assert.Equal(t, "VMware, Inc.", updated.PrimaryInterface.Vendor)
+
+	// Sem mudanças, nada é regravado
+	changed, err = repository.RefreshVendors(db, vendorOf)
+	require.NoError(t, err)
+	assert.Zero(t, changed)
+}
@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"api-golang/internal/domain"
+	"time"
+)
+
+// Modelo de persistência da assinatura de webhook
+type WebhookModel struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	URL       string   `gorm:"not null"`
+	Events    []string `gorm:"serializer:json"`
+	Secret    string   `gorm:"not null"`
+	Active    bool     `gorm:"not null"`
+}
+
+func (WebhookModel) TableName() string {
+	return "webhooks"
+}
+
+func newWebhookModel(webhook *domain.Webhook) *WebhookModel {
+	events := make([]string, 0, len(webhook.Events))
+	for _, event := range webhook.Events {
+		events = append(events, string(event))
+	}
+	return &WebhookModel{
+		ID:        webhook.ID,
+		CreatedAt: webhook.CreatedAt,
+		UpdatedAt: webhook.UpdatedAt,
+		URL:       webhook.URL,
+		Events:    events,
+		Secret:    webhook.Secret,
+		Active:    webhook.Active,
+	}
+}
+
+func (m *WebhookModel) toDomain() *domain.Webhook {
+	events := make([]domain.EventType, 0, len(m.Events))
+	for _, event := range m.Events {
+		events = append(events, domain.EventType(event))
+	}
+	return &domain.Webhook{
+		ID:        m.ID,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+		URL:       m.URL,
+		Events:    events,
+		Secret:    m.Secret,
+		Active:    m.Active,
+	}
+}
+
+// Entrega de um evento a uma assinatura
+type WebhookDeliveryModel struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	WebhookID uint   `gorm:"not null;uniqueIndex:idx_webhook_delivery_event,priority:1"`
+	EventID   string `gorm:"not null;uniqueIndex:idx_webhook_delivery_event,priority:2"`
+	EventType string `gorm:"not null"`
+	Payload   []byte `gorm:"not null"`
+
+	Status         string     `gorm:"not null;index:idx_webhook_delivery_due,priority:1"`
+	Attempts       int        `gorm:"not null"`
+	NextAttemptAt  *time.Time `gorm:"index:idx_webhook_delivery_due,priority:2"`
+	LastStatusCode int
+	LastError      string
+	DeliveredAt    *time.Time
+}
+
+func (WebhookDeliveryModel) TableName() string {
+	return "webhook_deliveries"
+}
+
+func newWebhookDeliveryModel(delivery *domain.WebhookDelivery) *WebhookDeliveryModel {
+	return &WebhookDeliveryModel{
+		ID:             delivery.ID,
+		CreatedAt:      delivery.CreatedAt,
+		UpdatedAt:      delivery.UpdatedAt,
+		WebhookID:      delivery.WebhookID,
+		EventID:        delivery.EventID,
+		EventType:      string(delivery.EventType),
+		Payload:        delivery.Payload,
+		Status:         string(delivery.Status),
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		LastStatusCode: delivery.LastStatusCode,
+		LastError:      delivery.LastError,
+		DeliveredAt:    delivery.DeliveredAt,
+	}
+}
+
+func (m *WebhookDeliveryModel) toDomain() *domain.WebhookDelivery {
+	return &domain.WebhookDelivery{
+		ID:             m.ID,
+		CreatedAt:      m.CreatedAt,
+		UpdatedAt:      m.UpdatedAt,
+		WebhookID:      m.WebhookID,
+		EventID:        m.EventID,
+		EventType:      domain.EventType(m.EventType),
+		Payload:        m.Payload,
+		Status:         domain.DeliveryStatus(m.Status),
+		Attempts:       m.Attempts,
+		NextAttemptAt:  m.NextAttemptAt,
+		LastStatusCode: m.LastStatusCode,
+		LastError:      m.LastError,
+		DeliveredAt:    m.DeliveredAt,
+	}
+}
+
+// Registro de cada tentativa de envio
+type WebhookAttemptModel struct {
+	ID          uint      `gorm:"primaryKey"`
+	DeliveryID  uint      `gorm:"not null;index"`
+	Number      int       `gorm:"not null"`
+	AttemptedAt time.Time `gorm:"not null"`
+	StatusCode  int
+	Error       string
+	DurationMS  int64 `gorm:"column:duration_ms;not null"`
+}
+
+func (WebhookAttemptModel) TableName() string {
+	return "webhook_attempts"
+}
+
+func (m *WebhookAttemptModel) toDomain() domain.DeliveryAttempt {
+	return domain.DeliveryAttempt{
+		ID:          m.ID,
+		DeliveryID:  m.DeliveryID,
+		Number:      m.Number,
+		AttemptedAt: m.AttemptedAt,
+		StatusCode:  m.StatusCode,
+		Error:       m.Error,
+		Duration:    time.Duration(m.DurationMS) * time.Millisecond,
+	}
+}
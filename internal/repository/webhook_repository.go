@@ -0,0 +1,235 @@
+package repository
+
+import (
+	"api-golang/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Repositório das assinaturas de webhook e das suas entregas
+type WebhookRepository struct {
+	DB *gorm.DB
+}
+
+func NewWebhookRepository(db *gorm.DB) *WebhookRepository {
+	return &WebhookRepository{DB: db}
+}
+
+func (r *WebhookRepository) CreateWebhook(webhook *domain.Webhook) error {
+	model := newWebhookModel(webhook)
+	if err := r.DB.Create(model).Error; err != nil {
+		return translateError(r.DB, err)
+	}
+	*webhook = *model.toDomain()
+	return nil
+}
+
+func (r *WebhookRepository) GetWebhooks() ([]domain.Webhook, error) {
+	var models []WebhookModel
+	if err := r.DB.Order("id").Find(&models).Error; err != nil {
+		return nil, err
+	}
+	webhooks := make([]domain.Webhook, 0, len(models))
+	for i := range models {
+		webhooks = append(webhooks, *models[i].toDomain())
+	}
+	return webhooks, nil
+}
+
+func (r *WebhookRepository) GetWebhookByID(id uint) (*domain.Webhook, error) {
+	var model WebhookModel
+	if err := r.DB.First(&model, id).Error; err != nil {
+		return nil, translateError(r.DB, err)
+	}
+	return model.toDomain(), nil
+}
+
+// Atualiza a assinatura; sem segredo, mantém o atual
+func (r *WebhookRepository) UpdateWebhook(webhook *domain.Webhook) error {
+	columns := []string{"url", "events", "active", "updated_at"}
+	if webhook.Secret != "" {
+		columns = append(columns, "secret")
+	}
+	result := r.DB.Model(&WebhookModel{ID: webhook.ID}).Select(columns).Updates(newWebhookModel(webhook))
+	if result.Error != nil {
+		return translateError(r.DB, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+
+	updated, err := r.GetWebhookByID(webhook.ID)
+	if err != nil {
+		return err
+	}
+	*webhook = *updated
+	return nil
+}
+
+// Remove a assinatura com as suas entregas e tentativas
+func (r *WebhookRepository) DeleteWebhook(id uint) error {
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&WebhookModel{}, id).Error; err != nil {
+			return err
+		}
+		deliveries := tx.Model(&WebhookDeliveryModel{}).Select("id").Where("webhook_id = ?", id)
+		if err := tx.Where("delivery_id IN (?)", deliveries).Delete(&WebhookAttemptModel{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("webhook_id = ?", id).Delete(&WebhookDeliveryModel{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&WebhookModel{}, id).Error
+	})
+	return translateError(r.DB, err)
+}
+
+// Cria uma entrega pendente do evento para cada assinatura ativa que o
+// recebe e ainda não o tem. Retorna a quantidade de entregas criadas.
+func (r *WebhookRepository) EnqueueDeliveries(eventID string, eventType domain.EventType, payload []byte, now time.Time) (int, error) {
+	var webhooks []WebhookModel
+	if err := r.DB.Where("active = ?", true).Order("id").Find(&webhooks).Error; err != nil {
+		return 0, err
+	}
+	var deliveries []WebhookDeliveryModel
+	for i := range webhooks {
+		if !webhooks[i].toDomain().Subscribes(eventType) {
+			continue
+		}
+		next := now
+		deliveries = append(deliveries, WebhookDeliveryModel{
+			WebhookID:     webhooks[i].ID,
+			EventID:       eventID,
+			EventType:     string(eventType),
+			Payload:       payload,
+			Status:        string(domain.DeliveryPending),
+			NextAttemptAt: &next,
+		})
+	}
+	if len(deliveries) == 0 {
+		return 0, nil
+	}
+	// O evento já enfileirado para a assinatura não é repetido
+	result := r.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(&deliveries)
+	return int(result.RowsAffected), result.Error
+}
+
+// Entregas pendentes com a próxima tentativa vencida, de assinaturas ativas,
+// já com o destino preenchido
+func (r *WebhookRepository) DueDeliveries(now time.Time, limit int) ([]domain.WebhookDelivery, error) {
+	var models []WebhookDeliveryModel
+	err := r.DB.Joins("JOIN webhooks ON webhooks.id = webhook_deliveries.webhook_id AND webhooks.active = ?", true).
+		Where("webhook_deliveries.status = ? AND webhook_deliveries.next_attempt_at <= ?", string(domain.DeliveryPending), now).
+		Order("webhook_deliveries.next_attempt_at, webhook_deliveries.id").Limit(limit).Find(&models).Error
+	if err != nil || len(models) == 0 {
+		return nil, err
+	}
+
+	ids := make([]uint, 0, len(models))
+	for _, m := range models {
+		ids = append(ids, m.WebhookID)
+	}
+	var webhooks []WebhookModel
+	if err := r.DB.Where("id IN ?", ids).Find(&webhooks).Error; err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]*WebhookModel, len(webhooks))
+	for i := range webhooks {
+		byID[webhooks[i].ID] = &webhooks[i]
+	}
+
+	deliveries := make([]domain.WebhookDelivery, 0, len(models))
+	for i := range models {
+		delivery := models[i].toDomain()
+		delivery.URL, delivery.Secret = byID[delivery.WebhookID].URL, byID[delivery.WebhookID].Secret
+		deliveries = append(deliveries, *delivery)
+	}
+	return deliveries, nil
+}
+
+// Registra a tentativa e grava o novo estado da entrega. A tentativa é
+// numerada pela quantidade de tentativas já registradas.
+func (r *WebhookRepository) RecordAttempt(delivery *domain.WebhookDelivery, attempt *domain.DeliveryAttempt) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&WebhookAttemptModel{}).Where("delivery_id = ?", delivery.ID).Count(&count).Error; err != nil {
+			return err
+		}
+		model := WebhookAttemptModel{
+			DeliveryID:  delivery.ID,
+			Number:      int(count) + 1,
+			AttemptedAt: attempt.AttemptedAt,
+			StatusCode:  attempt.StatusCode,
+			Error:       attempt.Error,
+			DurationMS:  attempt.Duration.Milliseconds(),
+		}
+		if err := tx.Create(&model).Error; err != nil {
+			return err
+		}
+		*attempt = model.toDomain()
+
+		return tx.Model(&WebhookDeliveryModel{ID: delivery.ID}).
+			Select("status", "attempts", "next_attempt_at", "last_status_code", "last_error", "delivered_at", "updated_at").
+			Updates(newWebhookDeliveryModel(delivery)).Error
+	})
+}
+
+// Entregas da assinatura, das mais recentes às mais antigas; sem situação,
+// lista todas
+func (r *WebhookRepository) GetDeliveries(webhookID uint, status domain.DeliveryStatus, limit int) ([]domain.WebhookDelivery, error) {
+	if err := r.DB.First(&WebhookModel{}, webhookID).Error; err != nil {
+		return nil, translateError(r.DB, err)
+	}
+	query := r.DB.Where("webhook_id = ?", webhookID)
+	if status != "" {
+		query = query.Where("status = ?", string(status))
+	}
+	var models []WebhookDeliveryModel
+	if err := query.Order("id DESC").Limit(limit).Find(&models).Error; err != nil {
+		return nil, err
+	}
+	deliveries := make([]domain.WebhookDelivery, 0, len(models))
+	for i := range models {
+		deliveries = append(deliveries, *models[i].toDomain())
+	}
+	return deliveries, nil
+}
+
+// Entrega com o histórico de tentativas
+func (r *WebhookRepository) GetDelivery(webhookID, id uint) (*domain.WebhookDelivery, error) {
+	var model WebhookDeliveryModel
+	if err := r.DB.Where("webhook_id = ?", webhookID).First(&model, id).Error; err != nil {
+		return nil, translateError(r.DB, err)
+	}
+	var attempts []WebhookAttemptModel
+	if err := r.DB.Where("delivery_id = ?", id).Order("number").Find(&attempts).Error; err != nil {
+		return nil, err
+	}
+	delivery := model.toDomain()
+	delivery.AttemptLog = make([]domain.DeliveryAttempt, 0, len(attempts))
+	for i := range attempts {
+		delivery.AttemptLog = append(delivery.AttemptLog, attempts[i].toDomain())
+	}
+	return delivery, nil
+}
+
+// Volta a entrega para a fila com um novo ciclo de tentativas, qualquer que
+// seja a sua situação; o histórico é mantido
+func (r *WebhookRepository) Redeliver(webhookID, id uint, now time.Time) (*domain.WebhookDelivery, error) {
+	result := r.DB.Model(&WebhookDeliveryModel{}).Where("id = ? AND webhook_id = ?", id, webhookID).
+		Updates(map[string]interface{}{
+			"status":          string(domain.DeliveryPending),
+			"attempts":        0,
+			"next_attempt_at": now,
+			"delivered_at":    nil,
+		})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, domain.ErrNotFound
+	}
+	return r.GetDelivery(webhookID, id)
+}
@@ -0,0 +1,127 @@
+package repository_test
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/repository"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookCRUD(t *testing.T) {
+	repo := repository.NewWebhookRepository(setupInMemoryDB())
+
+	webhook := &domain.Webhook{URL: "https://cmdb.example.com/hooks", Secret: "s1", Active: true,
+		Events: []domain.EventType{domain.EventCentralCreated}}
+	require.NoError(t, repo.CreateWebhook(webhook))
+	assert.NotZero(t, webhook.ID)
+
+	// Sem segredo, a atualização mantém o atual
+	update := &domain.Webhook{ID: webhook.ID, URL: "https://cmdb.example.com/v2", Active: false}
+	require.NoError(t, repo.UpdateWebhook(update))
+	assert.Equal(t, "s1", update.Secret)
+	assert.False(t, update.Active)
+	assert.Empty(t, update.Events)
+
+	update.Secret = "s2"
+	require.NoError(t, repo.UpdateWebhook(update))
+	found, err := repo.GetWebhookByID(webhook.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "s2", found.Secret)
+
+	assert.ErrorIs(t, repo.UpdateWebhook(&domain.Webhook{ID: 99, URL: "https://x"}), domain.ErrNotFound)
+	_, err = repo.GetWebhookByID(99)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.ErrorIs(t, repo.DeleteWebhook(99), domain.ErrNotFound)
+
+	webhooks, err := repo.GetWebhooks()
+	require.NoError(t, err)
+	assert.Len(t, webhooks, 1)
+}
+
+func TestWebhookDeliveries(t *testing.T) {
+	repo := repository.NewWebhookRepository(setupInMemoryDB())
+
+	all := &domain.Webhook{URL: "https://a.example.com", Secret: "a", Active: true}
+	deletes := &domain.Webhook{URL: "https://b.example.com", Secret: "b", Active: true,
+		Events: []domain.EventType{domain.EventCentralDeleted}}
+	paused := &domain.Webhook{URL: "https://c.example.com", Secret: "c", Active: false}
+	for _, w := range []*domain.Webhook{all, deletes, paused} {
+		require.NoError(t, repo.CreateWebhook(w))
+	}
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	count, err := repo.EnqueueDeliveries("e1", domain.EventCentralCreated, []byte(`{"id":"e1"}`), now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	count, err = repo.EnqueueDeliveries("e2", domain.EventCentralDeleted, []byte(`{"id":"e2"}`), now.Add(time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	// O mesmo evento reenviado pelo relay não duplica entregas
+	count, err = repo.EnqueueDeliveries("e1", domain.EventCentralCreated, []byte(`{"id":"e1"}`), now)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	// Só as vencidas, das mais antigas às mais novas, com o destino
+	due, err := repo.DueDeliveries(now, 10)
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+	assert.Equal(t, "e1", due[0].EventID)
+	assert.Equal(t, all.URL, due[0].URL)
+	assert.Equal(t, "a", due[0].Secret)
+
+	due, err = repo.DueDeliveries(now.Add(time.Minute), 10)
+	require.NoError(t, err)
+	require.Len(t, due, 3)
+
+	// Falha: a tentativa fica no histórico e a entrega volta para a fila
+	delivery := due[0]
+	next := now.Add(time.Hour)
+	delivery.Attempts, delivery.LastStatusCode, delivery.LastError, delivery.NextAttemptAt = 1, 500, "unexpected status 500", &next
+	attempt := &domain.DeliveryAttempt{AttemptedAt: now, StatusCode: 500, Error: "unexpected status 500", Duration: 120 * time.Millisecond}
+	require.NoError(t, repo.RecordAttempt(&delivery, attempt))
+	assert.Equal(t, 1, attempt.Number)
+
+	due, _ = repo.DueDeliveries(now.Add(time.Minute), 10)
+	assert.Len(t, due, 2)
+
+	// Esgotadas as tentativas, vai para dead-letter
+	delivery.Attempts, delivery.Status, delivery.NextAttemptAt = 2, domain.DeliveryDead, nil
+	require.NoError(t, repo.RecordAttempt(&delivery, &domain.DeliveryAttempt{AttemptedAt: next, StatusCode: 503}))
+
+	dead, err := repo.GetDeliveries(all.ID, domain.DeliveryDead, 10)
+	require.NoError(t, err)
+	require.Len(t, dead, 1)
+	found, err := repo.GetDelivery(all.ID, delivery.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.DeliveryDead, found.Status)
+	assert.Equal(t, `{"id":"e1"}`, string(found.Payload))
+	require.Len(t, found.AttemptLog, 2)
+	assert.Equal(t, 120*time.Millisecond, found.AttemptLog[0].Duration)
+	assert.Equal(t, 503, found.AttemptLog[1].StatusCode)
+
+	// O reenvio abre um novo ciclo e mantém a numeração do histórico
+	redelivered, err := repo.Redeliver(all.ID, delivery.ID, next)
+	require.NoError(t, err)
+	assert.Equal(t, domain.DeliveryPending, redelivered.Status)
+	assert.Zero(t, redelivered.Attempts)
+	redelivered.Attempts, redelivered.Status = 1, domain.DeliverySucceeded
+	attempt = &domain.DeliveryAttempt{AttemptedAt: next, StatusCode: 200}
+	require.NoError(t, repo.RecordAttempt(redelivered, attempt))
+	assert.Equal(t, 3, attempt.Number)
+
+	_, err = repo.Redeliver(deletes.ID, delivery.ID, next)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	_, err = repo.GetDelivery(deletes.ID, delivery.ID)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	_, err = repo.GetDeliveries(99, "", 10)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	// A remoção leva as entregas junto
+	require.NoError(t, repo.DeleteWebhook(all.ID))
+	due, _ = repo.DueDeliveries(now.Add(time.Minute), 10)
+	assert.Len(t, due, 1)
+}
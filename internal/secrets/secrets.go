@@ -0,0 +1,91 @@
+// Package secrets cifra os segredos gravados no banco, como as credenciais
+// SNMP das centrais, com AES-256-GCM.
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Tamanho da chave, em bytes
+const KeySize = 32
+
+// Prefixo dos valores cifrados, que identifica o formato
+const prefix = "v1:"
+
+var ErrDecrypt = errors.New("secrets: unable to decrypt value")
+
+// Cifra e decifra valores com uma chave fixa
+type Box struct {
+	aead cipher.AEAD
+}
+
+func NewBox(key []byte) (*Box, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("secrets: key must have %d bytes, got %d", KeySize, len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &Box{aead: aead}, nil
+}
+
+// Lê uma chave em base64 ou hexadecimal, como gerada por
+// "openssl rand -base64 32"
+func ParseKey(value string) ([]byte, error) {
+	value = strings.TrimSpace(value)
+	if key, err := base64.StdEncoding.DecodeString(value); err == nil && len(key) == KeySize {
+		return key, nil
+	}
+	if key, err := hex.DecodeString(value); err == nil && len(key) == KeySize {
+		return key, nil
+	}
+	return nil, fmt.Errorf("secrets: key must be %d bytes in base64 or hex", KeySize)
+}
+
+// Cifra o valor. O contexto (ex.: "central/3/community") é autenticado junto
+// e precisa ser o mesmo ao decifrar, o que impede trocar valores cifrados
+// entre registros. Valores vazios continuam vazios.
+func (b *Box) Seal(plaintext, context string) (string, error) {
+	if plaintext == "" {
+		return "", nil
+	}
+	nonce := make([]byte, b.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := b.aead.Seal(nonce, nonce, []byte(plaintext), []byte(context))
+	return prefix + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// Decifra um valor produzido por Seal com o mesmo contexto
+func (b *Box) Open(ciphertext, context string) (string, error) {
+	if ciphertext == "" {
+		return "", nil
+	}
+	encoded, ok := strings.CutPrefix(ciphertext, prefix)
+	if !ok {
+		return "", ErrDecrypt
+	}
+	sealed, err := base64.RawStdEncoding.DecodeString(encoded)
+	if err != nil || len(sealed) < b.aead.NonceSize() {
+		return "", ErrDecrypt
+	}
+	nonce, sealed := sealed[:b.aead.NonceSize()], sealed[b.aead.NonceSize():]
+	plaintext, err := b.aead.Open(nil, nonce, sealed, []byte(context))
+	if err != nil {
+		return "", ErrDecrypt
+	}
+	return string(plaintext), nil
+}
@@ -0,0 +1,82 @@
+package secrets_test
+
+import (
+	"api-golang/internal/secrets"
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var key = bytes.Repeat([]byte{7}, secrets.KeySize)
+
+func TestSealAndOpen(t *testing.T) {
+	box, err := secrets.NewBox(key)
+	require.NoError(t, err)
+
+	sealed, err := box.Seal("public", "central/1/community")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(sealed, "v1:"))
+	assert.NotContains(t, sealed, "public")
+
+	// Cada cifragem usa um nonce novo
+	again, err := box.Seal("public", "central/1/community")
+	require.NoError(t, err)
+	assert.NotEqual(t, sealed, again)
+
+	plaintext, err := box.Open(sealed, "central/1/community")
+	require.NoError(t, err)
+	assert.Equal(t, "public", plaintext)
+
+	empty, err := box.Seal("", "central/1/community")
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+	plaintext, err = box.Open("", "central/1/community")
+	require.NoError(t, err)
+	assert.Empty(t, plaintext)
+}
+
+func TestOpen_Rejects(t *testing.T) {
+	box, err := secrets.NewBox(key)
+	require.NoError(t, err)
+	sealed, err := box.Seal("public", "central/1/community")
+	require.NoError(t, err)
+
+	// Contexto de outro registro
+	_, err = box.Open(sealed, "central/2/community")
+	assert.ErrorIs(t, err, secrets.ErrDecrypt)
+
+	// Outra chave
+	other, err := secrets.NewBox(bytes.Repeat([]byte{8}, secrets.KeySize))
+	require.NoError(t, err)
+	_, err = other.Open(sealed, "central/1/community")
+	assert.ErrorIs(t, err, secrets.ErrDecrypt)
+
+	// Texto puro e valores adulterados
+	for _, value := range []string{"public", "v1:!!", "v1:AAAA", sealed[:len(sealed)-2] + "AA"} {
+		_, err = box.Open(value, "central/1/community")
+		assert.ErrorIs(t, err, secrets.ErrDecrypt, value)
+	}
+}
+
+func TestParseKey(t *testing.T) {
+	parsed, err := secrets.ParseKey(base64.StdEncoding.EncodeToString(key))
+	require.NoError(t, err)
+	assert.Equal(t, key, parsed)
+
+	parsed, err = secrets.ParseKey(" " + hex.EncodeToString(key) + "\n")
+	require.NoError(t, err)
+	assert.Equal(t, key, parsed)
+
+	for _, value := range []string{"", "short", base64.StdEncoding.EncodeToString(key[:16])} {
+		_, err = secrets.ParseKey(value)
+		assert.Error(t, err, value)
+	}
+
+	_, err = secrets.NewBox(key[:16])
+	assert.Error(t, err)
+}
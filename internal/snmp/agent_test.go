@@ -0,0 +1,233 @@
+package snmp_test
+
+import (
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gosnmp/gosnmp"
+	"github.com/stretchr/testify/require"
+)
+
+// Agente SNMP simulado em uma porta UDP de loopback. Responde Get, GetNext e
+// GetBulk de uma MIB fixa em v2c (pela comunidade) ou em v3 (USM, com a
+// descoberta do engine ID e autenticação/privacidade do usuário
+// configurado). Requisições com credenciais erradas são descartadas, como
+// fazem os agentes reais.
+type agent struct {
+	conn *net.UDPConn
+	mib  []gosnmp.SnmpPDU
+
+	community string
+	user      *gosnmp.UsmSecurityParameters
+	flags     gosnmp.SnmpV3MsgFlags
+	engineID  string
+
+	// Responde às consultas com este erro, como um agente sem acesso à MIB
+	errorStatus atomic.Int32
+	requests    atomic.Int32
+}
+
+type agentOptions struct {
+	community string
+	// Usuário v3; AuthoritativeEngineID é preenchido pelo agente
+	user  *gosnmp.UsmSecurityParameters
+	flags gosnmp.SnmpV3MsgFlags
+}
+
+func startAgent(t *testing.T, opts agentOptions, mib []gosnmp.SnmpPDU) *agent {
+	conn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	a := &agent{conn: conn, community: opts.community, flags: opts.flags, engineID: "\x80\x00\x1f\x88\x04sim-agent"}
+	a.mib = append(a.mib, mib...)
+	sort.Slice(a.mib, func(i, j int) bool { return compareOIDs(a.mib[i].Name, a.mib[j].Name) < 0 })
+	if opts.user != nil {
+		user := opts.user.Copy().(*gosnmp.UsmSecurityParameters)
+		user.AuthoritativeEngineID = a.engineID
+		user.AuthoritativeEngineBoots = 1
+		require.NoError(t, user.InitSecurityKeys())
+		a.user = user
+	}
+	go a.serve()
+	return a
+}
+
+func (a *agent) port() int {
+	return a.conn.LocalAddr().(*net.UDPAddr).Port
+}
+
+func (a *agent) serve() {
+	buf := make([]byte, 65535)
+	for {
+		n, addr, err := a.conn.ReadFromUDP(buf)
+		if err != nil {
+			return
+		}
+		a.requests.Add(1)
+		if reply := a.handle(append([]byte(nil), buf[:n]...)); reply != nil {
+			a.conn.WriteToUDP(reply, addr)
+		}
+	}
+}
+
+func (a *agent) handle(msg []byte) []byte {
+	if packetVersion(msg) == gosnmp.Version3 {
+		return a.handleV3(msg)
+	}
+	decoder := &gosnmp.GoSNMP{Version: gosnmp.Version2c, Community: a.community}
+	req, err := decoder.SnmpDecodePacket(msg)
+	if err != nil || req.Community != a.community {
+		return nil
+	}
+	resp := &gosnmp.SnmpPacket{Version: req.Version, Community: req.Community}
+	a.respond(req, resp)
+	out, err := resp.MarshalMsg()
+	if err != nil {
+		return nil
+	}
+	return out
+}
+
+func (a *agent) handleV3(msg []byte) []byte {
+	if a.user == nil {
+		return nil
+	}
+	decoder := &gosnmp.GoSNMP{
+		Version:            gosnmp.Version3,
+		SecurityModel:      gosnmp.UserSecurityModel,
+		MsgFlags:           a.flags,
+		SecurityParameters: a.user.Copy(),
+	}
+	req, err := decoder.SnmpDecodePacket(msg)
+	if err != nil {
+		return nil
+	}
+	usm := req.SecurityParameters.(*gosnmp.UsmSecurityParameters)
+
+	params := a.user.Copy().(*gosnmp.UsmSecurityParameters)
+	resp := &gosnmp.SnmpPacket{
+		Version:            gosnmp.Version3,
+		MsgID:              req.MsgID,
+		SecurityModel:      gosnmp.UserSecurityModel,
+		SecurityParameters: params,
+		ContextEngineID:    a.engineID,
+		ContextName:        req.ContextName,
+	}
+	switch {
+	case usm.AuthoritativeEngineID != a.engineID:
+		// Descoberta: informa o engine ID, sem autenticação
+		resp.MsgFlags = gosnmp.NoAuthNoPriv
+		resp.PDUType = gosnmp.Report
+		resp.RequestID = req.RequestID
+		resp.Variables = []gosnmp.SnmpPDU{{Name: ".1.3.6.1.6.3.15.1.1.4.0", Type: gosnmp.Counter32, Value: uint32(1)}}
+	case usm.UserName != a.user.UserName || req.MsgFlags&gosnmp.AuthPriv != a.flags:
+		return nil
+	default:
+		resp.MsgFlags = a.flags
+		a.respond(req, resp)
+		if err := params.InitPacket(resp); err != nil {
+			return nil
+		}
+	}
+	out, err := resp.MarshalMsg()
+	if err != nil {
+		return nil
+	}
+	return out
+}
+
+// Preenche a resposta à consulta com as variáveis da MIB
+func (a *agent) respond(req, resp *gosnmp.SnmpPacket) {
+	resp.PDUType = gosnmp.GetResponse
+	resp.RequestID = req.RequestID
+	if status := gosnmp.SNMPError(a.errorStatus.Load()); status != gosnmp.NoError {
+		resp.Error = status
+		resp.ErrorIndex = 1
+		resp.Variables = req.Variables
+		return
+	}
+	switch req.PDUType {
+	case gosnmp.GetRequest:
+		for _, v := range req.Variables {
+			resp.Variables = append(resp.Variables, a.get(v.Name))
+		}
+	case gosnmp.GetNextRequest:
+		for _, v := range req.Variables {
+			resp.Variables = append(resp.Variables, a.next(v.Name))
+		}
+	case gosnmp.GetBulkRequest:
+		nonRepeaters := min(int(req.NonRepeaters), len(req.Variables))
+		for _, v := range req.Variables[:nonRepeaters] {
+			resp.Variables = append(resp.Variables, a.next(v.Name))
+		}
+		current := req.Variables[nonRepeaters:]
+		for range req.MaxRepetitions {
+			next := make([]gosnmp.SnmpPDU, 0, len(current))
+			ended := true
+			for _, v := range current {
+				pdu := a.next(v.Name)
+				if pdu.Type != gosnmp.EndOfMibView {
+					ended = false
+				}
+				next = append(next, pdu)
+			}
+			resp.Variables = append(resp.Variables, next...)
+			current = next
+			if ended {
+				break
+			}
+		}
+	}
+}
+
+func (a *agent) get(oid string) gosnmp.SnmpPDU {
+	for _, pdu := range a.mib {
+		if pdu.Name == oid {
+			return pdu
+		}
+	}
+	return gosnmp.SnmpPDU{Name: oid, Type: gosnmp.NoSuchObject}
+}
+
+func (a *agent) next(oid string) gosnmp.SnmpPDU {
+	for _, pdu := range a.mib {
+		if compareOIDs(pdu.Name, oid) > 0 {
+			return pdu
+		}
+	}
+	return gosnmp.SnmpPDU{Name: oid, Type: gosnmp.EndOfMibView}
+}
+
+// Compara OIDs numericamente, componente a componente
+func compareOIDs(a, b string) int {
+	pa := strings.Split(strings.TrimPrefix(a, "."), ".")
+	pb := strings.Split(strings.TrimPrefix(b, "."), ".")
+	for i := 0; i < len(pa) && i < len(pb); i++ {
+		x, _ := strconv.Atoi(pa[i])
+		y, _ := strconv.Atoi(pb[i])
+		if x != y {
+			return x - y
+		}
+	}
+	return len(pa) - len(pb)
+}
+
+// Versão no cabeçalho da mensagem: SEQUENCE { INTEGER version, ... }
+func packetVersion(msg []byte) gosnmp.SnmpVersion {
+	if len(msg) < 2 || msg[0] != 0x30 {
+		return 0
+	}
+	i := 2
+	if msg[1]&0x80 != 0 {
+		i += int(msg[1] & 0x7f)
+	}
+	if len(msg) < i+3 || msg[i] != 0x02 || msg[i+1] != 0x01 {
+		return 0
+	}
+	return gosnmp.SnmpVersion(msg[i+2])
+}
@@ -0,0 +1,237 @@
+package snmp
+
+import (
+	"api-golang/internal/domain"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/gosnmp/gosnmp"
+)
+
+// OIDs consultados: o grupo system e as colunas da ifTable e da ifXTable
+const (
+	oidSysDescr  = ".1.3.6.1.2.1.1.1.0"
+	oidSysUpTime = ".1.3.6.1.2.1.1.3.0"
+	oidSysName   = ".1.3.6.1.2.1.1.5.0"
+
+	oidIfDescr       = ".1.3.6.1.2.1.2.2.1.2"
+	oidIfType        = ".1.3.6.1.2.1.2.2.1.3"
+	oidIfMtu         = ".1.3.6.1.2.1.2.2.1.4"
+	oidIfSpeed       = ".1.3.6.1.2.1.2.2.1.5"
+	oidIfPhysAddress = ".1.3.6.1.2.1.2.2.1.6"
+	oidIfAdminStatus = ".1.3.6.1.2.1.2.2.1.7"
+	oidIfOperStatus  = ".1.3.6.1.2.1.2.2.1.8"
+	oidIfName        = ".1.3.6.1.2.1.31.1.1.1.1"
+	oidIfHighSpeed   = ".1.3.6.1.2.1.31.1.1.1.15"
+)
+
+// Valores de ifAdminStatus e ifOperStatus (IF-MIB)
+var interfaceStatuses = map[int]string{
+	1: "up", 2: "down", 3: "testing", 4: "unknown", 5: "dormant", 6: "notPresent", 7: "lowerLayerDown",
+}
+
+var authProtocols = map[string]gosnmp.SnmpV3AuthProtocol{
+	"md5": gosnmp.MD5, "sha": gosnmp.SHA, "sha224": gosnmp.SHA224,
+	"sha256": gosnmp.SHA256, "sha384": gosnmp.SHA384, "sha512": gosnmp.SHA512,
+}
+
+var privProtocols = map[string]gosnmp.SnmpV3PrivProtocol{
+	"des": gosnmp.DES, "aes": gosnmp.AES, "aes192": gosnmp.AES192,
+	"aes256": gosnmp.AES256, "aes192c": gosnmp.AES192C, "aes256c": gosnmp.AES256C,
+}
+
+// Consulta os agentes por UDP com a biblioteca gosnmp
+type Client struct {
+	// Prazo de cada requisição e quantas vezes repeti-la sem resposta
+	Timeout time.Duration
+	Retries int
+	// Linhas pedidas em cada GetBulk ao percorrer as tabelas
+	MaxRepetitions uint32
+}
+
+func (c *Client) Poll(ctx context.Context, target domain.SNMPTarget) (*domain.SNMPInventory, time.Duration, error) {
+	conn, err := c.connect(ctx, target)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer conn.Conn.Close()
+
+	start := time.Now()
+	packet, err := conn.Get([]string{oidSysDescr, oidSysUpTime, oidSysName})
+	if err != nil {
+		return nil, 0, requestError(err)
+	}
+	rtt := time.Since(start)
+	if packet.Error != gosnmp.NoError {
+		return nil, rtt, fmt.Errorf("SNMP agent returned %s", packet.Error)
+	}
+
+	inventory := &domain.SNMPInventory{}
+	for _, v := range packet.Variables {
+		switch v.Name {
+		case oidSysDescr:
+			inventory.SysDescr = octetString(v)
+		case oidSysName:
+			inventory.SysName = octetString(v)
+		case oidSysUpTime:
+			// TimeTicks, em centésimos de segundo
+			if v.Type == gosnmp.TimeTicks {
+				inventory.SysUpTime = time.Duration(gosnmp.ToBigInt(v.Value).Int64()) * 10 * time.Millisecond
+			}
+		}
+	}
+	if inventory.Interfaces, err = c.interfaces(conn); err != nil {
+		return nil, rtt, requestError(err)
+	}
+	return inventory, rtt, nil
+}
+
+// Monta a conexão com a versão e as credenciais da central
+func (c *Client) connect(ctx context.Context, target domain.SNMPTarget) (*gosnmp.GoSNMP, error) {
+	creds := target.Credentials
+	port := creds.Port
+	if port == 0 {
+		port = domain.DefaultSNMPPort
+	}
+	conn := &gosnmp.GoSNMP{
+		Target:         target.Addr.String(),
+		Port:           uint16(port),
+		Transport:      "udp",
+		Timeout:        c.Timeout,
+		Retries:        c.Retries,
+		MaxOids:        gosnmp.MaxOids,
+		MaxRepetitions: c.MaxRepetitions,
+		Context:        ctx,
+	}
+	switch creds.Version {
+	case domain.SNMPv2c:
+		conn.Version = gosnmp.Version2c
+		conn.Community = creds.Community
+	case domain.SNMPv3:
+		usm := &gosnmp.UsmSecurityParameters{
+			UserName:                 creds.Username,
+			AuthenticationProtocol:   gosnmp.NoAuth,
+			PrivacyProtocol:          gosnmp.NoPriv,
+			AuthenticationPassphrase: creds.AuthPassword,
+			PrivacyPassphrase:        creds.PrivPassword,
+		}
+		conn.MsgFlags = gosnmp.NoAuthNoPriv
+		if creds.AuthProtocol != "" {
+			usm.AuthenticationProtocol = authProtocols[creds.AuthProtocol]
+			conn.MsgFlags = gosnmp.AuthNoPriv
+		}
+		if creds.PrivProtocol != "" {
+			usm.PrivacyProtocol = privProtocols[creds.PrivProtocol]
+			conn.MsgFlags = gosnmp.AuthPriv
+		}
+		conn.Version = gosnmp.Version3
+		conn.SecurityModel = gosnmp.UserSecurityModel
+		conn.SecurityParameters = usm
+	default:
+		return nil, fmt.Errorf("unsupported SNMP version %q", creds.Version)
+	}
+	if err := conn.Connect(); err != nil {
+		return nil, err
+	}
+	return conn, nil
+}
+
+// Percorre as colunas da ifTable e da ifXTable e monta as interfaces, na
+// ordem do ifIndex. Agentes sem a ifXTable ficam com o ifDescr como nome.
+func (c *Client) interfaces(conn *gosnmp.GoSNMP) ([]domain.SNMPInterface, error) {
+	byIndex := map[int]*domain.SNMPInterface{}
+	highSpeed := map[int]uint64{}
+	columns := []struct {
+		oid string
+		set func(iface *domain.SNMPInterface, v gosnmp.SnmpPDU)
+	}{
+		{oidIfDescr, func(iface *domain.SNMPInterface, v gosnmp.SnmpPDU) { iface.Descr = octetString(v) }},
+		{oidIfType, func(iface *domain.SNMPInterface, v gosnmp.SnmpPDU) {
+			iface.Type = int(gosnmp.ToBigInt(v.Value).Int64())
+		}},
+		{oidIfMtu, func(iface *domain.SNMPInterface, v gosnmp.SnmpPDU) { iface.MTU = int(gosnmp.ToBigInt(v.Value).Int64()) }},
+		{oidIfSpeed, func(iface *domain.SNMPInterface, v gosnmp.SnmpPDU) { iface.Speed = gosnmp.ToBigInt(v.Value).Uint64() }},
+		{oidIfPhysAddress, func(iface *domain.SNMPInterface, v gosnmp.SnmpPDU) {
+			if b, ok := v.Value.([]byte); ok && len(b) > 0 {
+				iface.MAC = net.HardwareAddr(b).String()
+			}
+		}},
+		{oidIfAdminStatus, func(iface *domain.SNMPInterface, v gosnmp.SnmpPDU) {
+			iface.AdminStatus = interfaceStatus(v)
+		}},
+		{oidIfOperStatus, func(iface *domain.SNMPInterface, v gosnmp.SnmpPDU) {
+			iface.OperStatus = interfaceStatus(v)
+		}},
+		{oidIfName, func(iface *domain.SNMPInterface, v gosnmp.SnmpPDU) { iface.Name = octetString(v) }},
+		{oidIfHighSpeed, func(iface *domain.SNMPInterface, v gosnmp.SnmpPDU) {
+			highSpeed[iface.Index] = gosnmp.ToBigInt(v.Value).Uint64()
+		}},
+	}
+	for _, column := range columns {
+		err := conn.BulkWalk(column.oid, func(v gosnmp.SnmpPDU) error {
+			index, err := strconv.Atoi(strings.TrimPrefix(v.Name, column.oid+"."))
+			if err != nil {
+				return nil
+			}
+			iface, ok := byIndex[index]
+			if !ok {
+				iface = &domain.SNMPInterface{Index: index}
+				byIndex[index] = iface
+			}
+			column.set(iface, v)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	interfaces := make([]domain.SNMPInterface, 0, len(byIndex))
+	for _, iface := range byIndex {
+		if iface.Name == "" {
+			iface.Name = iface.Descr
+		}
+		// ifSpeed satura em ~4,3 Gbit/s; ifHighSpeed vem em Mbit/s
+		if speed := highSpeed[iface.Index] * 1_000_000; speed > iface.Speed {
+			iface.Speed = speed
+		}
+		interfaces = append(interfaces, *iface)
+	}
+	sort.Slice(interfaces, func(i, j int) bool { return interfaces[i].Index < interfaces[j].Index })
+	return interfaces, nil
+}
+
+// Classifica o erro da requisição: sem resposta vira ErrNoResponse
+func requestError(err error) error {
+	var netErr net.Error
+	switch {
+	case errors.Is(err, context.Canceled):
+		return err
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, syscall.ECONNREFUSED),
+		errors.As(err, &netErr) && netErr.Timeout(), strings.Contains(err.Error(), "timeout"):
+		return fmt.Errorf("%w: %v", ErrNoResponse, err)
+	}
+	return err
+}
+
+func octetString(v gosnmp.SnmpPDU) string {
+	if b, ok := v.Value.([]byte); ok {
+		return string(b)
+	}
+	return ""
+}
+
+func interfaceStatus(v gosnmp.SnmpPDU) string {
+	code := int(gosnmp.ToBigInt(v.Value).Int64())
+	if status, ok := interfaceStatuses[code]; ok {
+		return status
+	}
+	return strconv.Itoa(code)
+}
@@ -0,0 +1,125 @@
+// Package snmp coleta periodicamente, por SNMP v2c ou v3, o inventário e a
+// situação das centrais com credenciais configuradas.
+package snmp
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/monitor"
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Consulta o agente SNMP da central
+type Poller interface {
+	// Retorna o inventário e o tempo de resposta da primeira consulta. Um
+	// agente que não responde resulta em ErrNoResponse.
+	Poll(ctx context.Context, target domain.SNMPTarget) (*domain.SNMPInventory, time.Duration, error)
+}
+
+// O agente não respondeu dentro do prazo e das repetições
+var ErrNoResponse = errors.New("no response from SNMP agent")
+
+// Origem dos alvos e destino dos resultados
+type Store interface {
+	ListSNMPTargets() ([]domain.SNMPTarget, error)
+	// Retorna as centrais cuja situação mudou
+	SaveSNMPResults(results []domain.SNMPResult) ([]domain.StatusChange, error)
+}
+
+type Options struct {
+	// Intervalo entre o início de duas rodadas
+	Interval time.Duration
+	// Coletas simultâneas em uma rodada
+	Concurrency int
+	// Tempo de resposta acima do qual a central é considerada degradada
+	DegradedRTT time.Duration
+}
+
+const defaultConcurrency = 16
+
+type Collector struct {
+	Store   Store
+	Poller  Poller
+	Options Options
+	// Chamado após cada rodada com as mudanças de situação; opcional
+	OnChange func(changes []domain.StatusChange)
+
+	// Relógio, substituível nos testes
+	Now func() time.Time
+}
+
+func New(store Store, poller Poller, opts Options) *Collector {
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = defaultConcurrency
+	}
+	return &Collector{Store: store, Poller: poller, Options: opts, Now: time.Now}
+}
+
+// Executa uma rodada imediatamente e depois a cada intervalo, até o
+// contexto ser cancelado
+func (c *Collector) Run(ctx context.Context) {
+	monitor.RunEvery(ctx, c.Options.Interval, "SNMP collector", func() error {
+		return c.CollectAll(ctx)
+	})
+}
+
+// Coleta todas as centrais uma vez e grava os resultados
+func (c *Collector) CollectAll(ctx context.Context) error {
+	targets, err := c.Store.ListSNMPTargets()
+	if err != nil {
+		return err
+	}
+
+	results := make([]domain.SNMPResult, len(targets))
+	sem := make(chan struct{}, c.Options.Concurrency)
+	var wg sync.WaitGroup
+	for i, target := range targets {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, target domain.SNMPTarget) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = c.collect(ctx, target)
+		}(i, target)
+	}
+	wg.Wait()
+
+	// Uma rodada interrompida não representa a situação real
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	changes, err := c.Store.SaveSNMPResults(results)
+	if err != nil {
+		return err
+	}
+	if c.OnChange != nil && len(changes) > 0 {
+		c.OnChange(changes)
+	}
+	return nil
+}
+
+// Coleta uma central. Sem resposta, ela fica offline; quando o agente
+// responde mas a coleta falha (ex.: usuário desconhecido), degradada.
+func (c *Collector) collect(ctx context.Context, target domain.SNMPTarget) domain.SNMPResult {
+	inventory, rtt, err := c.Poller.Poll(ctx, target)
+	result := domain.SNMPResult{CentralID: target.CentralID, CheckedAt: c.Now()}
+	switch {
+	case errors.Is(err, ErrNoResponse):
+		result.Status = domain.StatusOffline
+		result.Error = err.Error()
+	case err != nil:
+		result.Status = domain.StatusDegraded
+		result.Error = err.Error()
+		result.RTT = rtt
+	default:
+		result.Inventory = inventory
+		result.RTT = rtt
+		result.Status = domain.StatusOnline
+		if c.Options.DegradedRTT > 0 && rtt > c.Options.DegradedRTT {
+			result.Status = domain.StatusDegraded
+		}
+	}
+	return result
+}
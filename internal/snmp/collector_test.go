@@ -0,0 +1,192 @@
+package snmp_test
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/snmp"
+	"context"
+	"net"
+	"net/netip"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gosnmp/gosnmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Store em memória
+type fakeStore struct {
+	mu      sync.Mutex
+	targets []domain.SNMPTarget
+	saved   map[uint]domain.SNMPResult
+}
+
+func (s *fakeStore) ListSNMPTargets() ([]domain.SNMPTarget, error) {
+	return s.targets, nil
+}
+
+func (s *fakeStore) SaveSNMPResults(results []domain.SNMPResult) ([]domain.StatusChange, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.saved == nil {
+		s.saved = make(map[uint]domain.SNMPResult)
+	}
+	var changes []domain.StatusChange
+	for _, r := range results {
+		if s.saved[r.CentralID].Status != r.Status {
+			changes = append(changes, domain.StatusChange{CentralID: r.CentralID, To: r.Status, At: r.CheckedAt})
+		}
+		s.saved[r.CentralID] = r
+	}
+	return changes, nil
+}
+
+var loopback = netip.MustParseAddr("127.0.0.1")
+
+// MIB de uma central com duas interfaces; só a primeira tem entrada na
+// ifXTable, com velocidade acima do limite do ifSpeed
+func centralMIB() []gosnmp.SnmpPDU {
+	return []gosnmp.SnmpPDU{
+		{Name: ".1.3.6.1.2.1.1.1.0", Type: gosnmp.OctetString, Value: "PBX 9000 firmware 4.2"},
+		{Name: ".1.3.6.1.2.1.1.3.0", Type: gosnmp.TimeTicks, Value: uint32(360000)},
+		{Name: ".1.3.6.1.2.1.1.5.0", Type: gosnmp.OctetString, Value: "central-sp-01"},
+		{Name: ".1.3.6.1.2.1.2.2.1.2.1", Type: gosnmp.OctetString, Value: "eth0"},
+		{Name: ".1.3.6.1.2.1.2.2.1.2.2", Type: gosnmp.OctetString, Value: "E1 trunk"},
+		{Name: ".1.3.6.1.2.1.2.2.1.3.1", Type: gosnmp.Integer, Value: 6},
+		{Name: ".1.3.6.1.2.1.2.2.1.3.2", Type: gosnmp.Integer, Value: 18},
+		{Name: ".1.3.6.1.2.1.2.2.1.4.1", Type: gosnmp.Integer, Value: 1500},
+		{Name: ".1.3.6.1.2.1.2.2.1.5.1", Type: gosnmp.Gauge32, Value: uint32(4294967295)},
+		{Name: ".1.3.6.1.2.1.2.2.1.5.2", Type: gosnmp.Gauge32, Value: uint32(2048000)},
+		{Name: ".1.3.6.1.2.1.2.2.1.6.1", Type: gosnmp.OctetString, Value: []byte{0x00, 0x1a, 0x2b, 0x3c, 0x4d, 0x5e}},
+		{Name: ".1.3.6.1.2.1.2.2.1.6.2", Type: gosnmp.OctetString, Value: []byte{}},
+		{Name: ".1.3.6.1.2.1.2.2.1.7.1", Type: gosnmp.Integer, Value: 1},
+		{Name: ".1.3.6.1.2.1.2.2.1.7.2", Type: gosnmp.Integer, Value: 1},
+		{Name: ".1.3.6.1.2.1.2.2.1.8.1", Type: gosnmp.Integer, Value: 1},
+		{Name: ".1.3.6.1.2.1.2.2.1.8.2", Type: gosnmp.Integer, Value: 7},
+		{Name: ".1.3.6.1.2.1.31.1.1.1.1.1", Type: gosnmp.OctetString, Value: "Gi0/1"},
+		{Name: ".1.3.6.1.2.1.31.1.1.1.15.1", Type: gosnmp.Gauge32, Value: uint32(10000)},
+		// Fora das tabelas consultadas
+		{Name: ".1.3.6.1.4.1.9999.1.0", Type: gosnmp.Integer, Value: 42},
+	}
+}
+
+func expectedInventory() *domain.SNMPInventory {
+	return &domain.SNMPInventory{
+		SysName:   "central-sp-01",
+		SysDescr:  "PBX 9000 firmware 4.2",
+		SysUpTime: time.Hour,
+		Interfaces: []domain.SNMPInterface{
+			{Index: 1, Name: "Gi0/1", Descr: "eth0", Type: 6, MTU: 1500, Speed: 10_000_000_000,
+				MAC: "00:1a:2b:3c:4d:5e", AdminStatus: "up", OperStatus: "up"},
+			{Index: 2, Name: "E1 trunk", Descr: "E1 trunk", Type: 18, Speed: 2_048_000,
+				AdminStatus: "up", OperStatus: "lowerLayerDown"},
+		},
+	}
+}
+
+func newClient() *snmp.Client {
+	// Poucas linhas por GetBulk para exercitar a paginação das tabelas
+	return &snmp.Client{Timeout: 200 * time.Millisecond, Retries: 1, MaxRepetitions: 1}
+}
+
+func TestClientPoll_V2c(t *testing.T) {
+	agent := startAgent(t, agentOptions{community: "s3cret"}, centralMIB())
+
+	target := domain.SNMPTarget{CentralID: 1, Addr: loopback,
+		Credentials: domain.SNMPCredentials{Version: domain.SNMPv2c, Port: agent.port(), Community: "s3cret"}}
+	inventory, rtt, err := newClient().Poll(context.Background(), target)
+	require.NoError(t, err)
+	assert.Greater(t, rtt, time.Duration(0))
+	assert.Equal(t, expectedInventory(), inventory)
+}
+
+func TestClientPoll_V3AuthPriv(t *testing.T) {
+	user := &gosnmp.UsmSecurityParameters{
+		UserName:                 "monitor",
+		AuthenticationProtocol:   gosnmp.SHA256,
+		AuthenticationPassphrase: "auth-pass-123",
+		PrivacyProtocol:          gosnmp.AES,
+		PrivacyPassphrase:        "priv-pass-456",
+	}
+	agent := startAgent(t, agentOptions{user: user, flags: gosnmp.AuthPriv}, centralMIB())
+
+	creds := domain.SNMPCredentials{Version: domain.SNMPv3, Port: agent.port(), Username: "monitor",
+		AuthProtocol: "sha256", AuthPassword: "auth-pass-123", PrivProtocol: "aes", PrivPassword: "priv-pass-456"}
+	inventory, _, err := newClient().Poll(context.Background(), domain.SNMPTarget{CentralID: 1, Addr: loopback, Credentials: creds})
+	require.NoError(t, err)
+	assert.Equal(t, expectedInventory(), inventory)
+
+	// Senha de privacidade errada: o agente descarta as mensagens
+	creds.PrivPassword = "wrong-pass-789"
+	_, _, err = newClient().Poll(context.Background(), domain.SNMPTarget{CentralID: 1, Addr: loopback, Credentials: creds})
+	assert.ErrorIs(t, err, snmp.ErrNoResponse)
+}
+
+func TestClientPoll_NoResponse(t *testing.T) {
+	agent := startAgent(t, agentOptions{community: "s3cret"}, centralMIB())
+
+	creds := domain.SNMPCredentials{Version: domain.SNMPv2c, Port: agent.port(), Community: "public"}
+	_, _, err := newClient().Poll(context.Background(), domain.SNMPTarget{CentralID: 1, Addr: loopback, Credentials: creds})
+	assert.ErrorIs(t, err, snmp.ErrNoResponse)
+	// Tentativa inicial e uma repetição
+	assert.EqualValues(t, 2, agent.requests.Load())
+}
+
+func TestCollectAll(t *testing.T) {
+	online := startAgent(t, agentOptions{community: "public"}, centralMIB())
+	denied := startAgent(t, agentOptions{community: "public"}, centralMIB())
+	denied.errorStatus.Store(int32(gosnmp.AuthorizationError))
+
+	// Porta UDP sem agente
+	conn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	silentPort := conn.LocalAddr().(*net.UDPAddr).Port
+	t.Cleanup(func() { conn.Close() })
+
+	v2c := func(port int) domain.SNMPCredentials {
+		return domain.SNMPCredentials{Version: domain.SNMPv2c, Port: port, Community: "public"}
+	}
+	store := &fakeStore{targets: []domain.SNMPTarget{
+		{CentralID: 1, Addr: loopback, Credentials: v2c(online.port())},
+		{CentralID: 2, Addr: loopback, Credentials: v2c(denied.port())},
+		{CentralID: 3, Addr: loopback, Credentials: v2c(silentPort)},
+	}}
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	collector := snmp.New(store, newClient(), snmp.Options{Concurrency: 2})
+	collector.Now = func() time.Time { return now }
+	var changes []domain.StatusChange
+	collector.OnChange = func(c []domain.StatusChange) { changes = append(changes, c...) }
+
+	require.NoError(t, collector.CollectAll(context.Background()))
+	assert.Len(t, changes, 3)
+
+	assert.Equal(t, domain.StatusOnline, store.saved[1].Status)
+	assert.Equal(t, expectedInventory(), store.saved[1].Inventory)
+	assert.Equal(t, now, store.saved[1].CheckedAt)
+	assert.Empty(t, store.saved[1].Error)
+
+	// O agente respondeu, mas recusou a consulta
+	assert.Equal(t, domain.StatusDegraded, store.saved[2].Status)
+	assert.Nil(t, store.saved[2].Inventory)
+	assert.Contains(t, store.saved[2].Error, "AuthorizationError")
+
+	assert.Equal(t, domain.StatusOffline, store.saved[3].Status)
+	assert.Contains(t, store.saved[3].Error, snmp.ErrNoResponse.Error())
+
+	// Sem mudanças na segunda rodada
+	changes = nil
+	require.NoError(t, collector.CollectAll(context.Background()))
+	assert.Empty(t, changes)
+}
+
+func TestCollectAll_Cancelled(t *testing.T) {
+	agent := startAgent(t, agentOptions{community: "public"}, centralMIB())
+	store := &fakeStore{targets: []domain.SNMPTarget{{CentralID: 1, Addr: loopback,
+		Credentials: domain.SNMPCredentials{Version: domain.SNMPv2c, Port: agent.port(), Community: "public"}}}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, snmp.New(store, newClient(), snmp.Options{}).CollectAll(ctx), context.Canceled)
+	assert.Empty(t, store.saved)
+}
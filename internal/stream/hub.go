@@ -0,0 +1,188 @@
+// Package stream distribui aos clientes conectados os eventos gravados no
+// outbox, acompanhando a tabela pela ordem dos IDs.
+package stream
+
+import (
+	"api-golang/internal/domain"
+	"sync"
+)
+
+// Leitura do outbox pela ordem de gravação
+type Store interface {
+	EventsAfter(afterID uint, limit int) ([]domain.Event, error)
+	LastEventID() (uint, error)
+}
+
+type Options struct {
+	// Eventos guardados por inscrito; um inscrito que deixa o buffer encher
+	// é desconectado para não atrasar os demais
+	BufferSize int
+	// Eventos lidos do outbox por vez
+	BatchSize int
+}
+
+const (
+	defaultBufferSize = 256
+	defaultBatchSize  = 500
+)
+
+type Hub struct {
+	Store   Store
+	Options Options
+
+	mu          sync.Mutex
+	started     bool
+	cursor      uint
+	subscribers map[*Subscription]struct{}
+}
+
+func New(store Store, opts Options) *Hub {
+	if opts.BufferSize <= 0 {
+		opts.BufferSize = defaultBufferSize
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaultBatchSize
+	}
+	return &Hub{Store: store, Options: opts, subscribers: map[*Subscription]struct{}{}}
+}
+
+// Inscrição no hub. Events é fechado quando a inscrição termina, seja pelo
+// Close ou por falta de espaço no buffer (Lagged).
+type Subscription struct {
+	// ID do último evento distribuído antes da inscrição; eventos até ele
+	// só estão disponíveis pelo Replay
+	Since uint
+
+	hub    *Hub
+	filter domain.EventFilter
+	events chan domain.Event
+	lagged bool
+	closed bool
+}
+
+func (s *Subscription) Events() <-chan domain.Event {
+	return s.events
+}
+
+// Indica se a inscrição foi encerrada por ficar para trás
+func (s *Subscription) Lagged() bool {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	return s.lagged
+}
+
+func (s *Subscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	s.hub.remove(s)
+}
+
+// Inscreve um cliente para receber os eventos gravados a partir de agora que
+// passem pelo filtro
+func (h *Hub) Subscribe(filter domain.EventFilter) (*Subscription, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if err := h.start(); err != nil {
+		return nil, err
+	}
+	sub := &Subscription{
+		Since:  h.cursor,
+		hub:    h,
+		filter: filter,
+		events: make(chan domain.Event, h.Options.BufferSize),
+	}
+	h.subscribers[sub] = struct{}{}
+	return sub, nil
+}
+
+// Entrega a fn os eventos gravados depois de after e até until que passem
+// pelo filtro, lendo do outbox. Eventos já removidos pela retenção do outbox
+// não são reenviados.
+func (h *Hub) Replay(after, until uint, filter domain.EventFilter, fn func(domain.Event) error) error {
+	for after < until {
+		events, err := h.Store.EventsAfter(after, h.Options.BatchSize)
+		if err != nil {
+			return err
+		}
+		if len(events) == 0 {
+			return nil
+		}
+		for i := range events {
+			if events[i].ID > until {
+				return nil
+			}
+			if filter.Matches(&events[i]) {
+				if err := fn(events[i]); err != nil {
+					return err
+				}
+			}
+		}
+		after = events[len(events)-1].ID
+	}
+	return nil
+}
+
+// Lê os eventos novos do outbox e os distribui aos inscritos. Retorna
+// quantos foram lidos.
+func (h *Hub) Poll() (int, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if err := h.start(); err != nil {
+		return 0, err
+	}
+	total := 0
+	for {
+		events, err := h.Store.EventsAfter(h.cursor, h.Options.BatchSize)
+		if err != nil {
+			return total, err
+		}
+		for i := range events {
+			h.broadcast(events[i])
+			h.cursor = events[i].ID
+		}
+		total += len(events)
+		if len(events) < h.Options.BatchSize {
+			return total, nil
+		}
+	}
+}
+
+// Na primeira chamada, passa a acompanhar o outbox a partir do último evento
+// gravado. Deve ser chamada com o lock.
+func (h *Hub) start() error {
+	if h.started {
+		return nil
+	}
+	last, err := h.Store.LastEventID()
+	if err != nil {
+		return err
+	}
+	h.cursor, h.started = last, true
+	return nil
+}
+
+// Entrega sem bloquear; quem estiver com o buffer cheio é desconectado e
+// pode retomar pelo último ID recebido
+func (h *Hub) broadcast(event domain.Event) {
+	for sub := range h.subscribers {
+		if !sub.filter.Matches(&event) {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+			sub.lagged = true
+			h.remove(sub)
+		}
+	}
+}
+
+// Deve ser chamada com o lock
+func (h *Hub) remove(sub *Subscription) {
+	if sub.closed {
+		return
+	}
+	sub.closed = true
+	delete(h.subscribers, sub)
+	close(sub.events)
+}
@@ -0,0 +1,159 @@
+package stream_test
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/stream"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Outbox em memória
+type fakeStore struct {
+	mu     sync.Mutex
+	events []domain.Event
+}
+
+func (s *fakeStore) add(events ...domain.Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, events...)
+}
+
+func (s *fakeStore) EventsAfter(afterID uint, limit int) ([]domain.Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var result []domain.Event
+	for _, e := range s.events {
+		if e.ID > afterID && len(result) < limit {
+			result = append(result, e)
+		}
+	}
+	return result, nil
+}
+
+func (s *fakeStore) LastEventID() (uint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.events) == 0 {
+		return 0, nil
+	}
+	return s.events[len(s.events)-1].ID, nil
+}
+
+func centralEvent(id uint, siteID uint, status domain.ReachabilityStatus, labels map[string]string) domain.Event {
+	return domain.Event{
+		ID: id, Type: domain.EventCentralUpdated, CentralID: id,
+		Central: &domain.Central{ID: id, SiteID: &siteID, Labels: labels, Status: domain.CentralStatus{Status: status}},
+	}
+}
+
+func received(sub *stream.Subscription) []uint {
+	var ids []uint
+	for {
+		select {
+		case e, ok := <-sub.Events():
+			if !ok {
+				return ids
+			}
+			ids = append(ids, e.ID)
+		default:
+			return ids
+		}
+	}
+}
+
+func TestHub_StartsAtLastEventAndFilters(t *testing.T) {
+	store := &fakeStore{}
+	store.add(centralEvent(1, 1, domain.StatusOnline, nil))
+	hub := stream.New(store, stream.Options{BatchSize: 2})
+
+	all, err := hub.Subscribe(domain.EventFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), all.Since)
+	selector := domain.LabelSelector{{Key: "env", Operator: domain.SelectorEquals, Values: []string{"prod"}}}
+	prod, err := hub.Subscribe(domain.EventFilter{Selector: selector})
+	require.NoError(t, err)
+	site := uint(2)
+	offline, err := hub.Subscribe(domain.EventFilter{SiteID: &site, Status: domain.StatusOffline})
+	require.NoError(t, err)
+
+	toOffline := centralEvent(4, 2, domain.StatusOffline, nil)
+	toOffline.Type = domain.EventCentralStatusChanged
+	toOffline.StatusChange = &domain.StatusChange{CentralID: 4, From: domain.StatusOnline, To: domain.StatusOffline}
+	backOnline := centralEvent(5, 2, domain.StatusOnline, nil)
+	backOnline.Type = domain.EventCentralStatusChanged
+	backOnline.StatusChange = &domain.StatusChange{CentralID: 5, From: domain.StatusOffline, To: domain.StatusOnline}
+	store.add(
+		centralEvent(2, 1, domain.StatusOnline, map[string]string{"env": "prod"}),
+		centralEvent(3, 2, domain.StatusOnline, map[string]string{"env": "dev"}),
+		toOffline, backOnline,
+	)
+
+	// Lê em lotes até alcançar o fim, sem repetir o evento anterior à inscrição
+	count, err := hub.Poll()
+	require.NoError(t, err)
+	assert.Equal(t, 4, count)
+	assert.Equal(t, []uint{2, 3, 4, 5}, received(all))
+	assert.Equal(t, []uint{2}, received(prod))
+	// A volta ao online ainda interessa a quem acompanha os offline
+	assert.Equal(t, []uint{4, 5}, received(offline))
+
+	count, err = hub.Poll()
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
+
+func TestHub_SlowSubscriberIsDropped(t *testing.T) {
+	store := &fakeStore{}
+	hub := stream.New(store, stream.Options{BufferSize: 2})
+	slow, err := hub.Subscribe(domain.EventFilter{})
+	require.NoError(t, err)
+	fast, err := hub.Subscribe(domain.EventFilter{})
+	require.NoError(t, err)
+
+	var got []uint
+	for id := uint(1); id <= 5; id++ {
+		store.add(centralEvent(id, 1, domain.StatusOnline, nil))
+		_, err := hub.Poll()
+		require.NoError(t, err)
+		got = append(got, received(fast)...)
+	}
+
+	// O lento recebe o que coube e é desconectado; o outro não é afetado
+	assert.Equal(t, []uint{1, 2}, received(slow))
+	assert.True(t, slow.Lagged())
+	assert.Equal(t, []uint{1, 2, 3, 4, 5}, got)
+	assert.False(t, fast.Lagged())
+
+	fast.Close()
+	fast.Close()
+	_, ok := <-fast.Events()
+	assert.False(t, ok)
+}
+
+func TestHub_Replay(t *testing.T) {
+	store := &fakeStore{}
+	for id := uint(1); id <= 6; id++ {
+		store.add(centralEvent(id, id%2, domain.StatusOnline, nil))
+	}
+	hub := stream.New(store, stream.Options{BatchSize: 2})
+	sub, err := hub.Subscribe(domain.EventFilter{})
+	require.NoError(t, err)
+	require.Equal(t, uint(6), sub.Since)
+
+	var ids []uint
+	collect := func(e domain.Event) error {
+		ids = append(ids, e.ID)
+		return nil
+	}
+	require.NoError(t, hub.Replay(1, 5, domain.EventFilter{}, collect))
+	assert.Equal(t, []uint{2, 3, 4, 5}, ids)
+
+	ids = nil
+	odd := uint(1)
+	require.NoError(t, hub.Replay(0, sub.Since, domain.EventFilter{SiteID: &odd}, collect))
+	assert.Equal(t, []uint{1, 3, 5}, ids)
+}
@@ -0,0 +1,432 @@
+package usecase
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/utils"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Janela padrão das regras de métrica
+const DefaultAlertWindow = 5 * time.Minute
+
+type AlertRepository interface {
+	CreateRule(rule *domain.AlertRule) error
+	GetRules() ([]domain.AlertRule, error)
+	GetRuleByID(id uint) (*domain.AlertRule, error)
+	UpdateRule(rule *domain.AlertRule) error
+	DeleteRule(id uint) error
+
+	GetAlerts(filter domain.AlertFilter) ([]domain.Alert, error)
+	SaveAlerts(alerts []domain.Alert, removed []uint) error
+
+	CreateSilence(silence *domain.Silence) error
+	GetSilences(now time.Time) ([]domain.Silence, error)
+	ExpireSilence(id uint, now time.Time) error
+}
+
+// Centrais avaliadas pelas regras, com labels e situação
+type CentralLister interface {
+	GetAll(filter domain.CentralFilter) ([]domain.Central, error)
+}
+
+// Valores de telemetria das regras de métrica
+type MetricSource interface {
+	MetricValues(name string, from, to time.Time) (map[uint][]float64, error)
+}
+
+type AlertUseCase struct {
+	Repo     AlertRepository
+	Centrals CentralLister
+	Metrics  MetricSource
+
+	// Relógio, substituível nos testes
+	Now func() time.Time
+}
+
+func NewAlertUseCase(repo AlertRepository, centrals CentralLister, metrics MetricSource) *AlertUseCase {
+	return &AlertUseCase{Repo: repo, Centrals: centrals, Metrics: metrics, Now: time.Now}
+}
+
+func (uc *AlertUseCase) CreateRule(rule *domain.AlertRule) error {
+	if err := validateAlertRule(rule); err != nil {
+		return err
+	}
+	return uc.Repo.CreateRule(rule)
+}
+
+func (uc *AlertUseCase) GetRules() ([]domain.AlertRule, error) {
+	return uc.Repo.GetRules()
+}
+
+func (uc *AlertUseCase) GetRuleByID(id uint) (*domain.AlertRule, error) {
+	return uc.Repo.GetRuleByID(id)
+}
+
+func (uc *AlertUseCase) UpdateRule(rule *domain.AlertRule) error {
+	if err := validateAlertRule(rule); err != nil {
+		return err
+	}
+	return uc.Repo.UpdateRule(rule)
+}
+
+func (uc *AlertUseCase) DeleteRule(id uint) error {
+	return uc.Repo.DeleteRule(id)
+}
+
+func (uc *AlertUseCase) GetAlerts(filter domain.AlertFilter) ([]domain.Alert, error) {
+	switch filter.State {
+	case "", domain.AlertPending, domain.AlertFiring, domain.AlertResolved:
+	default:
+		return nil, fmt.Errorf("%w: invalid alert state %q", domain.ErrInvalid, filter.State)
+	}
+	return uc.Repo.GetAlerts(filter)
+}
+
+// Cria o silêncio; sem início, começa agora
+func (uc *AlertUseCase) CreateSilence(silence *domain.Silence) error {
+	if silence.RuleID == nil && silence.CentralID == nil {
+		return fmt.Errorf("%w: a silence needs a rule, a central or both", domain.ErrInvalid)
+	}
+	if silence.StartsAt.IsZero() {
+		silence.StartsAt = uc.Now().UTC()
+	}
+	if !silence.EndsAt.After(silence.StartsAt) {
+		return fmt.Errorf("%w: ends_at must be after starts_at", domain.ErrInvalid)
+	}
+	return uc.Repo.CreateSilence(silence)
+}
+
+// Silêncios vigentes e agendados
+func (uc *AlertUseCase) GetSilences() ([]domain.Silence, error) {
+	return uc.Repo.GetSilences(uc.Now().UTC())
+}
+
+func (uc *AlertUseCase) ExpireSilence(id uint) error {
+	return uc.Repo.ExpireSilence(id, uc.Now().UTC())
+}
+
+// Condição satisfeita por uma central numa avaliação
+type alertObservation struct {
+	centralID uint
+	value     *float64
+	summary   string
+}
+
+// Avalia todas as regras habilitadas no instante do relógio e grava o novo
+// estado dos alertas. Uma condição nova abre um alerta pendente, que dispara
+// depois de se manter pelo tempo da regra; quando deixa de valer, o alerta
+// pendente é descartado e o disparado, resolvido. Retorna os alertas que
+// dispararam ou foram resolvidos nesta avaliação, na ordem de avaliação.
+func (uc *AlertUseCase) Evaluate() ([]domain.Alert, error) {
+	now := uc.Now().UTC()
+	rules, err := uc.Repo.GetRules()
+	if err != nil {
+		return nil, err
+	}
+	active, err := uc.Repo.GetAlerts(domain.AlertFilter{})
+	if err != nil {
+		return nil, err
+	}
+	silences, err := uc.Repo.GetSilences(now)
+	if err != nil {
+		return nil, err
+	}
+
+	type key struct{ ruleID, centralID uint }
+	alerts := make([]*domain.Alert, 0, len(active))
+	current := make(map[key]*domain.Alert, len(active))
+	for i := range active {
+		alerts = append(alerts, &active[i])
+		current[key{active[i].RuleID, active[i].CentralID}] = &active[i]
+	}
+
+	seen := map[key]bool{}
+	changed := map[*domain.Alert]bool{}
+	rulesByID := make(map[uint]*domain.AlertRule, len(rules))
+	for i := range rules {
+		rule := &rules[i]
+		rulesByID[rule.ID] = rule
+		if !rule.Enabled {
+			continue
+		}
+		observations, err := uc.observe(rule, now)
+		if err != nil {
+			return nil, fmt.Errorf("alert rule %q: %w", rule.Name, err)
+		}
+		for _, obs := range observations {
+			k := key{rule.ID, obs.centralID}
+			seen[k] = true
+			alert := current[k]
+			if alert == nil {
+				alert = &domain.Alert{RuleID: rule.ID, CentralID: obs.centralID, State: domain.AlertPending, ActiveSince: now}
+				alerts = append(alerts, alert)
+				current[k] = alert
+			}
+			alert.Severity, alert.Summary, alert.Value, alert.LastEvaluatedAt = rule.Severity, obs.summary, obs.value, now
+			if alert.State == domain.AlertPending && now.Sub(alert.ActiveSince) >= rule.For {
+				fired := now
+				alert.State, alert.FiredAt = domain.AlertFiring, &fired
+				changed[alert] = true
+			}
+		}
+	}
+
+	// Alertas cuja condição deixou de valer, inclusive de regras desabilitadas
+	// e de centrais que saíram do seletor
+	var removed []uint
+	kept := alerts[:0]
+	for _, alert := range alerts {
+		if seen[key{alert.RuleID, alert.CentralID}] {
+			kept = append(kept, alert)
+			continue
+		}
+		if alert.State == domain.AlertPending {
+			removed = append(removed, alert.ID)
+			continue
+		}
+		resolved := now
+		alert.State, alert.ResolvedAt, alert.LastEvaluatedAt = domain.AlertResolved, &resolved, now
+		alert.Silenced, alert.Inhibited = false, false
+		changed[alert] = true
+		kept = append(kept, alert)
+	}
+	alerts = kept
+
+	// Alertas disparados inibem os das regras listadas na mesma central
+	inhibited := map[key]bool{}
+	for _, alert := range alerts {
+		if alert.State != domain.AlertFiring {
+			continue
+		}
+		for _, target := range rulesByID[alert.RuleID].Inhibits {
+			inhibited[key{target, alert.CentralID}] = true
+		}
+	}
+	for _, alert := range alerts {
+		if alert.State == domain.AlertResolved {
+			continue
+		}
+		alert.Inhibited = inhibited[key{alert.RuleID, alert.CentralID}]
+		alert.Silenced = false
+		for i := range silences {
+			if silences[i].Matches(alert, now) {
+				alert.Silenced = true
+				break
+			}
+		}
+	}
+
+	toSave := make([]domain.Alert, 0, len(alerts))
+	for _, alert := range alerts {
+		toSave = append(toSave, *alert)
+	}
+	if err := uc.Repo.SaveAlerts(toSave, removed); err != nil {
+		return nil, err
+	}
+	var transitions []domain.Alert
+	for i, alert := range alerts {
+		if changed[alert] {
+			transitions = append(transitions, toSave[i])
+		}
+	}
+	return transitions, nil
+}
+
+// Centrais que satisfazem a condição da regra
+func (uc *AlertUseCase) observe(rule *domain.AlertRule, now time.Time) ([]alertObservation, error) {
+	selector, err := utils.ParseLabelSelector(rule.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalid, err)
+	}
+	filter := domain.CentralFilter{Selector: selector}
+	if rule.Kind == domain.AlertKindStatus {
+		filter.Status = rule.Status
+	}
+	centrals, err := uc.Centrals.GetAll(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var values map[uint][]float64
+	if rule.Kind == domain.AlertKindMetric {
+		if values, err = uc.Metrics.MetricValues(rule.Metric, now.Add(-rule.Window), now); err != nil {
+			return nil, err
+		}
+	}
+
+	var observations []alertObservation
+	for _, central := range centrals {
+		switch rule.Kind {
+		case domain.AlertKindStatus:
+			observations = append(observations, alertObservation{
+				centralID: central.ID,
+				summary:   fmt.Sprintf("central %q is %s", central.Name, rule.Status),
+			})
+		case domain.AlertKindMetric:
+			samples := values[central.ID]
+			if len(samples) == 0 {
+				continue
+			}
+			value := aggregateMetric(samples, rule.Aggregation)
+			if !compareThreshold(value, rule.Operator, rule.Threshold) {
+				continue
+			}
+			observations = append(observations, alertObservation{
+				centralID: central.ID,
+				value:     &value,
+				summary: fmt.Sprintf("central %q: %s of %s over %s is %g (%s %g)",
+					central.Name, rule.Aggregation, rule.Metric, rule.Window, value, rule.Operator, rule.Threshold),
+			})
+		case domain.AlertKindFirmware:
+			firmware := central.Status.FirmwareVersion
+			if firmware == "" || compareVersions(firmware, rule.MinFirmware) >= 0 {
+				continue
+			}
+			observations = append(observations, alertObservation{
+				centralID: central.ID,
+				summary:   fmt.Sprintf("central %q runs firmware %s, older than %s", central.Name, firmware, rule.MinFirmware),
+			})
+		}
+	}
+	return observations, nil
+}
+
+func validateAlertRule(rule *domain.AlertRule) error {
+	rule.Name = strings.TrimSpace(rule.Name)
+	if rule.Name == "" {
+		return fmt.Errorf("%w: rule name is required", domain.ErrInvalid)
+	}
+	switch rule.Severity {
+	case "":
+		rule.Severity = domain.SeverityWarning
+	case domain.SeverityInfo, domain.SeverityWarning, domain.SeverityCritical:
+	default:
+		return fmt.Errorf("%w: invalid severity %q", domain.ErrInvalid, rule.Severity)
+	}
+	if rule.For < 0 {
+		return fmt.Errorf("%w: for must not be negative", domain.ErrInvalid)
+	}
+	if _, err := utils.ParseLabelSelector(rule.Selector); err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInvalid, err)
+	}
+
+	// Campos de outros tipos de regra são descartados
+	status, metric, aggregation, operator, threshold, window, minFirmware :=
+		rule.Status, rule.Metric, rule.Aggregation, rule.Operator, rule.Threshold, rule.Window, rule.MinFirmware
+	rule.Status, rule.Metric, rule.Aggregation, rule.Operator, rule.Threshold, rule.Window, rule.MinFirmware =
+		"", "", "", "", 0, 0, ""
+	switch rule.Kind {
+	case domain.AlertKindStatus:
+		if !status.Valid() {
+			return fmt.Errorf("%w: invalid status %q", domain.ErrInvalid, status)
+		}
+		rule.Status = status
+	case domain.AlertKindMetric:
+		rule.Metric = strings.TrimSpace(metric)
+		if rule.Metric == "" {
+			return fmt.Errorf("%w: metric is required", domain.ErrInvalid)
+		}
+		switch aggregation {
+		case "":
+			aggregation = domain.AggregationAvg
+		case domain.AggregationAvg, domain.AggregationMin, domain.AggregationMax, domain.AggregationP95:
+		default:
+			return fmt.Errorf("%w: invalid aggregation %q", domain.ErrInvalid, aggregation)
+		}
+		switch operator {
+		case domain.OperatorGreater, domain.OperatorGreaterEqual, domain.OperatorLess, domain.OperatorLessEqual:
+		default:
+			return fmt.Errorf("%w: invalid operator %q", domain.ErrInvalid, operator)
+		}
+		if window < 0 {
+			return fmt.Errorf("%w: window must not be negative", domain.ErrInvalid)
+		}
+		if window == 0 {
+			window = DefaultAlertWindow
+		}
+		rule.Aggregation, rule.Operator, rule.Threshold, rule.Window = aggregation, operator, threshold, window
+	case domain.AlertKindFirmware:
+		rule.MinFirmware = strings.TrimSpace(minFirmware)
+		if parseVersion(rule.MinFirmware) == nil {
+			return fmt.Errorf("%w: invalid minimum firmware version %q", domain.ErrInvalid, minFirmware)
+		}
+	default:
+		return fmt.Errorf("%w: invalid rule kind %q", domain.ErrInvalid, rule.Kind)
+	}
+
+	unique := make([]uint, 0, len(rule.Inhibits))
+	seen := map[uint]bool{}
+	for _, id := range rule.Inhibits {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	rule.Inhibits = unique
+	return nil
+}
+
+func aggregateMetric(values []float64, aggregation string) float64 {
+	stats := summarize(values)
+	switch aggregation {
+	case domain.AggregationMin:
+		return stats.Min
+	case domain.AggregationMax:
+		return stats.Max
+	case domain.AggregationP95:
+		return stats.P95
+	}
+	return stats.Sum / float64(stats.Count)
+}
+
+func compareThreshold(value float64, operator string, threshold float64) bool {
+	switch operator {
+	case domain.OperatorGreater:
+		return value > threshold
+	case domain.OperatorGreaterEqual:
+		return value >= threshold
+	case domain.OperatorLess:
+		return value < threshold
+	case domain.OperatorLessEqual:
+		return value <= threshold
+	}
+	return false
+}
+
+// Compara versões pelos seus números ("v1.2.10" > "1.2.9"); partes ausentes
+// valem zero e o que não é número é ignorado
+func compareVersions(a, b string) int {
+	va, vb := parseVersion(a), parseVersion(b)
+	for i := 0; i < max(len(va), len(vb)); i++ {
+		var x, y int
+		if i < len(va) {
+			x = va[i]
+		}
+		if i < len(vb) {
+			y = vb[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+func parseVersion(version string) []int {
+	var parts []int
+	for _, field := range strings.FieldsFunc(version, func(r rune) bool { return !unicode.IsDigit(r) }) {
+		n, err := strconv.Atoi(field)
+		if err != nil {
+			return nil
+		}
+		parts = append(parts, n)
+	}
+	return parts
+}
@@ -0,0 +1,244 @@
+package usecase_test
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Mock do Repositório de alertas
+type MockAlertRepository struct {
+	mock.Mock
+}
+
+func (m *MockAlertRepository) CreateRule(rule *domain.AlertRule) error {
+	args := m.Called(rule)
+	return args.Error(0)
+}
+
+func (m *MockAlertRepository) GetRules() ([]domain.AlertRule, error) {
+	args := m.Called()
+	return args.Get(0).([]domain.AlertRule), args.Error(1)
+}
+
+func (m *MockAlertRepository) GetRuleByID(id uint) (*domain.AlertRule, error) {
+	args := m.Called(id)
+	return args.Get(0).(*domain.AlertRule), args.Error(1)
+}
+
+func (m *MockAlertRepository) UpdateRule(rule *domain.AlertRule) error {
+	args := m.Called(rule)
+	return args.Error(0)
+}
+
+func (m *MockAlertRepository) DeleteRule(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockAlertRepository) GetAlerts(filter domain.AlertFilter) ([]domain.Alert, error) {
+	args := m.Called(filter)
+	return args.Get(0).([]domain.Alert), args.Error(1)
+}
+
+func (m *MockAlertRepository) SaveAlerts(alerts []domain.Alert, removed []uint) error {
+	args := m.Called(alerts, removed)
+	return args.Error(0)
+}
+
+func (m *MockAlertRepository) CreateSilence(silence *domain.Silence) error {
+	args := m.Called(silence)
+	return args.Error(0)
+}
+
+func (m *MockAlertRepository) GetSilences(now time.Time) ([]domain.Silence, error) {
+	args := m.Called(now)
+	return args.Get(0).([]domain.Silence), args.Error(1)
+}
+
+func (m *MockAlertRepository) ExpireSilence(id uint, now time.Time) error {
+	args := m.Called(id, now)
+	return args.Error(0)
+}
+
+var alertStart = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+type alertMocks struct {
+	repo     *MockAlertRepository
+	centrals *MockCentralRepository
+	metrics  *MockTelemetryRepository
+}
+
+// Monta o caso de uso com o relógio parado no instante informado
+func setupAlertUseCase(now time.Time) (*usecase.AlertUseCase, alertMocks) {
+	mocks := alertMocks{new(MockAlertRepository), new(MockCentralRepository), new(MockTelemetryRepository)}
+	uc := usecase.NewAlertUseCase(mocks.repo, mocks.centrals, mocks.metrics)
+	uc.Now = func() time.Time { return now }
+	return uc, mocks
+}
+
+// Avalia com as regras, alertas ativos e silêncios informados e retorna o
+// que foi gravado
+func evaluateAt(t *testing.T, uc *usecase.AlertUseCase, mocks alertMocks, rules []domain.AlertRule,
+	active []domain.Alert, silences []domain.Silence) ([]domain.Alert, []domain.Alert, []uint) {
+	now := uc.Now()
+	mocks.repo.On("GetRules").Return(rules, nil)
+	mocks.repo.On("GetAlerts", domain.AlertFilter{}).Return(active, nil)
+	mocks.repo.On("GetSilences", now).Return(silences, nil)
+
+	var saved []domain.Alert
+	var removed []uint
+	mocks.repo.On("SaveAlerts", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		saved, removed = args.Get(0).([]domain.Alert), args.Get(1).([]uint)
+	}).Return(nil)
+
+	transitions, err := uc.Evaluate()
+	require.NoError(t, err)
+	return transitions, saved, removed
+}
+
+func TestEvaluate_Lifecycle(t *testing.T) {
+	rules := []domain.AlertRule{{ID: 1, Name: "offline", Kind: domain.AlertKindStatus, Severity: domain.SeverityCritical,
+		Enabled: true, Status: domain.StatusOffline, For: 5 * time.Minute}}
+	offline := []domain.Central{{ID: 7, Name: "Portaria"}}
+	byStatus := domain.CentralFilter{Status: domain.StatusOffline}
+
+	// A condição aparece: alerta pendente, sem notificação
+	uc, mocks := setupAlertUseCase(alertStart)
+	mocks.centrals.On("GetAll", byStatus).Return(offline, nil)
+	transitions, saved, _ := evaluateAt(t, uc, mocks, rules, []domain.Alert{}, []domain.Silence{})
+	assert.Empty(t, transitions)
+	require.Len(t, saved, 1)
+	assert.Equal(t, domain.AlertPending, saved[0].State)
+	assert.Equal(t, `central "Portaria" is offline`, saved[0].Summary)
+	pending := saved[0]
+	pending.ID = 10
+
+	// Ainda dentro do prazo da regra: continua pendente
+	uc, mocks = setupAlertUseCase(alertStart.Add(4 * time.Minute))
+	mocks.centrals.On("GetAll", byStatus).Return(offline, nil)
+	transitions, saved, _ = evaluateAt(t, uc, mocks, rules, []domain.Alert{pending}, []domain.Silence{})
+	assert.Empty(t, transitions)
+	assert.Equal(t, domain.AlertPending, saved[0].State)
+
+	// Depois de 5 minutos o alerta dispara, sem duplicar
+	firedAt := alertStart.Add(5 * time.Minute)
+	uc, mocks = setupAlertUseCase(firedAt)
+	mocks.centrals.On("GetAll", byStatus).Return(offline, nil)
+	transitions, saved, _ = evaluateAt(t, uc, mocks, rules, []domain.Alert{pending}, []domain.Silence{})
+	require.Len(t, saved, 1)
+	require.Len(t, transitions, 1)
+	assert.Equal(t, uint(10), transitions[0].ID)
+	assert.Equal(t, domain.AlertFiring, transitions[0].State)
+	assert.True(t, firedAt.Equal(*transitions[0].FiredAt))
+	firing := saved[0]
+
+	// Disparado e ainda valendo: nenhuma nova transição
+	uc, mocks = setupAlertUseCase(firedAt.Add(time.Minute))
+	mocks.centrals.On("GetAll", byStatus).Return(offline, nil)
+	transitions, _, _ = evaluateAt(t, uc, mocks, rules, []domain.Alert{firing}, []domain.Silence{})
+	assert.Empty(t, transitions)
+
+	// A central volta: o alerta é resolvido
+	uc, mocks = setupAlertUseCase(firedAt.Add(2 * time.Minute))
+	mocks.centrals.On("GetAll", byStatus).Return([]domain.Central{}, nil)
+	transitions, _, _ = evaluateAt(t, uc, mocks, rules, []domain.Alert{firing}, []domain.Silence{})
+	require.Len(t, transitions, 1)
+	assert.Equal(t, domain.AlertResolved, transitions[0].State)
+	assert.True(t, firedAt.Add(2*time.Minute).Equal(*transitions[0].ResolvedAt))
+
+	// Um pendente cuja condição some é descartado sem notificar
+	uc, mocks = setupAlertUseCase(alertStart.Add(time.Minute))
+	mocks.centrals.On("GetAll", byStatus).Return([]domain.Central{}, nil)
+	transitions, saved, removed := evaluateAt(t, uc, mocks, rules, []domain.Alert{pending}, []domain.Silence{})
+	assert.Empty(t, transitions)
+	assert.Empty(t, saved)
+	assert.Equal(t, []uint{10}, removed)
+}
+
+func TestEvaluate_InhibitionAndSilence(t *testing.T) {
+	rules := []domain.AlertRule{
+		{ID: 1, Name: "offline", Kind: domain.AlertKindStatus, Enabled: true, Status: domain.StatusOffline, Inhibits: []uint{2}},
+		{ID: 2, Name: "rtt", Kind: domain.AlertKindMetric, Enabled: true, Metric: "rtt_ms", Aggregation: domain.AggregationP95,
+			Operator: domain.OperatorGreater, Threshold: 200, Window: 5 * time.Minute},
+		{ID: 3, Name: "firmware", Kind: domain.AlertKindFirmware, Enabled: true, MinFirmware: "2.0"},
+		{ID: 4, Name: "disabled", Kind: domain.AlertKindStatus, Enabled: false, Status: domain.StatusOnline},
+	}
+	c1 := domain.Central{ID: 1, Name: "c1", Status: domain.CentralStatus{Status: domain.StatusOffline, FirmwareVersion: "1.9"}}
+	c2 := domain.Central{ID: 2, Name: "c2", Status: domain.CentralStatus{Status: domain.StatusOnline, FirmwareVersion: "v2.0.1"}}
+
+	uc, mocks := setupAlertUseCase(alertStart)
+	mocks.centrals.On("GetAll", domain.CentralFilter{Status: domain.StatusOffline}).Return([]domain.Central{c1}, nil)
+	mocks.centrals.On("GetAll", domain.CentralFilter{}).Return([]domain.Central{c1, c2}, nil)
+	mocks.metrics.On("MetricValues", "rtt_ms", alertStart.Add(-5*time.Minute), alertStart).
+		Return(map[uint][]float64{1: {300}, 2: {100, 250, 300}}, nil)
+
+	ruleID, centralID := uint(3), uint(1)
+	silences := []domain.Silence{{ID: 1, RuleID: &ruleID, CentralID: &centralID, StartsAt: alertStart.Add(-time.Hour), EndsAt: alertStart.Add(time.Hour)}}
+	transitions, saved, _ := evaluateAt(t, uc, mocks, rules, []domain.Alert{}, silences)
+
+	type result struct {
+		ruleID, centralID   uint
+		silenced, inhibited bool
+	}
+	var got []result
+	for _, alert := range saved {
+		assert.Equal(t, domain.AlertFiring, alert.State)
+		got = append(got, result{alert.RuleID, alert.CentralID, alert.Silenced, alert.Inhibited})
+	}
+	assert.Equal(t, []result{
+		{1, 1, false, false},
+		{2, 1, false, true},
+		{2, 2, false, false},
+		{3, 1, true, false},
+	}, got)
+	assert.Len(t, transitions, 4)
+	assert.InDelta(t, 295, *saved[2].Value, 1e-9)
+}
+
+func TestCreateRule_Validation(t *testing.T) {
+	uc, mocks := setupAlertUseCase(alertStart)
+
+	invalid := []domain.AlertRule{
+		{Kind: domain.AlertKindStatus, Status: domain.StatusOffline},
+		{Name: "x", Kind: "cpu"},
+		{Name: "x", Kind: domain.AlertKindStatus, Status: "down"},
+		{Name: "x", Kind: domain.AlertKindMetric, Metric: "cpu_percent", Operator: "eq"},
+		{Name: "x", Kind: domain.AlertKindMetric, Metric: "cpu_percent", Operator: domain.OperatorGreater, Aggregation: "median"},
+		{Name: "x", Kind: domain.AlertKindFirmware, MinFirmware: "latest"},
+		{Name: "x", Kind: domain.AlertKindStatus, Status: domain.StatusOffline, Selector: "env in (prod"},
+		{Name: "x", Kind: domain.AlertKindStatus, Status: domain.StatusOffline, Severity: "page"},
+	}
+	for _, rule := range invalid {
+		assert.ErrorIs(t, uc.CreateRule(&rule), domain.ErrInvalid, rule)
+	}
+
+	mocks.repo.On("CreateRule", mock.Anything).Return(nil)
+	rule := &domain.AlertRule{Name: " cpu ", Kind: domain.AlertKindMetric, Metric: "cpu_percent",
+		Operator: domain.OperatorGreater, Threshold: 90, MinFirmware: "1.0", Inhibits: []uint{2, 2, 3}}
+	require.NoError(t, uc.CreateRule(rule))
+	assert.Equal(t, "cpu", rule.Name)
+	assert.Equal(t, domain.SeverityWarning, rule.Severity)
+	assert.Equal(t, domain.AggregationAvg, rule.Aggregation)
+	assert.Equal(t, usecase.DefaultAlertWindow, rule.Window)
+	assert.Empty(t, rule.MinFirmware)
+	assert.Equal(t, []uint{2, 3}, rule.Inhibits)
+}
+
+func TestCreateSilence(t *testing.T) {
+	uc, mocks := setupAlertUseCase(alertStart)
+
+	ruleID := uint(1)
+	assert.ErrorIs(t, uc.CreateSilence(&domain.Silence{EndsAt: alertStart.Add(time.Hour)}), domain.ErrInvalid)
+	assert.ErrorIs(t, uc.CreateSilence(&domain.Silence{RuleID: &ruleID, EndsAt: alertStart.Add(-time.Hour)}), domain.ErrInvalid)
+
+	mocks.repo.On("CreateSilence", mock.Anything).Return(nil)
+	silence := &domain.Silence{RuleID: &ruleID, EndsAt: alertStart.Add(time.Hour)}
+	require.NoError(t, uc.CreateSilence(silence))
+	assert.True(t, alertStart.Equal(silence.StartsAt))
+}
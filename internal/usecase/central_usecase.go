@@ -2,11 +2,14 @@ package usecase
 
 import (
 	"api-golang/internal/domain"
+	"api-golang/internal/oui"
+	"api-golang/internal/utils"
+	"fmt"
 )
 
 type CentralRepository interface {
 	Create(user *domain.Central) error
-	GetAll() ([]domain.Central, error)
+	GetAll(filter domain.CentralFilter) ([]domain.Central, error)
 	GetByID(id uint) (*domain.Central, error)
 	Update(user *domain.Central) error
 	Delete(id uint) error
@@ -14,18 +17,34 @@ type CentralRepository interface {
 
 type CentralUseCase struct {
 	Repo CentralRepository
+	// Base de OUIs usada para derivar o fabricante; por padrão, a embutida
+	Vendors VendorRegistry
 }
 
 func NewCentralUseCase(repo CentralRepository) *CentralUseCase {
-	return &CentralUseCase{Repo: repo}
+	return &CentralUseCase{Repo: repo, Vendors: oui.Embedded()}
 }
 
 func (uc *CentralUseCase) CreateCentral(central *domain.Central) error {
+	if err := normalizeAddresses(central); err != nil {
+		return err
+	}
+	central.Vendor = uc.Vendors.VendorOf(central.MAC)
+	if err := validateLabels(central.Labels); err != nil {
+		return err
+	}
 	return uc.Repo.Create(central)
 }
 
-func (uc *CentralUseCase) GetAllCentrals() ([]domain.Central, error) {
-	return uc.Repo.GetAll()
+func (uc *CentralUseCase) GetAllCentrals(filter domain.CentralFilter) ([]domain.Central, error) {
+	if filter.IP != "" {
+		ip, err := utils.NormalizeIP(filter.IP)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", domain.ErrInvalid, err)
+		}
+		filter.IP = ip.String()
+	}
+	return uc.Repo.GetAll(filter)
 }
 
 func (uc *CentralUseCase) GetCentralByID(id uint) (*domain.Central, error) {
@@ -33,9 +52,54 @@ func (uc *CentralUseCase) GetCentralByID(id uint) (*domain.Central, error) {
 }
 
 func (uc *CentralUseCase) UpdateCentral(central *domain.Central) error {
+	if err := normalizeAddresses(central); err != nil {
+		return err
+	}
+	central.Vendor = uc.Vendors.VendorOf(central.MAC)
+	if err := validateLabels(central.Labels); err != nil {
+		return err
+	}
 	return uc.Repo.Update(central)
 }
 
 func (uc *CentralUseCase) DeleteCentral(id uint) error {
 	return uc.Repo.Delete(id)
 }
+
+// Garante que MAC e IPs sejam gravados sempre na forma canônica, para que a
+// unicidade não dependa da notação usada pelo cliente. Os IPs informados são
+// redistribuídos pela família real de cada endereço.
+func normalizeAddresses(central *domain.Central) error {
+	mac, err := utils.NormalizeMAC(central.MAC)
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInvalid, err)
+	}
+
+	var ipv4, ipv6 string
+	for _, value := range []string{central.IPv4, central.IPv6} {
+		if value == "" {
+			continue
+		}
+		ip, err := utils.NormalizeIP(value)
+		if err != nil {
+			return fmt.Errorf("%w: %v", domain.ErrInvalid, err)
+		}
+		target := &ipv6
+		if ip.Is4() {
+			target = &ipv4
+		}
+		if *target != "" && *target != ip.String() {
+			return fmt.Errorf("%w: a central can have only one address per IP family", domain.ErrInvalid)
+		}
+		*target = ip.String()
+	}
+	// Com pool, o endereço é alocado pelo repositório
+	if ipv4 == "" && ipv6 == "" && central.PoolID == nil {
+		return fmt.Errorf("%w: at least one IP address is required", domain.ErrInvalid)
+	}
+
+	central.MAC = mac
+	central.IPv4 = ipv4
+	central.IPv6 = ipv6
+	return nil
+}
@@ -20,8 +20,8 @@ func (m *MockCentralRepository) Create(user *domain.Central) error {
 	return args.Error(0)
 }
 
-func (m *MockCentralRepository) GetAll() ([]domain.Central, error) {
-	args := m.Called()
+func (m *MockCentralRepository) GetAll(filter domain.CentralFilter) ([]domain.Central, error) {
+	args := m.Called(filter)
 	return args.Get(0).([]domain.Central), args.Error(1)
 }
 
@@ -50,7 +50,7 @@ func TestCreateCentral(t *testing.T) {
 	uc, mockRepo := setupUseCase()
 
 	// Dados de entrada
-	central := &domain.Central{Name: "Central Test", MAC: "00:11:22:33:44:55", IP: "192.168.0.1"}
+	central := &domain.Central{Name: "Central Test", MAC: "00:11:22:33:44:55", IPv4: "192.168.0.1"}
 
 	// Configura o mock
 	mockRepo.On("Create", central).Return(nil)
@@ -63,33 +63,93 @@ func TestCreateCentral(t *testing.T) {
 	mockRepo.AssertCalled(t, "Create", central)
 }
 
+func TestCreateCentral_NormalizesAddresses(t *testing.T) {
+	uc, mockRepo := setupUseCase()
+
+	// Notação Cisco e IPv4 mapeado em IPv6
+	central := &domain.Central{Name: "Central Test", MAC: "0011.2233.44AA", IPv4: "::ffff:192.168.0.1"}
+
+	mockRepo.On("Create", central).Return(nil)
+
+	err := uc.CreateCentral(central)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "00:11:22:33:44:aa", central.MAC)
+	assert.Equal(t, "192.168.0.1", central.IPv4)
+}
+
+func TestCreateCentral_DualStack(t *testing.T) {
+	uc, mockRepo := setupUseCase()
+
+	// IPv6 informado no campo IPv4 é redistribuído pela família real
+	central := &domain.Central{Name: "Central Test", MAC: "00:11:22:33:44:55", IPv4: "2001:DB8::0:1", IPv6: "192.168.0.1"}
+
+	mockRepo.On("Create", central).Return(nil)
+
+	err := uc.CreateCentral(central)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "192.168.0.1", central.IPv4)
+	assert.Equal(t, "2001:db8::1", central.IPv6)
+}
+
+func TestCreateCentral_InvalidAddresses(t *testing.T) {
+	uc, mockRepo := setupUseCase()
+
+	invalid := []*domain.Central{
+		{Name: "Central Test", MAC: "invalid", IPv4: "192.168.0.1"},
+		{Name: "Central Test", MAC: "00:11:22:33:44:55", IPv4: "192.168.0"},
+		{Name: "Central Test", MAC: "00:11:22:33:44:55", IPv4: "2001:db8::1", IPv6: "2001:db8::2"},
+		{Name: "Central Test", MAC: "00:11:22:33:44:55"},
+	}
+	for _, central := range invalid {
+		err := uc.CreateCentral(central)
+		assert.ErrorIs(t, err, domain.ErrInvalid)
+	}
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
 func TestGetAllCentrals(t *testing.T) {
 	uc, mockRepo := setupUseCase()
 
 	// Dados simulados
 	centrals := []domain.Central{
-		{Name: "Central 1", MAC: "00:11:22:33:44:55", IP: "192.168.0.1"},
-		{Name: "Central 2", MAC: "00:11:22:33:44:56", IP: "192.168.0.2"},
+		{Name: "Central 1", MAC: "00:11:22:33:44:55", IPv4: "192.168.0.1"},
+		{Name: "Central 2", MAC: "00:11:22:33:44:56", IPv4: "192.168.0.2"},
 	}
 
 	// Configura o mock
-	mockRepo.On("GetAll").Return(centrals, nil)
+	mockRepo.On("GetAll", domain.CentralFilter{}).Return(centrals, nil)
 
 	// Chama o método
-	result, err := uc.GetAllCentrals()
+	result, err := uc.GetAllCentrals(domain.CentralFilter{})
 
 	// Valida os resultados
 	assert.NoError(t, err)
 	assert.Len(t, result, 2)
 	assert.Equal(t, centrals, result)
-	mockRepo.AssertCalled(t, "GetAll")
+	mockRepo.AssertCalled(t, "GetAll", domain.CentralFilter{})
+}
+
+func TestGetAllCentrals_FilterByIP(t *testing.T) {
+	uc, mockRepo := setupUseCase()
+
+	// O filtro é normalizado antes de chegar ao repositório
+	mockRepo.On("GetAll", domain.CentralFilter{IP: "2001:db8::1"}).Return([]domain.Central{}, nil)
+
+	_, err := uc.GetAllCentrals(domain.CentralFilter{IP: "2001:DB8:0::1"})
+	assert.NoError(t, err)
+	mockRepo.AssertCalled(t, "GetAll", domain.CentralFilter{IP: "2001:db8::1"})
+
+	_, err = uc.GetAllCentrals(domain.CentralFilter{IP: "invalid"})
+	assert.ErrorIs(t, err, domain.ErrInvalid)
 }
 
 func TestGetCentralByID_ValidID(t *testing.T) {
 	uc, mockRepo := setupUseCase()
 
 	// Dado simulado
-	mockCentral := &domain.Central{Name: "Central 1", MAC: "00:11:22:33:44:55", IP: "192.168.0.1"}
+	mockCentral := &domain.Central{Name: "Central 1", MAC: "00:11:22:33:44:55", IPv4: "192.168.0.1"}
 
 	// Configura o mock
 	mockRepo.On("GetByID", uint(1)).Return(mockCentral, nil)
@@ -122,7 +182,7 @@ func TestUpdateCentral(t *testing.T) {
 	uc, mockRepo := setupUseCase()
 
 	// Dados de entrada
-	central := &domain.Central{ID: 1, Name: "Updated Central", MAC: "00:11:22:33:44:55", IP: "192.168.0.2"}
+	central := &domain.Central{ID: 1, Name: "Updated Central", MAC: "00:11:22:33:44:55", IPv4: "192.168.0.2"}
 
 	// Configura o mock
 	mockRepo.On("Update", central).Return(nil)
@@ -135,6 +195,19 @@ func TestUpdateCentral(t *testing.T) {
 	mockRepo.AssertCalled(t, "Update", central)
 }
 
+func TestUpdateCentral_NormalizesAddresses(t *testing.T) {
+	uc, mockRepo := setupUseCase()
+
+	central := &domain.Central{ID: 1, Name: "Updated Central", MAC: "00-11-22-33-44-55", IPv4: "192.168.0.2"}
+
+	mockRepo.On("Update", central).Return(nil)
+
+	err := uc.UpdateCentral(central)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "00:11:22:33:44:55", central.MAC)
+}
+
 func TestDeleteCentral(t *testing.T) {
 	uc, mockRepo := setupUseCase()
 
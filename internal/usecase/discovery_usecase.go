@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"api-golang/internal/domain"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type DiscoveryRepository interface {
+	GetCandidates(filter domain.CandidateFilter) ([]domain.DiscoveryCandidate, error)
+	GetCandidate(id uint) (*domain.DiscoveryCandidate, error)
+	ClaimCandidate(id uint, at time.Time) (*domain.DiscoveryCandidate, error)
+	LinkCandidate(id, centralID uint) (*domain.DiscoveryCandidate, error)
+	ReleaseCandidate(id uint) error
+	RejectCandidate(id uint, at time.Time) (*domain.DiscoveryCandidate, error)
+}
+
+// Criação de centrais com as mesmas regras da API
+type CentralCreator interface {
+	CreateCentral(central *domain.Central) error
+}
+
+type DiscoveryUseCase struct {
+	Repo     DiscoveryRepository
+	Centrals CentralCreator
+
+	// Relógio, substituível nos testes
+	Now func() time.Time
+}
+
+func NewDiscoveryUseCase(repo DiscoveryRepository, centrals CentralCreator) *DiscoveryUseCase {
+	return &DiscoveryUseCase{Repo: repo, Centrals: centrals, Now: time.Now}
+}
+
+func (uc *DiscoveryUseCase) GetCandidates(filter domain.CandidateFilter) ([]domain.DiscoveryCandidate, error) {
+	switch filter.Status {
+	case "", domain.CandidatePending, domain.CandidateAccepted, domain.CandidateRejected:
+	default:
+		return nil, fmt.Errorf("%w: status must be pending, accepted or rejected", domain.ErrInvalid)
+	}
+	return uc.Repo.GetCandidates(filter)
+}
+
+func (uc *DiscoveryUseCase) GetCandidate(id uint) (*domain.DiscoveryCandidate, error) {
+	return uc.Repo.GetCandidate(id)
+}
+
+// Cria a central a partir do candidato pendente e o marca como aceito.
+// Sem nome, a central recebe o fabricante (ou o tipo inferido) e o IP.
+func (uc *DiscoveryUseCase) AcceptCandidate(id uint, acceptance domain.CandidateAcceptance) (*domain.Central, *domain.DiscoveryCandidate, error) {
+	candidate, err := uc.Repo.GetCandidate(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if candidate.Status != domain.CandidatePending {
+		return nil, nil, fmt.Errorf("%w: candidate %d is already %s", domain.ErrPrecondition, id, candidate.Status)
+	}
+
+	central := &domain.Central{
+		Name:       acceptance.Name,
+		MAC:        acceptance.MAC,
+		IPv4:       candidate.IP,
+		Notes:      acceptance.Notes,
+		SiteID:     acceptance.SiteID,
+		LocationID: acceptance.LocationID,
+		Labels:     acceptance.Labels,
+	}
+	if central.MAC == "" {
+		central.MAC = candidate.MAC
+	}
+	if central.MAC == "" {
+		return nil, nil, fmt.Errorf("%w: mac is required, the scan did not find the MAC of %s", domain.ErrInvalid, candidate.IP)
+	}
+	if central.Name == "" {
+		central.Name = defaultCandidateName(candidate)
+	}
+	if central.Notes == "" {
+		central.Notes = candidateNotes(candidate)
+	}
+	// Reserva o candidato antes de criar a central: uma rejeição ou outro
+	// aceite simultâneo falha aqui, sem deixar uma central para trás
+	if _, err := uc.Repo.ClaimCandidate(id, uc.Now()); err != nil {
+		return nil, nil, err
+	}
+	if err := uc.Centrals.CreateCentral(central); err != nil {
+		if releaseErr := uc.Repo.ReleaseCandidate(id); releaseErr != nil {
+			return nil, nil, errors.Join(err, releaseErr)
+		}
+		return nil, nil, err
+	}
+
+	candidate, err = uc.Repo.LinkCandidate(id, central.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return central, candidate, nil
+}
+
+func (uc *DiscoveryUseCase) RejectCandidate(id uint) (*domain.DiscoveryCandidate, error) {
+	return uc.Repo.RejectCandidate(id, uc.Now())
+}
+
+func defaultCandidateName(c *domain.DiscoveryCandidate) string {
+	prefix := c.Vendor
+	if prefix == "" {
+		prefix = c.Fingerprint
+	}
+	if prefix == "" || prefix == domain.FingerprintUnknown {
+		prefix = "Discovered"
+	}
+	return prefix + " " + c.IP
+}
+
+func candidateNotes(c *domain.DiscoveryCandidate) string {
+	ports := make([]string, 0, len(c.OpenPorts))
+	for _, port := range c.OpenPorts {
+		ports = append(ports, strconv.Itoa(port))
+	}
+	notes := "Discovered by network scan as " + c.Fingerprint
+	if len(ports) > 0 {
+		notes += " (open ports " + strings.Join(ports, ", ") + ")"
+	}
+	return notes
+}
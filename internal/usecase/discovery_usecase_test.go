@@ -0,0 +1,125 @@
+package usecase_test
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/usecase"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Mock do Repositório de descoberta
+type MockDiscoveryRepository struct {
+	mock.Mock
+}
+
+func (m *MockDiscoveryRepository) GetCandidates(filter domain.CandidateFilter) ([]domain.DiscoveryCandidate, error) {
+	args := m.Called(filter)
+	return args.Get(0).([]domain.DiscoveryCandidate), args.Error(1)
+}
+
+func (m *MockDiscoveryRepository) GetCandidate(id uint) (*domain.DiscoveryCandidate, error) {
+	args := m.Called(id)
+	return args.Get(0).(*domain.DiscoveryCandidate), args.Error(1)
+}
+
+func (m *MockDiscoveryRepository) ClaimCandidate(id uint, at time.Time) (*domain.DiscoveryCandidate, error) {
+	args := m.Called(id, at)
+	return &domain.DiscoveryCandidate{ID: id, Status: domain.CandidateAccepted}, args.Error(0)
+}
+
+func (m *MockDiscoveryRepository) LinkCandidate(id, centralID uint) (*domain.DiscoveryCandidate, error) {
+	args := m.Called(id, centralID)
+	return &domain.DiscoveryCandidate{ID: id, Status: domain.CandidateAccepted, CentralID: &centralID}, args.Error(0)
+}
+
+func (m *MockDiscoveryRepository) ReleaseCandidate(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockDiscoveryRepository) RejectCandidate(id uint, at time.Time) (*domain.DiscoveryCandidate, error) {
+	args := m.Called(id, at)
+	return &domain.DiscoveryCandidate{ID: id, Status: domain.CandidateRejected}, args.Error(0)
+}
+
+// Mock da criação de centrais
+type MockCentralCreator struct {
+	mock.Mock
+}
+
+func (m *MockCentralCreator) CreateCentral(central *domain.Central) error {
+	args := m.Called(central)
+	central.ID = 42
+	return args.Error(0)
+}
+
+func TestAcceptCandidate(t *testing.T) {
+	mockRepo, mockCentrals := new(MockDiscoveryRepository), new(MockCentralCreator)
+	uc := usecase.NewDiscoveryUseCase(mockRepo, mockCentrals)
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	uc.Now = func() time.Time { return now }
+
+	mockRepo.On("GetCandidate", uint(1)).Return(&domain.DiscoveryCandidate{ID: 1, IP: "10.0.0.10", MAC: "00:aa:bb:cc:dd:01",
+		Vendor: "Intelbras", Fingerprint: domain.FingerprintPBX, OpenPorts: []int{80, 5060}, Status: domain.CandidatePending}, nil)
+	mockCentrals.On("CreateCentral", mock.MatchedBy(func(c *domain.Central) bool {
+		return c.Name == "Intelbras 10.0.0.10" && c.MAC == "00:aa:bb:cc:dd:01" && c.IPv4 == "10.0.0.10" &&
+			c.Notes == "Discovered by network scan as pbx (open ports 80, 5060)" && c.Labels["env"] == "prod"
+	})).Return(nil)
+	mockRepo.On("ClaimCandidate", uint(1), now).Return(nil)
+	mockRepo.On("LinkCandidate", uint(1), uint(42)).Return(nil)
+
+	central, candidate, err := uc.AcceptCandidate(1, domain.CandidateAcceptance{Labels: map[string]string{"env": "prod"}})
+	require.NoError(t, err)
+	assert.Equal(t, uint(42), central.ID)
+	assert.Equal(t, domain.CandidateAccepted, candidate.Status)
+	mockRepo.AssertExpectations(t)
+	mockCentrals.AssertExpectations(t)
+}
+
+func TestAcceptCandidate_Invalid(t *testing.T) {
+	mockRepo, mockCentrals := new(MockDiscoveryRepository), new(MockCentralCreator)
+	uc := usecase.NewDiscoveryUseCase(mockRepo, mockCentrals)
+
+	// Sem MAC na varredura nem na requisição
+	mockRepo.On("GetCandidate", uint(1)).Return(&domain.DiscoveryCandidate{ID: 1, IP: "10.1.0.10", Status: domain.CandidatePending}, nil)
+	_, _, err := uc.AcceptCandidate(1, domain.CandidateAcceptance{})
+	assert.ErrorIs(t, err, domain.ErrInvalid)
+
+	mockRepo.On("GetCandidate", uint(2)).Return(&domain.DiscoveryCandidate{ID: 2, Status: domain.CandidateRejected}, nil)
+	_, _, err = uc.AcceptCandidate(2, domain.CandidateAcceptance{})
+	assert.ErrorIs(t, err, domain.ErrConflict)
+
+	// A central já existe: o candidato continua pendente
+	mockRepo.On("GetCandidate", uint(3)).Return(&domain.DiscoveryCandidate{ID: 3, IP: "10.0.0.30", MAC: "00:aa:bb:cc:dd:03",
+		Status: domain.CandidatePending}, nil)
+	mockRepo.On("ClaimCandidate", uint(3), mock.Anything).Return(nil)
+	mockCentrals.On("CreateCentral", mock.Anything).Return(domain.ErrConflict)
+	mockRepo.On("ReleaseCandidate", uint(3)).Return(nil)
+	_, _, err = uc.AcceptCandidate(3, domain.CandidateAcceptance{Name: "Portaria"})
+	assert.ErrorIs(t, err, domain.ErrConflict)
+	mockRepo.AssertCalled(t, "ReleaseCandidate", uint(3))
+	mockRepo.AssertNotCalled(t, "LinkCandidate", mock.Anything, mock.Anything)
+
+	_, err = uc.GetCandidates(domain.CandidateFilter{Status: "ignored"})
+	assert.ErrorIs(t, err, domain.ErrInvalid)
+}
+
+func TestAcceptCandidate_ConcurrentReject(t *testing.T) {
+	mockRepo, mockCentrals := new(MockDiscoveryRepository), new(MockCentralCreator)
+	uc := usecase.NewDiscoveryUseCase(mockRepo, mockCentrals)
+
+	// Lido ainda pendente, mas rejeitado antes da reserva: nenhuma central
+	// é criada
+	mockRepo.On("GetCandidate", uint(1)).Return(&domain.DiscoveryCandidate{ID: 1, IP: "10.0.0.10", MAC: "00:aa:bb:cc:dd:01",
+		Status: domain.CandidatePending}, nil)
+	mockRepo.On("ClaimCandidate", uint(1), mock.Anything).Return(fmt.Errorf("%w: candidate 1 is already rejected", domain.ErrPrecondition))
+
+	_, _, err := uc.AcceptCandidate(1, domain.CandidateAcceptance{})
+	assert.ErrorIs(t, err, domain.ErrPrecondition)
+	mockCentrals.AssertNotCalled(t, "CreateCentral", mock.Anything)
+}
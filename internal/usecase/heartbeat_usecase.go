@@ -0,0 +1,132 @@
+package usecase
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/utils"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultHeartbeatLimit = 20
+	MaxHeartbeatLimit     = 500
+)
+
+type HeartbeatRepository interface {
+	Record(hb *domain.Heartbeat) (*domain.StatusChange, error)
+	List(centralID uint, limit int) ([]domain.Heartbeat, error)
+	FindCentralIDByMAC(mac string) (uint, error)
+	ExpireHeartbeats(now time.Time, defaultWindow time.Duration) ([]domain.StatusChange, error)
+}
+
+// Destino das métricas recebidas nos heartbeats
+type MetricWriter interface {
+	WritePoints(points []domain.MetricPoint) error
+}
+
+type HeartbeatUseCase struct {
+	Repo HeartbeatRepository
+	// Prazo sem heartbeat para centrais sem prazo próprio
+	DefaultWindow time.Duration
+	// Armazenamento de telemetria; nil descarta as métricas
+	Telemetry MetricWriter
+
+	// Relógio, substituível nos testes
+	Now func() time.Time
+}
+
+func NewHeartbeatUseCase(repo HeartbeatRepository, defaultWindow time.Duration) *HeartbeatUseCase {
+	return &HeartbeatUseCase{Repo: repo, DefaultWindow: defaultWindow, Now: time.Now}
+}
+
+// Registra o heartbeat da central, que passa a constar como vista agora
+func (uc *HeartbeatUseCase) RecordHeartbeat(hb *domain.Heartbeat) error {
+	if err := validateHeartbeat(hb); err != nil {
+		return err
+	}
+	hb.ReceivedAt = uc.Now().UTC()
+	if _, err := uc.Repo.Record(hb); err != nil {
+		return err
+	}
+	if uc.Telemetry == nil {
+		return nil
+	}
+	return uc.Telemetry.WritePoints(heartbeatPoints(hb))
+}
+
+// Registra o heartbeat identificando a central pelo MAC de qualquer uma
+// das suas interfaces
+func (uc *HeartbeatUseCase) RecordHeartbeatByMAC(mac string, hb *domain.Heartbeat) error {
+	normalized, err := utils.NormalizeMAC(mac)
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInvalid, err)
+	}
+	centralID, err := uc.Repo.FindCentralIDByMAC(normalized)
+	if err != nil {
+		return err
+	}
+	hb.CentralID = centralID
+	return uc.RecordHeartbeat(hb)
+}
+
+func (uc *HeartbeatUseCase) GetHeartbeats(centralID uint, limit int) ([]domain.Heartbeat, error) {
+	if limit <= 0 {
+		limit = DefaultHeartbeatLimit
+	}
+	return uc.Repo.List(centralID, min(limit, MaxHeartbeatLimit))
+}
+
+// Marca como offline as centrais cujo prazo sem heartbeat venceu
+func (uc *HeartbeatUseCase) ExpireHeartbeats() ([]domain.StatusChange, error) {
+	return uc.Repo.ExpireHeartbeats(uc.Now().UTC(), uc.DefaultWindow)
+}
+
+// Pontos de telemetria do heartbeat: as métricas livres mais uptime, CPU e
+// memória, que prevalecem sobre métricas livres de mesmo nome
+func heartbeatPoints(hb *domain.Heartbeat) []domain.MetricPoint {
+	values := make(map[string]float64, len(hb.Metrics)+3)
+	for name, value := range hb.Metrics {
+		values[strings.TrimSpace(name)] = value
+	}
+	values["uptime_seconds"] = hb.Uptime.Seconds()
+	if hb.CPUPercent != nil {
+		values["cpu_percent"] = *hb.CPUPercent
+	}
+	if hb.MemoryPercent != nil {
+		values["memory_percent"] = *hb.MemoryPercent
+	}
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	points := make([]domain.MetricPoint, 0, len(names))
+	for _, name := range names {
+		points = append(points, domain.MetricPoint{CentralID: hb.CentralID, Name: name, Timestamp: hb.ReceivedAt, Value: values[name]})
+	}
+	return points
+}
+
+func validateHeartbeat(hb *domain.Heartbeat) error {
+	if hb.Uptime < 0 {
+		return fmt.Errorf("%w: uptime must not be negative", domain.ErrInvalid)
+	}
+	for name, value := range map[string]*float64{"cpu_percent": hb.CPUPercent, "memory_percent": hb.MemoryPercent} {
+		if value != nil && (*value < 0 || *value > 100) {
+			return fmt.Errorf("%w: %s must be between 0 and 100", domain.ErrInvalid, name)
+		}
+	}
+	for name, value := range hb.Metrics {
+		if strings.TrimSpace(name) == "" {
+			return fmt.Errorf("%w: metric names must not be empty", domain.ErrInvalid)
+		}
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			return fmt.Errorf("%w: metric %q must be a finite number", domain.ErrInvalid, name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package usecase_test
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock do Repositório de heartbeats
+type MockHeartbeatRepository struct {
+	mock.Mock
+}
+
+func (m *MockHeartbeatRepository) Record(hb *domain.Heartbeat) (*domain.StatusChange, error) {
+	args := m.Called(hb)
+	return args.Get(0).(*domain.StatusChange), args.Error(1)
+}
+
+func (m *MockHeartbeatRepository) List(centralID uint, limit int) ([]domain.Heartbeat, error) {
+	args := m.Called(centralID, limit)
+	return args.Get(0).([]domain.Heartbeat), args.Error(1)
+}
+
+func (m *MockHeartbeatRepository) FindCentralIDByMAC(mac string) (uint, error) {
+	args := m.Called(mac)
+	return args.Get(0).(uint), args.Error(1)
+}
+
+func (m *MockHeartbeatRepository) ExpireHeartbeats(now time.Time, defaultWindow time.Duration) ([]domain.StatusChange, error) {
+	args := m.Called(now, defaultWindow)
+	return args.Get(0).([]domain.StatusChange), args.Error(1)
+}
+
+var heartbeatNow = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func setupHeartbeatUseCase() (*usecase.HeartbeatUseCase, *MockHeartbeatRepository) {
+	mockRepo := new(MockHeartbeatRepository)
+	uc := usecase.NewHeartbeatUseCase(mockRepo, 5*time.Minute)
+	uc.Now = func() time.Time { return heartbeatNow }
+	return uc, mockRepo
+}
+
+func TestRecordHeartbeatByMAC(t *testing.T) {
+	uc, mockRepo := setupHeartbeatUseCase()
+
+	change := &domain.StatusChange{CentralID: 7, From: domain.StatusOffline, To: domain.StatusOnline, At: heartbeatNow}
+	mockRepo.On("FindCentralIDByMAC", "00:11:22:33:44:55").Return(uint(7), nil)
+	mockRepo.On("Record", mock.MatchedBy(func(hb *domain.Heartbeat) bool {
+		return hb.CentralID == 7 && hb.ReceivedAt.Equal(heartbeatNow)
+	})).Return(change, nil)
+
+	assert.NoError(t, uc.RecordHeartbeatByMAC("0011.2233.4455", &domain.Heartbeat{FirmwareVersion: "2.0"}))
+	mockRepo.AssertExpectations(t)
+
+	assert.ErrorIs(t, uc.RecordHeartbeatByMAC("invalid", &domain.Heartbeat{}), domain.ErrInvalid)
+}
+
+func TestRecordHeartbeat_WritesTelemetry(t *testing.T) {
+	uc, mockRepo := setupHeartbeatUseCase()
+	telemetry := new(MockTelemetryRepository)
+	uc.Telemetry = telemetry
+
+	cpu := 42.0
+	mockRepo.On("Record", mock.Anything).Return((*domain.StatusChange)(nil), nil)
+	telemetry.On("WritePoints", []domain.MetricPoint{
+		{CentralID: 1, Name: "calls", Timestamp: heartbeatNow, Value: 3},
+		{CentralID: 1, Name: "cpu_percent", Timestamp: heartbeatNow, Value: 42},
+		{CentralID: 1, Name: "uptime_seconds", Timestamp: heartbeatNow, Value: 60},
+	}).Return(nil)
+
+	err := uc.RecordHeartbeat(&domain.Heartbeat{CentralID: 1, Uptime: time.Minute, CPUPercent: &cpu,
+		Metrics: map[string]float64{"calls": 3, "cpu_percent": 1}})
+	assert.NoError(t, err)
+	telemetry.AssertExpectations(t)
+}
+
+func TestRecordHeartbeat_Invalid(t *testing.T) {
+	uc, mockRepo := setupHeartbeatUseCase()
+
+	cpu := 120.0
+	invalid := []*domain.Heartbeat{
+		{CentralID: 1, Uptime: -time.Second},
+		{CentralID: 1, CPUPercent: &cpu},
+		{CentralID: 1, Metrics: map[string]float64{" ": 1}},
+	}
+	for _, hb := range invalid {
+		assert.ErrorIs(t, uc.RecordHeartbeat(hb), domain.ErrInvalid)
+	}
+	mockRepo.AssertNotCalled(t, "Record", mock.Anything)
+}
+
+func TestExpireHeartbeats(t *testing.T) {
+	uc, mockRepo := setupHeartbeatUseCase()
+
+	change := domain.StatusChange{CentralID: 3, From: domain.StatusOnline, To: domain.StatusOffline, At: heartbeatNow}
+	mockRepo.On("ExpireHeartbeats", heartbeatNow, 5*time.Minute).Return([]domain.StatusChange{change}, nil)
+	mockRepo.On("List", uint(1), usecase.MaxHeartbeatLimit).Return([]domain.Heartbeat{}, nil)
+
+	expired, err := uc.ExpireHeartbeats()
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.StatusChange{change}, expired)
+
+	_, err = uc.GetHeartbeats(1, 10000)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,194 @@
+package usecase
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/utils"
+	"fmt"
+)
+
+type IPAMRepository interface {
+	CreateSubnet(subnet *domain.Subnet) error
+	GetSubnets() ([]domain.Subnet, error)
+	GetSubnetByID(id uint) (*domain.Subnet, error)
+	UpdateSubnet(subnet *domain.Subnet) error
+	DeleteSubnet(id uint) error
+
+	CreatePool(pool *domain.Pool) error
+	GetPools(subnetID uint) ([]domain.Pool, error)
+	GetPoolByID(id uint) (*domain.Pool, error)
+	UpdatePool(pool *domain.Pool) error
+	DeletePool(id uint) error
+
+	GetSubnetUtilization(id uint) (*domain.SubnetUtilization, error)
+	GetUtilization() ([]domain.SubnetUtilization, error)
+}
+
+type IPAMUseCase struct {
+	Repo IPAMRepository
+}
+
+func NewIPAMUseCase(repo IPAMRepository) *IPAMUseCase {
+	return &IPAMUseCase{Repo: repo}
+}
+
+// Cadastra a sub-rede na forma canônica. Sub-redes não podem se sobrepor.
+func (uc *IPAMUseCase) CreateSubnet(subnet *domain.Subnet) error {
+	prefix, err := utils.NormalizeSubnet(subnet.CIDR)
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInvalid, err)
+	}
+	subnet.CIDR = prefix.String()
+	if err := normalizeSubnetAddresses(subnet); err != nil {
+		return err
+	}
+
+	existing, err := uc.Repo.GetSubnets()
+	if err != nil {
+		return err
+	}
+	r, _ := utils.SubnetRange(subnet.CIDR)
+	for _, other := range existing {
+		otherRange, err := utils.SubnetRange(other.CIDR)
+		if err == nil && utils.RangesOverlap(r, otherRange) {
+			return fmt.Errorf("%w: subnet overlaps %s", domain.ErrConflict, other.CIDR)
+		}
+	}
+	return uc.Repo.CreateSubnet(subnet)
+}
+
+func (uc *IPAMUseCase) GetSubnets() ([]domain.Subnet, error) {
+	return uc.Repo.GetSubnets()
+}
+
+func (uc *IPAMUseCase) GetSubnetByID(id uint) (*domain.Subnet, error) {
+	return uc.Repo.GetSubnetByID(id)
+}
+
+// Atualiza gateway, descrição e reservas; o CIDR não muda
+func (uc *IPAMUseCase) UpdateSubnet(subnet *domain.Subnet) error {
+	current, err := uc.Repo.GetSubnetByID(subnet.ID)
+	if err != nil {
+		return err
+	}
+	subnet.CIDR = current.CIDR
+	if err := normalizeSubnetAddresses(subnet); err != nil {
+		return err
+	}
+	return uc.Repo.UpdateSubnet(subnet)
+}
+
+func (uc *IPAMUseCase) DeleteSubnet(id uint) error {
+	return uc.Repo.DeleteSubnet(id)
+}
+
+func (uc *IPAMUseCase) CreatePool(pool *domain.Pool) error {
+	if err := uc.validatePool(pool); err != nil {
+		return err
+	}
+	return uc.Repo.CreatePool(pool)
+}
+
+func (uc *IPAMUseCase) GetPools(subnetID uint) ([]domain.Pool, error) {
+	return uc.Repo.GetPools(subnetID)
+}
+
+func (uc *IPAMUseCase) GetPoolByID(id uint) (*domain.Pool, error) {
+	return uc.Repo.GetPoolByID(id)
+}
+
+// Atualiza nome e faixa do pool, que continua na mesma sub-rede
+func (uc *IPAMUseCase) UpdatePool(pool *domain.Pool) error {
+	current, err := uc.Repo.GetPoolByID(pool.ID)
+	if err != nil {
+		return err
+	}
+	pool.SubnetID = current.SubnetID
+	if err := uc.validatePool(pool); err != nil {
+		return err
+	}
+	return uc.Repo.UpdatePool(pool)
+}
+
+func (uc *IPAMUseCase) DeletePool(id uint) error {
+	return uc.Repo.DeletePool(id)
+}
+
+func (uc *IPAMUseCase) GetSubnetUtilization(id uint) (*domain.SubnetUtilization, error) {
+	return uc.Repo.GetSubnetUtilization(id)
+}
+
+func (uc *IPAMUseCase) GetUtilization() ([]domain.SubnetUtilization, error) {
+	return uc.Repo.GetUtilization()
+}
+
+// A faixa do pool precisa estar dentro da sub-rede e não pode se sobrepor
+// aos outros pools dela
+func (uc *IPAMUseCase) validatePool(pool *domain.Pool) error {
+	subnet, err := uc.Repo.GetSubnetByID(pool.SubnetID)
+	if err != nil {
+		return err
+	}
+	r, err := subnetAddressRange(subnet, domain.AddressRange{Start: pool.Start, End: pool.End})
+	if err != nil {
+		return err
+	}
+	pool.Start, pool.End = r.From.String(), r.To.String()
+
+	pools, err := uc.Repo.GetPools(pool.SubnetID)
+	if err != nil {
+		return err
+	}
+	for _, other := range pools {
+		if other.ID == pool.ID {
+			continue
+		}
+		otherRange, err := utils.AddressRange(other.Start, other.End)
+		if err == nil && utils.RangesOverlap(r, otherRange) {
+			return fmt.Errorf("%w: range overlaps pool %q", domain.ErrConflict, other.Name)
+		}
+	}
+	return nil
+}
+
+// Normaliza gateway e reservas, que precisam estar dentro da sub-rede
+func normalizeSubnetAddresses(subnet *domain.Subnet) error {
+	if subnet.Gateway != "" {
+		gateway, err := subnetAddressRange(subnet, domain.AddressRange{Start: subnet.Gateway, End: subnet.Gateway})
+		if err != nil {
+			return err
+		}
+		subnet.Gateway = gateway.From.String()
+	}
+
+	ranges := make([]domain.IPRange, 0, len(subnet.Reserved))
+	for i, reserved := range subnet.Reserved {
+		r, err := subnetAddressRange(subnet, reserved)
+		if err != nil {
+			return err
+		}
+		for _, other := range ranges {
+			if utils.RangesOverlap(r, other) {
+				return fmt.Errorf("%w: reserved ranges must not overlap", domain.ErrInvalid)
+			}
+		}
+		ranges = append(ranges, r)
+		subnet.Reserved[i] = domain.AddressRange{Start: r.From.String(), End: r.To.String()}
+	}
+	return nil
+}
+
+// Converte a faixa e confere se ela cabe na sub-rede
+func subnetAddressRange(subnet *domain.Subnet, value domain.AddressRange) (domain.IPRange, error) {
+	r, err := utils.ParseAddressRange(value)
+	if err != nil {
+		return r, fmt.Errorf("%w: %v", domain.ErrInvalid, err)
+	}
+	bounds, err := utils.SubnetRange(subnet.CIDR)
+	if err != nil {
+		return r, fmt.Errorf("%w: %v", domain.ErrInvalid, err)
+	}
+	if !utils.RangeContains(bounds, r.From) || !utils.RangeContains(bounds, r.To) {
+		return r, fmt.Errorf("%w: %s-%s is outside subnet %s", domain.ErrInvalid, value.Start, value.End, subnet.CIDR)
+	}
+	return r, nil
+}
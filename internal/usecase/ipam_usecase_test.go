@@ -0,0 +1,156 @@
+package usecase_test
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/usecase"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock do Repositório do IPAM
+type MockIPAMRepository struct {
+	mock.Mock
+}
+
+func (m *MockIPAMRepository) CreateSubnet(subnet *domain.Subnet) error {
+	args := m.Called(subnet)
+	return args.Error(0)
+}
+
+func (m *MockIPAMRepository) GetSubnets() ([]domain.Subnet, error) {
+	args := m.Called()
+	return args.Get(0).([]domain.Subnet), args.Error(1)
+}
+
+func (m *MockIPAMRepository) GetSubnetByID(id uint) (*domain.Subnet, error) {
+	args := m.Called(id)
+	return args.Get(0).(*domain.Subnet), args.Error(1)
+}
+
+func (m *MockIPAMRepository) UpdateSubnet(subnet *domain.Subnet) error {
+	args := m.Called(subnet)
+	return args.Error(0)
+}
+
+func (m *MockIPAMRepository) DeleteSubnet(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockIPAMRepository) CreatePool(pool *domain.Pool) error {
+	args := m.Called(pool)
+	return args.Error(0)
+}
+
+func (m *MockIPAMRepository) GetPools(subnetID uint) ([]domain.Pool, error) {
+	args := m.Called(subnetID)
+	return args.Get(0).([]domain.Pool), args.Error(1)
+}
+
+func (m *MockIPAMRepository) GetPoolByID(id uint) (*domain.Pool, error) {
+	args := m.Called(id)
+	return args.Get(0).(*domain.Pool), args.Error(1)
+}
+
+func (m *MockIPAMRepository) UpdatePool(pool *domain.Pool) error {
+	args := m.Called(pool)
+	return args.Error(0)
+}
+
+func (m *MockIPAMRepository) DeletePool(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockIPAMRepository) GetSubnetUtilization(id uint) (*domain.SubnetUtilization, error) {
+	args := m.Called(id)
+	return args.Get(0).(*domain.SubnetUtilization), args.Error(1)
+}
+
+func (m *MockIPAMRepository) GetUtilization() ([]domain.SubnetUtilization, error) {
+	args := m.Called()
+	return args.Get(0).([]domain.SubnetUtilization), args.Error(1)
+}
+
+func setupIPAMUseCase() (*usecase.IPAMUseCase, *MockIPAMRepository) {
+	mockRepo := new(MockIPAMRepository)
+	return usecase.NewIPAMUseCase(mockRepo), mockRepo
+}
+
+func TestCreateSubnet_Normalizes(t *testing.T) {
+	uc, mockRepo := setupIPAMUseCase()
+
+	mockRepo.On("GetSubnets").Return([]domain.Subnet{{ID: 1, CIDR: "10.1.0.0/24"}}, nil)
+	mockRepo.On("CreateSubnet", mock.Anything).Return(nil)
+
+	// Bits de host são descartados e as reservas normalizadas
+	subnet := &domain.Subnet{
+		CIDR:     "10.0.0.77/24",
+		Gateway:  "::ffff:10.0.0.1",
+		Reserved: []domain.AddressRange{{Start: "10.0.0.10", End: "10.0.0.20"}},
+	}
+	assert.NoError(t, uc.CreateSubnet(subnet))
+	assert.Equal(t, "10.0.0.0/24", subnet.CIDR)
+	assert.Equal(t, "10.0.0.1", subnet.Gateway)
+}
+
+func TestCreateSubnet_Invalid(t *testing.T) {
+	uc, mockRepo := setupIPAMUseCase()
+
+	mockRepo.On("GetSubnets").Return([]domain.Subnet{{ID: 1, CIDR: "10.0.0.0/16"}}, nil)
+
+	invalid := []*domain.Subnet{
+		{CIDR: "invalid"},
+		{CIDR: "192.168.0.0/24", Gateway: "192.168.1.1"},
+		{CIDR: "192.168.0.0/24", Reserved: []domain.AddressRange{{Start: "192.168.0.20", End: "192.168.0.10"}}},
+		{CIDR: "192.168.0.0/24", Reserved: []domain.AddressRange{
+			{Start: "192.168.0.10", End: "192.168.0.20"}, {Start: "192.168.0.15", End: "192.168.0.30"},
+		}},
+	}
+	for _, subnet := range invalid {
+		assert.ErrorIs(t, uc.CreateSubnet(subnet), domain.ErrInvalid, subnet.CIDR)
+	}
+
+	// Sobreposição com uma sub-rede existente
+	assert.ErrorIs(t, uc.CreateSubnet(&domain.Subnet{CIDR: "10.0.5.0/24"}), domain.ErrConflict)
+	mockRepo.AssertNotCalled(t, "CreateSubnet", mock.Anything)
+}
+
+func TestCreatePool_Validation(t *testing.T) {
+	uc, mockRepo := setupIPAMUseCase()
+
+	mockRepo.On("GetSubnetByID", uint(1)).Return(&domain.Subnet{ID: 1, CIDR: "10.0.0.0/24"}, nil)
+	mockRepo.On("GetPools", uint(1)).Return([]domain.Pool{
+		{ID: 1, SubnetID: 1, Name: "dhcp", Start: "10.0.0.100", End: "10.0.0.200"},
+	}, nil)
+	mockRepo.On("CreatePool", mock.Anything).Return(nil)
+
+	// Fora da sub-rede
+	err := uc.CreatePool(&domain.Pool{SubnetID: 1, Name: "p", Start: "10.0.1.1", End: "10.0.1.10"})
+	assert.ErrorIs(t, err, domain.ErrInvalid)
+
+	// Sobrepõe o pool existente
+	err = uc.CreatePool(&domain.Pool{SubnetID: 1, Name: "p", Start: "10.0.0.50", End: "10.0.0.100"})
+	assert.ErrorIs(t, err, domain.ErrConflict)
+
+	assert.NoError(t, uc.CreatePool(&domain.Pool{SubnetID: 1, Name: "p", Start: "10.0.0.10", End: "10.0.0.99"}))
+	mockRepo.AssertNumberOfCalls(t, "CreatePool", 1)
+}
+
+func TestUpdatePool_KeepsSubnet(t *testing.T) {
+	uc, mockRepo := setupIPAMUseCase()
+
+	mockRepo.On("GetPoolByID", uint(1)).Return(&domain.Pool{ID: 1, SubnetID: 1}, nil)
+	mockRepo.On("GetSubnetByID", uint(1)).Return(&domain.Subnet{ID: 1, CIDR: "10.0.0.0/24"}, nil)
+	mockRepo.On("GetPools", uint(1)).Return([]domain.Pool{
+		{ID: 1, SubnetID: 1, Name: "dhcp", Start: "10.0.0.100", End: "10.0.0.200"},
+	}, nil)
+	mockRepo.On("UpdatePool", mock.Anything).Return(nil)
+
+	// O próprio pool não conta como sobreposição
+	pool := &domain.Pool{ID: 1, Name: "dhcp", Start: "10.0.0.150", End: "10.0.0.250"}
+	assert.NoError(t, uc.UpdatePool(pool))
+	assert.Equal(t, uint(1), pool.SubnetID)
+}
@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/utils"
+	"fmt"
+)
+
+type LabelRepository interface {
+	GetLabels(centralID uint) (map[string]string, error)
+	AddLabels(centralIDs []uint, labels map[string]string) error
+	RemoveLabels(centralIDs []uint, keys []string) error
+}
+
+type LabelUseCase struct {
+	Repo LabelRepository
+}
+
+func NewLabelUseCase(repo LabelRepository) *LabelUseCase {
+	return &LabelUseCase{Repo: repo}
+}
+
+func (uc *LabelUseCase) GetLabels(centralID uint) (map[string]string, error) {
+	return uc.Repo.GetLabels(centralID)
+}
+
+// Adiciona os labels em lote; chaves já existentes têm o valor sobrescrito
+func (uc *LabelUseCase) AddLabels(centralIDs []uint, labels map[string]string) error {
+	if len(centralIDs) == 0 || len(labels) == 0 {
+		return fmt.Errorf("%w: at least one central and one label are required", domain.ErrInvalid)
+	}
+	if err := validateLabels(labels); err != nil {
+		return err
+	}
+	return uc.Repo.AddLabels(centralIDs, labels)
+}
+
+// Remove as chaves em lote; chaves ausentes são ignoradas
+func (uc *LabelUseCase) RemoveLabels(centralIDs []uint, keys []string) error {
+	if len(centralIDs) == 0 || len(keys) == 0 {
+		return fmt.Errorf("%w: at least one central and one key are required", domain.ErrInvalid)
+	}
+	for _, key := range keys {
+		if err := utils.ValidateLabelKey(key); err != nil {
+			return fmt.Errorf("%w: %v", domain.ErrInvalid, err)
+		}
+	}
+	return uc.Repo.RemoveLabels(centralIDs, keys)
+}
+
+// Confere a sintaxe de chaves e valores dos labels
+func validateLabels(labels map[string]string) error {
+	for key, value := range labels {
+		if err := utils.ValidateLabelKey(key); err != nil {
+			return fmt.Errorf("%w: %v", domain.ErrInvalid, err)
+		}
+		if err := utils.ValidateLabelValue(value); err != nil {
+			return fmt.Errorf("%w: %v", domain.ErrInvalid, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package usecase_test
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/usecase"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock do Repositório de labels
+type MockLabelRepository struct {
+	mock.Mock
+}
+
+func (m *MockLabelRepository) GetLabels(centralID uint) (map[string]string, error) {
+	args := m.Called(centralID)
+	return args.Get(0).(map[string]string), args.Error(1)
+}
+
+func (m *MockLabelRepository) AddLabels(centralIDs []uint, labels map[string]string) error {
+	args := m.Called(centralIDs, labels)
+	return args.Error(0)
+}
+
+func (m *MockLabelRepository) RemoveLabels(centralIDs []uint, keys []string) error {
+	args := m.Called(centralIDs, keys)
+	return args.Error(0)
+}
+
+func TestAddLabels(t *testing.T) {
+	mockRepo := new(MockLabelRepository)
+	uc := usecase.NewLabelUseCase(mockRepo)
+
+	labels := map[string]string{"env": "prod", "example.com/tier": "a"}
+	mockRepo.On("AddLabels", []uint{1, 2}, labels).Return(nil)
+
+	assert.NoError(t, uc.AddLabels([]uint{1, 2}, labels))
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAddLabels_Invalid(t *testing.T) {
+	mockRepo := new(MockLabelRepository)
+	uc := usecase.NewLabelUseCase(mockRepo)
+
+	assert.ErrorIs(t, uc.AddLabels([]uint{1}, map[string]string{"env": "bad value"}), domain.ErrInvalid)
+	assert.ErrorIs(t, uc.AddLabels(nil, map[string]string{"env": "prod"}), domain.ErrInvalid)
+	assert.ErrorIs(t, uc.RemoveLabels([]uint{1}, []string{"-env"}), domain.ErrInvalid)
+	mockRepo.AssertNotCalled(t, "AddLabels", mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "RemoveLabels", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/oui"
+	"api-golang/internal/utils"
+	"fmt"
+)
+
+type NetworkInterfaceRepository interface {
+	Create(iface *domain.NetworkInterface) error
+	GetAll(centralID uint) ([]domain.NetworkInterface, error)
+	GetByCentrals(centralIDs []uint) ([]domain.NetworkInterface, error)
+	GetByID(centralID, id uint) (*domain.NetworkInterface, error)
+	Update(iface *domain.NetworkInterface) error
+	Delete(centralID, id uint) error
+}
+
+type NetworkInterfaceUseCase struct {
+	Repo NetworkInterfaceRepository
+	// Base de OUIs usada para derivar o fabricante; por padrão, a embutida
+	Vendors VendorRegistry
+}
+
+func NewNetworkInterfaceUseCase(repo NetworkInterfaceRepository) *NetworkInterfaceUseCase {
+	return &NetworkInterfaceUseCase{Repo: repo, Vendors: oui.Embedded()}
+}
+
+func (uc *NetworkInterfaceUseCase) CreateInterface(iface *domain.NetworkInterface) error {
+	if err := normalizeInterface(iface); err != nil {
+		return err
+	}
+	iface.Vendor = uc.Vendors.VendorOf(iface.MAC)
+	return uc.Repo.Create(iface)
+}
+
+func (uc *NetworkInterfaceUseCase) GetInterfaces(centralID uint) ([]domain.NetworkInterface, error) {
+	return uc.Repo.GetAll(centralID)
+}
+
+// Interfaces de várias centrais, para carregamento em lote
+func (uc *NetworkInterfaceUseCase) GetInterfacesByCentrals(centralIDs []uint) ([]domain.NetworkInterface, error) {
+	return uc.Repo.GetByCentrals(centralIDs)
+}
+
+func (uc *NetworkInterfaceUseCase) GetInterfaceByID(centralID, id uint) (*domain.NetworkInterface, error) {
+	return uc.Repo.GetByID(centralID, id)
+}
+
+func (uc *NetworkInterfaceUseCase) UpdateInterface(iface *domain.NetworkInterface) error {
+	if err := normalizeInterface(iface); err != nil {
+		return err
+	}
+	iface.Vendor = uc.Vendors.VendorOf(iface.MAC)
+	return uc.Repo.Update(iface)
+}
+
+func (uc *NetworkInterfaceUseCase) DeleteInterface(centralID, id uint) error {
+	return uc.Repo.Delete(centralID, id)
+}
+
+// Normaliza MAC e IPs e valida a VLAN. A interface principal precisa de ao
+// menos um IP, pois ele é espelhado nos campos da central.
+func normalizeInterface(iface *domain.NetworkInterface) error {
+	mac, err := utils.NormalizeMAC(iface.MAC)
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInvalid, err)
+	}
+
+	ips := make([]string, 0, len(iface.IPs))
+	seen := make(map[string]bool)
+	for _, value := range iface.IPs {
+		ip, err := utils.NormalizeIP(value)
+		if err != nil {
+			return fmt.Errorf("%w: %v", domain.ErrInvalid, err)
+		}
+		if !seen[ip.String()] {
+			seen[ip.String()] = true
+			ips = append(ips, ip.String())
+		}
+	}
+
+	if iface.VLAN < 0 || iface.VLAN > 4094 {
+		return fmt.Errorf("%w: VLAN must be between 0 and 4094", domain.ErrInvalid)
+	}
+	if iface.Primary && len(ips) == 0 {
+		return fmt.Errorf("%w: the primary interface needs at least one IP address", domain.ErrInvalid)
+	}
+
+	iface.MAC = mac
+	iface.IPs = ips
+	return nil
+}
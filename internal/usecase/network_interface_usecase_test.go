@@ -0,0 +1,98 @@
+package usecase_test
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/usecase"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock do Repositório de interfaces
+type MockNetworkInterfaceRepository struct {
+	mock.Mock
+}
+
+func (m *MockNetworkInterfaceRepository) Create(iface *domain.NetworkInterface) error {
+	args := m.Called(iface)
+	return args.Error(0)
+}
+
+func (m *MockNetworkInterfaceRepository) GetAll(centralID uint) ([]domain.NetworkInterface, error) {
+	args := m.Called(centralID)
+	return args.Get(0).([]domain.NetworkInterface), args.Error(1)
+}
+
+func (m *MockNetworkInterfaceRepository) GetByCentrals(centralIDs []uint) ([]domain.NetworkInterface, error) {
+	args := m.Called(centralIDs)
+	return args.Get(0).([]domain.NetworkInterface), args.Error(1)
+}
+
+func (m *MockNetworkInterfaceRepository) GetByID(centralID, id uint) (*domain.NetworkInterface, error) {
+	args := m.Called(centralID, id)
+	return args.Get(0).(*domain.NetworkInterface), args.Error(1)
+}
+
+func (m *MockNetworkInterfaceRepository) Update(iface *domain.NetworkInterface) error {
+	args := m.Called(iface)
+	return args.Error(0)
+}
+
+func (m *MockNetworkInterfaceRepository) Delete(centralID, id uint) error {
+	args := m.Called(centralID, id)
+	return args.Error(0)
+}
+
+func setupInterfaceUseCase() (*usecase.NetworkInterfaceUseCase, *MockNetworkInterfaceRepository) {
+	mockRepo := new(MockNetworkInterfaceRepository)
+	return usecase.NewNetworkInterfaceUseCase(mockRepo), mockRepo
+}
+
+func TestCreateInterface_Normalizes(t *testing.T) {
+	uc, mockRepo := setupInterfaceUseCase()
+
+	// IPs repetidos em notações diferentes são consolidados
+	iface := &domain.NetworkInterface{
+		CentralID: 1,
+		Name:      "eth1",
+		MAC:       "0011.2233.4466",
+		IPs:       []string{"10.0.0.1", "::ffff:10.0.0.1", "2001:DB8::1"},
+		VLAN:      100,
+	}
+	mockRepo.On("Create", iface).Return(nil)
+
+	err := uc.CreateInterface(iface)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "00:11:22:33:44:66", iface.MAC)
+	assert.Equal(t, []string{"10.0.0.1", "2001:db8::1"}, iface.IPs)
+}
+
+func TestCreateInterface_Invalid(t *testing.T) {
+	uc, mockRepo := setupInterfaceUseCase()
+
+	invalid := []*domain.NetworkInterface{
+		{CentralID: 1, Name: "eth1", MAC: "invalid"},
+		{CentralID: 1, Name: "eth1", MAC: "00:11:22:33:44:66", IPs: []string{"invalid"}},
+		{CentralID: 1, Name: "eth1", MAC: "00:11:22:33:44:66", VLAN: 4095},
+		// A interface principal precisa de IP
+		{CentralID: 1, Name: "eth1", MAC: "00:11:22:33:44:66", Primary: true},
+	}
+	for _, iface := range invalid {
+		assert.ErrorIs(t, uc.CreateInterface(iface), domain.ErrInvalid)
+	}
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestUpdateAndDeleteInterface(t *testing.T) {
+	uc, mockRepo := setupInterfaceUseCase()
+
+	iface := &domain.NetworkInterface{ID: 2, CentralID: 1, Name: "eth1", MAC: "00-11-22-33-44-66"}
+	mockRepo.On("Update", iface).Return(nil)
+	mockRepo.On("Delete", uint(1), uint(2)).Return(domain.ErrConflict)
+
+	assert.NoError(t, uc.UpdateInterface(iface))
+	assert.Equal(t, "00:11:22:33:44:66", iface.MAC)
+	assert.ErrorIs(t, uc.DeleteInterface(1, 2), domain.ErrConflict)
+}
@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/oui"
+	"fmt"
+)
+
+// Base de fabricantes indexada pelo OUI (ver oui.Registry)
+type VendorRegistry interface {
+	Lookup(prefix oui.Prefix) (string, bool)
+	VendorOf(mac string) string
+}
+
+type OUIUseCase struct {
+	Vendors VendorRegistry
+}
+
+func NewOUIUseCase(vendors VendorRegistry) *OUIUseCase {
+	return &OUIUseCase{Vendors: vendors}
+}
+
+// Consulta o fabricante do prefixo, informado em qualquer notação de MAC ou
+// como MAC completo. Prefixos administrados localmente não têm fabricante,
+// mas são respondidos com a indicação.
+func (uc *OUIUseCase) LookupOUI(value string) (*domain.OUIInfo, error) {
+	prefix, err := oui.ParsePrefix(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalid, err)
+	}
+	info := &domain.OUIInfo{
+		Prefix:              prefix.String(),
+		LocallyAdministered: prefix.LocallyAdministered(),
+		Multicast:           prefix.Multicast(),
+	}
+	vendor, ok := uc.Vendors.Lookup(prefix)
+	if !ok && !info.LocallyAdministered {
+		return nil, fmt.Errorf("%w: unknown OUI %s", domain.ErrNotFound, info.Prefix)
+	}
+	info.Vendor = vendor
+	return info, nil
+}
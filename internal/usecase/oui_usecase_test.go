@@ -0,0 +1,46 @@
+package usecase_test
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/oui"
+	"api-golang/internal/usecase"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestLookupOUI(t *testing.T) {
+	uc := usecase.NewOUIUseCase(oui.Embedded())
+
+	info, err := uc.LookupOUI("00-50-56")
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.OUIInfo{Prefix: "00:50:56", Vendor: "VMware, Inc."}, info)
+
+	// Prefixo administrado localmente não tem fabricante, mas é respondido
+	info, err = uc.LookupOUI("0242.ac11.0002")
+	assert.NoError(t, err)
+	assert.True(t, info.LocallyAdministered)
+	assert.Empty(t, info.Vendor)
+
+	_, err = uc.LookupOUI("fc:ff:ff")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	_, err = uc.LookupOUI("invalid")
+	assert.ErrorIs(t, err, domain.ErrInvalid)
+}
+
+func TestCreateCentral_DerivesVendor(t *testing.T) {
+	uc, mockRepo := setupUseCase()
+
+	central := &domain.Central{Name: "Central Test", MAC: "B8-27-EB-00-00-01", IPv4: "192.168.0.1"}
+	mockRepo.On("Create", central).Return(nil)
+
+	assert.NoError(t, uc.CreateCentral(central))
+	assert.Equal(t, "Raspberry Pi Foundation", central.Vendor)
+
+	// Trocar o MAC recalcula o fabricante
+	updated := &domain.Central{ID: 1, Name: "Central Test", MAC: "fc:ff:ff:00:00:01", IPv4: "192.168.0.1"}
+	mockRepo.On("Update", mock.Anything).Return(nil)
+	assert.NoError(t, uc.UpdateCentral(updated))
+	assert.Empty(t, updated.Vendor)
+}
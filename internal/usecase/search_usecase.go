@@ -0,0 +1,48 @@
+package usecase
+
+import (
+	"api-golang/internal/domain"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Limites de resultados da busca
+const (
+	DefaultSearchLimit = 20
+	MaxSearchLimit     = 100
+)
+
+type SearchRepository interface {
+	Search(terms []string, limit int) ([]domain.CentralSearchResult, error)
+}
+
+type SearchUseCase struct {
+	Repo SearchRepository
+}
+
+func NewSearchUseCase(repo SearchRepository) *SearchUseCase {
+	return &SearchUseCase{Repo: repo}
+}
+
+// Busca centrais pelos termos de q (ex.: "portaria bloco B"). Termos sem
+// letras nem dígitos são descartados.
+func (uc *SearchUseCase) SearchCentrals(q string, limit int) ([]domain.CentralSearchResult, error) {
+	var terms []string
+	for _, term := range strings.Fields(strings.ToLower(q)) {
+		if strings.IndexFunc(term, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) >= 0 {
+			terms = append(terms, term)
+		}
+	}
+	if len(terms) == 0 {
+		return nil, fmt.Errorf("%w: search query must not be empty", domain.ErrInvalid)
+	}
+
+	switch {
+	case limit == 0:
+		limit = DefaultSearchLimit
+	case limit < 0 || limit > MaxSearchLimit:
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", domain.ErrInvalid, MaxSearchLimit)
+	}
+	return uc.Repo.Search(terms, limit)
+}
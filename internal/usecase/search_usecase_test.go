@@ -0,0 +1,43 @@
+package usecase_test
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/usecase"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock do Repositório de busca
+type MockSearchRepository struct {
+	mock.Mock
+}
+
+func (m *MockSearchRepository) Search(terms []string, limit int) ([]domain.CentralSearchResult, error) {
+	args := m.Called(terms, limit)
+	return args.Get(0).([]domain.CentralSearchResult), args.Error(1)
+}
+
+func TestSearchCentrals_SplitsTerms(t *testing.T) {
+	mockRepo := new(MockSearchRepository)
+	uc := usecase.NewSearchUseCase(mockRepo)
+
+	mockRepo.On("Search", []string{"portaria", "bloco", "b"}, usecase.DefaultSearchLimit).
+		Return([]domain.CentralSearchResult{}, nil)
+
+	_, err := uc.SearchCentrals("  Portaria  bloco B - ", 0)
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSearchCentrals_Invalid(t *testing.T) {
+	mockRepo := new(MockSearchRepository)
+	uc := usecase.NewSearchUseCase(mockRepo)
+
+	_, err := uc.SearchCentrals(" -- ", 0)
+	assert.ErrorIs(t, err, domain.ErrInvalid)
+	_, err = uc.SearchCentrals("portaria", usecase.MaxSearchLimit+1)
+	assert.ErrorIs(t, err, domain.ErrInvalid)
+	mockRepo.AssertNotCalled(t, "Search", mock.Anything, mock.Anything)
+}
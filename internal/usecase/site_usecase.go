@@ -0,0 +1,147 @@
+package usecase
+
+import (
+	"api-golang/internal/domain"
+	"errors"
+	"fmt"
+)
+
+type SiteRepository interface {
+	CreateSite(site *domain.Site) error
+	GetSites() ([]domain.Site, error)
+	GetSiteByID(id uint) (*domain.Site, error)
+	GetSitesByIDs(ids []uint) ([]domain.Site, error)
+	UpdateSite(site *domain.Site) error
+	DeleteSite(id uint, opts domain.SiteDeleteOptions) error
+
+	CreateLocation(location *domain.Location) error
+	GetLocations(siteID uint) ([]domain.Location, error)
+	GetLocationByID(id uint) (*domain.Location, error)
+	GetLocationsByIDs(ids []uint) ([]domain.Location, error)
+	UpdateLocation(location *domain.Location) error
+	DeleteLocation(id uint) error
+}
+
+type SiteUseCase struct {
+	Repo     SiteRepository
+	Centrals CentralRepository
+}
+
+func NewSiteUseCase(repo SiteRepository, centrals CentralRepository) *SiteUseCase {
+	return &SiteUseCase{Repo: repo, Centrals: centrals}
+}
+
+func (uc *SiteUseCase) CreateSite(site *domain.Site) error {
+	return uc.Repo.CreateSite(site)
+}
+
+func (uc *SiteUseCase) GetSites() ([]domain.Site, error) {
+	return uc.Repo.GetSites()
+}
+
+func (uc *SiteUseCase) GetSiteByID(id uint) (*domain.Site, error) {
+	return uc.Repo.GetSiteByID(id)
+}
+
+// Sites encontrados entre os IDs, para carregamento em lote
+func (uc *SiteUseCase) GetSitesByIDs(ids []uint) ([]domain.Site, error) {
+	return uc.Repo.GetSitesByIDs(ids)
+}
+
+func (uc *SiteUseCase) UpdateSite(site *domain.Site) error {
+	return uc.Repo.UpdateSite(site)
+}
+
+func (uc *SiteUseCase) DeleteSite(id uint, opts domain.SiteDeleteOptions) error {
+	if opts.Cascade && opts.ReassignTo != nil {
+		return fmt.Errorf("%w: use either cascade or reassign_to, not both", domain.ErrInvalid)
+	}
+	return uc.Repo.DeleteSite(id, opts)
+}
+
+// Centrais do site, inclusive as que estão nas suas localizações. Com
+// unplaced, apenas as ligadas direto ao site, sem localização.
+func (uc *SiteUseCase) GetSiteCentrals(siteID uint, unplaced bool) ([]domain.Central, error) {
+	if _, err := uc.Repo.GetSiteByID(siteID); err != nil {
+		return nil, err
+	}
+	return uc.Centrals.GetAll(domain.CentralFilter{SiteID: &siteID, Unplaced: unplaced})
+}
+
+func (uc *SiteUseCase) CreateLocation(location *domain.Location) error {
+	if err := uc.validateParent(location); err != nil {
+		return err
+	}
+	return uc.Repo.CreateLocation(location)
+}
+
+func (uc *SiteUseCase) GetLocations(siteID uint) ([]domain.Location, error) {
+	return uc.Repo.GetLocations(siteID)
+}
+
+func (uc *SiteUseCase) GetLocationByID(id uint) (*domain.Location, error) {
+	return uc.Repo.GetLocationByID(id)
+}
+
+// Localizações encontradas entre os IDs, para carregamento em lote
+func (uc *SiteUseCase) GetLocationsByIDs(ids []uint) ([]domain.Location, error) {
+	return uc.Repo.GetLocationsByIDs(ids)
+}
+
+// Atualiza nome e pai da localização; site e tipo não mudam
+func (uc *SiteUseCase) UpdateLocation(location *domain.Location) error {
+	current, err := uc.Repo.GetLocationByID(location.ID)
+	if err != nil {
+		return err
+	}
+	location.SiteID = current.SiteID
+	location.Kind = current.Kind
+	if err := uc.validateParent(location); err != nil {
+		return err
+	}
+	return uc.Repo.UpdateLocation(location)
+}
+
+func (uc *SiteUseCase) DeleteLocation(id uint) error {
+	return uc.Repo.DeleteLocation(id)
+}
+
+// Centrais da localização e, com recursive, das sublocalizações
+func (uc *SiteUseCase) GetLocationCentrals(locationID uint, recursive bool) ([]domain.Central, error) {
+	if _, err := uc.Repo.GetLocationByID(locationID); err != nil {
+		return nil, err
+	}
+	return uc.Centrals.GetAll(domain.CentralFilter{LocationID: &locationID, Recursive: recursive})
+}
+
+// Garante a hierarquia site > prédio > andar > sala
+func (uc *SiteUseCase) validateParent(location *domain.Location) error {
+	parentKind, ok := domain.LocationParentKind[location.Kind]
+	if !ok {
+		return fmt.Errorf("%w: unknown location kind %q", domain.ErrInvalid, location.Kind)
+	}
+	if parentKind == "" {
+		if location.ParentID != nil {
+			return fmt.Errorf("%w: a %s sits directly under the site", domain.ErrInvalid, location.Kind)
+		}
+		return nil
+	}
+	if location.ParentID == nil {
+		return fmt.Errorf("%w: a %s needs a %s as parent", domain.ErrInvalid, location.Kind, parentKind)
+	}
+
+	parent, err := uc.Repo.GetLocationByID(*location.ParentID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return fmt.Errorf("%w: parent location %d not found", domain.ErrInvalid, *location.ParentID)
+	}
+	if err != nil {
+		return err
+	}
+	if parent.SiteID != location.SiteID {
+		return fmt.Errorf("%w: parent location belongs to another site", domain.ErrInvalid)
+	}
+	if parent.Kind != parentKind {
+		return fmt.Errorf("%w: a %s needs a %s as parent, got a %s", domain.ErrInvalid, location.Kind, parentKind, parent.Kind)
+	}
+	return nil
+}
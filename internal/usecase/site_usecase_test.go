@@ -0,0 +1,129 @@
+package usecase_test
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/usecase"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock do Repositório de sites
+type MockSiteRepository struct {
+	mock.Mock
+}
+
+func (m *MockSiteRepository) CreateSite(site *domain.Site) error {
+	args := m.Called(site)
+	return args.Error(0)
+}
+
+func (m *MockSiteRepository) GetSites() ([]domain.Site, error) {
+	args := m.Called()
+	return args.Get(0).([]domain.Site), args.Error(1)
+}
+
+func (m *MockSiteRepository) GetSiteByID(id uint) (*domain.Site, error) {
+	args := m.Called(id)
+	return args.Get(0).(*domain.Site), args.Error(1)
+}
+
+func (m *MockSiteRepository) GetSitesByIDs(ids []uint) ([]domain.Site, error) {
+	args := m.Called(ids)
+	return args.Get(0).([]domain.Site), args.Error(1)
+}
+
+func (m *MockSiteRepository) UpdateSite(site *domain.Site) error {
+	args := m.Called(site)
+	return args.Error(0)
+}
+
+func (m *MockSiteRepository) DeleteSite(id uint, opts domain.SiteDeleteOptions) error {
+	args := m.Called(id, opts)
+	return args.Error(0)
+}
+
+func (m *MockSiteRepository) CreateLocation(location *domain.Location) error {
+	args := m.Called(location)
+	return args.Error(0)
+}
+
+func (m *MockSiteRepository) GetLocations(siteID uint) ([]domain.Location, error) {
+	args := m.Called(siteID)
+	return args.Get(0).([]domain.Location), args.Error(1)
+}
+
+func (m *MockSiteRepository) GetLocationByID(id uint) (*domain.Location, error) {
+	args := m.Called(id)
+	return args.Get(0).(*domain.Location), args.Error(1)
+}
+
+func (m *MockSiteRepository) GetLocationsByIDs(ids []uint) ([]domain.Location, error) {
+	args := m.Called(ids)
+	return args.Get(0).([]domain.Location), args.Error(1)
+}
+
+func (m *MockSiteRepository) UpdateLocation(location *domain.Location) error {
+	args := m.Called(location)
+	return args.Error(0)
+}
+
+func (m *MockSiteRepository) DeleteLocation(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func setupSiteUseCase() (*usecase.SiteUseCase, *MockSiteRepository, *MockCentralRepository) {
+	mockRepo := new(MockSiteRepository)
+	mockCentrals := new(MockCentralRepository)
+	return usecase.NewSiteUseCase(mockRepo, mockCentrals), mockRepo, mockCentrals
+}
+
+func TestCreateLocation_Hierarchy(t *testing.T) {
+	uc, mockRepo, _ := setupSiteUseCase()
+
+	building := &domain.Location{ID: 1, SiteID: 1, Kind: domain.LocationBuilding, Name: "Bloco B"}
+	mockRepo.On("GetLocationByID", uint(1)).Return(building, nil)
+
+	// Prédio fica direto no site
+	parentID := uint(1)
+	err := uc.CreateLocation(&domain.Location{SiteID: 1, Kind: domain.LocationBuilding, Name: "Bloco C", ParentID: &parentID})
+	assert.ErrorIs(t, err, domain.ErrInvalid)
+
+	// Sala precisa de um andar como pai
+	err = uc.CreateLocation(&domain.Location{SiteID: 1, Kind: domain.LocationRoom, Name: "Portaria", ParentID: &parentID})
+	assert.ErrorIs(t, err, domain.ErrInvalid)
+
+	// Pai de outro site é rejeitado
+	err = uc.CreateLocation(&domain.Location{SiteID: 2, Kind: domain.LocationFloor, Name: "Térreo", ParentID: &parentID})
+	assert.ErrorIs(t, err, domain.ErrInvalid)
+
+	floor := &domain.Location{SiteID: 1, Kind: domain.LocationFloor, Name: "Térreo", ParentID: &parentID}
+	mockRepo.On("CreateLocation", floor).Return(nil)
+	assert.NoError(t, uc.CreateLocation(floor))
+	mockRepo.AssertExpectations(t)
+}
+
+func TestDeleteSite_CascadeAndReassign(t *testing.T) {
+	uc, mockRepo, _ := setupSiteUseCase()
+
+	target := uint(2)
+	err := uc.DeleteSite(1, domain.SiteDeleteOptions{Cascade: true, ReassignTo: &target})
+	assert.ErrorIs(t, err, domain.ErrInvalid)
+	mockRepo.AssertNotCalled(t, "DeleteSite", mock.Anything, mock.Anything)
+}
+
+func TestGetSiteCentrals(t *testing.T) {
+	uc, mockRepo, mockCentrals := setupSiteUseCase()
+
+	siteID := uint(1)
+	mockRepo.On("GetSiteByID", siteID).Return(&domain.Site{ID: siteID, Name: "Matriz"}, nil)
+	mockCentrals.On("GetAll", domain.CentralFilter{SiteID: &siteID}).
+		Return([]domain.Central{{ID: 1, Name: "Central 1"}}, nil)
+
+	centrals, err := uc.GetSiteCentrals(siteID, false)
+	assert.NoError(t, err)
+	assert.Len(t, centrals, 1)
+	mockCentrals.AssertExpectations(t)
+}
@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"api-golang/internal/domain"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Tamanho mínimo das senhas do SNMPv3 (RFC 3414)
+const minSNMPPasswordLength = 8
+
+type SNMPRepository interface {
+	SaveCredentials(centralID uint, creds domain.SNMPCredentials) (*domain.CentralSNMP, error)
+	Get(centralID uint) (*domain.CentralSNMP, error)
+	Delete(centralID uint) error
+}
+
+type SNMPUseCase struct {
+	Repo SNMPRepository
+}
+
+func NewSNMPUseCase(repo SNMPRepository) *SNMPUseCase {
+	return &SNMPUseCase{Repo: repo}
+}
+
+// Configura a coleta SNMP da central, substituindo as credenciais atuais
+func (uc *SNMPUseCase) SetCredentials(centralID uint, creds domain.SNMPCredentials) (*domain.CentralSNMP, error) {
+	creds.AuthProtocol = strings.ToLower(creds.AuthProtocol)
+	creds.PrivProtocol = strings.ToLower(creds.PrivProtocol)
+	if creds.Port == 0 {
+		creds.Port = domain.DefaultSNMPPort
+	}
+	if err := validateSNMPCredentials(&creds); err != nil {
+		return nil, err
+	}
+	return uc.Repo.SaveCredentials(centralID, creds)
+}
+
+// Credenciais sem segredos e o resultado da última coleta
+func (uc *SNMPUseCase) GetSNMP(centralID uint) (*domain.CentralSNMP, error) {
+	return uc.Repo.Get(centralID)
+}
+
+func (uc *SNMPUseCase) DeleteCredentials(centralID uint) error {
+	return uc.Repo.Delete(centralID)
+}
+
+// Confere as credenciais e descarta os campos que a versão não usa
+func validateSNMPCredentials(creds *domain.SNMPCredentials) error {
+	if creds.Port < 1 || creds.Port > 65535 {
+		return fmt.Errorf("%w: port must be between 1 and 65535", domain.ErrInvalid)
+	}
+	switch creds.Version {
+	case domain.SNMPv2c:
+		if creds.Community == "" {
+			return fmt.Errorf("%w: community is required for SNMP v2c", domain.ErrInvalid)
+		}
+		*creds = domain.SNMPCredentials{Version: creds.Version, Port: creds.Port, Community: creds.Community}
+		return nil
+	case domain.SNMPv3:
+	default:
+		return fmt.Errorf("%w: version must be v2c or v3", domain.ErrInvalid)
+	}
+
+	if strings.TrimSpace(creds.Username) == "" {
+		return fmt.Errorf("%w: username is required for SNMP v3", domain.ErrInvalid)
+	}
+	creds.Community = ""
+	if creds.AuthProtocol == "" {
+		if creds.PrivProtocol != "" {
+			return fmt.Errorf("%w: priv_protocol requires auth_protocol", domain.ErrInvalid)
+		}
+		creds.AuthPassword, creds.PrivPassword = "", ""
+		return nil
+	}
+	if !slices.Contains(domain.SNMPAuthProtocols, creds.AuthProtocol) {
+		return fmt.Errorf("%w: auth_protocol must be one of %s", domain.ErrInvalid, strings.Join(domain.SNMPAuthProtocols, ", "))
+	}
+	if len(creds.AuthPassword) < minSNMPPasswordLength {
+		return fmt.Errorf("%w: auth_password must have at least %d characters", domain.ErrInvalid, minSNMPPasswordLength)
+	}
+	if creds.PrivProtocol == "" {
+		creds.PrivPassword = ""
+		return nil
+	}
+	if !slices.Contains(domain.SNMPPrivProtocols, creds.PrivProtocol) {
+		return fmt.Errorf("%w: priv_protocol must be one of %s", domain.ErrInvalid, strings.Join(domain.SNMPPrivProtocols, ", "))
+	}
+	if len(creds.PrivPassword) < minSNMPPasswordLength {
+		return fmt.Errorf("%w: priv_password must have at least %d characters", domain.ErrInvalid, minSNMPPasswordLength)
+	}
+	return nil
+}
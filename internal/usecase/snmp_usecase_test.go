@@ -0,0 +1,70 @@
+package usecase_test
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/usecase"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock do Repositório SNMP
+type MockSNMPRepository struct {
+	mock.Mock
+}
+
+func (m *MockSNMPRepository) SaveCredentials(centralID uint, creds domain.SNMPCredentials) (*domain.CentralSNMP, error) {
+	args := m.Called(centralID, creds)
+	return &domain.CentralSNMP{CentralID: centralID, Credentials: creds}, args.Error(0)
+}
+
+func (m *MockSNMPRepository) Get(centralID uint) (*domain.CentralSNMP, error) {
+	args := m.Called(centralID)
+	return args.Get(0).(*domain.CentralSNMP), args.Error(1)
+}
+
+func (m *MockSNMPRepository) Delete(centralID uint) error {
+	return m.Called(centralID).Error(0)
+}
+
+func TestSetSNMPCredentials(t *testing.T) {
+	mockRepo := new(MockSNMPRepository)
+	uc := usecase.NewSNMPUseCase(mockRepo)
+
+	// Porta padrão e campos da v3 descartados na v2c
+	mockRepo.On("SaveCredentials", uint(1), domain.SNMPCredentials{Version: domain.SNMPv2c, Port: 161, Community: "public"}).Return(nil)
+	_, err := uc.SetCredentials(1, domain.SNMPCredentials{Version: domain.SNMPv2c, Community: "public", Username: "ignored"})
+	assert.NoError(t, err)
+
+	// Protocolos normalizados; senha de privacidade descartada sem privacidade
+	mockRepo.On("SaveCredentials", uint(2), domain.SNMPCredentials{Version: domain.SNMPv3, Port: 1161, Username: "monitor",
+		AuthProtocol: "sha256", AuthPassword: "auth-pass-123"}).Return(nil)
+	_, err = uc.SetCredentials(2, domain.SNMPCredentials{Version: domain.SNMPv3, Port: 1161, Username: "monitor",
+		AuthProtocol: "SHA256", AuthPassword: "auth-pass-123", PrivPassword: "unused-pass"})
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSetSNMPCredentials_Invalid(t *testing.T) {
+	uc := usecase.NewSNMPUseCase(new(MockSNMPRepository))
+	v3 := func(auth, authPass, priv, privPass string) domain.SNMPCredentials {
+		return domain.SNMPCredentials{Version: domain.SNMPv3, Username: "monitor",
+			AuthProtocol: auth, AuthPassword: authPass, PrivProtocol: priv, PrivPassword: privPass}
+	}
+	cases := map[string]domain.SNMPCredentials{
+		"version":           {Version: "v1", Community: "public"},
+		"port":              {Version: domain.SNMPv2c, Community: "public", Port: 70000},
+		"community":         {Version: domain.SNMPv2c},
+		"username":          {Version: domain.SNMPv3},
+		"auth protocol":     v3("sha1024", "auth-pass-123", "", ""),
+		"short auth":        v3("sha", "short", "", ""),
+		"priv without auth": v3("", "", "aes", "priv-pass-456"),
+		"priv protocol":     v3("sha", "auth-pass-123", "rc4", "priv-pass-456"),
+		"short priv":        v3("sha", "auth-pass-123", "aes", "short"),
+	}
+	for name, creds := range cases {
+		_, err := uc.SetCredentials(1, creds)
+		assert.ErrorIs(t, err, domain.ErrInvalid, name)
+	}
+}
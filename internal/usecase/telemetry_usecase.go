@@ -0,0 +1,312 @@
+package usecase
+
+import (
+	"api-golang/internal/domain"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// Quantidade de intervalos quando o step não é informado
+	DefaultMetricBuckets = 60
+	MaxMetricBuckets     = 5000
+	// Janela padrão quando "from" não é informado
+	DefaultMetricRange = time.Hour
+
+	// Folga para pontos gravados no fim de um intervalo entrarem no rollup
+	rollupDelay = 30 * time.Second
+	// Intervalos agregados por leitura de pontos brutos
+	rollupChunk = 12
+)
+
+type TelemetryRepository interface {
+	WritePoints(points []domain.MetricPoint) error
+	ListPoints(from, to time.Time) ([]domain.MetricPoint, error)
+	SeriesPoints(centralID uint, name string, from, to time.Time) ([]domain.MetricPoint, error)
+	SeriesRollups(centralID uint, name string, resolution time.Duration, from, to time.Time) ([]domain.MetricRollup, error)
+	SaveRollups(rollups []domain.MetricRollup) error
+	LatestRollup(resolution time.Duration) (*time.Time, error)
+	EarliestPoint() (*time.Time, error)
+	Prune(cutoffs domain.RetentionCutoffs) (int64, error)
+}
+
+// Por quanto tempo cada resolução é mantida; zero mantém para sempre
+type TelemetryRetention struct {
+	Raw        time.Duration
+	FiveMinute time.Duration
+	Hour       time.Duration
+}
+
+type TelemetryUseCase struct {
+	Repo      TelemetryRepository
+	Retention TelemetryRetention
+
+	// Relógio, substituível nos testes
+	Now func() time.Time
+}
+
+func NewTelemetryUseCase(repo TelemetryRepository, retention TelemetryRetention) *TelemetryUseCase {
+	return &TelemetryUseCase{Repo: repo, Retention: retention, Now: time.Now}
+}
+
+// Série agregada de uma métrica. Enquanto "from" estiver dentro da retenção
+// dos pontos brutos, os agregados são exatos; fora dela, vêm dos rollups de
+// 5 minutos ou de 1 hora, e o step é arredondado para um múltiplo da
+// resolução. Ao juntar vários rollups num intervalo, o p95 é aproximado.
+func (uc *TelemetryUseCase) GetMetrics(query domain.MetricQuery) (*domain.MetricSeries, error) {
+	query.Name = strings.TrimSpace(query.Name)
+	if query.Name == "" {
+		return nil, fmt.Errorf("%w: metric name is required", domain.ErrInvalid)
+	}
+	now := uc.Now().UTC()
+	if query.To.IsZero() {
+		query.To = now
+	}
+	if query.From.IsZero() {
+		query.From = query.To.Add(-DefaultMetricRange)
+	}
+	if !query.From.Before(query.To) {
+		return nil, fmt.Errorf("%w: from must be before to", domain.ErrInvalid)
+	}
+	if query.Step < 0 {
+		return nil, fmt.Errorf("%w: step must be positive", domain.ErrInvalid)
+	}
+	if query.Step == 0 {
+		query.Step = max(query.To.Sub(query.From)/DefaultMetricBuckets, time.Second).Truncate(time.Second)
+	}
+
+	resolution := uc.resolutionFor(query.From, now)
+	if resolution > 0 && query.Step%resolution != 0 {
+		query.Step = (query.Step/resolution + 1) * resolution
+	}
+	if query.To.Sub(query.From)/query.Step > MaxMetricBuckets {
+		return nil, fmt.Errorf("%w: step too small, at most %d points per series", domain.ErrInvalid, MaxMetricBuckets)
+	}
+
+	series := &domain.MetricSeries{MetricQuery: query, Resolution: resolution}
+	if resolution == domain.ResolutionRaw {
+		points, err := uc.Repo.SeriesPoints(query.CentralID, query.Name, query.From, query.To)
+		if err != nil {
+			return nil, err
+		}
+		series.Buckets = bucketPoints(points, query.Step)
+		return series, nil
+	}
+
+	rollups, err := uc.Repo.SeriesRollups(query.CentralID, query.Name, resolution, alignTime(query.From, resolution), query.To)
+	if err != nil {
+		return nil, err
+	}
+	series.Buckets = mergeRollups(rollups, query.Step)
+	return series, nil
+}
+
+// Agrega os pontos brutos dos intervalos já encerrados em rollups de 5
+// minutos e de 1 hora. Retorna a quantidade de rollups gravados.
+func (uc *TelemetryUseCase) Rollup() (int, error) {
+	end := uc.Now().UTC().Add(-rollupDelay)
+	total := 0
+	for _, resolution := range []time.Duration{domain.ResolutionFiveMinute, domain.ResolutionHour} {
+		n, err := uc.rollup(resolution, alignTime(end, resolution))
+		total += n
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+// Continua a partir do último rollup gravado. Os pontos recebem o instante
+// de chegada, então intervalos encerrados não mudam depois de agregados.
+func (uc *TelemetryUseCase) rollup(resolution time.Duration, end time.Time) (int, error) {
+	earliest, err := uc.Repo.EarliestPoint()
+	if err != nil || earliest == nil {
+		return 0, err
+	}
+	start := alignTime(*earliest, resolution)
+	latest, err := uc.Repo.LatestRollup(resolution)
+	if err != nil {
+		return 0, err
+	}
+	if latest != nil && latest.Add(resolution).After(start) {
+		start = latest.Add(resolution)
+	}
+
+	count := 0
+	for start.Before(end) {
+		chunkEnd := start.Add(rollupChunk * resolution)
+		if chunkEnd.After(end) {
+			chunkEnd = end
+		}
+		points, err := uc.Repo.ListPoints(start, chunkEnd)
+		if err != nil {
+			return count, err
+		}
+		rollups := rollupPoints(points, resolution)
+		if err := uc.Repo.SaveRollups(rollups); err != nil {
+			return count, err
+		}
+		count += len(rollups)
+		start = chunkEnd
+	}
+	return count, nil
+}
+
+// Remove os dados fora da retenção. Retorna a quantidade de registros
+// removidos.
+func (uc *TelemetryUseCase) ApplyRetention() (int64, error) {
+	now := uc.Now().UTC()
+	cutoff := func(retention time.Duration) time.Time {
+		if retention == 0 {
+			return time.Time{}
+		}
+		return now.Add(-retention)
+	}
+	return uc.Repo.Prune(domain.RetentionCutoffs{
+		Raw:        cutoff(uc.Retention.Raw),
+		FiveMinute: cutoff(uc.Retention.FiveMinute),
+		Hour:       cutoff(uc.Retention.Hour),
+	})
+}
+
+// Resolução mais fina cuja retenção ainda cobre o instante
+func (uc *TelemetryUseCase) resolutionFor(from, now time.Time) time.Duration {
+	covers := func(retention time.Duration) bool {
+		return retention == 0 || !from.Before(now.Add(-retention))
+	}
+	switch {
+	case covers(uc.Retention.Raw):
+		return domain.ResolutionRaw
+	case covers(uc.Retention.FiveMinute):
+		return domain.ResolutionFiveMinute
+	default:
+		return domain.ResolutionHour
+	}
+}
+
+// Início do intervalo de tamanho d, alinhado à época Unix, que contém t
+func alignTime(t time.Time, d time.Duration) time.Time {
+	ms, step := t.UnixMilli(), d.Milliseconds()
+	offset := ms % step
+	if offset < 0 {
+		offset += step
+	}
+	return time.UnixMilli(ms - offset).UTC()
+}
+
+func bucketPoints(points []domain.MetricPoint, step time.Duration) []domain.MetricBucket {
+	groups := map[time.Time][]float64{}
+	for _, point := range points {
+		start := alignTime(point.Timestamp, step)
+		groups[start] = append(groups[start], point.Value)
+	}
+
+	buckets := make([]domain.MetricBucket, 0, len(groups))
+	for start, values := range groups {
+		stats := summarize(values)
+		buckets = append(buckets, domain.MetricBucket{
+			Start: start, Count: stats.Count, Min: stats.Min, Max: stats.Max, Avg: stats.Sum / float64(stats.Count), P95: stats.P95,
+		})
+	}
+	sort.Slice(buckets, func(i, j int) bool { return buckets[i].Start.Before(buckets[j].Start) })
+	return buckets
+}
+
+func rollupPoints(points []domain.MetricPoint, resolution time.Duration) []domain.MetricRollup {
+	type key struct {
+		centralID uint
+		name      string
+		start     time.Time
+	}
+	groups := map[key][]float64{}
+	var keys []key
+	for _, point := range points {
+		k := key{point.CentralID, point.Name, alignTime(point.Timestamp, resolution)}
+		if _, ok := groups[k]; !ok {
+			keys = append(keys, k)
+		}
+		groups[k] = append(groups[k], point.Value)
+	}
+
+	rollups := make([]domain.MetricRollup, 0, len(keys))
+	for _, k := range keys {
+		rollup := summarize(groups[k])
+		rollup.CentralID, rollup.Name, rollup.Resolution, rollup.Start = k.centralID, k.name, resolution, k.start
+		rollups = append(rollups, rollup)
+	}
+	return rollups
+}
+
+// Junta os rollups nos intervalos de step, um múltiplo da sua resolução.
+// O p95 combinado é o percentil dos p95 ponderados pela quantidade de pontos.
+func mergeRollups(rollups []domain.MetricRollup, step time.Duration) []domain.MetricBucket {
+	groups := map[time.Time][]domain.MetricRollup{}
+	for _, rollup := range rollups {
+		start := alignTime(rollup.Start, step)
+		groups[start] = append(groups[start], rollup)
+	}
+
+	buckets := make([]domain.MetricBucket, 0, len(groups))
+	for start, group := range groups {
+		bucket := domain.MetricBucket{Start: start, Min: math.Inf(1), Max: math.Inf(-1)}
+		sum := 0.0
+		for _, rollup := range group {
+			bucket.Count += rollup.Count
+			sum += rollup.Sum
+			bucket.Min = math.Min(bucket.Min, rollup.Min)
+			bucket.Max = math.Max(bucket.Max, rollup.Max)
+		}
+		bucket.Avg = sum / float64(bucket.Count)
+		bucket.P95 = weightedP95(group)
+		buckets = append(buckets, bucket)
+	}
+	sort.Slice(buckets, func(i, j int) bool { return buckets[i].Start.Before(buckets[j].Start) })
+	return buckets
+}
+
+func weightedP95(rollups []domain.MetricRollup) float64 {
+	sorted := append([]domain.MetricRollup(nil), rollups...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].P95 < sorted[j].P95 })
+	total := 0
+	for _, rollup := range sorted {
+		total += rollup.Count
+	}
+	threshold, seen := 0.95*float64(total), 0
+	for _, rollup := range sorted {
+		seen += rollup.Count
+		if float64(seen) >= threshold {
+			return rollup.P95
+		}
+	}
+	return sorted[len(sorted)-1].P95
+}
+
+// Estatísticas de um conjunto não vazio de valores
+func summarize(values []float64) domain.MetricRollup {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	sum := 0.0
+	for _, value := range sorted {
+		sum += value
+	}
+	return domain.MetricRollup{
+		Count: len(sorted),
+		Sum:   sum,
+		Min:   sorted[0],
+		Max:   sorted[len(sorted)-1],
+		P95:   percentile(sorted, 0.95),
+	}
+}
+
+// Percentil com interpolação linear entre as posições vizinhas
+func percentile(sorted []float64, p float64) float64 {
+	rank := p * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	if lower+1 >= len(sorted) {
+		return sorted[lower]
+	}
+	return sorted[lower] + (rank-float64(lower))*(sorted[lower+1]-sorted[lower])
+}
@@ -0,0 +1,186 @@
+package usecase_test
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Mock do Repositório de telemetria
+type MockTelemetryRepository struct {
+	mock.Mock
+}
+
+func (m *MockTelemetryRepository) WritePoints(points []domain.MetricPoint) error {
+	args := m.Called(points)
+	return args.Error(0)
+}
+
+func (m *MockTelemetryRepository) ListPoints(from, to time.Time) ([]domain.MetricPoint, error) {
+	args := m.Called(from, to)
+	return args.Get(0).([]domain.MetricPoint), args.Error(1)
+}
+
+func (m *MockTelemetryRepository) MetricValues(name string, from, to time.Time) (map[uint][]float64, error) {
+	args := m.Called(name, from, to)
+	return args.Get(0).(map[uint][]float64), args.Error(1)
+}
+
+func (m *MockTelemetryRepository) SeriesPoints(centralID uint, name string, from, to time.Time) ([]domain.MetricPoint, error) {
+	args := m.Called(centralID, name, from, to)
+	return args.Get(0).([]domain.MetricPoint), args.Error(1)
+}
+
+func (m *MockTelemetryRepository) SeriesRollups(centralID uint, name string, resolution time.Duration, from, to time.Time) ([]domain.MetricRollup, error) {
+	args := m.Called(centralID, name, resolution, from, to)
+	return args.Get(0).([]domain.MetricRollup), args.Error(1)
+}
+
+func (m *MockTelemetryRepository) SaveRollups(rollups []domain.MetricRollup) error {
+	args := m.Called(rollups)
+	return args.Error(0)
+}
+
+func (m *MockTelemetryRepository) LatestRollup(resolution time.Duration) (*time.Time, error) {
+	args := m.Called(resolution)
+	return args.Get(0).(*time.Time), args.Error(1)
+}
+
+func (m *MockTelemetryRepository) EarliestPoint() (*time.Time, error) {
+	args := m.Called()
+	return args.Get(0).(*time.Time), args.Error(1)
+}
+
+func (m *MockTelemetryRepository) Prune(cutoffs domain.RetentionCutoffs) (int64, error) {
+	args := m.Called(cutoffs)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+var telemetryNow = time.Date(2024, 1, 10, 12, 10, 40, 0, time.UTC)
+
+func setupTelemetryUseCase() (*usecase.TelemetryUseCase, *MockTelemetryRepository) {
+	mockRepo := new(MockTelemetryRepository)
+	uc := usecase.NewTelemetryUseCase(mockRepo, usecase.TelemetryRetention{
+		Raw: 24 * time.Hour, FiveMinute: 7 * 24 * time.Hour, Hour: 0,
+	})
+	uc.Now = func() time.Time { return telemetryNow }
+	return uc, mockRepo
+}
+
+func TestGetMetrics_Raw(t *testing.T) {
+	uc, mockRepo := setupTelemetryUseCase()
+
+	from := time.Date(2024, 1, 10, 11, 0, 0, 0, time.UTC)
+	to := from.Add(10 * time.Minute)
+	var points []domain.MetricPoint
+	for i := 1; i <= 20; i++ {
+		points = append(points, domain.MetricPoint{CentralID: 1, Name: "cpu_percent", Timestamp: from.Add(time.Duration(i*10) * time.Second), Value: float64(i)})
+	}
+	points = append(points, domain.MetricPoint{CentralID: 1, Name: "cpu_percent", Timestamp: from.Add(7 * time.Minute), Value: 50})
+	mockRepo.On("SeriesPoints", uint(1), "cpu_percent", from, to).Return(points, nil)
+
+	series, err := uc.GetMetrics(domain.MetricQuery{CentralID: 1, Name: " cpu_percent ", From: from, To: to, Step: 5 * time.Minute})
+	require.NoError(t, err)
+	assert.Equal(t, domain.ResolutionRaw, series.Resolution)
+	require.Len(t, series.Buckets, 2)
+
+	first := series.Buckets[0]
+	assert.True(t, from.Equal(first.Start))
+	assert.Equal(t, 20, first.Count)
+	assert.Equal(t, float64(1), first.Min)
+	assert.Equal(t, float64(20), first.Max)
+	assert.InDelta(t, 10.5, first.Avg, 1e-9)
+	assert.InDelta(t, 19.05, first.P95, 1e-9)
+	assert.Equal(t, float64(50), series.Buckets[1].P95)
+}
+
+func TestGetMetrics_FromRollups(t *testing.T) {
+	uc, mockRepo := setupTelemetryUseCase()
+
+	// Além da retenção dos pontos brutos: rollups de 5 minutos, com o step
+	// arredondado para 10 minutos
+	from := time.Date(2024, 1, 8, 12, 2, 0, 0, time.UTC)
+	to := from.Add(time.Hour)
+	rollupStart := time.Date(2024, 1, 8, 12, 0, 0, 0, time.UTC)
+	mockRepo.On("SeriesRollups", uint(1), "cpu_percent", domain.ResolutionFiveMinute, rollupStart, to).Return([]domain.MetricRollup{
+		{Start: rollupStart, Count: 10, Sum: 100, Min: 1, Max: 30, P95: 25},
+		{Start: rollupStart.Add(5 * time.Minute), Count: 30, Sum: 600, Min: 5, Max: 40, P95: 35},
+	}, nil)
+
+	series, err := uc.GetMetrics(domain.MetricQuery{CentralID: 1, Name: "cpu_percent", From: from, To: to, Step: 7 * time.Minute})
+	require.NoError(t, err)
+	assert.Equal(t, domain.ResolutionFiveMinute, series.Resolution)
+	assert.Equal(t, 10*time.Minute, series.Step)
+	require.Len(t, series.Buckets, 1)
+	assert.Equal(t, domain.MetricBucket{Start: rollupStart, Count: 40, Min: 1, Max: 40, Avg: 17.5, P95: 35}, series.Buckets[0])
+
+	// Fora de qualquer retenção de 5 minutos, restam os rollups de 1 hora
+	old := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	mockRepo.On("SeriesRollups", uint(1), "cpu_percent", domain.ResolutionHour, old, old.Add(24*time.Hour)).Return([]domain.MetricRollup{}, nil)
+	series, err = uc.GetMetrics(domain.MetricQuery{CentralID: 1, Name: "cpu_percent", From: old, To: old.Add(24 * time.Hour)})
+	require.NoError(t, err)
+	assert.Equal(t, domain.ResolutionHour, series.Resolution)
+	assert.Equal(t, time.Hour, series.Step)
+	assert.Empty(t, series.Buckets)
+}
+
+func TestGetMetrics_Invalid(t *testing.T) {
+	uc, mockRepo := setupTelemetryUseCase()
+
+	from := telemetryNow.Add(-time.Hour)
+	invalid := []domain.MetricQuery{
+		{CentralID: 1, From: from},
+		{CentralID: 1, Name: "cpu_percent", From: from, To: from},
+		{CentralID: 1, Name: "cpu_percent", From: from, Step: -time.Second},
+		{CentralID: 1, Name: "cpu_percent", From: telemetryNow.Add(-20 * time.Hour), Step: time.Second},
+	}
+	for _, query := range invalid {
+		_, err := uc.GetMetrics(query)
+		assert.ErrorIs(t, err, domain.ErrInvalid)
+	}
+	mockRepo.AssertNotCalled(t, "SeriesPoints", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRollup(t *testing.T) {
+	uc, mockRepo := setupTelemetryUseCase()
+
+	// Só o intervalo 12:05-12:10 está encerrado desde o último rollup
+	earliest := time.Date(2024, 1, 10, 9, 0, 0, 0, time.UTC)
+	latest := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	mockRepo.On("EarliestPoint").Return(&earliest, nil)
+	mockRepo.On("LatestRollup", domain.ResolutionFiveMinute).Return(&latest, nil)
+	mockRepo.On("LatestRollup", domain.ResolutionHour).Return(&latest, nil)
+	bucket := latest.Add(5 * time.Minute)
+	mockRepo.On("ListPoints", bucket, bucket.Add(5*time.Minute)).Return([]domain.MetricPoint{
+		{CentralID: 1, Name: "cpu_percent", Timestamp: bucket.Add(time.Minute), Value: 10},
+		{CentralID: 2, Name: "cpu_percent", Timestamp: bucket.Add(time.Minute), Value: 5},
+		{CentralID: 1, Name: "cpu_percent", Timestamp: bucket.Add(4 * time.Minute), Value: 30},
+	}, nil)
+	mockRepo.On("SaveRollups", []domain.MetricRollup{
+		{CentralID: 1, Name: "cpu_percent", Resolution: domain.ResolutionFiveMinute, Start: bucket, Count: 2, Sum: 40, Min: 10, Max: 30, P95: 29},
+		{CentralID: 2, Name: "cpu_percent", Resolution: domain.ResolutionFiveMinute, Start: bucket, Count: 1, Sum: 5, Min: 5, Max: 5, P95: 5},
+	}).Return(nil)
+
+	count, err := uc.Rollup()
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestApplyRetention(t *testing.T) {
+	uc, mockRepo := setupTelemetryUseCase()
+
+	mockRepo.On("Prune", domain.RetentionCutoffs{
+		Raw:        telemetryNow.Add(-24 * time.Hour),
+		FiveMinute: telemetryNow.Add(-7 * 24 * time.Hour),
+	}).Return(int64(3), nil)
+
+	deleted, err := uc.ApplyRetention()
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), deleted)
+}
@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"api-golang/internal/domain"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultDeliveryLimit = 50
+	MaxDeliveryLimit     = 500
+)
+
+type WebhookRepository interface {
+	CreateWebhook(webhook *domain.Webhook) error
+	GetWebhooks() ([]domain.Webhook, error)
+	GetWebhookByID(id uint) (*domain.Webhook, error)
+	UpdateWebhook(webhook *domain.Webhook) error
+	DeleteWebhook(id uint) error
+
+	GetDeliveries(webhookID uint, status domain.DeliveryStatus, limit int) ([]domain.WebhookDelivery, error)
+	GetDelivery(webhookID, id uint) (*domain.WebhookDelivery, error)
+	Redeliver(webhookID, id uint, now time.Time) (*domain.WebhookDelivery, error)
+}
+
+type WebhookUseCase struct {
+	Repo WebhookRepository
+
+	// Relógio, substituível nos testes
+	Now func() time.Time
+}
+
+func NewWebhookUseCase(repo WebhookRepository) *WebhookUseCase {
+	return &WebhookUseCase{Repo: repo, Now: time.Now}
+}
+
+// Cria a assinatura; sem segredo, um aleatório é gerado
+func (uc *WebhookUseCase) CreateWebhook(webhook *domain.Webhook) error {
+	if err := validateWebhook(webhook); err != nil {
+		return err
+	}
+	if webhook.Secret == "" {
+		webhook.Secret = newWebhookSecret()
+	}
+	return uc.Repo.CreateWebhook(webhook)
+}
+
+func (uc *WebhookUseCase) GetWebhooks() ([]domain.Webhook, error) {
+	return uc.Repo.GetWebhooks()
+}
+
+func (uc *WebhookUseCase) GetWebhookByID(id uint) (*domain.Webhook, error) {
+	return uc.Repo.GetWebhookByID(id)
+}
+
+// Atualiza a assinatura; sem segredo, mantém o atual
+func (uc *WebhookUseCase) UpdateWebhook(webhook *domain.Webhook) error {
+	if err := validateWebhook(webhook); err != nil {
+		return err
+	}
+	return uc.Repo.UpdateWebhook(webhook)
+}
+
+func (uc *WebhookUseCase) DeleteWebhook(id uint) error {
+	return uc.Repo.DeleteWebhook(id)
+}
+
+// Entregas da assinatura, das mais recentes às mais antigas
+func (uc *WebhookUseCase) GetDeliveries(webhookID uint, status domain.DeliveryStatus, limit int) ([]domain.WebhookDelivery, error) {
+	switch status {
+	case "", domain.DeliveryPending, domain.DeliverySucceeded, domain.DeliveryDead:
+	default:
+		return nil, fmt.Errorf("%w: invalid delivery status %q", domain.ErrInvalid, status)
+	}
+	if limit <= 0 {
+		limit = DefaultDeliveryLimit
+	}
+	return uc.Repo.GetDeliveries(webhookID, status, min(limit, MaxDeliveryLimit))
+}
+
+func (uc *WebhookUseCase) GetDelivery(webhookID, id uint) (*domain.WebhookDelivery, error) {
+	return uc.Repo.GetDelivery(webhookID, id)
+}
+
+// Agenda o reenvio imediato da entrega, inclusive das mortas e das já
+// entregues, com um novo ciclo de tentativas
+func (uc *WebhookUseCase) Redeliver(webhookID, id uint) (*domain.WebhookDelivery, error) {
+	return uc.Repo.Redeliver(webhookID, id, uc.Now().UTC())
+}
+
+func validateWebhook(webhook *domain.Webhook) error {
+	webhook.URL = strings.TrimSpace(webhook.URL)
+	u, err := url.Parse(webhook.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: webhook url must be an absolute http or https URL", domain.ErrInvalid)
+	}
+	seen := make(map[domain.EventType]bool, len(webhook.Events))
+	events := webhook.Events[:0]
+	for _, event := range webhook.Events {
+		if !event.Valid() {
+			return fmt.Errorf("%w: unknown event %q", domain.ErrInvalid, event)
+		}
+		if !seen[event] {
+			seen[event] = true
+			events = append(events, event)
+		}
+	}
+	webhook.Events = events
+	return nil
+}
+
+// Segredo aleatório de 256 bits, em hexadecimal
+func newWebhookSecret() string {
+	b := make([]byte, 32)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
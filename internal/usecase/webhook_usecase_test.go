@@ -0,0 +1,122 @@
+package usecase_test
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock do Repositório de webhooks
+type MockWebhookRepository struct {
+	mock.Mock
+}
+
+func (m *MockWebhookRepository) CreateWebhook(webhook *domain.Webhook) error {
+	args := m.Called(webhook)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) GetWebhooks() ([]domain.Webhook, error) {
+	args := m.Called()
+	return args.Get(0).([]domain.Webhook), args.Error(1)
+}
+
+func (m *MockWebhookRepository) GetWebhookByID(id uint) (*domain.Webhook, error) {
+	args := m.Called(id)
+	return args.Get(0).(*domain.Webhook), args.Error(1)
+}
+
+func (m *MockWebhookRepository) UpdateWebhook(webhook *domain.Webhook) error {
+	args := m.Called(webhook)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) DeleteWebhook(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) GetDeliveries(webhookID uint, status domain.DeliveryStatus, limit int) ([]domain.WebhookDelivery, error) {
+	args := m.Called(webhookID, status, limit)
+	return args.Get(0).([]domain.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockWebhookRepository) GetDelivery(webhookID, id uint) (*domain.WebhookDelivery, error) {
+	args := m.Called(webhookID, id)
+	return args.Get(0).(*domain.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockWebhookRepository) Redeliver(webhookID, id uint, now time.Time) (*domain.WebhookDelivery, error) {
+	args := m.Called(webhookID, id, now)
+	return args.Get(0).(*domain.WebhookDelivery), args.Error(1)
+}
+
+func TestCreateWebhook(t *testing.T) {
+	mockRepo := new(MockWebhookRepository)
+	uc := usecase.NewWebhookUseCase(mockRepo)
+
+	webhook := &domain.Webhook{URL: " https://cmdb.example.com/hooks ", Active: true,
+		Events: []domain.EventType{domain.EventCentralCreated, domain.EventCentralCreated, domain.EventCentralDeleted}}
+	mockRepo.On("CreateWebhook", webhook).Return(nil)
+
+	assert.NoError(t, uc.CreateWebhook(webhook))
+	assert.Equal(t, "https://cmdb.example.com/hooks", webhook.URL)
+	assert.Equal(t, []domain.EventType{domain.EventCentralCreated, domain.EventCentralDeleted}, webhook.Events)
+	// Segredo gerado
+	assert.Len(t, webhook.Secret, 64)
+
+	// Segredo informado é mantido
+	informed := &domain.Webhook{URL: "http://ticket.local/in", Secret: "abc"}
+	mockRepo.On("CreateWebhook", informed).Return(nil)
+	assert.NoError(t, uc.CreateWebhook(informed))
+	assert.Equal(t, "abc", informed.Secret)
+}
+
+func TestCreateWebhook_Invalid(t *testing.T) {
+	mockRepo := new(MockWebhookRepository)
+	uc := usecase.NewWebhookUseCase(mockRepo)
+
+	invalid := []*domain.Webhook{
+		{URL: "cmdb.example.com/hooks"},
+		{URL: "ftp://cmdb.example.com/hooks"},
+		{URL: "https://"},
+		{URL: "https://cmdb.example.com", Events: []domain.EventType{"central.renamed"}},
+	}
+	for _, webhook := range invalid {
+		assert.ErrorIs(t, uc.CreateWebhook(webhook), domain.ErrInvalid)
+	}
+	mockRepo.AssertNotCalled(t, "CreateWebhook", mock.Anything)
+}
+
+func TestGetDeliveries(t *testing.T) {
+	mockRepo := new(MockWebhookRepository)
+	uc := usecase.NewWebhookUseCase(mockRepo)
+
+	mockRepo.On("GetDeliveries", uint(1), domain.DeliveryDead, usecase.DefaultDeliveryLimit).Return([]domain.WebhookDelivery{}, nil)
+	mockRepo.On("GetDeliveries", uint(1), domain.DeliveryStatus(""), usecase.MaxDeliveryLimit).Return([]domain.WebhookDelivery{}, nil)
+
+	_, err := uc.GetDeliveries(1, domain.DeliveryDead, 0)
+	assert.NoError(t, err)
+	_, err = uc.GetDeliveries(1, "", 10000)
+	assert.NoError(t, err)
+	_, err = uc.GetDeliveries(1, "failed", 0)
+	assert.ErrorIs(t, err, domain.ErrInvalid)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestRedeliver(t *testing.T) {
+	mockRepo := new(MockWebhookRepository)
+	uc := usecase.NewWebhookUseCase(mockRepo)
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	uc.Now = func() time.Time { return now }
+
+	mockRepo.On("Redeliver", uint(1), uint(5), now).Return(&domain.WebhookDelivery{ID: 5, Status: domain.DeliveryPending}, nil)
+
+	delivery, err := uc.Redeliver(1, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.DeliveryPending, delivery.Status)
+}
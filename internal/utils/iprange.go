@@ -0,0 +1,91 @@
+package utils
+
+import (
+	"api-golang/internal/domain"
+	"fmt"
+	"math"
+	"math/big"
+	"net/netip"
+	"slices"
+)
+
+// Converte uma faixa em texto, exigindo início e fim da mesma família e
+// em ordem
+func ParseAddressRange(r domain.AddressRange) (domain.IPRange, error) {
+	if r.Start == "" || r.End == "" {
+		return domain.IPRange{}, fmt.Errorf("range %q-%q needs start and end", r.Start, r.End)
+	}
+	return AddressRange(r.Start, r.End)
+}
+
+// Faixa de endereços alocáveis da sub-rede: em IPv4, sem o endereço de rede
+// e o de broadcast (exceto em /31 e /32)
+func UsableRange(cidr string) (domain.IPRange, error) {
+	r, err := SubnetRange(cidr)
+	if err != nil {
+		return r, err
+	}
+	if r.From.Is4() && RangeSize(r) > 2 {
+		r.From, r.To = r.From.Next(), r.To.Prev()
+	}
+	return r, nil
+}
+
+// Indica se o endereço está dentro da faixa
+func RangeContains(r domain.IPRange, addr netip.Addr) bool {
+	return addr.Is4() == r.From.Is4() && !addr.Less(r.From) && !r.To.Less(addr)
+}
+
+// Indica se as duas faixas têm algum endereço em comum
+func RangesOverlap(a, b domain.IPRange) bool {
+	return a.From.Is4() == b.From.Is4() && !a.To.Less(b.From) && !b.To.Less(a.From)
+}
+
+// Quantidade de endereços da faixa, saturando em 2^64-1
+func RangeSize(r domain.IPRange) uint64 {
+	if r.To.Less(r.From) {
+		return 0
+	}
+	size := new(big.Int).Sub(new(big.Int).SetBytes(r.To.AsSlice()), new(big.Int).SetBytes(r.From.AsSlice()))
+	size.Add(size, big.NewInt(1))
+	if !size.IsUint64() {
+		return math.MaxUint64
+	}
+	return size.Uint64()
+}
+
+// Interseção de duas faixas; ok é falso quando elas não se tocam
+func RangeIntersection(a, b domain.IPRange) (domain.IPRange, bool) {
+	if !RangesOverlap(a, b) {
+		return domain.IPRange{}, false
+	}
+	r := a
+	if r.From.Less(b.From) {
+		r.From = b.From
+	}
+	if b.To.Less(r.To) {
+		r.To = b.To
+	}
+	return r, true
+}
+
+// Une as faixas que se sobrepõem ou se encostam, devolvendo-as em ordem e
+// sem endereços em comum. Todas devem ser da mesma família.
+func MergeRanges(ranges []domain.IPRange) []domain.IPRange {
+	sorted := slices.Clone(ranges)
+	slices.SortFunc(sorted, func(a, b domain.IPRange) int { return a.From.Compare(b.From) })
+	var merged []domain.IPRange
+	for _, r := range sorted {
+		if n := len(merged); n > 0 {
+			last := &merged[n-1]
+			if next := last.To.Next(); !next.IsValid() || !next.Less(r.From) {
+				if last.To.Less(r.To) {
+					last.To = r.To
+				}
+				continue
+			}
+		}
+		merged = append(merged, r)
+	}
+	return merged
+}
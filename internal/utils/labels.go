@@ -0,0 +1,118 @@
+package utils
+
+import (
+	"api-golang/internal/domain"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Mesmas regras de sintaxe de labels do Kubernetes
+var (
+	labelNamePattern   = regexp.MustCompile(`^[A-Za-z0-9]([-A-Za-z0-9_.]*[A-Za-z0-9])?$`)
+	labelPrefixPattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+	setPattern         = regexp.MustCompile(`^(\S+)\s+(in|notin)\s*\((.*)\)$`)
+)
+
+// Valida a chave de um label: nome de até 63 caracteres, opcionalmente
+// precedido de um prefixo DNS (ex.: example.com/tier)
+func ValidateLabelKey(key string) error {
+	name := key
+	if i := strings.LastIndex(key, "/"); i >= 0 {
+		prefix := key[:i]
+		name = key[i+1:]
+		if len(prefix) == 0 || len(prefix) > 253 || !labelPrefixPattern.MatchString(prefix) {
+			return fmt.Errorf("invalid label key %q: bad prefix", key)
+		}
+	}
+	if len(name) == 0 || len(name) > 63 || !labelNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid label key %q", key)
+	}
+	return nil
+}
+
+// Valida o valor de um label: vazio ou até 63 caracteres alfanuméricos,
+// '-', '_' ou '.', começando e terminando com alfanumérico
+func ValidateLabelValue(value string) error {
+	if value == "" {
+		return nil
+	}
+	if len(value) > 63 || !labelNamePattern.MatchString(value) {
+		return fmt.Errorf("invalid label value %q", value)
+	}
+	return nil
+}
+
+// Interpreta um seletor como "env=prod,vendor!=acme,tier in (a,b),!legacy".
+// Aceita =, ==, !=, in, notin, a chave sozinha (existe) e !chave (não existe).
+func ParseLabelSelector(selector string) (domain.LabelSelector, error) {
+	var result domain.LabelSelector
+	for _, part := range splitSelector(selector) {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			return nil, fmt.Errorf("invalid selector %q: empty requirement", selector)
+		}
+		req, err := parseRequirement(part)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, req)
+	}
+	return result, nil
+}
+
+// Separa as condições pelas vírgulas fora dos parênteses
+func splitSelector(selector string) []string {
+	if strings.TrimSpace(selector) == "" {
+		return nil
+	}
+	var parts []string
+	depth, start := 0, 0
+	for i, r := range selector {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, selector[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, selector[start:])
+}
+
+func parseRequirement(part string) (domain.LabelRequirement, error) {
+	var req domain.LabelRequirement
+	switch {
+	case setPattern.MatchString(part):
+		m := setPattern.FindStringSubmatch(part)
+		req = domain.LabelRequirement{Key: m[1], Operator: m[2]}
+		for _, value := range strings.Split(m[3], ",") {
+			req.Values = append(req.Values, strings.TrimSpace(value))
+		}
+	case strings.HasPrefix(part, "!") && !strings.Contains(part, "="):
+		req = domain.LabelRequirement{Key: strings.TrimSpace(part[1:]), Operator: domain.SelectorNotExists}
+	case strings.Contains(part, "!="):
+		key, value, _ := strings.Cut(part, "!=")
+		req = domain.LabelRequirement{Key: strings.TrimSpace(key), Operator: domain.SelectorNotEquals, Values: []string{strings.TrimSpace(value)}}
+	case strings.Contains(part, "="):
+		key, value, _ := strings.Cut(part, "=")
+		value = strings.TrimPrefix(value, "=")
+		req = domain.LabelRequirement{Key: strings.TrimSpace(key), Operator: domain.SelectorEquals, Values: []string{strings.TrimSpace(value)}}
+	default:
+		req = domain.LabelRequirement{Key: part, Operator: domain.SelectorExists}
+	}
+
+	if err := ValidateLabelKey(req.Key); err != nil {
+		return req, fmt.Errorf("invalid selector requirement %q: %v", part, err)
+	}
+	for _, value := range req.Values {
+		if err := ValidateLabelValue(value); err != nil {
+			return req, fmt.Errorf("invalid selector requirement %q: %v", part, err)
+		}
+	}
+	return req, nil
+}
@@ -0,0 +1,42 @@
+package utils_test
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLabelSelector(t *testing.T) {
+	selector, err := utils.ParseLabelSelector("env=prod, vendor!=acme,tier in (a, b),site notin (x),owner,!legacy,zone==sul")
+	assert.NoError(t, err)
+	assert.Equal(t, domain.LabelSelector{
+		{Key: "env", Operator: domain.SelectorEquals, Values: []string{"prod"}},
+		{Key: "vendor", Operator: domain.SelectorNotEquals, Values: []string{"acme"}},
+		{Key: "tier", Operator: domain.SelectorIn, Values: []string{"a", "b"}},
+		{Key: "site", Operator: domain.SelectorNotIn, Values: []string{"x"}},
+		{Key: "owner", Operator: domain.SelectorExists},
+		{Key: "legacy", Operator: domain.SelectorNotExists},
+		{Key: "zone", Operator: domain.SelectorEquals, Values: []string{"sul"}},
+	}, selector)
+
+	empty, err := utils.ParseLabelSelector("")
+	assert.NoError(t, err)
+	assert.Empty(t, empty)
+}
+
+func TestParseLabelSelector_Invalid(t *testing.T) {
+	for _, selector := range []string{"env=prod,,tier=a", "-env=prod", "env=bad value", "tier in (a,-b)"} {
+		_, err := utils.ParseLabelSelector(selector)
+		assert.Error(t, err, selector)
+	}
+}
+
+func TestValidateLabelKey(t *testing.T) {
+	assert.NoError(t, utils.ValidateLabelKey("env"))
+	assert.NoError(t, utils.ValidateLabelKey("example.com/tier"))
+	assert.Error(t, utils.ValidateLabelKey("/tier"))
+	assert.Error(t, utils.ValidateLabelKey("Example.com/tier"))
+	assert.Error(t, utils.ValidateLabelKey("tier_"))
+}
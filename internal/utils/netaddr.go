@@ -0,0 +1,172 @@
+package utils
+
+import (
+	"api-golang/internal/domain"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+)
+
+// Formatos de saída aceitos para endereços MAC
+const (
+	MACFormatColon  = "colon"  // 00:11:22:33:44:55 (forma canônica)
+	MACFormatHyphen = "hyphen" // 00-11-22-33-44-55
+	MACFormatDot    = "dot"    // 0011.2233.4455
+	MACFormatBare   = "bare"   // 001122334455
+)
+
+var ErrUnknownMACFormat = errors.New("unknown MAC format")
+
+// Converte um MAC em qualquer notação comum para a forma canônica
+// (minúsculas, separado por dois-pontos). Aceita dois-pontos, hífen,
+// notação Cisco com pontos e os 12 dígitos sem separador.
+func NormalizeMAC(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if len(value) == 12 && isHex(value) {
+		parts := make([]string, 0, 6)
+		for i := 0; i < 12; i += 2 {
+			parts = append(parts, value[i:i+2])
+		}
+		value = strings.Join(parts, ":")
+	}
+
+	hw, err := net.ParseMAC(value)
+	if err != nil || len(hw) != 6 {
+		return "", fmt.Errorf("invalid MAC address %q", value)
+	}
+	return hw.String(), nil
+}
+
+// Formata um MAC canônico no formato pedido
+func FormatMAC(mac, format string) (string, error) {
+	hw, err := net.ParseMAC(mac)
+	if err != nil {
+		return "", err
+	}
+	hex := fmt.Sprintf("%x", []byte(hw))
+
+	if !IsMACFormat(format) {
+		return "", fmt.Errorf("%w: %q", ErrUnknownMACFormat, format)
+	}
+	switch format {
+	case MACFormatHyphen:
+		return strings.ReplaceAll(hw.String(), ":", "-"), nil
+	case MACFormatDot:
+		return hex[0:4] + "." + hex[4:8] + "." + hex[8:12], nil
+	case MACFormatBare:
+		return hex, nil
+	}
+	return hw.String(), nil
+}
+
+// Indica se o formato de saída de MAC é suportado
+func IsMACFormat(format string) bool {
+	switch format {
+	case "", MACFormatColon, MACFormatHyphen, MACFormatDot, MACFormatBare:
+		return true
+	}
+	return false
+}
+
+// Converte um IP para a forma canônica. Endereços IPv4 mapeados em IPv6
+// (::ffff:a.b.c.d) viram IPv4 e endereços com zona são rejeitados.
+func NormalizeIP(value string) (netip.Addr, error) {
+	addr, err := netip.ParseAddr(strings.TrimSpace(value))
+	if err != nil || addr.Zone() != "" {
+		return netip.Addr{}, fmt.Errorf("invalid IP address %q", value)
+	}
+	return addr.Unmap(), nil
+}
+
+// Converte uma sub-rede para a forma canônica: bits de host zerados e
+// IPv4 mapeado em IPv6 convertido para IPv4 (10.20.1.5/16 vira 10.20.0.0/16)
+func NormalizeSubnet(cidr string) (netip.Prefix, error) {
+	prefix, err := netip.ParsePrefix(strings.TrimSpace(cidr))
+	if err != nil || prefix.Addr().Zone() != "" {
+		return netip.Prefix{}, fmt.Errorf("invalid subnet %q", cidr)
+	}
+	if prefix.Addr().Is4In6() {
+		if prefix.Bits() < 96 {
+			return netip.Prefix{}, fmt.Errorf("invalid subnet %q", cidr)
+		}
+		prefix = netip.PrefixFrom(prefix.Addr().Unmap(), prefix.Bits()-96)
+	}
+	return prefix.Masked(), nil
+}
+
+// Faixa de endereços coberta por uma sub-rede em notação CIDR
+func SubnetRange(cidr string) (domain.IPRange, error) {
+	prefix, err := NormalizeSubnet(cidr)
+	if err != nil {
+		return domain.IPRange{}, err
+	}
+
+	last := prefix.Addr().AsSlice()
+	for bit := prefix.Bits(); bit < len(last)*8; bit++ {
+		last[bit/8] |= 0x80 >> (bit % 8)
+	}
+	to, _ := netip.AddrFromSlice(last)
+	return domain.IPRange{From: prefix.Addr(), To: to}, nil
+}
+
+// Faixa entre dois endereços da mesma família. Um dos limites pode ficar
+// vazio: a faixa então vai até o início ou o fim da família do outro.
+func AddressRange(from, to string) (domain.IPRange, error) {
+	if from == "" && to == "" {
+		return domain.IPRange{}, errors.New("ip_from or ip_to is required")
+	}
+
+	var r domain.IPRange
+	if from != "" {
+		addr, err := NormalizeIP(from)
+		if err != nil {
+			return r, err
+		}
+		r.From = addr
+	}
+	if to != "" {
+		addr, err := NormalizeIP(to)
+		if err != nil {
+			return r, err
+		}
+		r.To = addr
+	}
+
+	switch {
+	case !r.From.IsValid():
+		r.From = familyBound(r.To.Is4(), false)
+	case !r.To.IsValid():
+		r.To = familyBound(r.From.Is4(), true)
+	}
+	if r.From.Is4() != r.To.Is4() {
+		return r, errors.New("ip_from and ip_to must be of the same IP family")
+	}
+	if r.To.Less(r.From) {
+		return r, errors.New("ip_from must not be greater than ip_to")
+	}
+	return r, nil
+}
+
+// Primeiro ou último endereço da família
+func familyBound(v4, last bool) netip.Addr {
+	prefix := netip.MustParsePrefix("::/0")
+	if v4 {
+		prefix = netip.MustParsePrefix("0.0.0.0/0")
+	}
+	if !last {
+		return prefix.Addr()
+	}
+	r, _ := SubnetRange(prefix.String())
+	return r.To
+}
+
+func isHex(value string) bool {
+	for _, r := range value {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,178 @@
+package utils_test
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/utils"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeMAC_Notations(t *testing.T) {
+	// Todas as notações comuns devem resultar na mesma forma canônica
+	inputs := []string{
+		"00:11:22:33:44:55",
+		"00-11-22-33-44-55",
+		"0011.2233.4455",
+		"001122334455",
+		"00:11:22:33:44:55 ",
+		"00:11:22:AA:bb:CC",
+	}
+	expected := []string{
+		"00:11:22:33:44:55",
+		"00:11:22:33:44:55",
+		"00:11:22:33:44:55",
+		"00:11:22:33:44:55",
+		"00:11:22:33:44:55",
+		"00:11:22:aa:bb:cc",
+	}
+
+	for i, input := range inputs {
+		mac, err := utils.NormalizeMAC(input)
+		assert.NoError(t, err, input)
+		assert.Equal(t, expected[i], mac, input)
+	}
+}
+
+func TestNormalizeMAC_Invalid(t *testing.T) {
+	for _, input := range []string{"", "invalid", "00:11:22:33:44", "00:11:22:33:44:55:66:77", "00112233445z"} {
+		_, err := utils.NormalizeMAC(input)
+		assert.Error(t, err, input)
+	}
+}
+
+func TestFormatMAC(t *testing.T) {
+	mac := "00:11:22:aa:bb:cc"
+
+	formats := map[string]string{
+		"":                    "00:11:22:aa:bb:cc",
+		utils.MACFormatColon:  "00:11:22:aa:bb:cc",
+		utils.MACFormatHyphen: "00-11-22-aa-bb-cc",
+		utils.MACFormatDot:    "0011.22aa.bbcc",
+		utils.MACFormatBare:   "001122aabbcc",
+	}
+	for format, expected := range formats {
+		formatted, err := utils.FormatMAC(mac, format)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, formatted)
+	}
+
+	_, err := utils.FormatMAC(mac, "weird")
+	assert.ErrorIs(t, err, utils.ErrUnknownMACFormat)
+}
+
+func TestNormalizeIP(t *testing.T) {
+	addr, err := utils.NormalizeIP(" 192.168.0.1 ")
+	assert.NoError(t, err)
+	assert.Equal(t, "192.168.0.1", addr.String())
+
+	// IPv4 mapeado em IPv6 vira IPv4
+	addr, err = utils.NormalizeIP("::ffff:192.168.0.1")
+	assert.NoError(t, err)
+	assert.True(t, addr.Is4())
+	assert.Equal(t, "192.168.0.1", addr.String())
+
+	// IPv6 é comprimido e colocado em minúsculas
+	addr, err = utils.NormalizeIP("2001:DB8:0:0:0:0:0:1")
+	assert.NoError(t, err)
+	assert.Equal(t, "2001:db8::1", addr.String())
+
+	for _, input := range []string{"", "999.1.1.1", "fe80::1%eth0", "192.168.0"} {
+		_, err := utils.NormalizeIP(input)
+		assert.Error(t, err, input)
+	}
+}
+
+func TestSubnetRange(t *testing.T) {
+	r, err := utils.SubnetRange("10.20.1.5/16")
+	assert.NoError(t, err)
+	assert.Equal(t, "10.20.0.0", r.From.String())
+	assert.Equal(t, "10.20.255.255", r.To.String())
+
+	r, err = utils.SubnetRange("2001:db8::/32")
+	assert.NoError(t, err)
+	assert.Equal(t, "2001:db8::", r.From.String())
+	assert.Equal(t, "2001:db8:ffff:ffff:ffff:ffff:ffff:ffff", r.To.String())
+
+	// Sub-rede IPv4 escrita como IPv6 mapeado
+	r, err = utils.SubnetRange("::ffff:192.168.0.0/120")
+	assert.NoError(t, err)
+	assert.Equal(t, "192.168.0.0", r.From.String())
+	assert.Equal(t, "192.168.0.255", r.To.String())
+
+	_, err = utils.SubnetRange("10.20.0.0")
+	assert.Error(t, err)
+}
+
+func TestAddressRange(t *testing.T) {
+	r, err := utils.AddressRange("10.0.0.10", "10.0.0.20")
+	assert.NoError(t, err)
+	assert.Equal(t, "10.0.0.10", r.From.String())
+	assert.Equal(t, "10.0.0.20", r.To.String())
+
+	// Limite ausente vai até o fim da família
+	r, err = utils.AddressRange("2001:db8::1", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff", r.To.String())
+
+	r, err = utils.AddressRange("", "10.0.0.1")
+	assert.NoError(t, err)
+	assert.Equal(t, "0.0.0.0", r.From.String())
+
+	for _, bounds := range [][2]string{{"", ""}, {"10.0.0.1", "2001:db8::1"}, {"10.0.0.2", "10.0.0.1"}, {"x", ""}} {
+		_, err := utils.AddressRange(bounds[0], bounds[1])
+		assert.Error(t, err, bounds)
+	}
+}
+
+func TestUsableRange(t *testing.T) {
+	r, err := utils.UsableRange("10.0.0.0/24")
+	assert.NoError(t, err)
+	assert.Equal(t, "10.0.0.1", r.From.String())
+	assert.Equal(t, "10.0.0.254", r.To.String())
+	assert.Equal(t, uint64(254), utils.RangeSize(r))
+
+	// Em /31 e em IPv6 todos os endereços são utilizáveis
+	r, _ = utils.UsableRange("10.0.0.0/31")
+	assert.Equal(t, uint64(2), utils.RangeSize(r))
+	r, _ = utils.UsableRange("2001:db8::/64")
+	assert.Equal(t, "2001:db8::", r.From.String())
+	assert.Equal(t, uint64(math.MaxUint64), utils.RangeSize(r))
+}
+
+func TestRangeIntersection(t *testing.T) {
+	a, _ := utils.AddressRange("10.0.0.10", "10.0.0.20")
+	b, _ := utils.AddressRange("10.0.0.15", "10.0.0.30")
+	c, _ := utils.AddressRange("10.0.0.21", "10.0.0.30")
+	v6, _ := utils.AddressRange("::a", "::14")
+
+	r, ok := utils.RangeIntersection(a, b)
+	assert.True(t, ok)
+	assert.Equal(t, "10.0.0.15", r.From.String())
+	assert.Equal(t, "10.0.0.20", r.To.String())
+
+	assert.False(t, utils.RangesOverlap(a, c))
+	assert.False(t, utils.RangesOverlap(a, v6))
+	_, ok = utils.RangeIntersection(a, v6)
+	assert.False(t, ok)
+}
+
+func TestMergeRanges(t *testing.T) {
+	ranges := make([]domain.IPRange, 0, 4)
+	for _, r := range [][2]string{{"10.0.0.30", "10.0.0.40"}, {"10.0.0.10", "10.0.0.20"}, {"10.0.0.15", "10.0.0.21"}, {"10.0.0.22", "10.0.0.25"}} {
+		parsed, err := utils.AddressRange(r[0], r[1])
+		require.NoError(t, err)
+		ranges = append(ranges, parsed)
+	}
+
+	// Sobrepostas e vizinhas viram uma só; separadas continuam separadas
+	merged := utils.MergeRanges(ranges)
+	require.Len(t, merged, 2)
+	assert.Equal(t, "10.0.0.10", merged[0].From.String())
+	assert.Equal(t, "10.0.0.25", merged[0].To.String())
+	assert.Equal(t, "10.0.0.30", merged[1].From.String())
+	assert.Equal(t, "10.0.0.40", merged[1].To.String())
+	assert.Empty(t, utils.MergeRanges(nil))
+}
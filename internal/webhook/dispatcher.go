@@ -0,0 +1,216 @@
+// Package webhook envia os eventos das centrais às assinaturas de webhook,
+// com corpo assinado, novas tentativas com backoff exponencial e dead-letter.
+package webhook
+
+import (
+	"api-golang/internal/domain"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Fila de entregas
+type Store interface {
+	EnqueueDeliveries(eventID string, eventType domain.EventType, payload []byte, now time.Time) (int, error)
+	DueDeliveries(now time.Time, limit int) ([]domain.WebhookDelivery, error)
+	RecordAttempt(delivery *domain.WebhookDelivery, attempt *domain.DeliveryAttempt) error
+}
+
+type Options struct {
+	// Entregas buscadas por vez
+	BatchSize int
+	// Tentativas antes de a entrega ir para dead-letter
+	MaxAttempts int
+	// Espera após a primeira falha, dobrada a cada nova falha até o máximo
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	// Prazo de cada requisição
+	Timeout time.Duration
+	// Envios simultâneos
+	Concurrency int
+}
+
+// Padrões para as opções não informadas
+var DefaultOptions = Options{
+	BatchSize:   50,
+	MaxAttempts: 8,
+	BaseBackoff: 10 * time.Second,
+	MaxBackoff:  time.Hour,
+	Timeout:     10 * time.Second,
+	Concurrency: 8,
+}
+
+type Dispatcher struct {
+	Store   Store
+	Client  *http.Client
+	Options Options
+
+	// Relógio e sorteio do jitter, substituíveis nos testes
+	Now  func() time.Time
+	Rand func() float64
+}
+
+func New(store Store, opts Options) *Dispatcher {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultOptions.BatchSize
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = DefaultOptions.MaxAttempts
+	}
+	if opts.BaseBackoff <= 0 {
+		opts.BaseBackoff = DefaultOptions.BaseBackoff
+	}
+	if opts.MaxBackoff < opts.BaseBackoff {
+		opts.MaxBackoff = max(DefaultOptions.MaxBackoff, opts.BaseBackoff)
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultOptions.Timeout
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = DefaultOptions.Concurrency
+	}
+	return &Dispatcher{Store: store, Client: &http.Client{}, Options: opts, Now: time.Now, Rand: rand.Float64}
+}
+
+// Enfileira uma entrega do evento para cada assinatura interessada. Eventos
+// do outbox usam o próprio ID, e repeti-los não duplica entregas.
+func (d *Dispatcher) Publish(event domain.Event) error {
+	id := NewID()
+	if event.ID != 0 {
+		id = strconv.FormatUint(uint64(event.ID), 10)
+	}
+	payload, err := Encode(id, event)
+	if err != nil {
+		return err
+	}
+	_, err = d.Store.EnqueueDeliveries(id, event.Type, payload, d.Now().UTC())
+	return err
+}
+
+// Envia as entregas com tentativa vencida, em lotes, até esvaziar a fila ou
+// o contexto ser cancelado. Retorna quantas tentativas foram feitas.
+func (d *Dispatcher) DeliverDue(ctx context.Context) (int, error) {
+	total := 0
+	for ctx.Err() == nil {
+		deliveries, err := d.Store.DueDeliveries(d.Now().UTC(), d.Options.BatchSize)
+		if err != nil {
+			return total, err
+		}
+		if err := d.deliverAll(ctx, deliveries); err != nil {
+			return total, err
+		}
+		total += len(deliveries)
+		if len(deliveries) < d.Options.BatchSize {
+			break
+		}
+	}
+	return total, nil
+}
+
+func (d *Dispatcher) deliverAll(ctx context.Context, deliveries []domain.WebhookDelivery) error {
+	sem := make(chan struct{}, d.Options.Concurrency)
+	errs := make([]error, len(deliveries))
+	var wg sync.WaitGroup
+	for i := range deliveries {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(delivery *domain.WebhookDelivery, err *error) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			*err = d.deliver(ctx, delivery)
+		}(&deliveries[i], &errs[i])
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Faz uma tentativa e grava o resultado: 2xx encerra a entrega; outra
+// resposta ou falha de rede agenda a próxima, até esgotar as tentativas
+func (d *Dispatcher) deliver(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	started := d.Now().UTC()
+	status, sendErr := d.send(ctx, delivery, started)
+	attempt := &domain.DeliveryAttempt{
+		AttemptedAt: started,
+		StatusCode:  status,
+		Duration:    d.Now().Sub(started),
+	}
+	if sendErr != nil {
+		attempt.Error = sendErr.Error()
+	}
+	// Cancelamento no meio do envio não conta como tentativa
+	if ctx.Err() != nil {
+		return nil
+	}
+
+	delivery.Attempts++
+	delivery.LastStatusCode = status
+	delivery.LastError = attempt.Error
+	switch {
+	case sendErr == nil:
+		delivery.Status = domain.DeliverySucceeded
+		delivery.NextAttemptAt = nil
+		delivery.DeliveredAt = &started
+	case delivery.Attempts >= d.Options.MaxAttempts:
+		delivery.Status = domain.DeliveryDead
+		delivery.NextAttemptAt = nil
+	default:
+		next := started.Add(Backoff(delivery.Attempts, d.Options.BaseBackoff, d.Options.MaxBackoff, d.Rand))
+		delivery.NextAttemptAt = &next
+	}
+	return d.Store.RecordAttempt(delivery, attempt)
+}
+
+// Envia o corpo assinado. Retorna o código HTTP recebido (zero sem
+// resposta) e erro para tudo que não for 2xx.
+func (d *Dispatcher) send(ctx context.Context, delivery *domain.WebhookDelivery, now time.Time) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, d.Options.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := now.Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "central-webhooks/1.0")
+	req.Header.Set(HeaderEvent, string(delivery.EventType))
+	req.Header.Set(HeaderDelivery, delivery.EventID)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, "sha256="+Sign(delivery.Secret, timestamp, delivery.Payload))
+
+	resp, err := d.Client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	// Descarta o corpo para reaproveitar a conexão
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// Espera antes da próxima tentativa após a falha de número attempt: base
+// dobrada a cada falha, limitada ao máximo, com "equal jitter" (metade fixa
+// e metade sorteada) para espalhar as tentativas de muitas entregas
+func Backoff(attempt int, base, maximum time.Duration, random func() float64) time.Duration {
+	d := base
+	for i := 1; i < attempt && d < maximum; i++ {
+		d *= 2
+	}
+	d = min(d, maximum)
+	half := d / 2
+	return half + time.Duration(random()*float64(d-half))
+}
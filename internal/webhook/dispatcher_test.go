@@ -0,0 +1,268 @@
+package webhook_test
+
+import (
+	"api-golang/internal/domain"
+	"api-golang/internal/webhook"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Fila em memória
+type fakeStore struct {
+	mu         sync.Mutex
+	webhooks   []domain.Webhook
+	deliveries []*domain.WebhookDelivery
+	attempts   []domain.DeliveryAttempt
+}
+
+func (s *fakeStore) EnqueueDeliveries(eventID string, eventType domain.EventType, payload []byte, now time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	count := 0
+	for _, w := range s.webhooks {
+		if !w.Active || !w.Subscribes(eventType) {
+			continue
+		}
+		next := now
+		s.deliveries = append(s.deliveries, &domain.WebhookDelivery{
+			ID: uint(len(s.deliveries) + 1), WebhookID: w.ID, EventID: eventID, EventType: eventType, Payload: payload,
+			Status: domain.DeliveryPending, NextAttemptAt: &next, URL: w.URL, Secret: w.Secret,
+		})
+		count++
+	}
+	return count, nil
+}
+
+func (s *fakeStore) DueDeliveries(now time.Time, limit int) ([]domain.WebhookDelivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var due []domain.WebhookDelivery
+	for _, d := range s.deliveries {
+		if d.Status == domain.DeliveryPending && !d.NextAttemptAt.After(now) && len(due) < limit {
+			due = append(due, *d)
+		}
+	}
+	return due, nil
+}
+
+func (s *fakeStore) RecordAttempt(delivery *domain.WebhookDelivery, attempt *domain.DeliveryAttempt) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	attempt.DeliveryID = delivery.ID
+	attempt.Number = delivery.Attempts
+	s.attempts = append(s.attempts, *attempt)
+	*s.deliveries[delivery.ID-1] = *delivery
+	return nil
+}
+
+// Receptor que confere a assinatura e responde com os códigos da fila,
+// repetindo o último
+type receiver struct {
+	t        *testing.T
+	secret   string
+	statuses []int
+	calls    atomic.Int32
+	mu       sync.Mutex
+	payloads []webhook.Payload
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+	timestamp, err := strconv.ParseInt(req.Header.Get(webhook.HeaderTimestamp), 10, 64)
+	assert.NoError(r.t, err)
+	assert.True(r.t, webhook.Verify(r.secret, timestamp, body, req.Header.Get(webhook.HeaderSignature)))
+	assert.Equal(r.t, "application/json", req.Header.Get("Content-Type"))
+
+	var payload webhook.Payload
+	assert.NoError(r.t, json.Unmarshal(body, &payload))
+	assert.Equal(r.t, payload.Type, req.Header.Get(webhook.HeaderEvent))
+	assert.Equal(r.t, payload.ID, req.Header.Get(webhook.HeaderDelivery))
+	r.mu.Lock()
+	r.payloads = append(r.payloads, payload)
+	r.mu.Unlock()
+
+	n := int(r.calls.Add(1))
+	w.WriteHeader(r.statuses[min(n, len(r.statuses))-1])
+}
+
+var dispatchNow = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func setup(t *testing.T, statuses ...int) (*webhook.Dispatcher, *fakeStore, *receiver, *time.Time) {
+	recv := &receiver{t: t, secret: "s3cr3t", statuses: statuses}
+	server := httptest.NewServer(recv)
+	t.Cleanup(server.Close)
+
+	store := &fakeStore{webhooks: []domain.Webhook{
+		{ID: 1, URL: server.URL, Secret: recv.secret, Active: true},
+		{ID: 2, URL: server.URL, Secret: recv.secret, Active: true, Events: []domain.EventType{domain.EventCentralDeleted}},
+		{ID: 3, URL: server.URL, Secret: recv.secret, Active: false},
+	}}
+	d := webhook.New(store, webhook.Options{MaxAttempts: 3, BaseBackoff: time.Second, MaxBackoff: time.Minute})
+	now := dispatchNow
+	d.Now = func() time.Time { return now }
+	// Sem jitter: a espera é sempre a metade fixa
+	d.Rand = func() float64 { return 0 }
+	return d, store, recv, &now
+}
+
+func createdEvent() domain.Event {
+	site := uint(4)
+	return domain.Event{Type: domain.EventCentralCreated, CentralID: 7, OccurredAt: dispatchNow, Central: &domain.Central{
+		ID: 7, Name: "Central 7", MAC: "00:11:22:33:44:55", IPv4: "10.0.0.7", SiteID: &site, Labels: map[string]string{"env": "prod"},
+	}}
+}
+
+func TestDeliverDue_Success(t *testing.T) {
+	d, store, recv, _ := setup(t, http.StatusNoContent)
+
+	require.NoError(t, d.Publish(createdEvent()))
+	// Só a assinatura ativa que recebe todos os eventos
+	require.Len(t, store.deliveries, 1)
+
+	sent, err := d.DeliverDue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+
+	delivery := store.deliveries[0]
+	assert.Equal(t, domain.DeliverySucceeded, delivery.Status)
+	assert.Equal(t, 1, delivery.Attempts)
+	assert.Equal(t, http.StatusNoContent, delivery.LastStatusCode)
+	assert.Nil(t, delivery.NextAttemptAt)
+	require.Len(t, store.attempts, 1)
+	assert.Empty(t, store.attempts[0].Error)
+
+	require.Len(t, recv.payloads, 1)
+	payload := recv.payloads[0]
+	assert.Equal(t, "central.created", payload.Type)
+	assert.Equal(t, uint(7), payload.CentralID)
+	var data webhook.CentralData
+	require.NoError(t, json.Unmarshal(payload.Data, &data))
+	assert.Equal(t, "10.0.0.7", data.IPv4)
+	assert.Equal(t, "prod", data.Labels["env"])
+	assert.Equal(t, "unknown", data.Status)
+
+	// Nada mais a enviar
+	sent, err = d.DeliverDue(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, sent)
+}
+
+func TestDeliverDue_RetriesThenSucceeds(t *testing.T) {
+	d, store, recv, now := setup(t, http.StatusInternalServerError, http.StatusOK)
+
+	change := domain.StatusChange{CentralID: 7, From: domain.StatusOnline, To: domain.StatusOffline, At: dispatchNow}
+	require.NoError(t, d.Publish(change.Event()))
+	_, err := d.DeliverDue(context.Background())
+	require.NoError(t, err)
+
+	delivery := store.deliveries[0]
+	assert.Equal(t, domain.DeliveryPending, delivery.Status)
+	assert.Equal(t, http.StatusInternalServerError, delivery.LastStatusCode)
+	assert.Equal(t, "unexpected status 500", delivery.LastError)
+	assert.Equal(t, dispatchNow.Add(500*time.Millisecond), *delivery.NextAttemptAt)
+
+	// Antes da hora, nada é reenviado
+	sent, _ := d.DeliverDue(context.Background())
+	assert.Zero(t, sent)
+
+	*now = now.Add(time.Second)
+	_, err = d.DeliverDue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, domain.DeliverySucceeded, store.deliveries[0].Status)
+	assert.Len(t, store.attempts, 2)
+	assert.EqualValues(t, 2, recv.calls.Load())
+
+	// O mesmo evento, com o mesmo ID, em todas as tentativas
+	assert.Equal(t, recv.payloads[0].ID, recv.payloads[1].ID)
+	var data webhook.StatusChangeData
+	require.NoError(t, json.Unmarshal(recv.payloads[1].Data, &data))
+	assert.Equal(t, "offline", data.To)
+}
+
+func TestDeliverDue_DeadLetter(t *testing.T) {
+	d, store, _, now := setup(t, http.StatusBadGateway)
+
+	require.NoError(t, d.Publish(createdEvent()))
+	for i := 0; i < 5; i++ {
+		_, err := d.DeliverDue(context.Background())
+		require.NoError(t, err)
+		*now = now.Add(time.Hour)
+	}
+
+	delivery := store.deliveries[0]
+	assert.Equal(t, domain.DeliveryDead, delivery.Status)
+	assert.Equal(t, 3, delivery.Attempts)
+	assert.Nil(t, delivery.NextAttemptAt)
+	assert.Len(t, store.attempts, 3)
+}
+
+func TestDeliverDue_Unreachable(t *testing.T) {
+	d, store, _, _ := setup(t, http.StatusOK)
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+	store.webhooks[0].URL = server.URL
+
+	require.NoError(t, d.Publish(createdEvent()))
+	_, err := d.DeliverDue(context.Background())
+	require.NoError(t, err)
+
+	delivery := store.deliveries[0]
+	assert.Equal(t, domain.DeliveryPending, delivery.Status)
+	assert.Zero(t, delivery.LastStatusCode)
+	assert.NotEmpty(t, delivery.LastError)
+}
+
+func TestDeliverDue_ManyDeliveries(t *testing.T) {
+	d, store, recv, _ := setup(t, http.StatusOK)
+	d.Options.BatchSize = 7
+
+	for i := 0; i < 30; i++ {
+		require.NoError(t, d.Publish(createdEvent()))
+	}
+	sent, err := d.DeliverDue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 30, sent)
+	assert.EqualValues(t, 30, recv.calls.Load())
+	for _, delivery := range store.deliveries {
+		assert.Equal(t, domain.DeliverySucceeded, delivery.Status)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	low := func() float64 { return 0 }
+	high := func() float64 { return 1 }
+
+	assert.Equal(t, 5*time.Second, webhook.Backoff(1, 10*time.Second, time.Hour, low))
+	assert.Equal(t, 10*time.Second, webhook.Backoff(1, 10*time.Second, time.Hour, high))
+	assert.Equal(t, 20*time.Second, webhook.Backoff(3, 10*time.Second, time.Hour, low))
+	assert.Equal(t, 40*time.Second, webhook.Backoff(3, 10*time.Second, time.Hour, high))
+	// Limitado ao máximo, mesmo após muitas falhas
+	assert.Equal(t, time.Hour, webhook.Backoff(100, 10*time.Second, time.Hour, high))
+	assert.Equal(t, 30*time.Minute, webhook.Backoff(100, 10*time.Second, time.Hour, low))
+
+	for attempt := 1; attempt <= 10; attempt++ {
+		d := webhook.Backoff(attempt, time.Second, time.Minute, func() float64 { return 0.5 })
+		assert.GreaterOrEqual(t, d, 500*time.Millisecond)
+		assert.LessOrEqual(t, d, time.Minute)
+	}
+}
+
+func TestSign(t *testing.T) {
+	body := []byte(`{"id":"1"}`)
+	signature := webhook.Sign("secret", 1700000000, body)
+	assert.Len(t, signature, 64)
+	assert.True(t, webhook.Verify("secret", 1700000000, body, "sha256="+signature))
+	assert.False(t, webhook.Verify("other", 1700000000, body, "sha256="+signature))
+	assert.False(t, webhook.Verify("secret", 1700000001, body, "sha256="+signature))
+}
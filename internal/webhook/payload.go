@@ -0,0 +1,98 @@
+package webhook
+
+import (
+	"api-golang/internal/domain"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+// Cabeçalhos enviados em cada entrega
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// Corpo das entregas
+type Payload struct {
+	ID         string          `json:"id"`
+	Type       string          `json:"type"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	CentralID  uint            `json:"central_id"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// Estado da central enviado nos eventos de ciclo de vida
+type CentralData struct {
+	ID         uint              `json:"id"`
+	Name       string            `json:"name"`
+	MAC        string            `json:"mac"`
+	IPv4       string            `json:"ipv4,omitempty"`
+	IPv6       string            `json:"ipv6,omitempty"`
+	Vendor     string            `json:"vendor,omitempty"`
+	SiteID     *uint             `json:"site_id,omitempty"`
+	LocationID *uint             `json:"location_id,omitempty"`
+	Labels     map[string]string `json:"labels,omitempty"`
+	Status     string            `json:"status"`
+}
+
+// Dados dos eventos de mudança de situação
+type StatusChangeData struct {
+	CentralID uint      `json:"central_id"`
+	From      string    `json:"from"`
+	To        string    `json:"to"`
+	At        time.Time `json:"at"`
+}
+
+// Monta o corpo da entrega do evento
+func Encode(id string, event domain.Event) ([]byte, error) {
+	var data any
+	switch {
+	case event.StatusChange != nil:
+		c := event.StatusChange
+		data = StatusChangeData{CentralID: c.CentralID, From: string(c.From), To: string(c.To), At: c.At.UTC()}
+	case event.Central != nil:
+		c := event.Central
+		status := c.Status.Status
+		if status == "" {
+			status = domain.StatusUnknown
+		}
+		data = CentralData{ID: c.ID, Name: c.Name, MAC: c.MAC, IPv4: c.IPv4, IPv6: c.IPv6, Vendor: c.Vendor,
+			SiteID: c.SiteID, LocationID: c.LocationID, Labels: c.Labels, Status: string(status)}
+	}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(Payload{ID: id, Type: string(event.Type), OccurredAt: event.OccurredAt.UTC(),
+		CentralID: event.CentralID, Data: raw})
+}
+
+// Assinatura HMAC-SHA256, em hexadecimal, de "<timestamp>.<corpo>". O
+// timestamp entra na assinatura para que o receptor recuse reenvios antigos.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Confere o cabeçalho de assinatura ("sha256=<hex>") recebido
+func Verify(secret string, timestamp int64, body []byte, header string) bool {
+	expected := "sha256=" + Sign(secret, timestamp, body)
+	return hmac.Equal([]byte(expected), []byte(header))
+}
+
+// Identificador aleatório de 128 bits, em hexadecimal
+func NewID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,199 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+type AlertRuleKind string
+
+const (
+	AlertKindStatus   AlertRuleKind = "status"
+	AlertKindMetric   AlertRuleKind = "metric"
+	AlertKindFirmware AlertRuleKind = "firmware"
+)
+
+type AlertSeverity string
+
+const (
+	SeverityInfo     AlertSeverity = "info"
+	SeverityWarning  AlertSeverity = "warning"
+	SeverityCritical AlertSeverity = "critical"
+)
+
+type AlertState string
+
+const (
+	AlertPending  AlertState = "pending"
+	AlertFiring   AlertState = "firing"
+	AlertResolved AlertState = "resolved"
+)
+
+// Corpo da criação e atualização de uma regra. Os campos usados dependem do
+// tipo: Status para status; Metric, Aggregation, Operator, Threshold e
+// WindowSeconds para metric; MinFirmware para firmware.
+type AlertRuleRequest struct {
+	Name       string        `json:"name"`
+	Kind       AlertRuleKind `json:"kind"`
+	Severity   AlertSeverity `json:"severity,omitempty"`
+	Enabled    *bool         `json:"enabled,omitempty"`
+	Selector   string        `json:"selector,omitempty"`
+	ForSeconds int           `json:"for_seconds,omitempty"`
+
+	Status ReachabilityStatus `json:"status,omitempty"`
+
+	Metric        string  `json:"metric,omitempty"`
+	Aggregation   string  `json:"aggregation,omitempty"`
+	Operator      string  `json:"operator,omitempty"`
+	Threshold     float64 `json:"threshold,omitempty"`
+	WindowSeconds int     `json:"window_seconds,omitempty"`
+
+	MinFirmware string `json:"min_firmware,omitempty"`
+
+	// Regras silenciadas enquanto esta dispara para a mesma central
+	Inhibits []uint `json:"inhibits,omitempty"`
+}
+
+type AlertRule struct {
+	ID         uint          `json:"id"`
+	CreatedAt  time.Time     `json:"created_at"`
+	UpdatedAt  time.Time     `json:"updated_at"`
+	Name       string        `json:"name"`
+	Kind       AlertRuleKind `json:"kind"`
+	Severity   AlertSeverity `json:"severity"`
+	Enabled    bool          `json:"enabled"`
+	Selector   string        `json:"selector,omitempty"`
+	ForSeconds int64         `json:"for_seconds"`
+
+	Status ReachabilityStatus `json:"status,omitempty"`
+
+	Metric        string   `json:"metric,omitempty"`
+	Aggregation   string   `json:"aggregation,omitempty"`
+	Operator      string   `json:"operator,omitempty"`
+	Threshold     *float64 `json:"threshold,omitempty"`
+	WindowSeconds int64    `json:"window_seconds,omitempty"`
+
+	MinFirmware string `json:"min_firmware,omitempty"`
+
+	Inhibits []uint `json:"inhibits"`
+}
+
+type Alert struct {
+	ID        uint          `json:"id"`
+	RuleID    uint          `json:"rule_id"`
+	CentralID uint          `json:"central_id"`
+	State     AlertState    `json:"state"`
+	Severity  AlertSeverity `json:"severity"`
+	Summary   string        `json:"summary"`
+	Value     *float64      `json:"value,omitempty"`
+
+	ActiveSince     time.Time  `json:"active_since"`
+	FiredAt         *time.Time `json:"fired_at,omitempty"`
+	ResolvedAt      *time.Time `json:"resolved_at,omitempty"`
+	LastEvaluatedAt time.Time  `json:"last_evaluated_at"`
+
+	Silenced  bool `json:"silenced"`
+	Inhibited bool `json:"inhibited"`
+}
+
+// Filtros de ListAlerts; sem State, lista os ativos (pendentes e disparados)
+type AlertFilter struct {
+	State     AlertState
+	RuleID    uint
+	CentralID uint
+}
+
+// Silêncio por regra, por central ou pelos dois
+type SilenceRequest struct {
+	RuleID    *uint      `json:"rule_id,omitempty"`
+	CentralID *uint      `json:"central_id,omitempty"`
+	StartsAt  *time.Time `json:"starts_at,omitempty"`
+	EndsAt    time.Time  `json:"ends_at"`
+	Comment   string     `json:"comment,omitempty"`
+	CreatedBy string     `json:"created_by,omitempty"`
+}
+
+type Silence struct {
+	ID        uint      `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	RuleID    *uint     `json:"rule_id,omitempty"`
+	CentralID *uint     `json:"central_id,omitempty"`
+	StartsAt  time.Time `json:"starts_at"`
+	EndsAt    time.Time `json:"ends_at"`
+	Comment   string    `json:"comment,omitempty"`
+	CreatedBy string    `json:"created_by,omitempty"`
+}
+
+func (c *Client) CreateAlertRule(ctx context.Context, req AlertRuleRequest) (*AlertRule, error) {
+	var rule AlertRule
+	if _, err := c.do(ctx, http.MethodPost, "/alerts/rules", nil, req, &rule); err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (c *Client) ListAlertRules(ctx context.Context) ([]AlertRule, error) {
+	var rules []AlertRule
+	if _, err := c.do(ctx, http.MethodGet, "/alerts/rules", nil, nil, &rules); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+func (c *Client) GetAlertRule(ctx context.Context, ruleID uint) (*AlertRule, error) {
+	var rule AlertRule
+	if _, err := c.do(ctx, http.MethodGet, "/alerts/rules/"+pathID(ruleID), nil, nil, &rule); err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (c *Client) UpdateAlertRule(ctx context.Context, ruleID uint, req AlertRuleRequest) (*AlertRule, error) {
+	var rule AlertRule
+	if _, err := c.do(ctx, http.MethodPut, "/alerts/rules/"+pathID(ruleID), nil, req, &rule); err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (c *Client) DeleteAlertRule(ctx context.Context, ruleID uint) error {
+	_, err := c.do(ctx, http.MethodDelete, "/alerts/rules/"+pathID(ruleID), nil, nil, nil)
+	return err
+}
+
+func (c *Client) ListAlerts(ctx context.Context, filter AlertFilter) ([]Alert, error) {
+	query := url.Values{}
+	setString(query, "state", string(filter.State))
+	setID(query, "rule_id", filter.RuleID)
+	setID(query, "central_id", filter.CentralID)
+	var alerts []Alert
+	if _, err := c.do(ctx, http.MethodGet, "/alerts", query, nil, &alerts); err != nil {
+		return nil, err
+	}
+	return alerts, nil
+}
+
+func (c *Client) CreateSilence(ctx context.Context, req SilenceRequest) (*Silence, error) {
+	var silence Silence
+	if _, err := c.do(ctx, http.MethodPost, "/alerts/silences", nil, req, &silence); err != nil {
+		return nil, err
+	}
+	return &silence, nil
+}
+
+// Silêncios vigentes e agendados
+func (c *Client) ListSilences(ctx context.Context) ([]Silence, error) {
+	var silences []Silence
+	if _, err := c.do(ctx, http.MethodGet, "/alerts/silences", nil, nil, &silences); err != nil {
+		return nil, err
+	}
+	return silences, nil
+}
+
+// Encerra o silêncio agora
+func (c *Client) ExpireSilence(ctx context.Context, silenceID uint) error {
+	_, err := c.do(ctx, http.MethodDelete, "/alerts/silences/"+pathID(silenceID), nil, nil, nil)
+	return err
+}
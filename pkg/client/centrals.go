@@ -0,0 +1,292 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Situação de alcance da central
+type ReachabilityStatus string
+
+const (
+	StatusUnknown  ReachabilityStatus = "unknown"
+	StatusOnline   ReachabilityStatus = "online"
+	StatusOffline  ReachabilityStatus = "offline"
+	StatusDegraded ReachabilityStatus = "degraded"
+)
+
+type Central struct {
+	ID          uint      `json:"id"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	Name        string    `json:"name"`
+	MAC         string    `json:"mac"`
+	IP          string    `json:"ip"`
+	IPv4        string    `json:"ipv4,omitempty"`
+	IPv6        string    `json:"ipv6,omitempty"`
+	Notes       string    `json:"notes,omitempty"`
+	Vendor      string    `json:"vendor,omitempty"`
+	MACWarnings []string  `json:"mac_warnings,omitempty"`
+
+	SiteID     *uint `json:"site_id,omitempty"`
+	LocationID *uint `json:"location_id,omitempty"`
+
+	Labels map[string]string `json:"labels"`
+
+	Status     ReachabilityStatus `json:"status"`
+	LastSeenAt *time.Time         `json:"last_seen_at,omitempty"`
+	RTTMillis  *float64           `json:"rtt_ms,omitempty"`
+
+	HeartbeatWindowSeconds int        `json:"heartbeat_window_seconds,omitempty"`
+	LastHeartbeatAt        *time.Time `json:"last_heartbeat_at,omitempty"`
+	FirmwareVersion        string     `json:"firmware_version,omitempty"`
+
+	PrimaryInterface *Interface `json:"primary_interface,omitempty"`
+}
+
+type CreateCentralRequest struct {
+	Name string `json:"name"`
+	MAC  string `json:"mac"`
+	// IP em qualquer família, ou IPv4/IPv6 separados
+	IP   string `json:"ip,omitempty"`
+	IPv4 string `json:"ipv4,omitempty"`
+	IPv6 string `json:"ipv6,omitempty"`
+	// Aloca o próximo IP livre do pool em vez de informar o endereço
+	PoolID *uint  `json:"pool_id,omitempty"`
+	Notes  string `json:"notes,omitempty"`
+	// Basta informar a localização; o site é deduzido dela
+	SiteID                 *uint             `json:"site_id,omitempty"`
+	LocationID             *uint             `json:"location_id,omitempty"`
+	Labels                 map[string]string `json:"labels,omitempty"`
+	HeartbeatWindowSeconds int               `json:"heartbeat_window_seconds,omitempty"`
+}
+
+// Substitui a central inteira. Labels nil mantém os atuais; um mapa vazio
+// remove todos.
+type UpdateCentralRequest struct {
+	Name                   string            `json:"name"`
+	MAC                    string            `json:"mac"`
+	IP                     string            `json:"ip,omitempty"`
+	IPv4                   string            `json:"ipv4,omitempty"`
+	IPv6                   string            `json:"ipv6,omitempty"`
+	Notes                  string            `json:"notes,omitempty"`
+	SiteID                 *uint             `json:"site_id,omitempty"`
+	LocationID             *uint             `json:"location_id,omitempty"`
+	Labels                 map[string]string `json:"labels,omitempty"`
+	HeartbeatWindowSeconds int               `json:"heartbeat_window_seconds,omitempty"`
+}
+
+// Envia labels vazios, que o omitempty descartaria
+func (r UpdateCentralRequest) MarshalJSON() ([]byte, error) {
+	type plain UpdateCentralRequest
+	body := struct {
+		plain
+		Labels *map[string]string `json:"labels,omitempty"`
+	}{plain: plain(r)}
+	if r.Labels != nil {
+		body.Labels = &r.Labels
+	}
+	return json.Marshal(body)
+}
+
+// Filtros de GET /centrals; campos vazios não filtram
+type CentralFilter struct {
+	SiteID     uint
+	LocationID uint
+	// Inclui as localizações descendentes da localização
+	Recursive bool
+	// Apenas as centrais sem localização
+	Unplaced bool
+	// Seletor de labels, ex.: "env=prod,tier in (a,b)"
+	Selector string
+	Status   ReachabilityStatus
+	// Parte do nome do fabricante
+	Vendor string
+	IP     string
+	// Faixa em CIDR, ou IPFrom/IPTo
+	Subnet string
+	IPFrom string
+	IPTo   string
+}
+
+func (f CentralFilter) query(query url.Values) url.Values {
+	setID(query, "site_id", f.SiteID)
+	setID(query, "location_id", f.LocationID)
+	if f.Recursive {
+		query.Set("recursive", "true")
+	}
+	if f.Unplaced {
+		query.Set("unplaced", "true")
+	}
+	setString(query, "selector", f.Selector)
+	setString(query, "status", string(f.Status))
+	setString(query, "vendor", f.Vendor)
+	setString(query, "ip", f.IP)
+	setString(query, "subnet", f.Subnet)
+	setString(query, "ip_from", f.IPFrom)
+	setString(query, "ip_to", f.IPTo)
+	return query
+}
+
+// Página da listagem: até Limit itens com ID maior que AfterID
+type Page struct {
+	Limit   int
+	AfterID uint
+}
+
+type CentralPage struct {
+	Centrals []Central
+	// Cursor da próxima página; zero na última
+	NextAfterID uint
+}
+
+// Tamanho de página do AllCentrals quando não informado
+const DefaultPageSize = 100
+
+func (c *Client) CreateCentral(ctx context.Context, req CreateCentralRequest) (*Central, error) {
+	var central Central
+	if _, err := c.do(ctx, http.MethodPost, "/central", c.macQuery(), req, &central); err != nil {
+		return nil, err
+	}
+	return &central, nil
+}
+
+// Lista todas as centrais do filtro, sem paginação
+func (c *Client) ListCentrals(ctx context.Context, filter CentralFilter) ([]Central, error) {
+	var centrals []Central
+	if _, err := c.do(ctx, http.MethodGet, "/centrals", filter.query(c.macQuery()), nil, &centrals); err != nil {
+		return nil, err
+	}
+	return centrals, nil
+}
+
+// Uma página da listagem, em ordem de ID
+func (c *Client) ListCentralsPage(ctx context.Context, filter CentralFilter, page Page) (*CentralPage, error) {
+	query := filter.query(c.macQuery())
+	if page.Limit > 0 {
+		query.Set("limit", strconv.Itoa(page.Limit))
+	}
+	setID(query, "after_id", page.AfterID)
+
+	result := &CentralPage{}
+	header, err := c.do(ctx, http.MethodGet, "/centrals", query, nil, &result.Centrals)
+	if err != nil {
+		return nil, err
+	}
+	result.NextAfterID = nextAfterID(header)
+	return result, nil
+}
+
+// Percorre as centrais do filtro página a página. Um erro encerra a
+// iteração depois de entregue.
+//
+//	for central, err := range c.AllCentrals(ctx, filter, 0) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+func (c *Client) AllCentrals(ctx context.Context, filter CentralFilter, pageSize int) iter.Seq2[Central, error] {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	return func(yield func(Central, error) bool) {
+		page := Page{Limit: pageSize}
+		for {
+			result, err := c.ListCentralsPage(ctx, filter, page)
+			if err != nil {
+				yield(Central{}, err)
+				return
+			}
+			for _, central := range result.Centrals {
+				if !yield(central, nil) {
+					return
+				}
+			}
+			if result.NextAfterID == 0 {
+				return
+			}
+			page.AfterID = result.NextAfterID
+		}
+	}
+}
+
+func (c *Client) GetCentral(ctx context.Context, centralID uint) (*Central, error) {
+	var central Central
+	if _, err := c.do(ctx, http.MethodGet, "/central/"+pathID(centralID), c.macQuery(), nil, &central); err != nil {
+		return nil, err
+	}
+	return &central, nil
+}
+
+func (c *Client) UpdateCentral(ctx context.Context, centralID uint, req UpdateCentralRequest) (*Central, error) {
+	var central Central
+	if _, err := c.do(ctx, http.MethodPut, "/central/"+pathID(centralID), c.macQuery(), req, &central); err != nil {
+		return nil, err
+	}
+	return &central, nil
+}
+
+func (c *Client) DeleteCentral(ctx context.Context, centralID uint) error {
+	_, err := c.do(ctx, http.MethodDelete, "/central/"+pathID(centralID), nil, nil, nil)
+	return err
+}
+
+// Central encontrada pela busca textual, com a relevância
+type CentralSearchResult struct {
+	Central
+	Score float64 `json:"score"`
+}
+
+// Busca textual em nome, notas, endereços, fabricante e labels; limit zero
+// usa o padrão do servidor
+func (c *Client) SearchCentrals(ctx context.Context, q string, limit int) ([]CentralSearchResult, error) {
+	query := c.macQuery()
+	query.Set("q", q)
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+	var results []CentralSearchResult
+	if _, err := c.do(ctx, http.MethodGet, "/centrals/search", query, nil, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// after_id do link rel="next", ex.: </centrals?after_id=12&limit=2>; rel="next"
+func nextAfterID(header http.Header) uint {
+	for _, link := range header.Values("Link") {
+		for _, part := range strings.Split(link, ",") {
+			target, params, ok := strings.Cut(part, ";")
+			if !ok || !strings.Contains(params, `rel="next"`) {
+				continue
+			}
+			u, err := url.Parse(strings.Trim(strings.TrimSpace(target), "<>"))
+			if err != nil {
+				continue
+			}
+			if id, err := strconv.ParseUint(u.Query().Get("after_id"), 10, 0); err == nil {
+				return uint(id)
+			}
+		}
+	}
+	return 0
+}
+
+func setID(query url.Values, key string, value uint) {
+	if value != 0 {
+		query.Set(key, pathID(value))
+	}
+}
+
+func setString(query url.Values, key, value string) {
+	if value != "" {
+		query.Set(key, value)
+	}
+}
@@ -0,0 +1,257 @@
+// Package client é o cliente Go da API de centrais: métodos tipados para cada
+// rota, contexto em todas as chamadas, novas tentativas com backoff nas
+// chamadas idempotentes, iteradores de paginação e erros decodificados em
+// valores tipados.
+//
+//	c, err := client.New("http://localhost:8080", client.Options{Token: "s3cr3t"})
+//	central, err := c.GetCentral(ctx, 12)
+//	if errors.Is(err, client.ErrNotFound) { ... }
+//
+//	for central, err := range c.AllCentrals(ctx, client.CentralFilter{Status: client.StatusOffline}, 0) {
+//		...
+//	}
+//
+// O pacote não depende dos pacotes internos do servidor; os tipos espelham o
+// JSON descrito em internal/openapi/openapi.yaml.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Options struct {
+	// Cliente HTTP usado nas chamadas. Prefira prazos por contexto: um
+	// Timeout no cliente também encerra os streams de eventos.
+	HTTPClient *http.Client
+	// Enviado como "Authorization: Bearer"
+	Token     string
+	UserAgent string
+	// Formato do MAC nas respostas que aceitam ?mac_format= (colon, hyphen,
+	// dot ou bare); vazio usa o padrão do servidor
+	MACFormat string
+
+	// Novas tentativas das chamadas idempotentes após falha de rede, 429 ou
+	// 502/503/504; negativo desativa
+	MaxRetries int
+	// Espera após a primeira falha, dobrada a cada nova falha até o máximo
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+}
+
+// Padrões para as opções não informadas
+var DefaultOptions = Options{
+	UserAgent:   "central-go-client",
+	MaxRetries:  3,
+	BaseBackoff: 100 * time.Millisecond,
+	MaxBackoff:  5 * time.Second,
+}
+
+type Client struct {
+	baseURL *url.URL
+	http    *http.Client
+	opts    Options
+
+	// Sorteio do jitter e espera entre tentativas, substituíveis nos testes
+	Rand  func() float64
+	Sleep func(ctx context.Context, d time.Duration) error
+}
+
+// Cria o cliente para a URL base da API, ex.: https://centrals.example.com
+func New(baseURL string, opts Options) (*Client, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid base URL %q", baseURL)
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/")
+
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = &http.Client{}
+	}
+	if opts.UserAgent == "" {
+		opts.UserAgent = DefaultOptions.UserAgent
+	}
+	if opts.MaxRetries == 0 {
+		opts.MaxRetries = DefaultOptions.MaxRetries
+	}
+	if opts.BaseBackoff <= 0 {
+		opts.BaseBackoff = DefaultOptions.BaseBackoff
+	}
+	if opts.MaxBackoff < opts.BaseBackoff {
+		opts.MaxBackoff = max(DefaultOptions.MaxBackoff, opts.BaseBackoff)
+	}
+	return &Client{baseURL: u, http: opts.HTTPClient, opts: opts, Rand: rand.Float64, Sleep: sleep}, nil
+}
+
+// Executa a chamada e decodifica a resposta JSON em out. Métodos idempotentes
+// são repetidos em falhas transitórias; POST nunca, para não duplicar efeitos.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out any) (http.Header, error) {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return nil, err
+		}
+	}
+
+	retries := 0
+	if idempotent(method) {
+		retries = max(c.opts.MaxRetries, 0)
+	}
+	for attempt := 1; ; attempt++ {
+		resp, err := c.send(ctx, method, path, query, payload)
+		if err != nil {
+			if attempt > retries || !retryableError(ctx, err) {
+				return nil, err
+			}
+			if err := c.Sleep(ctx, c.backoff(attempt, 0)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if resp.StatusCode >= 300 {
+			apiErr := decodeError(method, path, resp)
+			if attempt > retries || !retryableStatus(resp.StatusCode) {
+				return nil, apiErr
+			}
+			if err := c.Sleep(ctx, c.backoff(attempt, retryAfter(resp.Header))); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		defer resp.Body.Close()
+		if out == nil || resp.StatusCode == http.StatusNoContent {
+			io.Copy(io.Discard, resp.Body)
+			return resp.Header, nil
+		}
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return nil, fmt.Errorf("%s %s: invalid response: %w", method, path, err)
+		}
+		return resp.Header, nil
+	}
+}
+
+// Envia uma tentativa; o corpo da resposta fica por conta de quem chama
+func (c *Client) send(ctx context.Context, method, path string, query url.Values, payload []byte) (*http.Response, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.url(path, query), body)
+	if err != nil {
+		return nil, err
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+	c.setHeaders(req.Header)
+	return c.http.Do(req)
+}
+
+func (c *Client) setHeaders(h http.Header) {
+	if c.opts.Token != "" {
+		h.Set("Authorization", "Bearer "+c.opts.Token)
+	}
+	h.Set("User-Agent", c.opts.UserAgent)
+}
+
+func (c *Client) url(path string, query url.Values) string {
+	u := *c.baseURL
+	u.Path += path
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+// Query com o formato de MAC configurado, para as rotas que o aceitam
+func (c *Client) macQuery() url.Values {
+	query := url.Values{}
+	if c.opts.MACFormat != "" {
+		query.Set("mac_format", c.opts.MACFormat)
+	}
+	return query
+}
+
+// Espera da tentativa: o Retry-After do servidor ou o backoff exponencial
+// com jitter entre metade e o valor cheio
+func (c *Client) backoff(attempt int, retryAfter time.Duration) time.Duration {
+	if retryAfter > 0 {
+		return min(retryAfter, c.opts.MaxBackoff)
+	}
+	d := c.opts.BaseBackoff
+	for i := 1; i < attempt && d < c.opts.MaxBackoff; i++ {
+		d *= 2
+	}
+	d = min(d, c.opts.MaxBackoff)
+	half := d / 2
+	return half + time.Duration(c.Rand()*float64(d-half))
+}
+
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+func retryableStatus(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// Falhas de rede são repetidas; cancelamento e prazo do contexto, não
+func retryableError(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF)
+}
+
+// Retry-After em segundos ou como data HTTP
+func retryAfter(h http.Header) time.Duration {
+	value := h.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return time.Until(at)
+	}
+	return 0
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Segmento de caminho com um ID
+func pathID(value uint) string {
+	return strconv.FormatUint(uint64(value), 10)
+}
@@ -0,0 +1,165 @@
+package client_test
+
+import (
+	"api-golang/pkg/client"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Servidor que responde com os status informados, um por chamada, e 200 com
+// uma central depois deles
+func statusServer(t *testing.T, header http.Header, statuses ...int) (string, *atomic.Int32) {
+	calls := &atomic.Int32{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(calls.Add(1))
+		for key, values := range header {
+			w.Header()[key] = values
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if n <= len(statuses) {
+			w.WriteHeader(statuses[n-1])
+			w.Write([]byte(`{"error":"try again"}`))
+			return
+		}
+		w.Write([]byte(`{"id":1,"name":"Central 1","mac":"00:11:22:33:44:55","ip":"10.0.0.1","labels":{},"status":"online"}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL, calls
+}
+
+// Cliente que anota as esperas em vez de dormir
+func recordingClient(t *testing.T, url string, opts client.Options) (*client.Client, *[]time.Duration) {
+	c, err := client.New(url, opts)
+	require.NoError(t, err)
+	var waits []time.Duration
+	c.Rand = func() float64 { return 1 }
+	c.Sleep = func(ctx context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return ctx.Err()
+	}
+	return c, &waits
+}
+
+func TestNew_InvalidURL(t *testing.T) {
+	for _, url := range []string{"", "localhost:8080", "ftp://example.com", "http://"} {
+		_, err := client.New(url, client.Options{})
+		assert.Error(t, err, url)
+	}
+}
+
+func TestRetry_IdempotentCalls(t *testing.T) {
+	url, calls := statusServer(t, nil, http.StatusServiceUnavailable, http.StatusBadGateway)
+	c, waits := recordingClient(t, url, client.Options{BaseBackoff: 100 * time.Millisecond})
+
+	central, err := c.GetCentral(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, "Central 1", central.Name)
+	assert.Equal(t, int32(3), calls.Load())
+	// Backoff exponencial
+	assert.Equal(t, []time.Duration{100 * time.Millisecond, 200 * time.Millisecond}, *waits)
+}
+
+func TestRetry_GivesUpAfterMaxRetries(t *testing.T) {
+	url, calls := statusServer(t, nil, 503, 503, 503)
+	c, _ := recordingClient(t, url, client.Options{MaxRetries: 2})
+
+	_, err := c.GetCentral(context.Background(), 1)
+	assert.ErrorIs(t, err, client.ErrUnavailable)
+	assert.Equal(t, int32(3), calls.Load())
+
+	var apiErr *client.Error
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "try again", apiErr.Message)
+	assert.Equal(t, "GET /central/1: try again (HTTP 503)", err.Error())
+}
+
+func TestRetry_HonoursRetryAfter(t *testing.T) {
+	url, _ := statusServer(t, http.Header{"Retry-After": {"2"}}, http.StatusTooManyRequests)
+	c, waits := recordingClient(t, url, client.Options{})
+
+	require.NoError(t, c.DeleteCentral(context.Background(), 1))
+	assert.Equal(t, []time.Duration{2 * time.Second}, *waits)
+}
+
+func TestRetry_PostIsNotRetried(t *testing.T) {
+	url, calls := statusServer(t, nil, http.StatusServiceUnavailable)
+	c, waits := recordingClient(t, url, client.Options{})
+
+	_, err := c.CreateCentral(context.Background(), client.CreateCentralRequest{Name: "Central 1", MAC: "00:11:22:33:44:55", IP: "10.0.0.1"})
+	assert.ErrorIs(t, err, client.ErrUnavailable)
+	assert.Equal(t, int32(1), calls.Load())
+	assert.Empty(t, *waits)
+}
+
+func TestRetry_ClientErrorsAreNotRetried(t *testing.T) {
+	url, calls := statusServer(t, nil, http.StatusNotFound)
+	c, _ := recordingClient(t, url, client.Options{})
+
+	_, err := c.GetCentral(context.Background(), 1)
+	assert.ErrorIs(t, err, client.ErrNotFound)
+	assert.False(t, errors.Is(err, client.ErrUnavailable))
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestRetry_NetworkErrors(t *testing.T) {
+	// Porta fechada: falha de rede em todas as tentativas
+	c, waits := recordingClient(t, "http://127.0.0.1:1", client.Options{MaxRetries: 2})
+
+	_, err := c.ListCentrals(context.Background(), client.CentralFilter{})
+	require.Error(t, err)
+	assert.Len(t, *waits, 2)
+}
+
+func TestRetry_StopsWhenContextIsCanceled(t *testing.T) {
+	url, calls := statusServer(t, nil, 503, 503, 503)
+	c, err := client.New(url, client.Options{BaseBackoff: time.Hour})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = c.GetCentral(ctx, 1)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestError_NonJSONBody(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "upstream timeout", http.StatusGatewayTimeout)
+	}))
+	defer srv.Close()
+	c, _ := recordingClient(t, srv.URL, client.Options{MaxRetries: -1})
+
+	_, err := c.ListSites(context.Background())
+	var apiErr *client.Error
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusGatewayTimeout, apiErr.StatusCode)
+	assert.Equal(t, "upstream timeout", apiErr.Message)
+}
+
+func TestRequest_TokenAndMACFormat(t *testing.T) {
+	var got *http.Request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+	c, err := client.New(srv.URL+"/api/", client.Options{Token: "s3cret", MACFormat: "dot"})
+	require.NoError(t, err)
+
+	_, err = c.ListCentrals(context.Background(), client.CentralFilter{SiteID: 3, Recursive: true, Status: client.StatusOffline})
+	require.NoError(t, err)
+	assert.Equal(t, "/api/centrals", got.URL.Path)
+	assert.Equal(t, "dot", got.URL.Query().Get("mac_format"))
+	assert.Equal(t, "3", got.URL.Query().Get("site_id"))
+	assert.Equal(t, "true", got.URL.Query().Get("recursive"))
+	assert.Equal(t, "offline", got.URL.Query().Get("status"))
+	assert.Equal(t, "Bearer s3cret", got.Header.Get("Authorization"))
+}
@@ -0,0 +1,667 @@
+package client_test
+
+import (
+	"api-golang/internal/config"
+	"api-golang/internal/domain"
+	"api-golang/internal/graphapi"
+	"api-golang/internal/handler"
+	"api-golang/internal/monitor"
+	"api-golang/internal/openapi"
+	"api-golang/internal/oui"
+	"api-golang/internal/outbox"
+	"api-golang/internal/repository"
+	"api-golang/internal/secrets"
+	"api-golang/internal/stream"
+	"api-golang/internal/usecase"
+	"api-golang/internal/webhook"
+	"api-golang/pkg/client"
+	"bytes"
+	"context"
+	"net"
+	"net/netip"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const contractToken = "s3cret"
+
+// API completa, montada como em cmd/main.go, sobre um banco SQLite
+// temporário e com validação das requisições e respostas pelo contrato
+type contractServer struct {
+	URL       string
+	relay     *outbox.Relay
+	alerts    *usecase.AlertUseCase
+	snmp      *repository.SNMPRepository
+	discovery *repository.DiscoveryRepository
+
+	doc        *openapi3.T
+	mu         sync.Mutex
+	operations map[string]bool
+}
+
+func startContractServer(t *testing.T) *contractServer {
+	db, err := config.OpenDB(filepath.Join(t.TempDir(), "contract.db"))
+	require.NoError(t, err)
+	require.NoError(t, repository.Migrate(db))
+	doc, err := openapi.Load()
+	require.NoError(t, err)
+	validator, err := openapi.Middleware(doc, openapi.Options{ValidateResponses: true})
+	require.NoError(t, err)
+
+	vendors := oui.Embedded()
+	repo := repository.NewCentralRepository(db)
+	uc := usecase.NewCentralUseCase(repo)
+	uc.Vendors = vendors
+	outboxRepo := repository.NewOutboxRepository(db)
+	hub := stream.New(outboxRepo, stream.Options{})
+	webhookRepo := repository.NewWebhookRepository(db)
+	interfaceUC := usecase.NewNetworkInterfaceUseCase(repository.NewNetworkInterfaceRepository(db))
+	interfaceUC.Vendors = vendors
+	siteUC := usecase.NewSiteUseCase(repository.NewSiteRepository(db), repo)
+	telemetryRepo := repository.NewTelemetryRepository(db)
+	telemetryUC := usecase.NewTelemetryUseCase(telemetryRepo, usecase.TelemetryRetention{})
+	heartbeatUC := usecase.NewHeartbeatUseCase(repository.NewHeartbeatRepository(db), 5*time.Minute)
+	heartbeatUC.Telemetry = telemetryRepo
+	box, err := secrets.NewBox(bytes.Repeat([]byte{7}, secrets.KeySize))
+	require.NoError(t, err)
+
+	s := &contractServer{
+		relay: outbox.New(outboxRepo, []outbox.Sink{
+			&outbox.WebhookSink{Dispatcher: webhook.New(webhookRepo, webhook.Options{})},
+		}, outbox.Options{}),
+		alerts:     usecase.NewAlertUseCase(repository.NewAlertRepository(db), repo, telemetryRepo),
+		snmp:       repository.NewSNMPRepository(db, box),
+		discovery:  repository.NewDiscoveryRepository(db),
+		doc:        doc,
+		operations: map[string]bool{},
+	}
+
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	recorder, err := s.recorder()
+	require.NoError(t, err)
+	app.Use(recorder)
+	// O 101 do WebSocket não passa pela validação de respostas
+	handler.RegisterWebSocketRoutes(app, handler.NewWebSocketHandler(uc, hub, []string{contractToken}))
+	app.Use(validator)
+	handler.RegisterWebhookRoutes(app, handler.NewWebhookHandler(usecase.NewWebhookUseCase(webhookRepo)))
+	handler.RegisterCentralRoutes(app, handler.NewCentralHandler(uc))
+	handler.RegisterEventRoutes(app, handler.NewEventHandler(hub))
+	handler.RegisterInterfaceRoutes(app, handler.NewNetworkInterfaceHandler(interfaceUC))
+	handler.RegisterOUIRoutes(app, handler.NewOUIHandler(usecase.NewOUIUseCase(vendors)))
+	handler.RegisterSearchRoutes(app, handler.NewSearchHandler(usecase.NewSearchUseCase(repository.NewSearchRepository(db))))
+	handler.RegisterLabelRoutes(app, handler.NewLabelHandler(usecase.NewLabelUseCase(repository.NewLabelRepository(db))))
+	handler.RegisterIPAMRoutes(app, handler.NewIPAMHandler(usecase.NewIPAMUseCase(repository.NewIPAMRepository(db))))
+	handler.RegisterSiteRoutes(app, handler.NewSiteHandler(siteUC))
+	graphapi.RegisterRoutes(app, graphapi.New(uc, siteUC, interfaceUC, graphapi.Options{}))
+	handler.RegisterTelemetryRoutes(app, handler.NewTelemetryHandler(telemetryUC))
+	handler.RegisterHeartbeatRoutes(app, handler.NewHeartbeatHandler(heartbeatUC))
+	handler.RegisterAlertRoutes(app, handler.NewAlertHandler(s.alerts))
+	handler.RegisterSNMPRoutes(app, handler.NewSNMPHandler(usecase.NewSNMPUseCase(s.snmp)))
+	handler.RegisterDiscoveryRoutes(app, handler.NewDiscoveryHandler(usecase.NewDiscoveryUseCase(s.discovery, uc)))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go monitor.RunEvery(ctx, 10*time.Millisecond, "Event stream", func() error {
+		_, err := hub.Poll()
+		return err
+	})
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go app.Listener(ln)
+	t.Cleanup(func() {
+		cancel()
+		app.ShutdownWithTimeout(time.Second)
+	})
+	s.URL = "http://" + ln.Addr().String()
+	return s
+}
+
+// Anota a operação do contrato de cada requisição recebida
+func (s *contractServer) recorder() (fiber.Handler, error) {
+	router, err := gorillamux.NewRouter(s.doc)
+	if err != nil {
+		return nil, err
+	}
+	return func(c *fiber.Ctx) error {
+		if req, err := adaptor.ConvertRequest(c, false); err == nil {
+			if route, _, err := router.FindRoute(req); err == nil {
+				s.mu.Lock()
+				s.operations[route.Operation.OperationID] = true
+				s.mu.Unlock()
+			}
+		}
+		return c.Next()
+	}, nil
+}
+
+// Operações do contrato que nenhuma chamada exercitou
+func (s *contractServer) missingOperations() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var missing []string
+	for _, item := range s.doc.Paths.Map() {
+		for _, op := range item.Operations() {
+			if !s.operations[op.OperationID] {
+				missing = append(missing, op.OperationID)
+			}
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+func newClient(t *testing.T, url string, opts client.Options) *client.Client {
+	t.Helper()
+	if opts.Token == "" {
+		opts.Token = contractToken
+	}
+	c, err := client.New(url, opts)
+	require.NoError(t, err)
+	return c
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// Percorre todas as rotas pelo cliente contra os handlers reais. As respostas
+// são conferidas pelo contrato, e o teste falha se alguma operação do
+// documento ficar sem chamada.
+func TestContract(t *testing.T) {
+	server := startContractServer(t)
+	c := newClient(t, server.URL, client.Options{})
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	var (
+		site     *client.Site
+		location *client.Location
+		central  *client.Central
+		webhook  *client.Webhook
+	)
+
+	t.Run("sites and locations", func(t *testing.T) {
+		var err error
+		site, err = c.CreateSite(ctx, client.SiteRequest{Name: "HQ", Description: "Head office"})
+		require.NoError(t, err)
+		site, err = c.UpdateSite(ctx, site.ID, client.SiteRequest{Name: "HQ", Description: "Main office"})
+		require.NoError(t, err)
+		got, err := c.GetSite(ctx, site.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Main office", got.Description)
+		sites, err := c.ListSites(ctx)
+		require.NoError(t, err)
+		assert.Len(t, sites, 1)
+
+		location, err = c.CreateLocation(ctx, site.ID, client.CreateLocationRequest{Kind: client.LocationBuilding, Name: "A"})
+		require.NoError(t, err)
+		location, err = c.UpdateLocation(ctx, location.ID, client.UpdateLocationRequest{Name: "Building A"})
+		require.NoError(t, err)
+		got2, err := c.GetLocation(ctx, location.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Building A", got2.Name)
+		locations, err := c.ListLocations(ctx, site.ID)
+		require.NoError(t, err)
+		assert.Len(t, locations, 1)
+
+		_, err = c.CreateLocation(ctx, site.ID, client.CreateLocationRequest{Kind: "basement", Name: "B"})
+		assert.ErrorIs(t, err, client.ErrInvalid)
+	})
+
+	t.Run("centrals", func(t *testing.T) {
+		var err error
+		central, err = c.CreateCentral(ctx, client.CreateCentralRequest{
+			Name: "Portaria", MAC: "00-00-0c-11-22-33", IP: "10.0.0.10",
+			LocationID: &location.ID, Labels: map[string]string{"env": "prod"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "00:00:0c:11:22:33", central.MAC)
+		assert.Equal(t, "Cisco Systems, Inc", central.Vendor)
+		assert.Equal(t, &site.ID, central.SiteID)
+		assert.Equal(t, client.StatusUnknown, central.Status)
+
+		got, err := c.GetCentral(ctx, central.ID)
+		require.NoError(t, err)
+		assert.Equal(t, central.Name, got.Name)
+
+		hyphen := newClient(t, server.URL, client.Options{MACFormat: "hyphen"})
+		got, err = hyphen.GetCentral(ctx, central.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "00-00-0c-11-22-33", got.MAC)
+
+		// Sem labels, a atualização mantém os atuais; um mapa vazio remove
+		update := client.UpdateCentralRequest{Name: "Portaria 1", MAC: central.MAC, IPv4: central.IPv4, LocationID: &location.ID}
+		central, err = c.UpdateCentral(ctx, central.ID, update)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"env": "prod"}, central.Labels)
+		update.Labels = map[string]string{}
+		updated, err := c.UpdateCentral(ctx, central.ID, update)
+		require.NoError(t, err)
+		assert.Empty(t, updated.Labels)
+		update.Labels = map[string]string{"env": "prod"}
+		central, err = c.UpdateCentral(ctx, central.ID, update)
+		require.NoError(t, err)
+
+		for i, mac := range []string{"00:11:22:33:44:01", "00:11:22:33:44:02", "00:11:22:33:44:03", "00:11:22:33:44:04"} {
+			_, err := c.CreateCentral(ctx, client.CreateCentralRequest{Name: "Bloco " + string(rune('A'+i)), MAC: mac, IP: "10.0.1." + string(rune('1'+i))})
+			require.NoError(t, err)
+		}
+
+		list, err := c.ListCentrals(ctx, client.CentralFilter{SiteID: site.ID, Recursive: true, Selector: "env=prod"})
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+		assert.Equal(t, central.ID, list[0].ID)
+
+		results, err := c.SearchCentrals(ctx, "portaria", 5)
+		require.NoError(t, err)
+		require.NotEmpty(t, results)
+		assert.Equal(t, central.ID, results[0].ID)
+
+		_, err = c.GetCentral(ctx, 9999)
+		assert.ErrorIs(t, err, client.ErrNotFound)
+		_, err = c.CreateCentral(ctx, client.CreateCentralRequest{Name: "Dup", MAC: "00:00:0C:11:22:33", IP: "10.0.0.99"})
+		assert.ErrorIs(t, err, client.ErrConflict)
+		var apiErr *client.Error
+		_, err = c.CreateCentral(ctx, client.CreateCentralRequest{Name: "Bad", MAC: "zz", IP: "10.0.0.98"})
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, 400, apiErr.StatusCode)
+		assert.NotEmpty(t, apiErr.Message)
+	})
+
+	t.Run("pagination", func(t *testing.T) {
+		all, err := c.ListCentrals(ctx, client.CentralFilter{})
+		require.NoError(t, err)
+		require.Len(t, all, 5)
+
+		page, err := c.ListCentralsPage(ctx, client.CentralFilter{}, client.Page{Limit: 2})
+		require.NoError(t, err)
+		assert.Len(t, page.Centrals, 2)
+		assert.Equal(t, page.Centrals[1].ID, page.NextAfterID)
+
+		var ids []uint
+		for central, err := range c.AllCentrals(ctx, client.CentralFilter{}, 2) {
+			require.NoError(t, err)
+			ids = append(ids, central.ID)
+		}
+		require.Len(t, ids, len(all))
+		for i := range all {
+			assert.Equal(t, all[i].ID, ids[i])
+		}
+
+		// Interromper o laço não busca as páginas seguintes
+		count := 0
+		for range c.AllCentrals(ctx, client.CentralFilter{}, 2) {
+			count++
+			break
+		}
+		assert.Equal(t, 1, count)
+
+		for _, err := range c.AllCentrals(ctx, client.CentralFilter{Status: "bogus"}, 2) {
+			assert.ErrorIs(t, err, client.ErrInvalid)
+		}
+	})
+
+	t.Run("site and location centrals", func(t *testing.T) {
+		centrals, err := c.ListSiteCentrals(ctx, site.ID, false)
+		require.NoError(t, err)
+		assert.Len(t, centrals, 1)
+		centrals, err = c.ListSiteCentrals(ctx, site.ID, true)
+		require.NoError(t, err)
+		assert.Empty(t, centrals)
+		centrals, err = c.ListLocationCentrals(ctx, location.ID, false)
+		require.NoError(t, err)
+		assert.Len(t, centrals, 1)
+	})
+
+	t.Run("interfaces", func(t *testing.T) {
+		iface, err := c.CreateInterface(ctx, central.ID, client.InterfaceRequest{Name: "eth1", MAC: "00:11:22:33:55:01", IPs: []string{"10.0.2.1"}})
+		require.NoError(t, err)
+		iface, err = c.UpdateInterface(ctx, central.ID, iface.ID, client.InterfaceRequest{Name: "eth1", MAC: iface.MAC, IPs: []string{"10.0.2.2"}, VLAN: 20})
+		require.NoError(t, err)
+		assert.Equal(t, 20, iface.VLAN)
+		got, err := c.GetInterface(ctx, central.ID, iface.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"10.0.2.2"}, got.IPs)
+		ifaces, err := c.ListInterfaces(ctx, central.ID)
+		require.NoError(t, err)
+		assert.Len(t, ifaces, 2)
+		require.NoError(t, c.DeleteInterface(ctx, central.ID, iface.ID))
+		_, err = c.GetInterface(ctx, central.ID, iface.ID)
+		assert.ErrorIs(t, err, client.ErrNotFound)
+	})
+
+	t.Run("labels", func(t *testing.T) {
+		labels, err := c.AddLabels(ctx, central.ID, map[string]string{"tier": "a"})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"env": "prod", "tier": "a"}, labels)
+		labels, err = c.RemoveLabels(ctx, central.ID, "tier")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"env": "prod"}, labels)
+
+		require.NoError(t, c.BulkAddLabels(ctx, []uint{central.ID}, map[string]string{"team": "ops"}))
+		require.NoError(t, c.BulkRemoveLabels(ctx, []uint{central.ID}, "team"))
+		labels, err = c.GetLabels(ctx, central.ID)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"env": "prod"}, labels)
+	})
+
+	t.Run("oui", func(t *testing.T) {
+		info, err := c.LookupOUI(ctx, "00:00:0C")
+		require.NoError(t, err)
+		assert.Equal(t, "Cisco Systems, Inc", info.Vendor)
+		_, err = c.LookupOUI(ctx, "xyz")
+		assert.ErrorIs(t, err, client.ErrInvalid)
+	})
+
+	t.Run("ipam", func(t *testing.T) {
+		subnet, err := c.CreateSubnet(ctx, client.CreateSubnetRequest{CIDR: "192.168.10.0/24", Gateway: "192.168.10.1"})
+		require.NoError(t, err)
+		subnet, err = c.UpdateSubnet(ctx, subnet.ID, client.UpdateSubnetRequest{Gateway: "192.168.10.1", Description: "lab",
+			Reserved: []client.AddressRange{{Start: "192.168.10.1", End: "192.168.10.9"}}})
+		require.NoError(t, err)
+		got, err := c.GetSubnet(ctx, subnet.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "lab", got.Description)
+		subnets, err := c.ListSubnets(ctx)
+		require.NoError(t, err)
+		assert.Len(t, subnets, 1)
+
+		pool, err := c.CreatePool(ctx, subnet.ID, client.PoolRequest{Name: "cams", Start: "192.168.10.10", End: "192.168.10.19"})
+		require.NoError(t, err)
+		pool, err = c.UpdatePool(ctx, pool.ID, client.PoolRequest{Name: "cameras", Start: pool.Start, End: pool.End})
+		require.NoError(t, err)
+		gotPool, err := c.GetPool(ctx, pool.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "cameras", gotPool.Name)
+		pools, err := c.ListPools(ctx, subnet.ID)
+		require.NoError(t, err)
+		assert.Len(t, pools, 1)
+
+		allocated, err := c.CreateCentral(ctx, client.CreateCentralRequest{Name: "Camera", MAC: "00:11:22:33:66:01", PoolID: &pool.ID})
+		require.NoError(t, err)
+		assert.Equal(t, "192.168.10.10", allocated.IPv4)
+
+		utilization, err := c.GetSubnetUtilization(ctx, subnet.ID)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), utilization.Used)
+		require.Len(t, utilization.Pools, 1)
+		report, err := c.GetUtilization(ctx)
+		require.NoError(t, err)
+		assert.Len(t, report, 1)
+
+		require.NoError(t, c.DeleteCentral(ctx, allocated.ID))
+		require.NoError(t, c.DeletePool(ctx, pool.ID))
+		require.NoError(t, c.DeleteSubnet(ctx, subnet.ID))
+		_, err = c.GetSubnet(ctx, subnet.ID)
+		assert.ErrorIs(t, err, client.ErrNotFound)
+	})
+
+	t.Run("heartbeats and metrics", func(t *testing.T) {
+		hb, err := c.RecordHeartbeat(ctx, central.ID, client.HeartbeatRequest{UptimeSeconds: 60, FirmwareVersion: "1.2.0",
+			CPUPercent: ptr(12.5), Metrics: map[string]float64{"temperature": 41}})
+		require.NoError(t, err)
+		assert.Equal(t, central.ID, hb.CentralID)
+		_, err = c.RecordHeartbeatByMAC(ctx, client.HeartbeatRequest{MAC: "00-00-0C-11-22-33", UptimeSeconds: 120, Metrics: map[string]float64{"temperature": 43}})
+		require.NoError(t, err)
+		_, err = c.RecordHeartbeatByMAC(ctx, client.HeartbeatRequest{MAC: "00:00:00:00:00:99", UptimeSeconds: 1})
+		assert.ErrorIs(t, err, client.ErrNotFound)
+
+		heartbeats, err := c.ListHeartbeats(ctx, central.ID, 10)
+		require.NoError(t, err)
+		assert.Len(t, heartbeats, 2)
+
+		series, err := c.GetMetrics(ctx, central.ID, client.MetricQuery{Name: "temperature",
+			From: time.Now().Add(-time.Hour), To: time.Now().Add(time.Minute), Step: time.Hour + time.Minute})
+		require.NoError(t, err)
+		require.Len(t, series.Points, 1)
+		assert.Equal(t, 2, series.Points[0].Count)
+		assert.Equal(t, 43.0, series.Points[0].Max)
+	})
+
+	t.Run("snmp", func(t *testing.T) {
+		snmp, err := c.SetSNMPCredentials(ctx, central.ID, client.SNMPCredentialsRequest{Version: client.SNMPv3, Username: "monitor",
+			AuthProtocol: "SHA256", AuthPassword: "auth-pass-123", PrivProtocol: "aes", PrivPassword: "priv-pass-456"})
+		require.NoError(t, err)
+		assert.Equal(t, 161, snmp.Port)
+		assert.Equal(t, "sha256", snmp.AuthProtocol)
+		assert.Equal(t, "authPriv", snmp.SecurityLevel)
+		assert.Nil(t, snmp.Discovered)
+		_, err = c.SetSNMPCredentials(ctx, central.ID, client.SNMPCredentialsRequest{Version: client.SNMPv2c})
+		assert.ErrorIs(t, err, client.ErrInvalid)
+
+		// Resultado de uma coleta, como gravado pelo coletor
+		_, err = server.snmp.SaveSNMPResults([]domain.SNMPResult{{CentralID: central.ID, Status: domain.StatusOnline, CheckedAt: time.Now(),
+			Inventory: &domain.SNMPInventory{SysName: "portaria", SysDescr: "PBX", SysUpTime: time.Hour,
+				Interfaces: []domain.SNMPInterface{{Index: 1, Name: "eth0", Type: 6, MTU: 1500, Speed: 1_000_000_000, OperStatus: "up"}}}}})
+		require.NoError(t, err)
+		snmp, err = c.GetSNMP(ctx, central.ID)
+		require.NoError(t, err)
+		require.NotNil(t, snmp.Discovered)
+		assert.Equal(t, "portaria", snmp.Discovered.SysName)
+		assert.EqualValues(t, 3600, snmp.Discovered.SysUpTimeSeconds)
+		assert.Equal(t, "eth0", snmp.Discovered.Interfaces[0].Name)
+
+		require.NoError(t, c.DeleteSNMPCredentials(ctx, central.ID))
+		_, err = c.GetSNMP(ctx, central.ID)
+		assert.ErrorIs(t, err, client.ErrNotFound)
+	})
+
+	t.Run("discovery", func(t *testing.T) {
+		// Resultado de uma varredura, como gravado pela descoberta
+		seen := time.Now()
+		_, err := server.discovery.SaveDiscoveredHosts([]domain.DiscoveredHost{
+			{IP: netip.MustParseAddr("10.0.0.50"), MAC: "00:aa:bb:cc:dd:01", OpenPorts: []int{80, 5060}, Fingerprint: "pbx", SeenAt: seen},
+			{IP: netip.MustParseAddr("10.0.0.51"), OpenPorts: []int{22}, Fingerprint: "server", SeenAt: seen},
+		})
+		require.NoError(t, err)
+		candidates, err := c.ListCandidates(ctx, "")
+		require.NoError(t, err)
+		require.Len(t, candidates, 2)
+		candidate, err := c.GetCandidate(ctx, candidates[0].ID)
+		require.NoError(t, err)
+		assert.Equal(t, []int{80, 5060}, candidate.OpenPorts)
+
+		accepted, err := c.AcceptCandidate(ctx, candidate.ID, client.AcceptCandidateRequest{Labels: map[string]string{"env": "prod"}})
+		require.NoError(t, err)
+		assert.Equal(t, client.CandidateAccepted, accepted.Candidate.Status)
+		assert.Equal(t, "10.0.0.50", accepted.Central.IP)
+		_, err = c.AcceptCandidate(ctx, candidate.ID, client.AcceptCandidateRequest{})
+		assert.ErrorIs(t, err, client.ErrConflict)
+		// Sem MAC na varredura, ele precisa vir na requisição
+		_, err = c.AcceptCandidate(ctx, candidates[1].ID, client.AcceptCandidateRequest{})
+		assert.ErrorIs(t, err, client.ErrInvalid)
+
+		rejected, err := c.RejectCandidate(ctx, candidates[1].ID)
+		require.NoError(t, err)
+		assert.Equal(t, client.CandidateRejected, rejected.Status)
+		rejectedList, err := c.ListCandidates(ctx, client.CandidateRejected)
+		require.NoError(t, err)
+		assert.Len(t, rejectedList, 1)
+		require.NoError(t, c.DeleteCentral(ctx, accepted.Central.ID))
+	})
+
+	t.Run("alerts", func(t *testing.T) {
+		rule, err := c.CreateAlertRule(ctx, client.AlertRuleRequest{Name: "old firmware", Kind: client.AlertKindFirmware, MinFirmware: "2.0.0"})
+		require.NoError(t, err)
+		rule, err = c.UpdateAlertRule(ctx, rule.ID, client.AlertRuleRequest{Name: "old firmware", Kind: client.AlertKindFirmware,
+			MinFirmware: "2.0.0", Severity: client.SeverityCritical})
+		require.NoError(t, err)
+		got, err := c.GetAlertRule(ctx, rule.ID)
+		require.NoError(t, err)
+		assert.Equal(t, client.SeverityCritical, got.Severity)
+		rules, err := c.ListAlertRules(ctx)
+		require.NoError(t, err)
+		assert.Len(t, rules, 1)
+
+		_, err = server.alerts.Evaluate()
+		require.NoError(t, err)
+		alerts, err := c.ListAlerts(ctx, client.AlertFilter{RuleID: rule.ID, CentralID: central.ID})
+		require.NoError(t, err)
+		require.Len(t, alerts, 1)
+		assert.Equal(t, client.AlertFiring, alerts[0].State)
+
+		silence, err := c.CreateSilence(ctx, client.SilenceRequest{RuleID: &rule.ID, EndsAt: time.Now().Add(time.Hour), Comment: "upgrade scheduled"})
+		require.NoError(t, err)
+		silences, err := c.ListSilences(ctx)
+		require.NoError(t, err)
+		assert.Len(t, silences, 1)
+		require.NoError(t, c.ExpireSilence(ctx, silence.ID))
+		require.NoError(t, c.DeleteAlertRule(ctx, rule.ID))
+		_, err = c.ListAlerts(ctx, client.AlertFilter{State: "bogus"})
+		assert.ErrorIs(t, err, client.ErrInvalid)
+	})
+
+	t.Run("webhooks", func(t *testing.T) {
+		// Eventos anteriores à assinatura não geram entregas
+		_, err := server.relay.RelayPending(ctx)
+		require.NoError(t, err)
+		webhook, err = c.CreateWebhook(ctx, client.WebhookRequest{URL: "http://127.0.0.1:1/hook", Events: []client.EventType{client.EventCentralCreated}})
+		require.NoError(t, err)
+		assert.NotEmpty(t, webhook.Secret)
+		webhook, err = c.UpdateWebhook(ctx, webhook.ID, client.WebhookRequest{URL: webhook.URL,
+			Events: []client.EventType{client.EventCentralCreated, client.EventCentralDeleted}})
+		require.NoError(t, err)
+		assert.Empty(t, webhook.Secret)
+		got, err := c.GetWebhook(ctx, webhook.ID)
+		require.NoError(t, err)
+		assert.Len(t, got.Events, 2)
+		webhooks, err := c.ListWebhooks(ctx)
+		require.NoError(t, err)
+		assert.Len(t, webhooks, 1)
+
+		_, err = c.CreateCentral(ctx, client.CreateCentralRequest{Name: "Hooked", MAC: "00:11:22:33:77:01", IP: "10.0.3.1"})
+		require.NoError(t, err)
+		_, err = server.relay.RelayPending(ctx)
+		require.NoError(t, err)
+
+		deliveries, err := c.ListWebhookDeliveries(ctx, webhook.ID, client.DeliveryFilter{Status: client.DeliveryPending, Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, client.EventCentralCreated, deliveries[0].EventType)
+		detail, err := c.GetWebhookDelivery(ctx, webhook.ID, deliveries[0].ID)
+		require.NoError(t, err)
+		assert.NotEmpty(t, detail.Payload)
+		detail, err = c.RedeliverWebhookDelivery(ctx, webhook.ID, deliveries[0].ID)
+		require.NoError(t, err)
+		assert.Equal(t, client.DeliveryPending, detail.Status)
+	})
+
+	t.Run("graphql", func(t *testing.T) {
+		var data struct {
+			Central struct {
+				Name string `json:"name"`
+				Site struct {
+					Name string `json:"name"`
+				} `json:"site"`
+			} `json:"central"`
+		}
+		err := c.GraphQL(ctx, client.GraphQLRequest{
+			Query:     `query($id: ID!) { central(id: $id) { name site { name } } }`,
+			Variables: map[string]any{"id": strconv.FormatUint(uint64(central.ID), 10)},
+		}, &data)
+		require.NoError(t, err)
+		assert.Equal(t, "Portaria 1", data.Central.Name)
+		assert.Equal(t, "HQ", data.Central.Site.Name)
+
+		var gqlErrs client.GraphQLErrors
+		err = c.GraphQL(ctx, client.GraphQLRequest{Query: `{ nope }`}, nil)
+		require.ErrorAs(t, err, &gqlErrs)
+		assert.NotEmpty(t, gqlErrs[0].Message)
+	})
+
+	t.Run("server-sent events", func(t *testing.T) {
+		streamCtx, stop := context.WithTimeout(ctx, 10*time.Second)
+		defer stop()
+
+		// Do início do outbox até a criação da central
+		var created client.Event
+		for event, err := range c.Events(streamCtx, client.EventStreamOptions{LastEventID: "0"}) {
+			require.NoError(t, err)
+			if event.Type == client.EventCentralCreated && event.CentralID == central.ID {
+				created = event
+				break
+			}
+		}
+		require.NotEmpty(t, created.ID)
+		data, err := created.Central()
+		require.NoError(t, err)
+		assert.Equal(t, "Portaria", data.Name)
+
+		// Retomando desde a criação, o próximo evento da central é a atualização
+		for event, err := range c.Events(streamCtx, client.EventStreamOptions{LastEventID: created.ID, Selector: "env=prod"}) {
+			require.NoError(t, err)
+			next, _ := strconv.Atoi(event.ID)
+			first, _ := strconv.Atoi(created.ID)
+			assert.Greater(t, next, first)
+			assert.Equal(t, client.EventCentralUpdated, event.Type)
+			break
+		}
+
+		var streamErr error
+		for _, err := range c.Events(streamCtx, client.EventStreamOptions{Status: "bogus"}) {
+			streamErr = err
+		}
+		assert.ErrorIs(t, streamErr, client.ErrInvalid)
+	})
+
+	t.Run("websocket", func(t *testing.T) {
+		_, err := newClient(t, server.URL, client.Options{Token: "wrong"}).DialWebSocket(ctx)
+		assert.ErrorIs(t, err, client.ErrUnauthorized)
+
+		socket, err := c.DialWebSocket(ctx)
+		require.NoError(t, err)
+		defer socket.Close()
+
+		_, err = socket.Ping(ctx)
+		require.NoError(t, err)
+		got, err := socket.GetCentral(ctx, central.ID)
+		require.NoError(t, err)
+		assert.Equal(t, central.Name, got.Name)
+		list, err := socket.ListCentrals(ctx, client.SocketListFilter{Selector: "env=prod"})
+		require.NoError(t, err)
+		assert.Len(t, list, 1)
+		_, err = socket.GetCentral(ctx, 9999)
+		assert.ErrorIs(t, err, client.ErrNotFound)
+
+		subscription, err := socket.Subscribe(ctx, client.SubscribeOptions{Centrals: []uint{central.ID}})
+		require.NoError(t, err)
+		_, err = c.UpdateCentral(ctx, central.ID, client.UpdateCentralRequest{Name: "Portaria 2", MAC: central.MAC, IPv4: central.IPv4})
+		require.NoError(t, err)
+		select {
+		case event := <-socket.Events():
+			assert.Equal(t, subscription, event.Subscription)
+			assert.Equal(t, client.EventCentralUpdated, event.Event.Type)
+			assert.Equal(t, central.ID, event.Event.CentralID)
+		case <-time.After(5 * time.Second):
+			t.Fatal("no event received")
+		}
+		require.NoError(t, socket.Unsubscribe(ctx, subscription))
+		assert.ErrorIs(t, socket.Unsubscribe(ctx, subscription), client.ErrNotFound)
+	})
+
+	t.Run("deletes", func(t *testing.T) {
+		require.NoError(t, c.DeleteWebhook(ctx, webhook.ID))
+		require.NoError(t, c.DeleteCentral(ctx, central.ID))
+		_, err := c.GetCentral(ctx, central.ID)
+		assert.ErrorIs(t, err, client.ErrNotFound)
+
+		empty, err := c.CreateLocation(ctx, site.ID, client.CreateLocationRequest{Kind: client.LocationBuilding, Name: "Empty"})
+		require.NoError(t, err)
+		require.NoError(t, c.DeleteLocation(ctx, empty.ID))
+		require.NoError(t, c.DeleteSite(ctx, site.ID, client.DeleteSiteOptions{Cascade: true}))
+		_, err = c.GetSite(ctx, site.ID)
+		assert.ErrorIs(t, err, client.ErrNotFound)
+	})
+
+	assert.Empty(t, server.missingOperations(), "operations not exercised by the client")
+}
@@ -0,0 +1,84 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+type CandidateStatus string
+
+const (
+	CandidatePending  CandidateStatus = "pending"
+	CandidateAccepted CandidateStatus = "accepted"
+	CandidateRejected CandidateStatus = "rejected"
+)
+
+// Par MAC/IP encontrado pela descoberta de rede e ainda não cadastrado
+type Candidate struct {
+	ID  uint   `json:"id"`
+	IP  string `json:"ip"`
+	MAC string `json:"mac,omitempty"`
+	// Fabricante do OUI e tipo inferido (pbx, ip-phone, network-device, ...)
+	Vendor      string          `json:"vendor,omitempty"`
+	OpenPorts   []int           `json:"open_ports"`
+	Fingerprint string          `json:"fingerprint"`
+	Status      CandidateStatus `json:"status"`
+	FirstSeenAt time.Time       `json:"first_seen_at"`
+	LastSeenAt  time.Time       `json:"last_seen_at"`
+	DecidedAt   *time.Time      `json:"decided_at,omitempty"`
+	CentralID   *uint           `json:"central_id,omitempty"`
+}
+
+// Dados da central criada ao aceitar; os campos vazios vêm do candidato.
+// MAC é obrigatório quando a varredura não o encontrou.
+type AcceptCandidateRequest struct {
+	Name       string            `json:"name,omitempty"`
+	MAC        string            `json:"mac,omitempty"`
+	Notes      string            `json:"notes,omitempty"`
+	SiteID     *uint             `json:"site_id,omitempty"`
+	LocationID *uint             `json:"location_id,omitempty"`
+	Labels     map[string]string `json:"labels,omitempty"`
+}
+
+type AcceptedCandidate struct {
+	Candidate Candidate `json:"candidate"`
+	Central   Central   `json:"central"`
+}
+
+// Candidatos na situação informada; vazia lista os pendentes
+func (c *Client) ListCandidates(ctx context.Context, status CandidateStatus) ([]Candidate, error) {
+	query := url.Values{}
+	setString(query, "status", string(status))
+	var candidates []Candidate
+	if _, err := c.do(ctx, http.MethodGet, "/discovery/candidates", query, nil, &candidates); err != nil {
+		return nil, err
+	}
+	return candidates, nil
+}
+
+func (c *Client) GetCandidate(ctx context.Context, candidateID uint) (*Candidate, error) {
+	var candidate Candidate
+	if _, err := c.do(ctx, http.MethodGet, "/discovery/candidates/"+pathID(candidateID), nil, nil, &candidate); err != nil {
+		return nil, err
+	}
+	return &candidate, nil
+}
+
+// Cria a central a partir do candidato pendente
+func (c *Client) AcceptCandidate(ctx context.Context, candidateID uint, req AcceptCandidateRequest) (*AcceptedCandidate, error) {
+	var accepted AcceptedCandidate
+	if _, err := c.do(ctx, http.MethodPost, "/discovery/candidates/"+pathID(candidateID)+"/accept", nil, req, &accepted); err != nil {
+		return nil, err
+	}
+	return &accepted, nil
+}
+
+func (c *Client) RejectCandidate(ctx context.Context, candidateID uint) (*Candidate, error) {
+	var candidate Candidate
+	if _, err := c.do(ctx, http.MethodPost, "/discovery/candidates/"+pathID(candidateID)+"/reject", nil, nil, &candidate); err != nil {
+		return nil, err
+	}
+	return &candidate, nil
+}
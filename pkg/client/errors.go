@@ -0,0 +1,68 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Classes de erro da API, para uso com errors.Is
+var (
+	ErrInvalid      = errors.New("invalid request")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	ErrUnavailable  = errors.New("service unavailable")
+)
+
+// Resposta de erro da API. A mensagem é o campo "error" do corpo, ou o
+// próprio corpo quando não é JSON (ex.: um proxy no caminho).
+type Error struct {
+	StatusCode int
+	Message    string
+	Method     string
+	Path       string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s %s: %s (HTTP %d)", e.Method, e.Path, e.Message, e.StatusCode)
+}
+
+// Relaciona o status às classes de erro
+func (e *Error) Is(target error) bool {
+	switch target {
+	case ErrInvalid:
+		return e.StatusCode == http.StatusBadRequest || e.StatusCode == http.StatusUnprocessableEntity
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrUnavailable:
+		return retryableStatus(e.StatusCode)
+	}
+	return false
+}
+
+// Lê e fecha o corpo da resposta de erro
+func decodeError(method, path string, resp *http.Response) *Error {
+	defer resp.Body.Close()
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+
+	e := &Error{StatusCode: resp.StatusCode, Method: method, Path: path}
+	var body struct {
+		Error string `json:"error"`
+	}
+	if json.Unmarshal(data, &body) == nil && body.Error != "" {
+		e.Message = body.Error
+	} else if text := strings.TrimSpace(string(data)); text != "" {
+		e.Message = text
+	} else {
+		e.Message = http.StatusText(resp.StatusCode)
+	}
+	return e
+}
@@ -0,0 +1,194 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type EventType string
+
+const (
+	EventCentralCreated       EventType = "central.created"
+	EventCentralUpdated       EventType = "central.updated"
+	EventCentralDeleted       EventType = "central.deleted"
+	EventCentralStatusChanged EventType = "central.status_changed"
+)
+
+// Evento de central, no mesmo formato do corpo dos webhooks
+type Event struct {
+	ID         string          `json:"id"`
+	Type       EventType       `json:"type"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	CentralID  uint            `json:"central_id"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// Estado da central nos eventos de ciclo de vida
+type EventCentral struct {
+	ID         uint               `json:"id"`
+	Name       string             `json:"name"`
+	MAC        string             `json:"mac"`
+	IPv4       string             `json:"ipv4,omitempty"`
+	IPv6       string             `json:"ipv6,omitempty"`
+	Vendor     string             `json:"vendor,omitempty"`
+	SiteID     *uint              `json:"site_id,omitempty"`
+	LocationID *uint              `json:"location_id,omitempty"`
+	Labels     map[string]string  `json:"labels,omitempty"`
+	Status     ReachabilityStatus `json:"status"`
+}
+
+// Dados de central.status_changed
+type EventStatusChange struct {
+	CentralID uint               `json:"central_id"`
+	From      ReachabilityStatus `json:"from"`
+	To        ReachabilityStatus `json:"to"`
+	At        time.Time          `json:"at"`
+}
+
+// Dados dos eventos central.created, central.updated e central.deleted
+func (e Event) Central() (*EventCentral, error) {
+	var data EventCentral
+	if err := json.Unmarshal(e.Data, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// Dados do evento central.status_changed
+func (e Event) StatusChange() (*EventStatusChange, error) {
+	var data EventStatusChange
+	if err := json.Unmarshal(e.Data, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// Filtros do stream de eventos
+type EventStreamOptions struct {
+	SiteID   uint
+	Selector string
+	Status   ReachabilityStatus
+	// Retoma depois deste evento; "0" reenvia desde o mais antigo ainda
+	// guardado e vazio recebe só os novos
+	LastEventID string
+}
+
+// Eventos das centrais pelo stream SSE. Quedas da conexão são tratadas
+// reconectando com o ID do último evento recebido, sem perder eventos. A
+// iteração termina com o cancelamento do contexto, ou depois de entregar um
+// erro que não se resolve reconectando (ex.: filtro inválido).
+func (c *Client) Events(ctx context.Context, opts EventStreamOptions) iter.Seq2[Event, error] {
+	return func(yield func(Event, error) bool) {
+		lastID := opts.LastEventID
+		failures := 0
+		for {
+			delay, err := c.streamEvents(ctx, opts, &lastID, &failures, yield)
+			if ctx.Err() != nil || err == errStopped {
+				return
+			}
+			if err != nil {
+				yield(Event{}, err)
+				return
+			}
+			if c.Sleep(ctx, delay) != nil {
+				return
+			}
+		}
+	}
+}
+
+// Sinaliza que o laço do chamador parou de consumir os eventos
+var errStopped = errors.New("stopped")
+
+// Uma conexão do stream. Devolve a espera antes de reconectar, ou o erro
+// definitivo que encerra a iteração.
+func (c *Client) streamEvents(ctx context.Context, opts EventStreamOptions, lastID *string, failures *int, yield func(Event, error) bool) (time.Duration, error) {
+	const path = "/centrals/events"
+	query := url.Values{}
+	setID(query, "site_id", opts.SiteID)
+	setString(query, "selector", opts.Selector)
+	setString(query, "status", string(opts.Status))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url(path, query), nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	if *lastID != "" {
+		req.Header.Set("Last-Event-ID", *lastID)
+	}
+	c.setHeaders(req.Header)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		if !retryableError(ctx, err) || *failures >= max(c.opts.MaxRetries, 0) {
+			return 0, err
+		}
+		*failures++
+		return c.backoff(*failures, 0), nil
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		apiErr := decodeError(http.MethodGet, path, resp)
+		if !retryableStatus(resp.StatusCode) || *failures >= max(c.opts.MaxRetries, 0) {
+			return 0, apiErr
+		}
+		*failures++
+		return c.backoff(*failures, retryAfter(resp.Header)), nil
+	}
+	*failures = 0
+
+	// Sem o retry: do servidor, reconecta como após a primeira falha
+	delay := c.backoff(1, 0)
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	var id, data string
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			// Linha em branco encerra o evento
+			if data == "" {
+				continue
+			}
+			var event Event
+			err := json.Unmarshal([]byte(data), &event)
+			if event.ID == "" {
+				event.ID = id
+			}
+			if id != "" {
+				*lastID = id
+			}
+			id, data = "", ""
+			if !yield(event, err) {
+				return 0, errStopped
+			}
+			continue
+		}
+		// Comentários, como os de keepalive, começam com ":"
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "id":
+			id = value
+		case "data":
+			if data != "" {
+				data += "\n"
+			}
+			data += value
+		case "retry":
+			if ms, err := strconv.Atoi(value); err == nil && ms >= 0 {
+				delay = time.Duration(ms) * time.Millisecond
+			}
+		}
+	}
+	// Fim do corpo: o servidor encerrou o stream ou a conexão caiu
+	return delay, nil
+}
@@ -0,0 +1,83 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+type GraphQLRequest struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+}
+
+type GraphQLLocation struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+type GraphQLError struct {
+	Message    string            `json:"message"`
+	Locations  []GraphQLLocation `json:"locations,omitempty"`
+	Path       []any             `json:"path,omitempty"`
+	Extensions map[string]any    `json:"extensions,omitempty"`
+}
+
+func (e GraphQLError) Error() string {
+	return e.Message
+}
+
+// Erros da resposta GraphQL. Com dados parciais, o que foi resolvido continua
+// decodificado no destino.
+type GraphQLErrors []GraphQLError
+
+func (e GraphQLErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Message)
+	}
+	return "graphql: " + strings.Join(messages, "; ")
+}
+
+// Executa a consulta em /graphql e decodifica "data" em data. Consultas não
+// são repetidas em falhas, como os demais POST.
+func (c *Client) GraphQL(ctx context.Context, req GraphQLRequest, data any) error {
+	const path = "/graphql"
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	resp, err := c.send(ctx, http.MethodPost, path, nil, payload)
+	if err != nil {
+		return err
+	}
+	// 400 também traz o corpo GraphQL, com os erros da consulta
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusBadRequest {
+		return decodeError(http.MethodPost, path, resp)
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		Data   json.RawMessage `json:"data"`
+		Errors GraphQLErrors   `json:"errors"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return fmt.Errorf("POST %s: invalid response: %w", path, err)
+	}
+	if data != nil && len(body.Data) > 0 && !bytes.Equal(body.Data, []byte("null")) {
+		if err := json.Unmarshal(body.Data, data); err != nil {
+			return fmt.Errorf("POST %s: invalid data: %w", path, err)
+		}
+	}
+	if len(body.Errors) > 0 {
+		return body.Errors
+	}
+	if resp.StatusCode == http.StatusBadRequest {
+		return &Error{StatusCode: resp.StatusCode, Message: http.StatusText(resp.StatusCode), Method: http.MethodPost, Path: path}
+	}
+	return nil
+}
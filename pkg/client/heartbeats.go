@@ -0,0 +1,65 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+type HeartbeatRequest struct {
+	// Identifica a central em RecordHeartbeatByMAC; ignorado quando o ID é
+	// informado
+	MAC string `json:"mac,omitempty"`
+
+	UptimeSeconds   int64              `json:"uptime_seconds"`
+	FirmwareVersion string             `json:"firmware_version,omitempty"`
+	CPUPercent      *float64           `json:"cpu_percent,omitempty"`
+	MemoryPercent   *float64           `json:"memory_percent,omitempty"`
+	Metrics         map[string]float64 `json:"metrics,omitempty"`
+}
+
+type Heartbeat struct {
+	ID         uint      `json:"id"`
+	CentralID  uint      `json:"central_id"`
+	ReceivedAt time.Time `json:"received_at"`
+
+	UptimeSeconds   int64              `json:"uptime_seconds"`
+	FirmwareVersion string             `json:"firmware_version,omitempty"`
+	CPUPercent      *float64           `json:"cpu_percent,omitempty"`
+	MemoryPercent   *float64           `json:"memory_percent,omitempty"`
+	Metrics         map[string]float64 `json:"metrics"`
+}
+
+// Heartbeat não é idempotente e não é repetido em falhas; o próximo do
+// agente substitui o perdido
+func (c *Client) RecordHeartbeat(ctx context.Context, centralID uint, req HeartbeatRequest) (*Heartbeat, error) {
+	var hb Heartbeat
+	if _, err := c.do(ctx, http.MethodPost, "/central/"+pathID(centralID)+"/heartbeat", nil, req, &hb); err != nil {
+		return nil, err
+	}
+	return &hb, nil
+}
+
+// Heartbeat de uma central identificada por req.MAC
+func (c *Client) RecordHeartbeatByMAC(ctx context.Context, req HeartbeatRequest) (*Heartbeat, error) {
+	var hb Heartbeat
+	if _, err := c.do(ctx, http.MethodPost, "/heartbeat", nil, req, &hb); err != nil {
+		return nil, err
+	}
+	return &hb, nil
+}
+
+// Heartbeats mais recentes primeiro; limit zero usa o padrão do servidor
+func (c *Client) ListHeartbeats(ctx context.Context, centralID uint, limit int) ([]Heartbeat, error) {
+	query := url.Values{}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+	var heartbeats []Heartbeat
+	if _, err := c.do(ctx, http.MethodGet, "/central/"+pathID(centralID)+"/heartbeats", query, nil, &heartbeats); err != nil {
+		return nil, err
+	}
+	return heartbeats, nil
+}
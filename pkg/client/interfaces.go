@@ -0,0 +1,74 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Interface de rede de uma central
+type Interface struct {
+	ID          uint      `json:"id"`
+	CentralID   uint      `json:"central_id"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	Name        string    `json:"name"`
+	MAC         string    `json:"mac"`
+	Vendor      string    `json:"vendor,omitempty"`
+	MACWarnings []string  `json:"mac_warnings,omitempty"`
+	IPs         []string  `json:"ips"`
+	VLAN        int       `json:"vlan"`
+	Primary     bool      `json:"primary"`
+}
+
+// Corpo da criação e da atualização de interfaces
+type InterfaceRequest struct {
+	Name    string   `json:"name"`
+	MAC     string   `json:"mac"`
+	IPs     []string `json:"ips"`
+	VLAN    int      `json:"vlan"`
+	Primary bool     `json:"primary"`
+}
+
+func interfacesPath(centralID uint) string {
+	return "/central/" + pathID(centralID) + "/interfaces"
+}
+
+func (c *Client) CreateInterface(ctx context.Context, centralID uint, req InterfaceRequest) (*Interface, error) {
+	var iface Interface
+	if _, err := c.do(ctx, http.MethodPost, interfacesPath(centralID), c.macQuery(), req, &iface); err != nil {
+		return nil, err
+	}
+	return &iface, nil
+}
+
+func (c *Client) ListInterfaces(ctx context.Context, centralID uint) ([]Interface, error) {
+	var ifaces []Interface
+	if _, err := c.do(ctx, http.MethodGet, interfacesPath(centralID), c.macQuery(), nil, &ifaces); err != nil {
+		return nil, err
+	}
+	return ifaces, nil
+}
+
+func (c *Client) GetInterface(ctx context.Context, centralID, interfaceID uint) (*Interface, error) {
+	var iface Interface
+	path := interfacesPath(centralID) + "/" + pathID(interfaceID)
+	if _, err := c.do(ctx, http.MethodGet, path, c.macQuery(), nil, &iface); err != nil {
+		return nil, err
+	}
+	return &iface, nil
+}
+
+func (c *Client) UpdateInterface(ctx context.Context, centralID, interfaceID uint, req InterfaceRequest) (*Interface, error) {
+	var iface Interface
+	path := interfacesPath(centralID) + "/" + pathID(interfaceID)
+	if _, err := c.do(ctx, http.MethodPut, path, c.macQuery(), req, &iface); err != nil {
+		return nil, err
+	}
+	return &iface, nil
+}
+
+func (c *Client) DeleteInterface(ctx context.Context, centralID, interfaceID uint) error {
+	_, err := c.do(ctx, http.MethodDelete, interfacesPath(centralID)+"/"+pathID(interfaceID), nil, nil, nil)
+	return err
+}
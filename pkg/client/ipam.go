@@ -0,0 +1,166 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Faixa de endereços, inclusive nas duas pontas
+type AddressRange struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+type Subnet struct {
+	ID          uint           `json:"id"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	CIDR        string         `json:"cidr"`
+	Gateway     string         `json:"gateway,omitempty"`
+	Description string         `json:"description"`
+	Reserved    []AddressRange `json:"reserved"`
+}
+
+type CreateSubnetRequest struct {
+	CIDR        string         `json:"cidr"`
+	Gateway     string         `json:"gateway,omitempty"`
+	Description string         `json:"description,omitempty"`
+	Reserved    []AddressRange `json:"reserved,omitempty"`
+}
+
+// O CIDR não muda depois de criado
+type UpdateSubnetRequest struct {
+	Gateway     string         `json:"gateway,omitempty"`
+	Description string         `json:"description,omitempty"`
+	Reserved    []AddressRange `json:"reserved,omitempty"`
+}
+
+type Pool struct {
+	ID        uint      `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	SubnetID  uint      `json:"subnet_id"`
+	Name      string    `json:"name"`
+	Start     string    `json:"start"`
+	End       string    `json:"end"`
+}
+
+type PoolRequest struct {
+	Name  string `json:"name"`
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// Contagem de endereços de uma sub-rede ou pool
+type Utilization struct {
+	Total    uint64  `json:"total"`
+	Reserved uint64  `json:"reserved"`
+	Used     uint64  `json:"used"`
+	Free     uint64  `json:"free"`
+	Percent  float64 `json:"percent"`
+}
+
+type PoolUtilization struct {
+	PoolID uint   `json:"pool_id"`
+	Name   string `json:"name"`
+	Utilization
+}
+
+type SubnetUtilization struct {
+	SubnetID uint   `json:"subnet_id"`
+	CIDR     string `json:"cidr"`
+	Utilization
+	Pools []PoolUtilization `json:"pools"`
+}
+
+func (c *Client) ListSubnets(ctx context.Context) ([]Subnet, error) {
+	var subnets []Subnet
+	if _, err := c.do(ctx, http.MethodGet, "/subnets", nil, nil, &subnets); err != nil {
+		return nil, err
+	}
+	return subnets, nil
+}
+
+func (c *Client) CreateSubnet(ctx context.Context, req CreateSubnetRequest) (*Subnet, error) {
+	var subnet Subnet
+	if _, err := c.do(ctx, http.MethodPost, "/subnets", nil, req, &subnet); err != nil {
+		return nil, err
+	}
+	return &subnet, nil
+}
+
+func (c *Client) GetSubnet(ctx context.Context, subnetID uint) (*Subnet, error) {
+	var subnet Subnet
+	if _, err := c.do(ctx, http.MethodGet, "/subnets/"+pathID(subnetID), nil, nil, &subnet); err != nil {
+		return nil, err
+	}
+	return &subnet, nil
+}
+
+func (c *Client) UpdateSubnet(ctx context.Context, subnetID uint, req UpdateSubnetRequest) (*Subnet, error) {
+	var subnet Subnet
+	if _, err := c.do(ctx, http.MethodPut, "/subnets/"+pathID(subnetID), nil, req, &subnet); err != nil {
+		return nil, err
+	}
+	return &subnet, nil
+}
+
+func (c *Client) DeleteSubnet(ctx context.Context, subnetID uint) error {
+	_, err := c.do(ctx, http.MethodDelete, "/subnets/"+pathID(subnetID), nil, nil, nil)
+	return err
+}
+
+func (c *Client) GetSubnetUtilization(ctx context.Context, subnetID uint) (*SubnetUtilization, error) {
+	var utilization SubnetUtilization
+	if _, err := c.do(ctx, http.MethodGet, "/subnets/"+pathID(subnetID)+"/utilization", nil, nil, &utilization); err != nil {
+		return nil, err
+	}
+	return &utilization, nil
+}
+
+func (c *Client) ListPools(ctx context.Context, subnetID uint) ([]Pool, error) {
+	var pools []Pool
+	if _, err := c.do(ctx, http.MethodGet, "/subnets/"+pathID(subnetID)+"/pools", nil, nil, &pools); err != nil {
+		return nil, err
+	}
+	return pools, nil
+}
+
+func (c *Client) CreatePool(ctx context.Context, subnetID uint, req PoolRequest) (*Pool, error) {
+	var pool Pool
+	if _, err := c.do(ctx, http.MethodPost, "/subnets/"+pathID(subnetID)+"/pools", nil, req, &pool); err != nil {
+		return nil, err
+	}
+	return &pool, nil
+}
+
+func (c *Client) GetPool(ctx context.Context, poolID uint) (*Pool, error) {
+	var pool Pool
+	if _, err := c.do(ctx, http.MethodGet, "/pools/"+pathID(poolID), nil, nil, &pool); err != nil {
+		return nil, err
+	}
+	return &pool, nil
+}
+
+func (c *Client) UpdatePool(ctx context.Context, poolID uint, req PoolRequest) (*Pool, error) {
+	var pool Pool
+	if _, err := c.do(ctx, http.MethodPut, "/pools/"+pathID(poolID), nil, req, &pool); err != nil {
+		return nil, err
+	}
+	return &pool, nil
+}
+
+func (c *Client) DeletePool(ctx context.Context, poolID uint) error {
+	_, err := c.do(ctx, http.MethodDelete, "/pools/"+pathID(poolID), nil, nil, nil)
+	return err
+}
+
+// Utilização de todas as sub-redes
+func (c *Client) GetUtilization(ctx context.Context) ([]SubnetUtilization, error) {
+	var utilization []SubnetUtilization
+	if _, err := c.do(ctx, http.MethodGet, "/ipam/utilization", nil, nil, &utilization); err != nil {
+		return nil, err
+	}
+	return utilization, nil
+}
@@ -0,0 +1,64 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+type labelsBody struct {
+	Labels map[string]string `json:"labels"`
+}
+
+type labelKeysBody struct {
+	Keys []string `json:"keys"`
+}
+
+func labelsPath(centralID uint) string {
+	return "/central/" + pathID(centralID) + "/labels"
+}
+
+func (c *Client) GetLabels(ctx context.Context, centralID uint) (map[string]string, error) {
+	var body labelsBody
+	if _, err := c.do(ctx, http.MethodGet, labelsPath(centralID), nil, nil, &body); err != nil {
+		return nil, err
+	}
+	return body.Labels, nil
+}
+
+// Adiciona ou substitui os labels informados e devolve o conjunto resultante
+func (c *Client) AddLabels(ctx context.Context, centralID uint, labels map[string]string) (map[string]string, error) {
+	var body labelsBody
+	if _, err := c.do(ctx, http.MethodPost, labelsPath(centralID), nil, labelsBody{Labels: labels}, &body); err != nil {
+		return nil, err
+	}
+	return body.Labels, nil
+}
+
+// Remove os labels pelas chaves e devolve o conjunto resultante
+func (c *Client) RemoveLabels(ctx context.Context, centralID uint, keys ...string) (map[string]string, error) {
+	var body labelsBody
+	if _, err := c.do(ctx, http.MethodDelete, labelsPath(centralID), nil, labelKeysBody{Keys: keys}, &body); err != nil {
+		return nil, err
+	}
+	return body.Labels, nil
+}
+
+// Adiciona os labels a várias centrais de uma vez
+func (c *Client) BulkAddLabels(ctx context.Context, centralIDs []uint, labels map[string]string) error {
+	body := struct {
+		IDs    []uint            `json:"ids"`
+		Labels map[string]string `json:"labels"`
+	}{centralIDs, labels}
+	_, err := c.do(ctx, http.MethodPost, "/centrals/labels", nil, body, nil)
+	return err
+}
+
+// Remove os labels de várias centrais de uma vez
+func (c *Client) BulkRemoveLabels(ctx context.Context, centralIDs []uint, keys ...string) error {
+	body := struct {
+		IDs  []uint   `json:"ids"`
+		Keys []string `json:"keys"`
+	}{centralIDs, keys}
+	_, err := c.do(ctx, http.MethodDelete, "/centrals/labels", nil, body, nil)
+	return err
+}
@@ -0,0 +1,25 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// Fabricante e indicações de um prefixo de MAC
+type OUIInfo struct {
+	Prefix              string `json:"prefix"`
+	Vendor              string `json:"vendor,omitempty"`
+	LocallyAdministered bool   `json:"locally_administered"`
+	Multicast           bool   `json:"multicast"`
+}
+
+// Consulta o fabricante pelo prefixo ou por um MAC completo, em qualquer
+// notação
+func (c *Client) LookupOUI(ctx context.Context, prefix string) (*OUIInfo, error) {
+	var info OUIInfo
+	if _, err := c.do(ctx, http.MethodGet, "/oui/"+url.PathEscape(prefix), nil, nil, &info); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
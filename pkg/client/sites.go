@@ -0,0 +1,163 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+type Site struct {
+	ID          uint      `json:"id"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+}
+
+type SiteRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// Tipo da localização, do mais amplo ao mais específico
+type LocationKind string
+
+const (
+	LocationBuilding LocationKind = "building"
+	LocationFloor    LocationKind = "floor"
+	LocationRoom     LocationKind = "room"
+)
+
+type Location struct {
+	ID        uint         `json:"id"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+	SiteID    uint         `json:"site_id"`
+	ParentID  *uint        `json:"parent_id,omitempty"`
+	Kind      LocationKind `json:"kind"`
+	Name      string       `json:"name"`
+}
+
+type CreateLocationRequest struct {
+	Kind     LocationKind `json:"kind"`
+	Name     string       `json:"name"`
+	ParentID *uint        `json:"parent_id,omitempty"`
+}
+
+type UpdateLocationRequest struct {
+	Name     string `json:"name"`
+	ParentID *uint  `json:"parent_id,omitempty"`
+}
+
+// O que fazer com as centrais do site excluído. Sem nenhuma das opções, a
+// exclusão de um site com centrais falha com ErrConflict.
+type DeleteSiteOptions struct {
+	// Remove as centrais junto com o site
+	Cascade bool
+	// Move as centrais para este site
+	ReassignTo uint
+}
+
+func (c *Client) ListSites(ctx context.Context) ([]Site, error) {
+	var sites []Site
+	if _, err := c.do(ctx, http.MethodGet, "/sites", nil, nil, &sites); err != nil {
+		return nil, err
+	}
+	return sites, nil
+}
+
+func (c *Client) CreateSite(ctx context.Context, req SiteRequest) (*Site, error) {
+	var site Site
+	if _, err := c.do(ctx, http.MethodPost, "/sites", nil, req, &site); err != nil {
+		return nil, err
+	}
+	return &site, nil
+}
+
+func (c *Client) GetSite(ctx context.Context, siteID uint) (*Site, error) {
+	var site Site
+	if _, err := c.do(ctx, http.MethodGet, "/sites/"+pathID(siteID), nil, nil, &site); err != nil {
+		return nil, err
+	}
+	return &site, nil
+}
+
+func (c *Client) UpdateSite(ctx context.Context, siteID uint, req SiteRequest) (*Site, error) {
+	var site Site
+	if _, err := c.do(ctx, http.MethodPut, "/sites/"+pathID(siteID), nil, req, &site); err != nil {
+		return nil, err
+	}
+	return &site, nil
+}
+
+func (c *Client) DeleteSite(ctx context.Context, siteID uint, opts DeleteSiteOptions) error {
+	query := url.Values{}
+	if opts.Cascade {
+		query.Set("cascade", "true")
+	}
+	setID(query, "reassign_to", opts.ReassignTo)
+	_, err := c.do(ctx, http.MethodDelete, "/sites/"+pathID(siteID), query, nil, nil)
+	return err
+}
+
+// Centrais do site, inclusive as das suas localizações; com unplaced,
+// apenas as sem localização
+func (c *Client) ListSiteCentrals(ctx context.Context, siteID uint, unplaced bool) ([]Central, error) {
+	query := c.macQuery()
+	query.Set("unplaced", strconv.FormatBool(unplaced))
+	var centrals []Central
+	if _, err := c.do(ctx, http.MethodGet, "/sites/"+pathID(siteID)+"/centrals", query, nil, &centrals); err != nil {
+		return nil, err
+	}
+	return centrals, nil
+}
+
+func (c *Client) ListLocations(ctx context.Context, siteID uint) ([]Location, error) {
+	var locations []Location
+	if _, err := c.do(ctx, http.MethodGet, "/sites/"+pathID(siteID)+"/locations", nil, nil, &locations); err != nil {
+		return nil, err
+	}
+	return locations, nil
+}
+
+func (c *Client) CreateLocation(ctx context.Context, siteID uint, req CreateLocationRequest) (*Location, error) {
+	var location Location
+	if _, err := c.do(ctx, http.MethodPost, "/sites/"+pathID(siteID)+"/locations", nil, req, &location); err != nil {
+		return nil, err
+	}
+	return &location, nil
+}
+
+func (c *Client) GetLocation(ctx context.Context, locationID uint) (*Location, error) {
+	var location Location
+	if _, err := c.do(ctx, http.MethodGet, "/locations/"+pathID(locationID), nil, nil, &location); err != nil {
+		return nil, err
+	}
+	return &location, nil
+}
+
+func (c *Client) UpdateLocation(ctx context.Context, locationID uint, req UpdateLocationRequest) (*Location, error) {
+	var location Location
+	if _, err := c.do(ctx, http.MethodPut, "/locations/"+pathID(locationID), nil, req, &location); err != nil {
+		return nil, err
+	}
+	return &location, nil
+}
+
+func (c *Client) DeleteLocation(ctx context.Context, locationID uint) error {
+	_, err := c.do(ctx, http.MethodDelete, "/locations/"+pathID(locationID), nil, nil, nil)
+	return err
+}
+
+// Centrais da localização; com recursive, também as das localizações filhas
+func (c *Client) ListLocationCentrals(ctx context.Context, locationID uint, recursive bool) ([]Central, error) {
+	query := c.macQuery()
+	query.Set("recursive", strconv.FormatBool(recursive))
+	var centrals []Central
+	if _, err := c.do(ctx, http.MethodGet, "/locations/"+pathID(locationID)+"/centrals", query, nil, &centrals); err != nil {
+		return nil, err
+	}
+	return centrals, nil
+}
@@ -0,0 +1,89 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Versões de SNMP aceitas pela coleta
+type SNMPVersion string
+
+const (
+	SNMPv2c SNMPVersion = "v2c"
+	SNMPv3  SNMPVersion = "v3"
+)
+
+// Credenciais SNMP da central. Na v2c só a comunidade é usada; na v3, o
+// usuário e os protocolos e senhas do nível de segurança desejado. Port
+// zero usa a porta padrão do servidor (161).
+type SNMPCredentialsRequest struct {
+	Version   SNMPVersion `json:"version"`
+	Port      int         `json:"port,omitempty"`
+	Community string      `json:"community,omitempty"`
+
+	Username     string `json:"username,omitempty"`
+	AuthProtocol string `json:"auth_protocol,omitempty"`
+	AuthPassword string `json:"auth_password,omitempty"`
+	PrivProtocol string `json:"priv_protocol,omitempty"`
+	PrivPassword string `json:"priv_password,omitempty"`
+}
+
+type SNMPInterface struct {
+	Index int    `json:"index"`
+	Name  string `json:"name"`
+	Descr string `json:"descr,omitempty"`
+	Type  int    `json:"type"`
+	MTU   int    `json:"mtu"`
+	// Bits por segundo
+	Speed       uint64 `json:"speed"`
+	MAC         string `json:"mac,omitempty"`
+	AdminStatus string `json:"admin_status,omitempty"`
+	OperStatus  string `json:"oper_status,omitempty"`
+}
+
+type SNMPInventory struct {
+	SysName          string          `json:"sys_name"`
+	SysDescr         string          `json:"sys_descr"`
+	SysUpTimeSeconds int64           `json:"sys_uptime_seconds"`
+	Interfaces       []SNMPInterface `json:"interfaces"`
+}
+
+// Configuração SNMP da central; a comunidade e as senhas nunca voltam
+type CentralSNMP struct {
+	CentralID     uint        `json:"central_id"`
+	Version       SNMPVersion `json:"version"`
+	Port          int         `json:"port"`
+	Username      string      `json:"username,omitempty"`
+	AuthProtocol  string      `json:"auth_protocol,omitempty"`
+	PrivProtocol  string      `json:"priv_protocol,omitempty"`
+	SecurityLevel string      `json:"security_level,omitempty"`
+
+	// Última coleta bem-sucedida; nil até a primeira
+	Discovered  *SNMPInventory `json:"discovered"`
+	CollectedAt *time.Time     `json:"collected_at"`
+	PolledAt    *time.Time     `json:"polled_at"`
+	LastError   string         `json:"last_error,omitempty"`
+}
+
+// Substitui as credenciais SNMP da central
+func (c *Client) SetSNMPCredentials(ctx context.Context, centralID uint, req SNMPCredentialsRequest) (*CentralSNMP, error) {
+	var snmp CentralSNMP
+	if _, err := c.do(ctx, http.MethodPut, "/central/"+pathID(centralID)+"/snmp", nil, req, &snmp); err != nil {
+		return nil, err
+	}
+	return &snmp, nil
+}
+
+func (c *Client) GetSNMP(ctx context.Context, centralID uint) (*CentralSNMP, error) {
+	var snmp CentralSNMP
+	if _, err := c.do(ctx, http.MethodGet, "/central/"+pathID(centralID)+"/snmp", nil, nil, &snmp); err != nil {
+		return nil, err
+	}
+	return &snmp, nil
+}
+
+func (c *Client) DeleteSNMPCredentials(ctx context.Context, centralID uint) error {
+	_, err := c.do(ctx, http.MethodDelete, "/central/"+pathID(centralID)+"/snmp", nil, nil, nil)
+	return err
+}
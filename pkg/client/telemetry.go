@@ -0,0 +1,56 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Consulta de uma série de métricas; zeros usam os padrões do servidor
+type MetricQuery struct {
+	Name string
+	From time.Time
+	To   time.Time
+	Step time.Duration
+}
+
+type MetricPoint struct {
+	Timestamp time.Time `json:"timestamp"`
+	Count     int       `json:"count"`
+	Min       float64   `json:"min"`
+	Max       float64   `json:"max"`
+	Avg       float64   `json:"avg"`
+	P95       float64   `json:"p95"`
+}
+
+type MetricSeries struct {
+	CentralID   uint      `json:"central_id"`
+	Name        string    `json:"name"`
+	From        time.Time `json:"from"`
+	To          time.Time `json:"to"`
+	StepSeconds int64     `json:"step_seconds"`
+	// Origem dos agregados: "raw", "5m" ou "1h"
+	Resolution string        `json:"resolution"`
+	Points     []MetricPoint `json:"points"`
+}
+
+func (c *Client) GetMetrics(ctx context.Context, centralID uint, q MetricQuery) (*MetricSeries, error) {
+	query := url.Values{}
+	query.Set("name", q.Name)
+	if !q.From.IsZero() {
+		query.Set("from", q.From.UTC().Format(time.RFC3339))
+	}
+	if !q.To.IsZero() {
+		query.Set("to", q.To.UTC().Format(time.RFC3339))
+	}
+	if q.Step > 0 {
+		query.Set("step", strconv.FormatInt(int64(q.Step/time.Second), 10))
+	}
+	var series MetricSeries
+	if _, err := c.do(ctx, http.MethodGet, "/central/"+pathID(centralID)+"/metrics", query, nil, &series); err != nil {
+		return nil, err
+	}
+	return &series, nil
+}
@@ -0,0 +1,143 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliverySucceeded DeliveryStatus = "succeeded"
+	DeliveryDead      DeliveryStatus = "dead"
+)
+
+// Corpo da criação e atualização de uma assinatura. Sem Events, recebe todos;
+// sem Secret, a criação gera um e a atualização mantém o atual.
+type WebhookRequest struct {
+	URL    string      `json:"url"`
+	Events []EventType `json:"events,omitempty"`
+	Secret string      `json:"secret,omitempty"`
+	Active *bool       `json:"active,omitempty"`
+}
+
+type Webhook struct {
+	ID        uint        `json:"id"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+	URL       string      `json:"url"`
+	Events    []EventType `json:"events"`
+	Active    bool        `json:"active"`
+	// O segredo só é devolvido na criação
+	Secret string `json:"secret,omitempty"`
+}
+
+type Delivery struct {
+	ID             uint           `json:"id"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	WebhookID      uint           `json:"webhook_id"`
+	EventID        string         `json:"event_id"`
+	EventType      EventType      `json:"event_type"`
+	Status         DeliveryStatus `json:"status"`
+	Attempts       int            `json:"attempts"`
+	NextAttemptAt  *time.Time     `json:"next_attempt_at,omitempty"`
+	LastStatusCode int            `json:"last_status_code,omitempty"`
+	LastError      string         `json:"last_error,omitempty"`
+	DeliveredAt    *time.Time     `json:"delivered_at,omitempty"`
+}
+
+type DeliveryAttempt struct {
+	Number      int       `json:"number"`
+	AttemptedAt time.Time `json:"attempted_at"`
+	StatusCode  int       `json:"status_code,omitempty"`
+	Error       string    `json:"error,omitempty"`
+	DurationMS  int64     `json:"duration_ms"`
+}
+
+// Entrega com o corpo enviado e o histórico de tentativas
+type DeliveryDetail struct {
+	Delivery
+	Payload    json.RawMessage   `json:"payload"`
+	AttemptLog []DeliveryAttempt `json:"attempt_log"`
+}
+
+// Filtros de ListWebhookDeliveries; zeros usam os padrões do servidor
+type DeliveryFilter struct {
+	Status DeliveryStatus
+	Limit  int
+}
+
+func (c *Client) CreateWebhook(ctx context.Context, req WebhookRequest) (*Webhook, error) {
+	var webhook Webhook
+	if _, err := c.do(ctx, http.MethodPost, "/webhooks", nil, req, &webhook); err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+func (c *Client) ListWebhooks(ctx context.Context) ([]Webhook, error) {
+	var webhooks []Webhook
+	if _, err := c.do(ctx, http.MethodGet, "/webhooks", nil, nil, &webhooks); err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+func (c *Client) GetWebhook(ctx context.Context, webhookID uint) (*Webhook, error) {
+	var webhook Webhook
+	if _, err := c.do(ctx, http.MethodGet, "/webhooks/"+pathID(webhookID), nil, nil, &webhook); err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+func (c *Client) UpdateWebhook(ctx context.Context, webhookID uint, req WebhookRequest) (*Webhook, error) {
+	var webhook Webhook
+	if _, err := c.do(ctx, http.MethodPut, "/webhooks/"+pathID(webhookID), nil, req, &webhook); err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+func (c *Client) DeleteWebhook(ctx context.Context, webhookID uint) error {
+	_, err := c.do(ctx, http.MethodDelete, "/webhooks/"+pathID(webhookID), nil, nil, nil)
+	return err
+}
+
+func (c *Client) ListWebhookDeliveries(ctx context.Context, webhookID uint, filter DeliveryFilter) ([]Delivery, error) {
+	query := url.Values{}
+	setString(query, "status", string(filter.Status))
+	if filter.Limit > 0 {
+		query.Set("limit", strconv.Itoa(filter.Limit))
+	}
+	var deliveries []Delivery
+	if _, err := c.do(ctx, http.MethodGet, "/webhooks/"+pathID(webhookID)+"/deliveries", query, nil, &deliveries); err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+func (c *Client) GetWebhookDelivery(ctx context.Context, webhookID, deliveryID uint) (*DeliveryDetail, error) {
+	var delivery DeliveryDetail
+	path := "/webhooks/" + pathID(webhookID) + "/deliveries/" + pathID(deliveryID)
+	if _, err := c.do(ctx, http.MethodGet, path, nil, nil, &delivery); err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// Devolve a entrega à fila; ela é enviada na próxima rodada do dispatcher
+func (c *Client) RedeliverWebhookDelivery(ctx context.Context, webhookID, deliveryID uint) (*DeliveryDetail, error) {
+	var delivery DeliveryDetail
+	path := "/webhooks/" + pathID(webhookID) + "/deliveries/" + pathID(deliveryID) + "/redeliver"
+	if _, err := c.do(ctx, http.MethodPost, path, nil, nil, &delivery); err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
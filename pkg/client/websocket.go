@@ -0,0 +1,273 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/fasthttp/websocket"
+)
+
+// Evento recebido por uma inscrição do WebSocket. Quando o servidor encerra a
+// inscrição, Closed vem preenchido em vez do evento; com Reason "lagged", uma
+// nova inscrição com LastEventID recupera o que foi perdido.
+type SocketEvent struct {
+	Subscription string
+	Event        Event
+
+	Closed      bool
+	Reason      string
+	LastEventID uint
+}
+
+// Filtros de Subscribe; vazios recebem todos os eventos
+type SubscribeOptions struct {
+	Centrals []uint
+	SiteID   uint
+	Selector string
+	Status   ReachabilityStatus
+	// Reenvia os eventos gravados depois deste ID antes dos novos
+	LastEventID *uint
+}
+
+// Filtros de Socket.ListCentrals
+type SocketListFilter struct {
+	SiteID   uint
+	Selector string
+	Status   ReachabilityStatus
+}
+
+type wsRequest struct {
+	ID           string `json:"id"`
+	Type         string `json:"type"`
+	CentralID    uint   `json:"central_id,omitempty"`
+	Subscription string `json:"subscription,omitempty"`
+	Centrals     []uint `json:"centrals,omitempty"`
+	SiteID       *uint  `json:"site_id,omitempty"`
+	Selector     string `json:"selector,omitempty"`
+	Status       string `json:"status,omitempty"`
+	LastEventID  *uint  `json:"last_event_id,omitempty"`
+}
+
+type wsMessage struct {
+	Type         string          `json:"type"`
+	ID           string          `json:"id,omitempty"`
+	Subscription string          `json:"subscription,omitempty"`
+	Result       json.RawMessage `json:"result,omitempty"`
+	Error        string          `json:"error,omitempty"`
+	Code         int             `json:"code,omitempty"`
+	Event        json.RawMessage `json:"event,omitempty"`
+	Reason       string          `json:"reason,omitempty"`
+	LastEventID  uint            `json:"last_event_id,omitempty"`
+}
+
+// Conexão WebSocket com a API. Os comandos podem ser chamados de várias
+// goroutines; as respostas são correlacionadas pelo ID do comando. Os eventos
+// das inscrições chegam por Events, que precisa ser consumido: enquanto um
+// evento espera, as respostas seguintes também esperam.
+type Socket struct {
+	conn *websocket.Conn
+
+	writeMu sync.Mutex
+
+	mu      sync.Mutex
+	pending map[string]chan wsMessage
+	nextID  int
+	err     error
+
+	events chan SocketEvent
+	// Fechado quando a leitura termina
+	done chan struct{}
+}
+
+// Conexão encerrada por Close
+var ErrSocketClosed = errors.New("websocket closed")
+
+// Abre o WebSocket em /ws, com o token da configuração
+func (c *Client) DialWebSocket(ctx context.Context) (*Socket, error) {
+	u := *c.baseURL
+	u.Path += "/ws"
+	u.Scheme = "ws"
+	if c.baseURL.Scheme == "https" {
+		u.Scheme = "wss"
+	}
+	header := http.Header{}
+	c.setHeaders(header)
+
+	conn, resp, err := websocket.DefaultDialer.DialContext(ctx, u.String(), header)
+	if err != nil {
+		if resp != nil && resp.StatusCode != http.StatusSwitchingProtocols {
+			return nil, decodeError(http.MethodGet, "/ws", resp)
+		}
+		return nil, err
+	}
+
+	s := &Socket{
+		conn:    conn,
+		pending: map[string]chan wsMessage{},
+		events:  make(chan SocketEvent, 64),
+		done:    make(chan struct{}),
+	}
+	go s.readLoop()
+	return s, nil
+}
+
+// Eventos das inscrições; fechado quando a conexão termina
+func (s *Socket) Events() <-chan SocketEvent {
+	return s.events
+}
+
+// Motivo do fim da conexão, depois que Events é fechado
+func (s *Socket) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// Encerra a conexão e espera a leitura terminar
+func (s *Socket) Close() error {
+	s.mu.Lock()
+	if s.err == nil {
+		s.err = ErrSocketClosed
+	}
+	s.mu.Unlock()
+	s.conn.WriteControl(websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(time.Second))
+	err := s.conn.Close()
+	<-s.done
+	return err
+}
+
+// Inscreve nos eventos e devolve o ID da inscrição
+func (s *Socket) Subscribe(ctx context.Context, opts SubscribeOptions) (string, error) {
+	req := wsRequest{Type: "subscribe", Centrals: opts.Centrals, Selector: opts.Selector,
+		Status: string(opts.Status), LastEventID: opts.LastEventID}
+	if opts.SiteID != 0 {
+		req.SiteID = &opts.SiteID
+	}
+	var result struct {
+		Subscription string `json:"subscription"`
+	}
+	if err := s.call(ctx, req, &result); err != nil {
+		return "", err
+	}
+	return result.Subscription, nil
+}
+
+func (s *Socket) Unsubscribe(ctx context.Context, subscription string) error {
+	return s.call(ctx, wsRequest{Type: "unsubscribe", Subscription: subscription}, nil)
+}
+
+func (s *Socket) GetCentral(ctx context.Context, centralID uint) (*Central, error) {
+	var central Central
+	if err := s.call(ctx, wsRequest{Type: "get", CentralID: centralID}, &central); err != nil {
+		return nil, err
+	}
+	return &central, nil
+}
+
+func (s *Socket) ListCentrals(ctx context.Context, filter SocketListFilter) ([]Central, error) {
+	req := wsRequest{Type: "list", Selector: filter.Selector, Status: string(filter.Status)}
+	if filter.SiteID != 0 {
+		req.SiteID = &filter.SiteID
+	}
+	var centrals []Central
+	if err := s.call(ctx, req, &centrals); err != nil {
+		return nil, err
+	}
+	return centrals, nil
+}
+
+// Confere a conexão e devolve o horário do servidor
+func (s *Socket) Ping(ctx context.Context) (time.Time, error) {
+	var result struct {
+		Time time.Time `json:"time"`
+	}
+	if err := s.call(ctx, wsRequest{Type: "ping"}, &result); err != nil {
+		return time.Time{}, err
+	}
+	return result.Time, nil
+}
+
+// Envia o comando e espera a resposta com o mesmo ID
+func (s *Socket) call(ctx context.Context, req wsRequest, out any) error {
+	reply := make(chan wsMessage, 1)
+	s.mu.Lock()
+	if s.err != nil {
+		s.mu.Unlock()
+		return s.err
+	}
+	s.nextID++
+	req.ID = "c" + strconv.Itoa(s.nextID)
+	s.pending[req.ID] = reply
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.pending, req.ID)
+		s.mu.Unlock()
+	}()
+
+	s.writeMu.Lock()
+	if deadline, ok := ctx.Deadline(); ok {
+		s.conn.SetWriteDeadline(deadline)
+	} else {
+		s.conn.SetWriteDeadline(time.Time{})
+	}
+	err := s.conn.WriteJSON(req)
+	s.writeMu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	select {
+	case msg := <-reply:
+		if msg.Type == "error" {
+			return &Error{StatusCode: msg.Code, Message: msg.Error, Method: "WS", Path: req.Type}
+		}
+		if out == nil {
+			return nil
+		}
+		return json.Unmarshal(msg.Result, out)
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-s.done:
+		return s.Err()
+	}
+}
+
+func (s *Socket) readLoop() {
+	defer close(s.done)
+	defer close(s.events)
+	for {
+		var msg wsMessage
+		if err := s.conn.ReadJSON(&msg); err != nil {
+			s.mu.Lock()
+			if s.err == nil {
+				s.err = err
+			}
+			s.mu.Unlock()
+			return
+		}
+		switch msg.Type {
+		case "event":
+			var event Event
+			if json.Unmarshal(msg.Event, &event) != nil {
+				continue
+			}
+			s.events <- SocketEvent{Subscription: msg.Subscription, Event: event}
+		case "subscription_closed":
+			s.events <- SocketEvent{Subscription: msg.Subscription, Closed: true, Reason: msg.Reason, LastEventID: msg.LastEventID}
+		default:
+			s.mu.Lock()
+			reply, ok := s.pending[msg.ID]
+			s.mu.Unlock()
+			if ok {
+				reply <- msg
+			}
+		}
+	}
+}